@@ -0,0 +1,58 @@
+// Command bootstrap-admin grants the admin role to the first administrator, creating their
+// account if it does not exist yet. It refuses to run once any admin exists; further roles are
+// granted through the admin API.
+//
+// Usage:
+//
+//	BOOTSTRAP_ADMIN_PASSWORD=... bootstrap-admin -email admin@example.com -username admin
+//
+// The password is only needed when the account has to be created, and is read from the
+// environment so it does not end up in shell history.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"os"
+	"time"
+
+	"rating-system/internal/domain/model"
+	domainService "rating-system/internal/domain/service"
+	"rating-system/internal/infrastructure/db"
+	"rating-system/internal/infrastructure/repository"
+	"rating-system/pkg/logger"
+)
+
+func main() {
+	email := flag.String("email", "", "email of the admin account (required)")
+	username := flag.String("username", "", "username for the admin account if it has to be created")
+	flag.Parse()
+
+	log := logger.NewLogger()
+	if *email == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	dbConn, err := db.NewPostgresConnection()
+	if err != nil {
+		log.WithError(err).Fatal("Failed to connect to database")
+	}
+	defer dbConn.Close()
+
+	repo := repository.NewPostgresRepository(dbConn, log)
+	roleSvc := domainService.NewRoleService(repo, nil, log)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	user, err := roleSvc.BootstrapAdmin(ctx, *email, *username, os.Getenv("BOOTSTRAP_ADMIN_PASSWORD"))
+	if err != nil {
+		if errors.Is(err, model.ErrAdminAlreadyExists) {
+			log.Fatal("An admin already exists; grant further roles through the admin API")
+		}
+		log.WithError(err).Fatal("Failed to bootstrap admin")
+	}
+	log.WithField("user_id", user.ID).WithField("email", user.Email).Info("Admin bootstrapped")
+}
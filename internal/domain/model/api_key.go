@@ -0,0 +1,145 @@
+package model
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Errors returned when issuing and using API keys
+var (
+	ErrInvalidAPIKey     = errors.New("invalid API key")
+	ErrAPIKeyNotFound    = errors.New("API key not found")
+	ErrInvalidAPIKeyName = errors.New("API key name must be 1 to 100 characters")
+	ErrNoAPIKeyScopes    = errors.New("API key needs at least one scope")
+	ErrInvalidScope      = errors.New("invalid scope")
+	// ErrScopeNotForService is returned for service-restricted keys with scopes that act on
+	// content not tied to a single service
+	ErrScopeNotForService = errors.New("scope cannot be used by a key restricted to a service")
+	ErrAPIKeyExpiryPassed = errors.New("API key expiry must be in the future")
+	// ErrServiceNotAllowed is returned when a key restricted to a service is used on another
+	ErrServiceNotAllowed = errors.New("API key is restricted to another service")
+)
+
+const (
+	// apiKeyPrefix marks API keys so they are recognisable, for instance by secret scanners
+	apiKeyPrefix = "rsk_"
+	// apiKeyBytes is the amount of randomness in an API key
+	apiKeyBytes = 32
+	// apiKeyHintLength is how many characters of a key are kept to tell keys apart
+	apiKeyHintLength = 8
+	// maxAPIKeyNameLength is the longest name an API key can have
+	maxAPIKeyNameLength = 100
+)
+
+// serviceScopes are the scopes a key restricted to a service can hold; every route they allow
+// names the service it acts on
+var serviceScopes = []Permission{PermissionReadRatings, PermissionWriteRatings, PermissionWriteReviews}
+
+// APIKey lets an integration call the API as a user without a password. The key acts with the
+// permissions of its user's roles, narrowed to its scopes and optionally to one service. Only a
+// hash of the key is stored.
+type APIKey struct {
+	ID         uuid.UUID    `json:"id"`
+	UserID     uuid.UUID    `json:"user_id"`
+	Name       string       `json:"name"`
+	Hint       string       `json:"hint"`
+	KeyHash    string       `json:"-"`
+	Scopes     []Permission `json:"scopes"`
+	ServiceID  *uuid.UUID   `json:"service_id,omitempty"`
+	CreatedBy  uuid.UUID    `json:"created_by"`
+	ExpiresAt  *time.Time   `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time   `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time   `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time    `json:"created_at"`
+}
+
+// NewAPIKey creates an API key for a user, returning it along with the key handed to the caller,
+// which cannot be recovered later
+func NewAPIKey(userID, createdBy uuid.UUID, name string, scopes []Permission, serviceID *uuid.UUID, expiresAt *time.Time, now time.Time) (*APIKey, string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len([]rune(name)) > maxAPIKeyNameLength {
+		return nil, "", ErrInvalidAPIKeyName
+	}
+	if len(scopes) == 0 {
+		return nil, "", ErrNoAPIKeyScopes
+	}
+	unique := make([]Permission, 0, len(scopes))
+	for _, scope := range scopes {
+		if !scope.IsValid() {
+			return nil, "", ErrInvalidScope
+		}
+		if serviceID != nil && !containsPermission(serviceScopes, scope) {
+			return nil, "", ErrScopeNotForService
+		}
+		if !containsPermission(unique, scope) {
+			unique = append(unique, scope)
+		}
+	}
+	if expiresAt != nil && !expiresAt.After(now) {
+		return nil, "", ErrAPIKeyExpiryPassed
+	}
+
+	secret, err := newSecret(apiKeyBytes)
+	if err != nil {
+		return nil, "", err
+	}
+	key := apiKeyPrefix + secret
+
+	return &APIKey{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Name:      name,
+		Hint:      key[:len(apiKeyPrefix)+apiKeyHintLength],
+		KeyHash:   HashAPIKey(key),
+		Scopes:    unique,
+		ServiceID: serviceID,
+		CreatedBy: createdBy,
+		ExpiresAt: expiresAt,
+		CreatedAt: now,
+	}, key, nil
+}
+
+// HashAPIKey returns the hash an API key is stored and looked up by
+func HashAPIKey(key string) string {
+	return hashSecret(key)
+}
+
+// IsActive reports whether the key can still be used
+func (k *APIKey) IsActive(now time.Time) bool {
+	return k.RevokedAt == nil && (k.ExpiresAt == nil || now.Before(*k.ExpiresAt))
+}
+
+// Principal returns the caller the key authenticates, holding the roles of the key's user
+func (k *APIKey) Principal(roles []Role) *Principal {
+	id := k.ID
+	return &Principal{
+		UserID:    k.UserID,
+		Roles:     roles,
+		Scopes:    k.Scopes,
+		ServiceID: k.ServiceID,
+		APIKeyID:  &id,
+	}
+}
+
+// ParseScopes parses scopes stored as a comma separated list
+func ParseScopes(s string) []Permission {
+	scopes := []Permission{}
+	for _, scope := range strings.Split(s, ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			scopes = append(scopes, Permission(scope))
+		}
+	}
+	return scopes
+}
+
+// FormatScopes formats scopes as a comma separated list for storage
+func FormatScopes(scopes []Permission) string {
+	parts := make([]string, len(scopes))
+	for i, scope := range scopes {
+		parts[i] = string(scope)
+	}
+	return strings.Join(parts, ",")
+}
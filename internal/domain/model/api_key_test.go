@@ -0,0 +1,78 @@
+package model
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAPIKey(t *testing.T) {
+	now := time.Now()
+	userID, adminID := uuid.New(), uuid.New()
+
+	key, secret, err := NewAPIKey(userID, adminID, " importer ", []Permission{PermissionWriteRatings, PermissionWriteRatings, PermissionReadReviews}, nil, nil, now)
+	require.NoError(t, err)
+	assert.Equal(t, "importer", key.Name)
+	assert.True(t, strings.HasPrefix(secret, apiKeyPrefix))
+	assert.True(t, strings.HasPrefix(secret, key.Hint))
+	assert.Equal(t, HashAPIKey(secret), key.KeyHash)
+	assert.Equal(t, []Permission{PermissionWriteRatings, PermissionReadReviews}, key.Scopes)
+	assert.True(t, key.IsActive(now))
+
+	principal := key.Principal([]Role{RoleModerator})
+	assert.Equal(t, userID, principal.UserID)
+	assert.Equal(t, key.ID, *principal.APIKeyID)
+	assert.True(t, principal.Can(PermissionWriteRatings))
+	assert.False(t, principal.Can(PermissionModerate))
+}
+
+func TestNewAPIKeyValidation(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	serviceID := uuid.New()
+
+	tests := []struct {
+		name      string
+		keyName   string
+		scopes    []Permission
+		serviceID *uuid.UUID
+		expiresAt *time.Time
+		want      error
+	}{
+		{"empty name", "  ", []Permission{PermissionWriteRatings}, nil, nil, ErrInvalidAPIKeyName},
+		{"long name", strings.Repeat("a", 101), []Permission{PermissionWriteRatings}, nil, nil, ErrInvalidAPIKeyName},
+		{"no scopes", "job", nil, nil, nil, ErrNoAPIKeyScopes},
+		{"unknown scope", "job", []Permission{"reviews:delete"}, nil, nil, ErrInvalidScope},
+		{"scope outside service", "job", []Permission{PermissionWriteComments}, &serviceID, nil, ErrScopeNotForService},
+		{"expired", "job", []Permission{PermissionWriteRatings}, nil, &past, ErrAPIKeyExpiryPassed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := NewAPIKey(uuid.New(), uuid.New(), tt.keyName, tt.scopes, tt.serviceID, tt.expiresAt, now)
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+}
+
+func TestAPIKeyIsActive(t *testing.T) {
+	now := time.Now()
+	expiresAt := now.Add(time.Hour)
+	key, _, err := NewAPIKey(uuid.New(), uuid.New(), "job", []Permission{PermissionWriteRatings}, nil, &expiresAt, now)
+	require.NoError(t, err)
+
+	assert.True(t, key.IsActive(now))
+	assert.False(t, key.IsActive(expiresAt))
+
+	key.RevokedAt = &now
+	assert.False(t, key.IsActive(now))
+}
+
+func TestScopesRoundTrip(t *testing.T) {
+	scopes := []Permission{PermissionWriteRatings, PermissionReadReviews}
+	assert.Equal(t, scopes, ParseScopes(FormatScopes(scopes)))
+	assert.Equal(t, []Permission{}, ParseScopes(""))
+}
@@ -0,0 +1,29 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrInvalidAttestation is returned when an attestation token fails verification
+	ErrInvalidAttestation = errors.New("invalid attestation")
+	// ErrAttestationReused is returned when an attestation token has already been redeemed
+	ErrAttestationReused = errors.New("attestation has already been used")
+)
+
+// Attestation is a verified proof, issued by the order system, that a user
+// interacted with a service. Each attestation can be redeemed only once.
+type Attestation struct {
+	ID         uuid.UUID `json:"id"`
+	TokenID    string    `json:"-"` // Unique token identifier (jti) used for single-use checks
+	RatingID   uuid.UUID `json:"rating_id"`
+	UserID     uuid.UUID `json:"user_id"`
+	ServiceID  uuid.UUID `json:"service_id"`
+	OrderID    string    `json:"order_id"`
+	Algorithm  string    `json:"algorithm"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	VerifiedAt time.Time `json:"verified_at"`
+}
@@ -0,0 +1,41 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuditAction is a security relevant change to an account
+type AuditAction string
+
+// Audit actions
+const (
+	AuditActionPasswordResetRequested AuditAction = "password.reset_requested"
+	AuditActionPasswordReset          AuditAction = "password.reset"
+	AuditActionAccountLocked          AuditAction = "account.locked"
+	AuditActionAccountUnlocked        AuditAction = "account.unlocked"
+)
+
+// AuditEvent records a security relevant change to an account. ActorID is the user who made the
+// change when it is not the account's own user, such as an admin.
+type AuditEvent struct {
+	ID        uuid.UUID   `json:"id"`
+	UserID    uuid.UUID   `json:"user_id"`
+	ActorID   *uuid.UUID  `json:"actor_id,omitempty"`
+	Action    AuditAction `json:"action"`
+	IPAddress string      `json:"ip_address,omitempty"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+// NewAuditEvent creates an audit event for an action on a user's account
+func NewAuditEvent(userID uuid.UUID, actorID *uuid.UUID, action AuditAction, ipAddress string, now time.Time) *AuditEvent {
+	return &AuditEvent{
+		ID:        uuid.New(),
+		UserID:    userID,
+		ActorID:   actorID,
+		Action:    action,
+		IPAddress: ipAddress,
+		CreatedAt: now,
+	}
+}
@@ -0,0 +1,39 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Errors returned when blocking users
+var (
+	ErrCannotBlockSelf = errors.New("users cannot block themselves")
+	ErrAlreadyBlocked  = errors.New("user is already blocked")
+	ErrNotBlocked      = errors.New("user is not blocked")
+	// ErrBlocked is returned when a user tries to interact with someone who has blocked them
+	ErrBlocked = errors.New("this user has blocked you")
+)
+
+// Block records that a user blocked another. Reviews and comments of blocked users are left out of
+// the blocker's listings, and blocked users cannot comment on the blocker's reviews, reply to the
+// blocker's comments or mention the blocker.
+type Block struct {
+	BlockerID uuid.UUID `json:"-"`
+	UserID    uuid.UUID `json:"user_id"`
+	Username  string    `json:"username,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NewBlock creates a block of a user by another
+func NewBlock(blockerID, userID uuid.UUID) (*Block, error) {
+	if blockerID == userID {
+		return nil, ErrCannotBlockSelf
+	}
+	return &Block{
+		BlockerID: blockerID,
+		UserID:    userID,
+		CreatedAt: time.Now(),
+	}, nil
+}
@@ -0,0 +1,22 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBlock(t *testing.T) {
+	blockerID, userID := uuid.New(), uuid.New()
+
+	block, err := NewBlock(blockerID, userID)
+	require.NoError(t, err)
+	assert.Equal(t, blockerID, block.BlockerID)
+	assert.Equal(t, userID, block.UserID)
+	assert.False(t, block.CreatedAt.IsZero())
+
+	_, err = NewBlock(blockerID, blockerID)
+	assert.ErrorIs(t, err, ErrCannotBlockSelf)
+}
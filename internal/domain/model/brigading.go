@@ -0,0 +1,150 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Errors returned when reviewing rating flags
+var (
+	ErrRatingFlagNotFound     = errors.New("rating flag not found")
+	ErrRatingFlagResolved     = errors.New("rating flag already resolved")
+	ErrInvalidFlagResolution  = errors.New("invalid rating flag resolution")
+	ErrInvalidRatingFlagState = errors.New("invalid rating flag status")
+)
+
+// ClientInfo identifies the network address and device a request came from
+type ClientInfo struct {
+	IP       string `json:"ip,omitempty"`
+	DeviceID string `json:"device_id,omitempty"`
+}
+
+type clientInfoKey struct{}
+
+// WithClientInfo returns a context carrying the client the request came from
+func WithClientInfo(ctx context.Context, client ClientInfo) context.Context {
+	return context.WithValue(ctx, clientInfoKey{}, client)
+}
+
+// ClientInfoFromContext returns the client carried by the context, or a zero ClientInfo
+func ClientInfoFromContext(ctx context.Context) ClientInfo {
+	client, _ := ctx.Value(clientInfoKey{}).(ClientInfo)
+	return client
+}
+
+// BrigadingSignal names a reason a rating looks like part of a coordinated campaign
+type BrigadingSignal string
+
+// Brigading signals
+const (
+	// SignalNewAccount is raised for ratings from recently registered accounts
+	SignalNewAccount BrigadingSignal = "new_account"
+	// SignalRatingBurst is raised when a service receives unusually many ratings in a short window
+	SignalRatingBurst BrigadingSignal = "rating_burst"
+	// SignalIPCluster is raised when several accounts rate a service from the same address
+	SignalIPCluster BrigadingSignal = "ip_cluster"
+	// SignalDeviceCluster is raised when several accounts rate a service from the same device
+	SignalDeviceCluster BrigadingSignal = "device_cluster"
+	// SignalScoreDeviation is raised when a score is far from the service's established average
+	SignalScoreDeviation BrigadingSignal = "score_deviation"
+)
+
+// SignalHit is a signal raised for a rating, with its contribution to the suspicion score
+type SignalHit struct {
+	Signal BrigadingSignal `json:"signal"`
+	Weight float64         `json:"weight"`
+	Detail string          `json:"detail"`
+}
+
+// RatingAssessment is the result of scoring a rating for brigading
+type RatingAssessment struct {
+	Score      float64     `json:"score"`
+	Signals    []SignalHit `json:"signals"`
+	Flag       bool        `json:"flag"`
+	Quarantine bool        `json:"quarantine"`
+}
+
+// Add raises a signal, adding its weight to the suspicion score
+func (a *RatingAssessment) Add(signal BrigadingSignal, weight float64, detail string) {
+	a.Score += weight
+	a.Signals = append(a.Signals, SignalHit{Signal: signal, Weight: weight, Detail: detail})
+}
+
+// RatingFlagStatus is the review state of a rating flag
+type RatingFlagStatus string
+
+// Rating flag states
+const (
+	// RatingFlagOpen is a flag awaiting a moderator
+	RatingFlagOpen RatingFlagStatus = "open"
+	// RatingFlagCleared is a flag a moderator judged to be a genuine rating
+	RatingFlagCleared RatingFlagStatus = "cleared"
+	// RatingFlagConfirmed is a flag a moderator judged to be part of a brigading campaign
+	RatingFlagConfirmed RatingFlagStatus = "confirmed"
+)
+
+// IsValid reports whether the status is a known rating flag status
+func (s RatingFlagStatus) IsValid() bool {
+	return s == RatingFlagOpen || s == RatingFlagCleared || s == RatingFlagConfirmed
+}
+
+// RatingFlag records a rating the detector found suspicious. A quarantined rating is left out of
+// public listings and averages until a moderator clears it.
+type RatingFlag struct {
+	ID          uuid.UUID        `json:"id"`
+	RatingID    uuid.UUID        `json:"rating_id"`
+	UserID      uuid.UUID        `json:"user_id"`
+	ServiceID   uuid.UUID        `json:"service_id"`
+	RatingScore int              `json:"rating_score"`
+	Score       float64          `json:"suspicion_score"`
+	Signals     []SignalHit      `json:"signals"`
+	Client      ClientInfo       `json:"client"`
+	Quarantined bool             `json:"quarantined"`
+	Status      RatingFlagStatus `json:"status"`
+	ResolvedBy  *uuid.UUID       `json:"resolved_by,omitempty"`
+	ResolvedAt  *time.Time       `json:"resolved_at,omitempty"`
+	CreatedAt   time.Time        `json:"created_at"`
+}
+
+// NewRatingFlag creates an open flag for a rating from its assessment
+func NewRatingFlag(rating *Rating, assessment *RatingAssessment, client ClientInfo) *RatingFlag {
+	return &RatingFlag{
+		ID:          uuid.New(),
+		RatingID:    rating.ID,
+		UserID:      rating.UserID,
+		ServiceID:   rating.ServiceID,
+		RatingScore: rating.Score,
+		Score:       assessment.Score,
+		Signals:     assessment.Signals,
+		Client:      client,
+		Quarantined: assessment.Quarantine,
+		Status:      RatingFlagOpen,
+		CreatedAt:   time.Now(),
+	}
+}
+
+// Resolve records a moderator's judgement of the flag. Clearing releases the rating from
+// quarantine; confirming keeps it out of public aggregates for good.
+func (f *RatingFlag) Resolve(moderatorID uuid.UUID, status RatingFlagStatus, at time.Time) error {
+	if status != RatingFlagCleared && status != RatingFlagConfirmed {
+		return ErrInvalidFlagResolution
+	}
+	if f.Status != RatingFlagOpen {
+		return ErrRatingFlagResolved
+	}
+
+	f.Status = status
+	f.Quarantined = status == RatingFlagConfirmed
+	f.ResolvedBy = &moderatorID
+	f.ResolvedAt = &at
+	return nil
+}
+
+// RatingFlagFilter narrows a listing of rating flags
+type RatingFlagFilter struct {
+	Status    RatingFlagStatus
+	ServiceID *uuid.UUID
+}
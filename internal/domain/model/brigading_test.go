@@ -0,0 +1,55 @@
+package model
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientInfoContext(t *testing.T) {
+	assert.Equal(t, ClientInfo{}, ClientInfoFromContext(context.Background()))
+
+	client := ClientInfo{IP: "203.0.113.7", DeviceID: "device-1"}
+	assert.Equal(t, client, ClientInfoFromContext(WithClientInfo(context.Background(), client)))
+}
+
+func TestNewRatingFlag(t *testing.T) {
+	rating, err := NewRating(uuid.New(), uuid.New(), 1)
+	require.NoError(t, err)
+
+	assessment := &RatingAssessment{}
+	assessment.Add(SignalNewAccount, 0.5, "account is 10m0s old")
+	assessment.Add(SignalIPCluster, 0.75, "3 other accounts")
+	assessment.Quarantine = true
+
+	flag := NewRatingFlag(rating, assessment, ClientInfo{IP: "203.0.113.7"})
+	assert.Equal(t, rating.ID, flag.RatingID)
+	assert.Equal(t, rating.UserID, flag.UserID)
+	assert.Equal(t, 1, flag.RatingScore)
+	assert.InDelta(t, 1.25, flag.Score, 1e-9)
+	assert.Len(t, flag.Signals, 2)
+	assert.True(t, flag.Quarantined)
+	assert.Equal(t, RatingFlagOpen, flag.Status)
+}
+
+func TestRatingFlagResolve(t *testing.T) {
+	moderatorID, now := uuid.New(), time.Now()
+
+	cleared := &RatingFlag{Status: RatingFlagOpen, Quarantined: true}
+	require.NoError(t, cleared.Resolve(moderatorID, RatingFlagCleared, now))
+	assert.Equal(t, RatingFlagCleared, cleared.Status)
+	assert.False(t, cleared.Quarantined)
+	assert.Equal(t, &moderatorID, cleared.ResolvedBy)
+	assert.Equal(t, &now, cleared.ResolvedAt)
+
+	confirmed := &RatingFlag{Status: RatingFlagOpen}
+	require.NoError(t, confirmed.Resolve(moderatorID, RatingFlagConfirmed, now))
+	assert.True(t, confirmed.Quarantined)
+
+	assert.ErrorIs(t, confirmed.Resolve(moderatorID, RatingFlagCleared, now), ErrRatingFlagResolved)
+	assert.ErrorIs(t, (&RatingFlag{Status: RatingFlagOpen}).Resolve(moderatorID, RatingFlagOpen, now), ErrInvalidFlagResolution)
+}
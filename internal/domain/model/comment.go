@@ -7,17 +7,38 @@ import (
 	"github.com/google/uuid"
 )
 
-// Comment represents a user comment on a review
+// DefaultCommentMaxDepth is the default maximum nesting depth of replies; top-level comments have depth 0
+const DefaultCommentMaxDepth = 5
+
+// Errors returned when building comment threads
+var (
+	ErrCommentDepthExceeded = errors.New("maximum reply depth exceeded")
+	ErrCommentDeleted       = errors.New("comment has been deleted")
+	ErrInvalidParentComment = errors.New("parent comment belongs to a different review")
+)
+
+// Comment represents a user comment on a review, optionally in reply to another comment
 type Comment struct {
-	ID        uuid.UUID `json:"id"`
-	UserID    uuid.UUID `json:"user_id"`
-	ReviewID  uuid.UUID `json:"review_id"`
-	Content   string    `json:"content"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID               uuid.UUID        `json:"id"`
+	UserID           uuid.UUID        `json:"user_id"`
+	ReviewID         uuid.UUID        `json:"review_id"`
+	ParentID         *uuid.UUID       `json:"parent_id,omitempty"` // Nil for top-level comments
+	ThreadID         uuid.UUID        `json:"thread_id"`           // ID of the top-level comment of the thread
+	Depth            int              `json:"depth"`
+	Content          string           `json:"content"`      // Markdown source
+	ContentHTML      string           `json:"content_html"` // Sanitised rendering of the content
+	Deleted          bool             `json:"deleted"`      // Tombstoned; kept so that replies stay attached
+	DeletedAt        *time.Time       `json:"deleted_at,omitempty"`
+	ModerationStatus ModerationStatus `json:"moderation_status"`
+	CreatedAt        time.Time        `json:"created_at"`
+	UpdatedAt        time.Time        `json:"updated_at"`
+	Mentions         []*Mention       `json:"mentions,omitempty"` // Users mentioned in the content
+	Reactions        []*ReactionCount `json:"reactions,omitempty"`
+	Shadowed         bool             `json:"-"`                  // Written while the author was shadow-banned
+	Withheld         bool             `json:"withheld,omitempty"` // Not visible to the viewer; kept so that visible replies stay attached
 }
 
-// NewComment creates a new comment with validation
+// NewComment creates a new top-level comment with validation
 func NewComment(userID, reviewID uuid.UUID, content string) (*Comment, error) {
 	if userID == uuid.Nil {
 		return nil, errors.New("user ID cannot be empty")
@@ -31,24 +52,158 @@ func NewComment(userID, reviewID uuid.UUID, content string) (*Comment, error) {
 		return nil, errors.New("content cannot be empty")
 	}
 
+	if err := checkLength("content", content, contentLimits.CommentContent); err != nil {
+		return nil, err
+	}
+
 	now := time.Now()
+	id := uuid.New()
 	return &Comment{
-		ID:        uuid.New(),
-		UserID:    userID,
-		ReviewID:  reviewID,
-		Content:   content,
-		CreatedAt: now,
-		UpdatedAt: now,
+		ID:               id,
+		UserID:           userID,
+		ReviewID:         reviewID,
+		ThreadID:         id,
+		Content:          content,
+		ModerationStatus: ModerationVisible,
+		CreatedAt:        now,
+		UpdatedAt:        now,
 	}, nil
 }
 
+// ReplyTo attaches the comment to a parent comment on the same review.
+// A maxDepth of zero or less disables the depth limit.
+func (c *Comment) ReplyTo(parent *Comment, maxDepth int) error {
+	if parent.ReviewID != c.ReviewID {
+		return ErrInvalidParentComment
+	}
+
+	if parent.Deleted {
+		return ErrCommentDeleted
+	}
+
+	depth := parent.Depth + 1
+	if maxDepth > 0 && depth > maxDepth {
+		return ErrCommentDepthExceeded
+	}
+
+	parentID := parent.ID
+	c.ParentID = &parentID
+	c.ThreadID = parent.ThreadID
+	c.Depth = depth
+	return nil
+}
+
 // UpdateContent updates the comment content
 func (c *Comment) UpdateContent(content string) error {
+	if c.Deleted {
+		return ErrCommentDeleted
+	}
+
 	if content == "" {
 		return errors.New("content cannot be empty")
 	}
 
+	if err := checkLength("content", content, contentLimits.CommentContent); err != nil {
+		return err
+	}
+
 	c.Content = content
 	c.UpdatedAt = time.Now()
 	return nil
 }
+
+// Tombstone removes the comment's content while keeping its place in the thread
+func (c *Comment) Tombstone(now time.Time) {
+	c.Deleted = true
+	c.Content = ""
+	c.ContentHTML = ""
+	c.Mentions = nil
+	c.DeletedAt = &now
+	c.UpdatedAt = now
+}
+
+// Withhold strips a comment the viewer may not see down to a placeholder that keeps its place in the thread
+func (c *Comment) Withhold() {
+	c.Withheld = true
+	c.UserID = uuid.Nil
+	c.Content = ""
+	c.ContentHTML = ""
+	c.Mentions = nil
+	c.Reactions = nil
+}
+
+// CommentNode is a comment with its replies
+type CommentNode struct {
+	Comment
+	ReplyCount   int            `json:"reply_count"`   // Direct replies
+	TotalReplies int            `json:"total_replies"` // All replies below this comment
+	Replies      []*CommentNode `json:"replies"`
+}
+
+// BuildCommentTree nests replies under their top-level threads. Replies must belong to the
+// given threads; replies whose parent is missing are dropped. Withheld threads and replies are
+// kept as placeholders while they have replies of their own and dropped otherwise, so that a
+// hidden comment never takes the visible replies below it out of the tree.
+func BuildCommentTree(threads, replies []*Comment) []*CommentNode {
+	nodes := make(map[uuid.UUID]*CommentNode, len(threads)+len(replies))
+	roots := make([]*CommentNode, 0, len(threads))
+	for _, comment := range threads {
+		node := &CommentNode{Comment: *comment, Replies: []*CommentNode{}}
+		nodes[comment.ID] = node
+		roots = append(roots, node)
+	}
+	for _, comment := range replies {
+		nodes[comment.ID] = &CommentNode{Comment: *comment, Replies: []*CommentNode{}}
+	}
+
+	// Attach in the order given so that siblings keep the repository's ordering
+	for _, comment := range replies {
+		if comment.ParentID == nil {
+			continue
+		}
+		if parent, ok := nodes[*comment.ParentID]; ok {
+			parent.Replies = append(parent.Replies, nodes[comment.ID])
+		}
+	}
+
+	kept := roots[:0]
+	for _, root := range roots {
+		root.pruneWithheld()
+		if root.Withheld {
+			if len(root.Replies) == 0 {
+				continue
+			}
+			root.Withhold()
+		}
+		root.countReplies()
+		kept = append(kept, root)
+	}
+	return kept
+}
+
+// pruneWithheld drops withheld replies below the node that have nothing visible beneath them and
+// turns the remaining ones into placeholders
+func (n *CommentNode) pruneWithheld() {
+	kept := n.Replies[:0]
+	for _, reply := range n.Replies {
+		reply.pruneWithheld()
+		if reply.Withheld {
+			if len(reply.Replies) == 0 {
+				continue
+			}
+			reply.Withhold()
+		}
+		kept = append(kept, reply)
+	}
+	n.Replies = kept
+}
+
+// countReplies fills in the reply counts of the node and its descendants and returns its total
+func (n *CommentNode) countReplies() int {
+	n.ReplyCount = len(n.Replies)
+	n.TotalReplies = 0
+	for _, reply := range n.Replies {
+		n.TotalReplies += 1 + reply.countReplies()
+	}
+	return n.TotalReplies
+}
@@ -0,0 +1,121 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestReply(t *testing.T, parent *Comment, maxDepth int) *Comment {
+	reply, err := NewComment(uuid.New(), parent.ReviewID, "Reply")
+	require.NoError(t, err)
+	require.NoError(t, reply.ReplyTo(parent, maxDepth))
+	return reply
+}
+
+func TestCommentReplyTo(t *testing.T) {
+	root, err := NewComment(uuid.New(), uuid.New(), "Root")
+	require.NoError(t, err)
+	assert.Equal(t, root.ID, root.ThreadID)
+	assert.Nil(t, root.ParentID)
+
+	reply := newTestReply(t, root, 2)
+	assert.Equal(t, root.ID, *reply.ParentID)
+	assert.Equal(t, root.ID, reply.ThreadID)
+	assert.Equal(t, 1, reply.Depth)
+
+	nested := newTestReply(t, reply, 2)
+	assert.Equal(t, root.ID, nested.ThreadID)
+	assert.Equal(t, 2, nested.Depth)
+
+	tooDeep, _ := NewComment(uuid.New(), root.ReviewID, "Too deep")
+	assert.ErrorIs(t, tooDeep.ReplyTo(nested, 2), ErrCommentDepthExceeded)
+	assert.NoError(t, tooDeep.ReplyTo(nested, 0), "zero disables the limit")
+
+	otherReview, _ := NewComment(uuid.New(), uuid.New(), "Elsewhere")
+	assert.ErrorIs(t, otherReview.ReplyTo(root, 2), ErrInvalidParentComment)
+}
+
+func TestCommentTombstone(t *testing.T) {
+	comment, _ := NewComment(uuid.New(), uuid.New(), "Content")
+	comment.Tombstone(time.Now())
+
+	assert.True(t, comment.Deleted)
+	assert.Empty(t, comment.Content)
+	assert.NotNil(t, comment.DeletedAt)
+	assert.ErrorIs(t, comment.UpdateContent("Edited"), ErrCommentDeleted)
+
+	reply, _ := NewComment(uuid.New(), comment.ReviewID, "Reply")
+	assert.ErrorIs(t, reply.ReplyTo(comment, 0), ErrCommentDeleted)
+}
+
+func TestBuildCommentTree(t *testing.T) {
+	first, _ := NewComment(uuid.New(), uuid.New(), "First")
+	second, _ := NewComment(uuid.New(), first.ReviewID, "Second")
+	a := newTestReply(t, first, 0)
+	b := newTestReply(t, first, 0)
+	c := newTestReply(t, a, 0)
+
+	tree := BuildCommentTree([]*Comment{first, second}, []*Comment{a, b, c})
+	require.Len(t, tree, 2)
+
+	assert.Equal(t, first.ID, tree[0].ID)
+	assert.Equal(t, 2, tree[0].ReplyCount)
+	assert.Equal(t, 3, tree[0].TotalReplies)
+	require.Len(t, tree[0].Replies, 2)
+	assert.Equal(t, a.ID, tree[0].Replies[0].ID)
+	assert.Equal(t, 1, tree[0].Replies[0].ReplyCount)
+	assert.Equal(t, c.ID, tree[0].Replies[0].Replies[0].ID)
+
+	assert.Equal(t, 0, tree[1].ReplyCount)
+	assert.NotNil(t, tree[1].Replies)
+}
+
+func TestBuildCommentTreeWithheldReplies(t *testing.T) {
+	root, _ := NewComment(uuid.New(), uuid.New(), "Root")
+	hidden := newTestReply(t, root, 0)
+	visible := newTestReply(t, hidden, 0)
+	hiddenLeaf := newTestReply(t, root, 0)
+	hidden.Withheld = true
+	hiddenLeaf.Withheld = true
+
+	tree := BuildCommentTree([]*Comment{root}, []*Comment{hidden, visible, hiddenLeaf})
+	require.Len(t, tree, 1)
+
+	// The hidden middle reply stays as a placeholder so its visible reply is not lost
+	assert.Equal(t, 1, tree[0].ReplyCount)
+	assert.Equal(t, 2, tree[0].TotalReplies)
+	require.Len(t, tree[0].Replies, 1)
+	placeholder := tree[0].Replies[0]
+	assert.Equal(t, hidden.ID, placeholder.ID)
+	assert.True(t, placeholder.Withheld)
+	assert.Empty(t, placeholder.Content)
+	assert.Equal(t, uuid.Nil, placeholder.UserID)
+	assert.Equal(t, 1, placeholder.ReplyCount)
+	require.Len(t, placeholder.Replies, 1)
+	assert.Equal(t, visible.ID, placeholder.Replies[0].ID)
+	assert.Equal(t, "Reply", placeholder.Replies[0].Content)
+}
+
+func TestBuildCommentTreeWithheldThreads(t *testing.T) {
+	hidden, _ := NewComment(uuid.New(), uuid.New(), "Hidden")
+	hiddenAlone, _ := NewComment(uuid.New(), hidden.ReviewID, "Hidden alone")
+	visible := newTestReply(t, hidden, 0)
+	hidden.Withheld = true
+	hiddenAlone.Withheld = true
+
+	tree := BuildCommentTree([]*Comment{hidden, hiddenAlone}, []*Comment{visible})
+
+	// A hidden thread is kept as a placeholder for its visible reply, and dropped without one
+	require.Len(t, tree, 1)
+	assert.Equal(t, hidden.ID, tree[0].ID)
+	assert.True(t, tree[0].Withheld)
+	assert.Empty(t, tree[0].Content)
+	assert.Equal(t, uuid.Nil, tree[0].UserID)
+	assert.Equal(t, 1, tree[0].TotalReplies)
+	require.Len(t, tree[0].Replies, 1)
+	assert.Equal(t, visible.ID, tree[0].Replies[0].ID)
+}
@@ -0,0 +1,53 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"unicode/utf8"
+)
+
+// ErrContentTooLong is returned when user-written text exceeds its configured maximum length
+var ErrContentTooLong = errors.New("content too long")
+
+// ContentLimits bounds the length of user-written text, in characters
+type ContentLimits struct {
+	ReviewTitle    int
+	ReviewContent  int
+	CommentContent int
+}
+
+// DefaultContentLimits returns the default content limits
+func DefaultContentLimits() ContentLimits {
+	return ContentLimits{
+		ReviewTitle:    255,
+		ReviewContent:  10000,
+		CommentContent: 2000,
+	}
+}
+
+// contentLimits are enforced by the review and comment constructors and content updates
+var contentLimits = DefaultContentLimits()
+
+// SetContentLimits sets the limits enforced when reviews and comments are created or edited;
+// zero or negative values keep the defaults. It is meant to be called once at start-up.
+func SetContentLimits(limits ContentLimits) {
+	defaults := DefaultContentLimits()
+	if limits.ReviewTitle <= 0 {
+		limits.ReviewTitle = defaults.ReviewTitle
+	}
+	if limits.ReviewContent <= 0 {
+		limits.ReviewContent = defaults.ReviewContent
+	}
+	if limits.CommentContent <= 0 {
+		limits.CommentContent = defaults.CommentContent
+	}
+	contentLimits = limits
+}
+
+// checkLength returns ErrContentTooLong when value has more than limit characters
+func checkLength(field, value string, limit int) error {
+	if utf8.RuneCountInString(value) > limit {
+		return fmt.Errorf("%w: %s must not exceed %d characters", ErrContentTooLong, field, limit)
+	}
+	return nil
+}
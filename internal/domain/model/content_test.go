@@ -0,0 +1,41 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContentLimits(t *testing.T) {
+	SetContentLimits(ContentLimits{ReviewTitle: 5, ReviewContent: 10, CommentContent: 3})
+	defer SetContentLimits(DefaultContentLimits())
+
+	userID, serviceID, ratingID := uuid.New(), uuid.New(), uuid.New()
+
+	_, err := NewReview(userID, serviceID, ratingID, "Title!", "ok")
+	assert.ErrorIs(t, err, ErrContentTooLong)
+
+	_, err = NewReview(userID, serviceID, ratingID, "Title", strings.Repeat("x", 11))
+	assert.ErrorIs(t, err, ErrContentTooLong)
+
+	review, err := NewReview(userID, serviceID, ratingID, "Title", "Ünïcödé ok")
+	require.NoError(t, err, "limits count characters, not bytes")
+	assert.ErrorIs(t, review.UpdateContent("Title", strings.Repeat("x", 11)), ErrContentTooLong)
+
+	_, err = NewComment(userID, uuid.New(), "four")
+	assert.ErrorIs(t, err, ErrContentTooLong)
+
+	comment, err := NewComment(userID, uuid.New(), "one")
+	require.NoError(t, err)
+	assert.ErrorIs(t, comment.UpdateContent("four"), ErrContentTooLong)
+}
+
+func TestSetContentLimitsKeepsDefaults(t *testing.T) {
+	SetContentLimits(ContentLimits{CommentContent: 100})
+	defer SetContentLimits(DefaultContentLimits())
+
+	assert.Equal(t, ContentLimits{ReviewTitle: 255, ReviewContent: 10000, CommentContent: 100}, contentLimits)
+}
@@ -0,0 +1,126 @@
+package model
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidMinHash is returned when a stored MinHash signature cannot be decoded
+var ErrInvalidMinHash = errors.New("invalid MinHash signature")
+
+// MinHash is a MinHash signature of a text's shingles. The fraction of positions two signatures
+// agree on estimates the Jaccard similarity of the shingle sets.
+type MinHash []uint32
+
+// Similarity estimates the Jaccard similarity of the texts behind two signatures, from 0 to 1.
+// Signatures of different lengths are not comparable and have no similarity.
+func (m MinHash) Similarity(other MinHash) float64 {
+	if len(m) == 0 || len(m) != len(other) {
+		return 0
+	}
+
+	same := 0
+	for i := range m {
+		if m[i] == other[i] {
+			same++
+		}
+	}
+	return float64(same) / float64(len(m))
+}
+
+// Encode returns the signature as a hex string for storage
+func (m MinHash) Encode() string {
+	buf := make([]byte, 4*len(m))
+	for i, v := range m {
+		binary.BigEndian.PutUint32(buf[4*i:], v)
+	}
+	return hex.EncodeToString(buf)
+}
+
+// DecodeMinHash parses a signature stored with Encode
+func DecodeMinHash(s string) (MinHash, error) {
+	buf, err := hex.DecodeString(s)
+	if err != nil || len(buf)%4 != 0 {
+		return nil, ErrInvalidMinHash
+	}
+
+	m := make(MinHash, len(buf)/4)
+	for i := range m {
+		m[i] = binary.BigEndian.Uint32(buf[4*i:])
+	}
+	return m, nil
+}
+
+// ReviewSignature is the MinHash signature stored alongside a review, with the LSH band hashes
+// used to find candidate duplicates. Reviews found to nearly match earlier ones join a cluster
+// named after the first review in it.
+type ReviewSignature struct {
+	ReviewID  uuid.UUID
+	UserID    uuid.UUID
+	ServiceID uuid.UUID
+	MinHash   MinHash
+	Bands     []int64
+	// ClusterID is the first review of the duplicate cluster the review belongs to, if any
+	ClusterID *uuid.UUID
+	// Similarity is the estimated similarity to the review it matched when it joined the cluster
+	Similarity float64
+	CreatedAt  time.Time
+}
+
+// DuplicateMatch is an existing review from another account whose text nearly matches a new one
+type DuplicateMatch struct {
+	ReviewID   uuid.UUID  `json:"review_id"`
+	UserID     uuid.UUID  `json:"user_id"`
+	ServiceID  uuid.UUID  `json:"service_id"`
+	ClusterID  *uuid.UUID `json:"cluster_id,omitempty"`
+	Similarity float64    `json:"similarity"`
+}
+
+// DuplicateCheck is the result of checking a new review for near-duplicates. The signature is
+// nil when the text was too short to check; matches are ordered most similar first.
+type DuplicateCheck struct {
+	Signature *ReviewSignature
+	Matches   []DuplicateMatch
+	Result    FilterResult
+}
+
+// DuplicateScope is where near-duplicates of a review are looked for
+type DuplicateScope string
+
+// Duplicate scopes
+const (
+	// DuplicateScopeService only compares reviews of the same service
+	DuplicateScopeService DuplicateScope = "service"
+	// DuplicateScopeGlobal compares reviews across all services
+	DuplicateScopeGlobal DuplicateScope = "global"
+)
+
+// IsValid reports whether the scope is a known duplicate scope
+func (s DuplicateScope) IsValid() bool {
+	return s == DuplicateScopeService || s == DuplicateScopeGlobal
+}
+
+// DuplicateCluster is a group of near-identical reviews, usually posted from several accounts
+type DuplicateCluster struct {
+	ID          uuid.UUID                `json:"id"`
+	Reviews     int                      `json:"reviews"`
+	Accounts    int                      `json:"accounts"`
+	Services    int                      `json:"services"`
+	FirstSeenAt time.Time                `json:"first_seen_at"`
+	LastSeenAt  time.Time                `json:"last_seen_at"`
+	Members     []DuplicateClusterMember `json:"members"`
+}
+
+// DuplicateClusterMember is a review in a duplicate cluster. Similarity is to the review it
+// matched when it joined and is left out for the first review of the cluster.
+type DuplicateClusterMember struct {
+	ReviewID   uuid.UUID `json:"review_id"`
+	UserID     uuid.UUID `json:"user_id"`
+	ServiceID  uuid.UUID `json:"service_id"`
+	Similarity float64   `json:"similarity,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
@@ -0,0 +1,30 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMinHashSimilarity(t *testing.T) {
+	a := MinHash{1, 2, 3, 4}
+
+	assert.Equal(t, 1.0, a.Similarity(MinHash{1, 2, 3, 4}))
+	assert.Equal(t, 0.5, a.Similarity(MinHash{1, 2, 9, 9}))
+	assert.Equal(t, 0.0, a.Similarity(MinHash{1, 2}))
+	assert.Equal(t, 0.0, MinHash(nil).Similarity(nil))
+}
+
+func TestMinHashEncoding(t *testing.T) {
+	m := MinHash{0, 1, 0xdeadbeef, 0xffffffff}
+
+	decoded, err := DecodeMinHash(m.Encode())
+	require.NoError(t, err)
+	assert.Equal(t, m, decoded)
+
+	_, err = DecodeMinHash("zz")
+	assert.ErrorIs(t, err, ErrInvalidMinHash)
+	_, err = DecodeMinHash("abcdef")
+	assert.ErrorIs(t, err, ErrInvalidMinHash)
+}
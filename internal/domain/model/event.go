@@ -0,0 +1,96 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Event is a domain event published after a state change has been persisted
+type Event interface {
+	// EventType identifies the event for subscribers
+	EventType() string
+}
+
+// EventTypeReviewPublished is published when a review becomes publicly visible
+const EventTypeReviewPublished = "review.published"
+
+// ReviewPublishedEvent is published when a review becomes publicly visible
+type ReviewPublishedEvent struct {
+	ReviewID    uuid.UUID
+	UserID      uuid.UUID
+	ServiceID   uuid.UUID
+	PublishedAt time.Time
+}
+
+// EventType implements Event
+func (ReviewPublishedEvent) EventType() string {
+	return EventTypeReviewPublished
+}
+
+// EventTypeCommentCreated is published when a comment or reply is posted
+const EventTypeCommentCreated = "comment.created"
+
+// CommentCreatedEvent is published when a comment or reply is posted
+type CommentCreatedEvent struct {
+	CommentID uuid.UUID
+	UserID    uuid.UUID
+	ReviewID  uuid.UUID
+	ParentID  *uuid.UUID
+}
+
+// EventType implements Event
+func (CommentCreatedEvent) EventType() string {
+	return EventTypeCommentCreated
+}
+
+// EventTypeReviewModerated is published when a moderator approves or rejects a review
+const EventTypeReviewModerated = "review.moderated"
+
+// ReviewModeratedEvent is published when a moderator approves or rejects a review
+type ReviewModeratedEvent struct {
+	ReviewID uuid.UUID
+	UserID   uuid.UUID
+	Status   ReviewStatus
+}
+
+// EventType implements Event
+func (ReviewModeratedEvent) EventType() string {
+	return EventTypeReviewModerated
+}
+
+// EventTypeContentModerated is published when a moderator approves, hides or removes a review or comment
+const EventTypeContentModerated = "content.moderated"
+
+// ContentModeratedEvent is published when a moderator approves, hides or removes a review or
+// comment. UserID is the content's author and ReviewID the review it is, or belongs to.
+type ContentModeratedEvent struct {
+	TargetType ReportTarget
+	TargetID   uuid.UUID
+	UserID     uuid.UUID
+	ReviewID   uuid.UUID
+	Action     ModerationAction
+	Status     ModerationStatus
+}
+
+// EventType implements Event
+func (ContentModeratedEvent) EventType() string {
+	return EventTypeContentModerated
+}
+
+// EventTypeUsersMentioned is published when a visible review or comment mentions users
+const EventTypeUsersMentioned = "users.mentioned"
+
+// UsersMentionedEvent is published when a visible review or comment mentions users. CommentID
+// is nil for mentions in a review.
+type UsersMentionedEvent struct {
+	ActorID   uuid.UUID
+	ReviewID  uuid.UUID
+	CommentID *uuid.UUID
+	UserIDs   []uuid.UUID
+}
+
+// EventType implements Event
+func (UsersMentionedEvent) EventType() string {
+	return EventTypeUsersMentioned
+}
@@ -0,0 +1,90 @@
+package model
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrContentRejected is returned when a content filter rejects a review or comment
+var ErrContentRejected = errors.New("content rejected")
+
+// FilterOutcome is a content filter's verdict on a piece of text, from least to most severe
+type FilterOutcome int
+
+// Filter outcomes
+const (
+	// FilterAllow lets the content through
+	FilterAllow FilterOutcome = iota
+	// FilterFlag lets the content through and queues it for a moderator
+	FilterFlag
+	// FilterReject refuses the content
+	FilterReject
+)
+
+// String returns the name of the outcome
+func (o FilterOutcome) String() string {
+	switch o {
+	case FilterFlag:
+		return "flag"
+	case FilterReject:
+		return "reject"
+	}
+	return "allow"
+}
+
+// FilterResult is the outcome of running content filters, with the reasons for flagging or
+// rejecting the content
+type FilterResult struct {
+	Outcome FilterOutcome
+	Reasons []string
+}
+
+// Allow returns a result letting content through
+func Allow() FilterResult {
+	return FilterResult{Outcome: FilterAllow}
+}
+
+// Flag returns a result queueing content for a moderator for the given reason
+func Flag(reason string) FilterResult {
+	return FilterResult{Outcome: FilterFlag, Reasons: []string{reason}}
+}
+
+// Reject returns a result refusing content for the given reason
+func Reject(reason string) FilterResult {
+	return FilterResult{Outcome: FilterReject, Reasons: []string{reason}}
+}
+
+// Merge combines two results, keeping the more severe outcome and the reasons of both
+func (r FilterResult) Merge(other FilterResult) FilterResult {
+	if other.Outcome > r.Outcome {
+		r.Outcome = other.Outcome
+	}
+	r.Reasons = append(append([]string(nil), r.Reasons...), other.Reasons...)
+	return r
+}
+
+// Err returns a ContentRejectedError if the content was rejected, and nil otherwise
+func (r FilterResult) Err() error {
+	if r.Outcome != FilterReject {
+		return nil
+	}
+	return &ContentRejectedError{Reasons: r.Reasons}
+}
+
+// ContentRejectedError reports why a content filter rejected a review or comment
+type ContentRejectedError struct {
+	Reasons []string
+}
+
+// Error lists the reasons for the rejection
+func (e *ContentRejectedError) Error() string {
+	if len(e.Reasons) == 0 {
+		return ErrContentRejected.Error()
+	}
+	return ErrContentRejected.Error() + ": " + strings.Join(e.Reasons, "; ")
+}
+
+// Is makes the error match ErrContentRejected
+func (e *ContentRejectedError) Is(target error) bool {
+	return target == ErrContentRejected
+}
@@ -0,0 +1,29 @@
+package model
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterResultMerge(t *testing.T) {
+	result := Allow().Merge(Flag("shouting")).Merge(Allow())
+	assert.Equal(t, FilterFlag, result.Outcome)
+	assert.NoError(t, result.Err())
+
+	result = result.Merge(Reject("banned domain")).Merge(Flag("links"))
+	assert.Equal(t, FilterReject, result.Outcome)
+	assert.Equal(t, []string{"shouting", "banned domain", "links"}, result.Reasons)
+}
+
+func TestContentRejectedError(t *testing.T) {
+	err := Reject("contains blocked word \"scam\"").Err()
+
+	assert.True(t, errors.Is(err, ErrContentRejected))
+	assert.Equal(t, "content rejected: contains blocked word \"scam\"", err.Error())
+
+	var rejected *ContentRejectedError
+	assert.True(t, errors.As(err, &rejected))
+	assert.Len(t, rejected.Reasons, 1)
+}
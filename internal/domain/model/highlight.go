@@ -0,0 +1,23 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Highlight is a key phrase that characterizes a service's reviews
+type Highlight struct {
+	Phrase   string  `json:"phrase"`
+	Score    float64 `json:"score"`    // Relative weight; higher is more characteristic
+	Mentions int     `json:"mentions"` // Number of reviews on this side that mention the phrase
+}
+
+// ServiceHighlights holds the pros and cons extracted from a service's published reviews
+type ServiceHighlights struct {
+	ServiceID   uuid.UUID   `json:"service_id"`
+	Pros        []Highlight `json:"pros"` // Phrases common in high-score reviews
+	Cons        []Highlight `json:"cons"` // Phrases common in low-score reviews
+	ReviewCount int         `json:"review_count"`
+	GeneratedAt time.Time   `json:"generated_at"`
+}
@@ -0,0 +1,19 @@
+package model
+
+// JSONWebKey is a public token verification key in the JWK format (RFC 7517). RSA keys set N and
+// E, Ed25519 keys set Curve and X.
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+}
+
+// JSONWebKeySet is the set of keys other services verify access tokens with
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
@@ -0,0 +1,85 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrTooManyLoginAttempts is returned when logins are refused after too many failed attempts
+var ErrTooManyLoginAttempts = errors.New("too many failed login attempts; try again later")
+
+// LoginThrottledError is returned when a login is refused because of earlier failed attempts,
+// telling the caller how long to wait. It matches ErrTooManyLoginAttempts with errors.Is.
+type LoginThrottledError struct {
+	RetryAfter time.Duration
+}
+
+// Error implements error
+func (e *LoginThrottledError) Error() string {
+	return ErrTooManyLoginAttempts.Error()
+}
+
+// Unwrap lets errors.Is match ErrTooManyLoginAttempts
+func (e *LoginThrottledError) Unwrap() error {
+	return ErrTooManyLoginAttempts
+}
+
+// LoginBackoff is how long a caller must wait after failed login attempts. The first FreeAttempts
+// failures cost nothing; from then on the wait after each failure doubles, starting at BaseDelay
+// and capped at MaxDelay.
+type LoginBackoff struct {
+	FreeAttempts int
+	BaseDelay    time.Duration
+	MaxDelay     time.Duration
+}
+
+// Delay returns how long to wait after the last of the given number of consecutive failures
+func (b LoginBackoff) Delay(failures int) time.Duration {
+	if failures == 0 || failures < b.FreeAttempts {
+		return 0
+	}
+	delay := b.BaseDelay
+	for i := b.FreeAttempts; i < failures && delay < b.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > b.MaxDelay {
+		return b.MaxDelay
+	}
+	return delay
+}
+
+// LoginPolicy sets how failed logins are throttled, per account and per client address, and when
+// an account is locked
+type LoginPolicy struct {
+	Account LoginBackoff
+	Client  LoginBackoff
+	// LockoutThreshold is the number of consecutive failures that lock an account
+	LockoutThreshold int
+	LockoutDuration  time.Duration
+	// FailureTTL is how long failures are remembered after the last one
+	FailureTTL time.Duration
+}
+
+// DefaultLoginPolicy slows an account down after 3 failures and locks it for 15 minutes after 10.
+// Client addresses get more attempts, since users behind one NAT share an address.
+var DefaultLoginPolicy = LoginPolicy{
+	Account:          LoginBackoff{FreeAttempts: 3, BaseDelay: time.Second, MaxDelay: 5 * time.Minute},
+	Client:           LoginBackoff{FreeAttempts: 20, BaseDelay: time.Second, MaxDelay: 15 * time.Minute},
+	LockoutThreshold: 10,
+	LockoutDuration:  15 * time.Minute,
+	FailureTTL:       24 * time.Hour,
+}
+
+// AccountDelay returns how long an account must wait after the given number of consecutive
+// failures, which is the lockout duration once the lockout threshold is reached
+func (p LoginPolicy) AccountDelay(failures int) time.Duration {
+	if p.LockoutThreshold > 0 && failures >= p.LockoutThreshold {
+		return p.LockoutDuration
+	}
+	return p.Account.Delay(failures)
+}
+
+// ShouldLock reports whether the given number of consecutive failures locks an account
+func (p LoginPolicy) ShouldLock(failures int) bool {
+	return p.LockoutThreshold > 0 && failures >= p.LockoutThreshold
+}
@@ -0,0 +1,54 @@
+package model
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoginBackoffDelay(t *testing.T) {
+	backoff := LoginBackoff{FreeAttempts: 3, BaseDelay: time.Second, MaxDelay: 10 * time.Second}
+
+	assert.Equal(t, time.Duration(0), backoff.Delay(0))
+	assert.Equal(t, time.Duration(0), backoff.Delay(2))
+	assert.Equal(t, time.Second, backoff.Delay(3))
+	assert.Equal(t, 2*time.Second, backoff.Delay(4))
+	assert.Equal(t, 8*time.Second, backoff.Delay(6))
+	assert.Equal(t, 10*time.Second, backoff.Delay(7))
+	assert.Equal(t, 10*time.Second, backoff.Delay(1000))
+}
+
+func TestLoginPolicyLockout(t *testing.T) {
+	policy := DefaultLoginPolicy
+
+	assert.False(t, policy.ShouldLock(policy.LockoutThreshold-1))
+	assert.True(t, policy.ShouldLock(policy.LockoutThreshold))
+	assert.Less(t, policy.AccountDelay(policy.LockoutThreshold-1), policy.LockoutDuration)
+	assert.Equal(t, policy.LockoutDuration, policy.AccountDelay(policy.LockoutThreshold))
+
+	disabled := LoginPolicy{Account: policy.Account}
+	assert.False(t, disabled.ShouldLock(1000))
+	assert.Equal(t, policy.Account.MaxDelay, disabled.AccountDelay(1000))
+}
+
+func TestLoginThrottledError(t *testing.T) {
+	var err error = &LoginThrottledError{RetryAfter: time.Minute}
+	assert.True(t, errors.Is(err, ErrTooManyLoginAttempts))
+
+	var throttled *LoginThrottledError
+	assert.True(t, errors.As(err, &throttled))
+	assert.Equal(t, time.Minute, throttled.RetryAfter)
+}
+
+func TestUserIsLocked(t *testing.T) {
+	now := time.Now()
+	user := &User{}
+	assert.False(t, user.IsLocked(now))
+
+	until := now.Add(time.Minute)
+	user.LockedUntil = &until
+	assert.True(t, user.IsLocked(now))
+	assert.False(t, user.IsLocked(until))
+}
@@ -0,0 +1,106 @@
+package model
+
+import (
+	"errors"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+// DefaultMaxMentionsPerPost is the default maximum number of distinct users one review or comment can mention
+const DefaultMaxMentionsPerPost = 10
+
+// maxUsernameLength bounds the handles recognized after an @
+const maxUsernameLength = 64
+
+// ErrTooManyMentions is returned when a post mentions more distinct users than allowed
+var ErrTooManyMentions = errors.New("too many mentions in one post")
+
+// MentionTarget identifies the kind of post a mention appears in
+type MentionTarget string
+
+// Mention targets
+const (
+	MentionTargetReview  MentionTarget = "review"
+	MentionTargetComment MentionTarget = "comment"
+)
+
+// MentionSpan is an @username occurrence in a post's content. Offsets count runes, the
+// start includes the @ and the end is exclusive.
+type MentionSpan struct {
+	Username string
+	Start    int
+	End      int
+}
+
+// Mention links an @username occurrence in a review or comment to the mentioned user
+type Mention struct {
+	ID         uuid.UUID     `json:"-"`
+	TargetType MentionTarget `json:"-"`
+	TargetID   uuid.UUID     `json:"-"`
+	UserID     uuid.UUID     `json:"user_id"`
+	Username   string        `json:"username"`
+	Start      int           `json:"start"`
+	End        int           `json:"end"`
+	CreatedAt  time.Time     `json:"-"`
+}
+
+// NewMention creates a mention of a resolved user at the given span
+func NewMention(targetType MentionTarget, targetID uuid.UUID, user *User, span MentionSpan) *Mention {
+	return &Mention{
+		ID:         uuid.New(),
+		TargetType: targetType,
+		TargetID:   targetID,
+		UserID:     user.ID,
+		Username:   user.Username,
+		Start:      span.Start,
+		End:        span.End,
+		CreatedAt:  time.Now(),
+	}
+}
+
+// ParseMentions finds the @username occurrences in content. An @ only starts a mention at the
+// beginning of the text or after a character that cannot be part of a username, so e-mail
+// addresses are not mistaken for mentions. Trailing dots and dashes are treated as punctuation.
+func ParseMentions(content string) []MentionSpan {
+	runes := []rune(content)
+
+	var spans []MentionSpan
+	for i := 0; i < len(runes); i++ {
+		if runes[i] != '@' || (i > 0 && (isUsernameRune(runes[i-1]) || runes[i-1] == '@')) {
+			continue
+		}
+
+		end := i + 1
+		for end < len(runes) && isUsernameRune(runes[end]) {
+			end++
+		}
+		for end > i+1 && (runes[end-1] == '.' || runes[end-1] == '-') {
+			end--
+		}
+
+		if length := end - i - 1; length > 0 && length <= maxUsernameLength {
+			spans = append(spans, MentionSpan{Username: string(runes[i+1 : end]), Start: i, End: end})
+		}
+		i = end - 1
+	}
+	return spans
+}
+
+// MentionedUsernames returns the distinct usernames of the spans in order of first appearance
+func MentionedUsernames(spans []MentionSpan) []string {
+	seen := make(map[string]bool, len(spans))
+	var usernames []string
+	for _, span := range spans {
+		if !seen[span.Username] {
+			seen[span.Username] = true
+			usernames = append(usernames, span.Username)
+		}
+	}
+	return usernames
+}
+
+func isUsernameRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '.' || r == '-'
+}
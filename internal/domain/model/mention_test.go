@@ -0,0 +1,29 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMentions(t *testing.T) {
+	spans := ParseMentions("Thanks @alice and @bob.smith! Ask @alice, not bob@example.com or @@carol")
+
+	assert.Equal(t, []MentionSpan{
+		{Username: "alice", Start: 7, End: 13},
+		{Username: "bob.smith", Start: 18, End: 28},
+		{Username: "alice", Start: 34, End: 40},
+	}, spans)
+	assert.Equal(t, []string{"alice", "bob.smith"}, MentionedUsernames(spans))
+}
+
+func TestParseMentionsOffsetsCountRunes(t *testing.T) {
+	spans := ParseMentions("Très bien @zoë")
+
+	assert.Equal(t, []MentionSpan{{Username: "zoë", Start: 10, End: 14}}, spans)
+}
+
+func TestParseMentionsIgnoresBareAt(t *testing.T) {
+	assert.Empty(t, ParseMentions("meet @ noon"))
+	assert.Empty(t, ParseMentions("trailing @"))
+}
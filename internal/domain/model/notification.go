@@ -0,0 +1,101 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NotificationType identifies the kind of activity a notification reports
+type NotificationType string
+
+// Notification types
+const (
+	// NotificationCommentOnReview is sent to a review's author when someone comments on it
+	NotificationCommentOnReview NotificationType = "comment_on_review"
+	// NotificationCommentReply is sent to a comment's author when someone replies to it
+	NotificationCommentReply NotificationType = "comment_reply"
+	// NotificationOwnerResponse is sent to a comment's author when the review's author replies to it
+	NotificationOwnerResponse NotificationType = "owner_response"
+	// NotificationModerationOutcome is sent to the author of a review or comment when a moderator
+	// decides on it
+	NotificationModerationOutcome NotificationType = "moderation_outcome"
+	// NotificationMention is sent to a user when someone mentions them in a review or comment
+	NotificationMention NotificationType = "mention"
+)
+
+// NotificationTypes lists all notification types
+var NotificationTypes = []NotificationType{
+	NotificationCommentOnReview,
+	NotificationCommentReply,
+	NotificationOwnerResponse,
+	NotificationModerationOutcome,
+	NotificationMention,
+}
+
+// IsValid reports whether the type is a known notification type
+func (t NotificationType) IsValid() bool {
+	for _, known := range NotificationTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Notification is an entry in a user's inbox
+type Notification struct {
+	ID        uuid.UUID        `json:"id"`
+	UserID    uuid.UUID        `json:"user_id"` // Recipient
+	Type      NotificationType `json:"type"`
+	ActorID   *uuid.UUID       `json:"actor_id,omitempty"` // User whose action caused the notification
+	ReviewID  *uuid.UUID       `json:"review_id,omitempty"`
+	CommentID *uuid.UUID       `json:"comment_id,omitempty"`
+	Detail    string           `json:"detail,omitempty"` // Type specific detail, e.g. the moderation outcome
+	ReadAt    *time.Time       `json:"read_at,omitempty"`
+	CreatedAt time.Time        `json:"created_at"`
+}
+
+// NewNotification creates an unread notification for a recipient
+func NewNotification(userID uuid.UUID, notificationType NotificationType) *Notification {
+	return &Notification{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Type:      notificationType,
+		CreatedAt: time.Now(),
+	}
+}
+
+// NotificationFilter narrows the notifications returned by listing queries
+type NotificationFilter struct {
+	UnreadOnly bool
+}
+
+// NotificationPreferences records which notification types a user wants; types without an
+// explicit preference are enabled
+type NotificationPreferences struct {
+	UserID  uuid.UUID                 `json:"user_id"`
+	Enabled map[NotificationType]bool `json:"enabled"`
+}
+
+// Allows reports whether notifications of the given type should be recorded
+func (p *NotificationPreferences) Allows(notificationType NotificationType) bool {
+	enabled, ok := p.Enabled[notificationType]
+	return !ok || enabled
+}
+
+// WithDefaults returns the preferences with every notification type filled in
+func (p *NotificationPreferences) WithDefaults() *NotificationPreferences {
+	enabled := make(map[NotificationType]bool, len(NotificationTypes))
+	for _, notificationType := range NotificationTypes {
+		enabled[notificationType] = p.Allows(notificationType)
+	}
+	return &NotificationPreferences{UserID: p.UserID, Enabled: enabled}
+}
+
+// NotificationPage is one page of a user's inbox
+type NotificationPage struct {
+	Notifications []*Notification `json:"notifications"`
+	NextCursor    string          `json:"next_cursor,omitempty"` // Empty on the last page
+	UnreadCount   int             `json:"unread_count"`
+}
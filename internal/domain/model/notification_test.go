@@ -0,0 +1,28 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotificationPreferences(t *testing.T) {
+	preferences := &NotificationPreferences{
+		UserID:  uuid.New(),
+		Enabled: map[NotificationType]bool{NotificationCommentReply: false},
+	}
+
+	assert.False(t, preferences.Allows(NotificationCommentReply))
+	assert.True(t, preferences.Allows(NotificationCommentOnReview), "types without a preference are enabled")
+
+	all := preferences.WithDefaults()
+	assert.Len(t, all.Enabled, len(NotificationTypes))
+	assert.False(t, all.Enabled[NotificationCommentReply])
+	assert.True(t, all.Enabled[NotificationModerationOutcome])
+}
+
+func TestNotificationTypeIsValid(t *testing.T) {
+	assert.True(t, NotificationOwnerResponse.IsValid())
+	assert.False(t, NotificationType("unknown").IsValid())
+}
@@ -0,0 +1,78 @@
+package model
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Errors returned by the password policy
+var (
+	ErrPasswordTooShort         = errors.New("password is too short")
+	ErrPasswordTooLong          = errors.New("password must be at most 72 bytes")
+	ErrPasswordTooCommon        = errors.New("password is too common")
+	ErrPasswordContainsIdentity = errors.New("password must not contain your username or email address")
+)
+
+// maxPasswordBytes is the most bcrypt hashes; longer passwords would be silently truncated
+const maxPasswordBytes = 72
+
+// commonPasswords are passwords long enough to pass the length check that are among the first
+// tried by anyone guessing
+var commonPasswords = map[string]bool{
+	"password": true, "password1": true, "password12": true, "password123": true,
+	"passw0rd": true, "p@ssw0rd": true, "12345678": true, "123456789": true,
+	"1234567890": true, "87654321": true, "11111111": true, "00000000": true,
+	"qwertyui": true, "qwerty123": true, "qwertyuiop": true, "1q2w3e4r": true,
+	"1qaz2wsx": true, "abcd1234": true, "abc12345": true, "iloveyou": true,
+	"sunshine": true, "princess": true, "football": true, "baseball": true,
+	"welcome1": true, "letmein1": true, "trustno1": true, "superman": true,
+	"starwars": true, "whatever": true, "changeme": true, "admin123": true,
+}
+
+// PasswordPolicy is what new passwords must satisfy
+type PasswordPolicy struct {
+	MinLength int
+}
+
+// DefaultPasswordPolicy requires passwords of at least 8 characters
+var DefaultPasswordPolicy = PasswordPolicy{MinLength: 8}
+
+// Check returns an error if a password breaks the policy for the given user
+func (p PasswordPolicy) Check(password, username, email string) error {
+	if utf8.RuneCountInString(password) < p.MinLength {
+		return ErrPasswordTooShort
+	}
+	if len(password) > maxPasswordBytes {
+		return ErrPasswordTooLong
+	}
+
+	lower := strings.ToLower(password)
+	if commonPasswords[lower] {
+		return ErrPasswordTooCommon
+	}
+	local, _, _ := strings.Cut(strings.ToLower(email), "@")
+	for _, identity := range []string{strings.ToLower(username), local} {
+		if len(identity) >= 3 && strings.Contains(lower, identity) {
+			return ErrPasswordContainsIdentity
+		}
+	}
+	return nil
+}
+
+// IsPasswordPolicyError reports whether an error is a password policy violation
+func IsPasswordPolicyError(err error) bool {
+	return errors.Is(err, ErrPasswordTooShort) || errors.Is(err, ErrPasswordTooLong) ||
+		errors.Is(err, ErrPasswordTooCommon) || errors.Is(err, ErrPasswordContainsIdentity)
+}
+
+// HashPassword hashes a password for storage
+func HashPassword(password string) (string, error) {
+	hashed, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hashed), nil
+}
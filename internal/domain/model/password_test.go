@@ -0,0 +1,49 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestPasswordPolicy(t *testing.T) {
+	policy := DefaultPasswordPolicy
+
+	tests := []struct {
+		name     string
+		username string
+		password string
+		want     error
+	}{
+		{"acceptable", "alice", "correct horse battery", nil},
+		{"too short", "alice", "s3cret", ErrPasswordTooShort},
+		{"short in characters, not bytes", "alice", "ééééééé", ErrPasswordTooShort},
+		{"too long for bcrypt", "alice", strings.Repeat("a", 73), ErrPasswordTooLong},
+		{"common", "alice", "Password123", ErrPasswordTooCommon},
+		{"contains username", "alice", "my-alice-secret", ErrPasswordContainsIdentity},
+		{"contains email local part", "someone", "x-alice.w-x", ErrPasswordContainsIdentity},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.Check(tt.password, tt.username, "alice.w@example.com")
+			if tt.want == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tt.want)
+			assert.True(t, IsPasswordPolicyError(err))
+		})
+	}
+}
+
+func TestHashPassword(t *testing.T) {
+	hashed, err := HashPassword("correct horse battery")
+	assert.NoError(t, err)
+	assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(hashed), []byte("correct horse battery")))
+
+	user := &User{PasswordHash: hashed}
+	assert.True(t, user.CheckPassword("correct horse battery"))
+	assert.False(t, user.CheckPassword("wrong horse battery"))
+}
@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+// RateLimitDecision is the outcome of counting a request against a rate limit
+type RateLimitDecision struct {
+	Allowed bool
+	// Limit is the number of requests allowed per Window
+	Limit  int
+	Window time.Duration
+	// Remaining is the number of requests the caller can make right now
+	Remaining int
+	// Reset is how long until the caller's full limit is available again
+	Reset time.Duration
+	// RetryAfter is how long until the caller can make another request; zero when allowed
+	RetryAfter time.Duration
+}
@@ -9,12 +9,15 @@ import (
 
 // Rating represents a user rating for a specific service
 type Rating struct {
-	ID        uuid.UUID `json:"id"`
-	UserID    uuid.UUID `json:"user_id"`
-	ServiceID uuid.UUID `json:"service_id"`
-	Score     int       `json:"score"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID         uuid.UUID  `json:"id"`
+	UserID     uuid.UUID  `json:"user_id"`
+	ServiceID  uuid.UUID  `json:"service_id"`
+	Score      int        `json:"score"`
+	Verified   bool       `json:"verified"`
+	VerifiedAt *time.Time `json:"verified_at,omitempty"` // Set once an attestation has been accepted
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	Shadowed   bool       `json:"-"` // Written while the author was shadow-banned
 }
 
 // NewRating creates a new rating with validation
@@ -58,4 +61,16 @@ type AverageRating struct {
 	ServiceID   uuid.UUID `json:"service_id"`
 	AverageScore float64   `json:"average_score"`
 	TotalRatings int       `json:"total_ratings"`
+	VerifiedOnly bool      `json:"verified_only"`
+}
+
+// RatingFilter narrows the ratings considered by listing and aggregate queries
+type RatingFilter struct {
+	VerifiedOnly bool
+}
+
+// MarkVerified flags the rating as backed by a verified interaction
+func (r *Rating) MarkVerified(at time.Time) {
+	r.Verified = true
+	r.VerifiedAt = &at
 }
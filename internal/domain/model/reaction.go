@@ -0,0 +1,97 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultReactionEmojis is the default set of reactions users can leave
+var DefaultReactionEmojis = []string{"👍", "❤️", "😂", "😮"}
+
+// ErrInvalidReaction is returned when a reaction is not in the allowed set
+var ErrInvalidReaction = errors.New("reaction is not allowed")
+
+// ReactionTarget identifies the kind of post a reaction is left on
+type ReactionTarget string
+
+// Reaction targets
+const (
+	ReactionTargetReview  ReactionTarget = "review"
+	ReactionTargetComment ReactionTarget = "comment"
+)
+
+// ReactionSet is the ordered set of emojis users can react with
+type ReactionSet struct {
+	emojis []string
+}
+
+// NewReactionSet creates a reaction set, dropping blank and repeated emojis
+func NewReactionSet(emojis []string) ReactionSet {
+	seen := make(map[string]bool, len(emojis))
+	var set ReactionSet
+	for _, emoji := range emojis {
+		if emoji != "" && !seen[emoji] {
+			seen[emoji] = true
+			set.emojis = append(set.emojis, emoji)
+		}
+	}
+	return set
+}
+
+// Allows reports whether the emoji is in the set
+func (s ReactionSet) Allows(emoji string) bool {
+	for _, allowed := range s.emojis {
+		if allowed == emoji {
+			return true
+		}
+	}
+	return false
+}
+
+// Emojis returns the emojis of the set in order
+func (s ReactionSet) Emojis() []string {
+	return append([]string(nil), s.emojis...)
+}
+
+// Reaction is one user's emoji reaction to a review or comment. A user can leave each emoji
+// once per target.
+type Reaction struct {
+	ID         uuid.UUID      `json:"id"`
+	TargetType ReactionTarget `json:"target_type"`
+	TargetID   uuid.UUID      `json:"target_id"`
+	UserID     uuid.UUID      `json:"user_id"`
+	Emoji      string         `json:"emoji"`
+	CreatedAt  time.Time      `json:"created_at"`
+}
+
+// NewReaction creates a reaction, validating the emoji against the allowed set
+func NewReaction(userID uuid.UUID, targetType ReactionTarget, targetID uuid.UUID, emoji string, allowed ReactionSet) (*Reaction, error) {
+	if !allowed.Allows(emoji) {
+		return nil, ErrInvalidReaction
+	}
+
+	return &Reaction{
+		ID:         uuid.New(),
+		TargetType: targetType,
+		TargetID:   targetID,
+		UserID:     userID,
+		Emoji:      emoji,
+		CreatedAt:  time.Now(),
+	}, nil
+}
+
+// ReactionCount is the number of users who left an emoji on a review or comment
+type ReactionCount struct {
+	TargetID    uuid.UUID `json:"-"`
+	Emoji       string    `json:"emoji"`
+	Count       int       `json:"count"`
+	ReactedByMe bool      `json:"reacted_by_me"` // The viewer left this reaction
+}
+
+// ReactionToggle is the result of toggling a reaction
+type ReactionToggle struct {
+	Reacted   bool             `json:"reacted"` // Whether the reaction was added rather than removed
+	Reactions []*ReactionCount `json:"reactions"`
+}
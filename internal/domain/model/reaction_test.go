@@ -0,0 +1,32 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewReactionSet(t *testing.T) {
+	set := NewReactionSet([]string{"👍", "", "🔥", "👍"})
+
+	assert.Equal(t, []string{"👍", "🔥"}, set.Emojis())
+	assert.True(t, set.Allows("🔥"))
+	assert.False(t, set.Allows("😂"))
+}
+
+func TestNewReaction(t *testing.T) {
+	set := NewReactionSet(DefaultReactionEmojis)
+	userID, targetID := uuid.New(), uuid.New()
+
+	reaction, err := NewReaction(userID, ReactionTargetComment, targetID, "❤️", set)
+	require.NoError(t, err)
+	assert.Equal(t, userID, reaction.UserID)
+	assert.Equal(t, ReactionTargetComment, reaction.TargetType)
+	assert.Equal(t, targetID, reaction.TargetID)
+	assert.Equal(t, "❤️", reaction.Emoji)
+
+	_, err = NewReaction(userID, ReactionTargetComment, targetID, "💩", set)
+	assert.ErrorIs(t, err, ErrInvalidReaction)
+}
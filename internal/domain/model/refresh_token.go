@@ -0,0 +1,70 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Errors returned when refreshing tokens
+var (
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	// ErrRefreshTokenReused is returned when a refresh token that was already exchanged is presented
+	// again; the token has probably been stolen, so its whole family is revoked
+	ErrRefreshTokenReused = errors.New("refresh token reused")
+)
+
+// refreshTokenBytes is the amount of randomness in a refresh token
+const refreshTokenBytes = 32
+
+// RefreshToken is a long-lived, single-use credential exchanged for a new access token and a new
+// refresh token. Only a hash of the token is stored. Tokens descending from the same login form a
+// family, which is revoked as a whole if any of its used tokens is presented again.
+type RefreshToken struct {
+	ID        uuid.UUID
+	FamilyID  uuid.UUID
+	UserID    uuid.UUID
+	TokenHash string
+	ExpiresAt time.Time
+	// RotatedAt is when the token was exchanged for its successor
+	RotatedAt *time.Time
+	RevokedAt *time.Time
+	CreatedAt time.Time
+}
+
+// NewRefreshToken creates a refresh token in a family, returning it along with the opaque token
+// handed to the client. A new login starts a new family.
+func NewRefreshToken(userID, familyID uuid.UUID, ttl time.Duration, now time.Time) (*RefreshToken, string, error) {
+	token, err := newSecret(refreshTokenBytes)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &RefreshToken{
+		ID:        uuid.New(),
+		FamilyID:  familyID,
+		UserID:    userID,
+		TokenHash: HashRefreshToken(token),
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+	}, token, nil
+}
+
+// HashRefreshToken returns the hash a refresh token is stored and looked up by
+func HashRefreshToken(token string) string {
+	return hashSecret(token)
+}
+
+// IsActive reports whether the token can still be exchanged
+func (t *RefreshToken) IsActive(now time.Time) bool {
+	return t.RotatedAt == nil && t.RevokedAt == nil && now.Before(t.ExpiresAt)
+}
+
+// TokenPair is the access token and refresh token issued on login, registration and refresh
+type TokenPair struct {
+	AccessToken           string    `json:"token"`
+	AccessTokenExpiresAt  time.Time `json:"token_expires_at"`
+	RefreshToken          string    `json:"refresh_token"`
+	RefreshTokenExpiresAt time.Time `json:"refresh_token_expires_at"`
+}
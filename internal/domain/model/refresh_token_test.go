@@ -0,0 +1,44 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRefreshToken(t *testing.T) {
+	now := time.Now()
+	userID, familyID := uuid.New(), uuid.New()
+
+	stored, token, err := NewRefreshToken(userID, familyID, time.Hour, now)
+	require.NoError(t, err)
+	assert.Equal(t, userID, stored.UserID)
+	assert.Equal(t, familyID, stored.FamilyID)
+	assert.Equal(t, HashRefreshToken(token), stored.TokenHash)
+	assert.NotContains(t, stored.TokenHash, token)
+	assert.Equal(t, now.Add(time.Hour), stored.ExpiresAt)
+
+	_, other, err := NewRefreshToken(userID, familyID, time.Hour, now)
+	require.NoError(t, err)
+	assert.NotEqual(t, token, other)
+}
+
+func TestRefreshTokenIsActive(t *testing.T) {
+	now := time.Now()
+	stored, _, err := NewRefreshToken(uuid.New(), uuid.New(), time.Hour, now)
+	require.NoError(t, err)
+
+	assert.True(t, stored.IsActive(now))
+	assert.False(t, stored.IsActive(now.Add(2*time.Hour)))
+
+	rotated := *stored
+	rotated.RotatedAt = &now
+	assert.False(t, rotated.IsActive(now))
+
+	revoked := *stored
+	revoked.RevokedAt = &now
+	assert.False(t, revoked.IsActive(now))
+}
@@ -0,0 +1,207 @@
+package model
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// maxReportDetailsLength bounds the free text a reporter can add, in characters
+const maxReportDetailsLength = 1000
+
+// Errors returned when reporting and moderating content
+var (
+	ErrDuplicateReport        = errors.New("content already reported by this user")
+	ErrInvalidReportReason    = errors.New("invalid report reason")
+	ErrInvalidReportTarget    = errors.New("invalid report target")
+	ErrInvalidModeration      = errors.New("invalid moderation action")
+	ErrModerationReasonNeeded = errors.New("a reason is required for moderation decisions")
+	ErrModerationConflict     = errors.New("content was moderated by someone else in the meantime")
+)
+
+// ReportTarget identifies the kind of content a report or moderation decision concerns
+type ReportTarget string
+
+// Report targets
+const (
+	ReportTargetReview  ReportTarget = "review"
+	ReportTargetComment ReportTarget = "comment"
+)
+
+// IsValid reports whether the target is a kind of content that can be reported
+func (t ReportTarget) IsValid() bool {
+	return t == ReportTargetReview || t == ReportTargetComment
+}
+
+// ReportReason categorizes why content was reported
+type ReportReason string
+
+// Report reasons
+const (
+	ReportReasonSpam           ReportReason = "spam"
+	ReportReasonHarassment     ReportReason = "harassment"
+	ReportReasonHateSpeech     ReportReason = "hate_speech"
+	ReportReasonMisinformation ReportReason = "misinformation"
+	ReportReasonOffTopic       ReportReason = "off_topic"
+	ReportReasonOther          ReportReason = "other"
+	// ReportReasonContentFilter is used for reports raised by the content filter; users cannot pick it
+	ReportReasonContentFilter ReportReason = "content_filter"
+)
+
+// IsValid reports whether the reason is a known report reason
+func (r ReportReason) IsValid() bool {
+	switch r {
+	case ReportReasonSpam, ReportReasonHarassment, ReportReasonHateSpeech, ReportReasonMisinformation,
+		ReportReasonOffTopic, ReportReasonOther:
+		return true
+	}
+	return false
+}
+
+// ModerationStatus is the moderation state of a review or comment
+type ModerationStatus string
+
+// Moderation states
+const (
+	// ModerationVisible is content nobody has reported, or that a moderator approved
+	ModerationVisible ModerationStatus = "visible"
+	// ModerationPendingReview is reported content awaiting a moderator; it stays visible meanwhile
+	ModerationPendingReview ModerationStatus = "pending_review"
+	// ModerationHidden is content a moderator hid from everyone but its author
+	ModerationHidden ModerationStatus = "hidden"
+	// ModerationRemoved is content a moderator took down
+	ModerationRemoved ModerationStatus = "removed"
+)
+
+// IsPublic reports whether content in this state is shown in public queries and aggregates
+func (s ModerationStatus) IsPublic() bool {
+	return s == ModerationVisible || s == ModerationPendingReview
+}
+
+// Report is one user's report of a review or comment. Each user can report a piece of content once.
+type Report struct {
+	ID         uuid.UUID    `json:"id"`
+	ReporterID *uuid.UUID   `json:"reporter_id,omitempty"` // Nil for reports raised by the content filter
+	TargetType ReportTarget `json:"target_type"`
+	TargetID   uuid.UUID    `json:"target_id"`
+	Reason     ReportReason `json:"reason"`
+	Details    string       `json:"details,omitempty"`
+	ResolvedAt *time.Time   `json:"resolved_at,omitempty"` // Set when a moderator decides on the content
+	CreatedAt  time.Time    `json:"created_at"`
+}
+
+// NewReport creates a report with validation
+func NewReport(reporterID uuid.UUID, targetType ReportTarget, targetID uuid.UUID, reason ReportReason, details string) (*Report, error) {
+	if !targetType.IsValid() || targetID == uuid.Nil {
+		return nil, ErrInvalidReportTarget
+	}
+
+	if !reason.IsValid() {
+		return nil, ErrInvalidReportReason
+	}
+
+	details = strings.TrimSpace(details)
+	if err := checkLength("details", details, maxReportDetailsLength); err != nil {
+		return nil, err
+	}
+
+	return &Report{
+		ID:         uuid.New(),
+		ReporterID: &reporterID,
+		TargetType: targetType,
+		TargetID:   targetID,
+		Reason:     reason,
+		Details:    details,
+		CreatedAt:  time.Now(),
+	}, nil
+}
+
+// NewFilterReport creates a report on behalf of the content filter, which flagged the content
+// for the given reasons
+func NewFilterReport(targetType ReportTarget, targetID uuid.UUID, reasons []string) *Report {
+	return &Report{
+		ID:         uuid.New(),
+		TargetType: targetType,
+		TargetID:   targetID,
+		Reason:     ReportReasonContentFilter,
+		Details:    strings.Join(reasons, "; "),
+		CreatedAt:  time.Now(),
+	}
+}
+
+// ModerationAction is a moderator's decision on reported content
+type ModerationAction string
+
+// Moderation actions
+const (
+	ModerationActionApprove ModerationAction = "approve"
+	ModerationActionHide    ModerationAction = "hide"
+	ModerationActionRemove  ModerationAction = "remove"
+)
+
+// Status returns the moderation state content is left in by the action
+func (a ModerationAction) Status() (ModerationStatus, bool) {
+	switch a {
+	case ModerationActionApprove:
+		return ModerationVisible, true
+	case ModerationActionHide:
+		return ModerationHidden, true
+	case ModerationActionRemove:
+		return ModerationRemoved, true
+	}
+	return "", false
+}
+
+// ModerationDecision records a moderator's decision on a review or comment
+type ModerationDecision struct {
+	ID             uuid.UUID        `json:"id"`
+	ModeratorID    uuid.UUID        `json:"moderator_id"`
+	TargetType     ReportTarget     `json:"target_type"`
+	TargetID       uuid.UUID        `json:"target_id"`
+	Action         ModerationAction `json:"action"`
+	Reason         string           `json:"reason"`
+	PreviousStatus ModerationStatus `json:"previous_status"`
+	Status         ModerationStatus `json:"status"`
+	CreatedAt      time.Time        `json:"created_at"`
+}
+
+// NewModerationDecision creates a decision moving content from its previous moderation state
+func NewModerationDecision(moderatorID uuid.UUID, targetType ReportTarget, targetID uuid.UUID, action ModerationAction, reason string, previous ModerationStatus) (*ModerationDecision, error) {
+	if !targetType.IsValid() || targetID == uuid.Nil {
+		return nil, ErrInvalidReportTarget
+	}
+
+	status, ok := action.Status()
+	if !ok {
+		return nil, ErrInvalidModeration
+	}
+
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, ErrModerationReasonNeeded
+	}
+
+	return &ModerationDecision{
+		ID:             uuid.New(),
+		ModeratorID:    moderatorID,
+		TargetType:     targetType,
+		TargetID:       targetID,
+		Action:         action,
+		Reason:         reason,
+		PreviousStatus: previous,
+		Status:         status,
+		CreatedAt:      time.Now(),
+	}, nil
+}
+
+// ModerationQueueItem is reported content awaiting a moderator's decision
+type ModerationQueueItem struct {
+	TargetType      ReportTarget   `json:"target_type"`
+	TargetID        uuid.UUID      `json:"target_id"`
+	ReportCount     int            `json:"report_count"`
+	Reasons         []ReportReason `json:"reasons"`
+	FirstReportedAt time.Time      `json:"first_reported_at"`
+	LastReportedAt  time.Time      `json:"last_reported_at"`
+}
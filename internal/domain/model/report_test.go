@@ -0,0 +1,71 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewReport(t *testing.T) {
+	reporterID, targetID := uuid.New(), uuid.New()
+
+	report, err := NewReport(reporterID, ReportTargetComment, targetID, ReportReasonSpam, "  buy now links  ")
+	require.NoError(t, err)
+	assert.Equal(t, &reporterID, report.ReporterID)
+	assert.Equal(t, ReportTargetComment, report.TargetType)
+	assert.Equal(t, targetID, report.TargetID)
+	assert.Equal(t, "buy now links", report.Details)
+	assert.Nil(t, report.ResolvedAt)
+
+	_, err = NewReport(reporterID, "rating", targetID, ReportReasonSpam, "")
+	assert.ErrorIs(t, err, ErrInvalidReportTarget)
+
+	_, err = NewReport(reporterID, ReportTargetReview, uuid.Nil, ReportReasonSpam, "")
+	assert.ErrorIs(t, err, ErrInvalidReportTarget)
+
+	_, err = NewReport(reporterID, ReportTargetReview, targetID, "rude", "")
+	assert.ErrorIs(t, err, ErrInvalidReportReason)
+
+	_, err = NewReport(reporterID, ReportTargetReview, targetID, ReportReasonOther, strings.Repeat("x", maxReportDetailsLength+1))
+	assert.ErrorIs(t, err, ErrContentTooLong)
+}
+
+func TestModerationStatusIsPublic(t *testing.T) {
+	assert.True(t, ModerationVisible.IsPublic())
+	assert.True(t, ModerationPendingReview.IsPublic())
+	assert.False(t, ModerationHidden.IsPublic())
+	assert.False(t, ModerationRemoved.IsPublic())
+}
+
+func TestNewModerationDecision(t *testing.T) {
+	moderatorID, targetID := uuid.New(), uuid.New()
+
+	decision, err := NewModerationDecision(moderatorID, ReportTargetReview, targetID, ModerationActionHide, " abusive ", ModerationPendingReview)
+	require.NoError(t, err)
+	assert.Equal(t, ModerationPendingReview, decision.PreviousStatus)
+	assert.Equal(t, ModerationHidden, decision.Status)
+	assert.Equal(t, "abusive", decision.Reason)
+
+	decision, err = NewModerationDecision(moderatorID, ReportTargetReview, targetID, ModerationActionApprove, "fine", ModerationHidden)
+	require.NoError(t, err)
+	assert.Equal(t, ModerationVisible, decision.Status)
+
+	_, err = NewModerationDecision(moderatorID, ReportTargetReview, targetID, "ban", "spam", ModerationVisible)
+	assert.ErrorIs(t, err, ErrInvalidModeration)
+
+	_, err = NewModerationDecision(moderatorID, ReportTargetReview, targetID, ModerationActionRemove, "   ", ModerationVisible)
+	assert.ErrorIs(t, err, ErrModerationReasonNeeded)
+}
+
+func TestNewFilterReport(t *testing.T) {
+	targetID := uuid.New()
+
+	report := NewFilterReport(ReportTargetReview, targetID, []string{"contains watched word \"scam\"", "written mostly in capital letters"})
+	assert.Nil(t, report.ReporterID)
+	assert.Equal(t, ReportReasonContentFilter, report.Reason)
+	assert.Equal(t, "contains watched word \"scam\"; written mostly in capital letters", report.Details)
+	assert.False(t, ReportReasonContentFilter.IsValid(), "users cannot pick the content filter reason")
+}
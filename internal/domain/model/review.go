@@ -7,16 +7,61 @@ import (
 	"github.com/google/uuid"
 )
 
+// ReviewStatus is the lifecycle state of a review
+type ReviewStatus string
+
+// Review lifecycle states
+const (
+	// ReviewStatusDraft is only visible to its author
+	ReviewStatusDraft ReviewStatus = "draft"
+	// ReviewStatusPending awaits moderation or the end of a cool-down period
+	ReviewStatusPending ReviewStatus = "pending"
+	// ReviewStatusPublished is publicly visible and counted in aggregates
+	ReviewStatusPublished ReviewStatus = "published"
+	// ReviewStatusRejected was declined by a moderator
+	ReviewStatusRejected ReviewStatus = "rejected"
+)
+
+// ErrInvalidReviewTransition is returned when a review cannot move to the requested state
+var ErrInvalidReviewTransition = errors.New("invalid review status transition")
+
+// IsValid reports whether the status is a known review state
+func (s ReviewStatus) IsValid() bool {
+	switch s {
+	case ReviewStatusDraft, ReviewStatusPending, ReviewStatusPublished, ReviewStatusRejected:
+		return true
+	}
+	return false
+}
+
 // Review represents a user review for a specific service
 type Review struct {
-	ID        uuid.UUID `json:"id"`
-	UserID    uuid.UUID `json:"user_id"`
-	ServiceID uuid.UUID `json:"service_id"`
-	RatingID  uuid.UUID `json:"rating_id"`
-	Title     string    `json:"title"`
-	Content   string    `json:"content"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID                uuid.UUID        `json:"id"`
+	UserID            uuid.UUID        `json:"user_id"`
+	ServiceID         uuid.UUID        `json:"service_id"`
+	RatingID          uuid.UUID        `json:"rating_id"`
+	Title             string           `json:"title"`
+	Content           string           `json:"content"`      // Markdown source
+	ContentHTML       string           `json:"content_html"` // Sanitised rendering of the content
+	Status            ReviewStatus     `json:"status"`
+	ModerationStatus  ModerationStatus `json:"moderation_status"`
+	SentimentScore    *float64         `json:"sentiment_score,omitempty"` // In [-1, 1]; nil when the text has not been analyzed
+	SentimentMismatch bool             `json:"sentiment_mismatch"`        // Text sentiment contradicts the star score
+	PublishAt         *time.Time       `json:"publish_at,omitempty"`      // End of the cool-down for pending reviews
+	PublishedAt       *time.Time       `json:"published_at,omitempty"`
+	CreatedAt         time.Time        `json:"created_at"`
+	UpdatedAt         time.Time        `json:"updated_at"`
+	Mentions          []*Mention       `json:"mentions,omitempty"` // Users mentioned in the content
+	Reactions         []*ReactionCount `json:"reactions,omitempty"`
+	Shadowed          bool             `json:"-"` // Written while the author was shadow-banned
+}
+
+// ReviewPolicy decides what happens to a review when its author submits it
+type ReviewPolicy struct {
+	// RequireModeration holds submitted reviews as pending until a moderator approves them
+	RequireModeration bool
+	// CoolDown holds submitted reviews as pending for this long before publishing them
+	CoolDown time.Duration
 }
 
 // NewReview creates a new review with validation
@@ -41,19 +86,83 @@ func NewReview(userID, serviceID, ratingID uuid.UUID, title, content string) (*R
 		return nil, errors.New("content cannot be empty")
 	}
 
+	if err := checkReviewLength(title, content); err != nil {
+		return nil, err
+	}
+
 	now := time.Now()
 	return &Review{
-		ID:        uuid.New(),
-		UserID:    userID,
-		ServiceID: serviceID,
-		RatingID:  ratingID,
-		Title:     title,
-		Content:   content,
-		CreatedAt: now,
-		UpdatedAt: now,
+		ID:               uuid.New(),
+		UserID:           userID,
+		ServiceID:        serviceID,
+		RatingID:         ratingID,
+		Title:            title,
+		Content:          content,
+		Status:           ReviewStatusDraft,
+		ModerationStatus: ModerationVisible,
+		CreatedAt:        now,
+		UpdatedAt:        now,
 	}, nil
 }
 
+// Submit moves a draft or rejected review into the publication flow according to the policy
+func (r *Review) Submit(policy ReviewPolicy, now time.Time) error {
+	if r.Status != ReviewStatusDraft && r.Status != ReviewStatusRejected {
+		return ErrInvalidReviewTransition
+	}
+
+	switch {
+	case policy.RequireModeration:
+		r.Status = ReviewStatusPending
+		r.PublishAt = nil
+	case policy.CoolDown > 0:
+		publishAt := now.Add(policy.CoolDown)
+		r.Status = ReviewStatusPending
+		r.PublishAt = &publishAt
+	default:
+		r.Status = ReviewStatusPublished
+		r.PublishAt = nil
+		r.PublishedAt = &now
+	}
+
+	r.UpdatedAt = now
+	return nil
+}
+
+// Publish makes a pending review publicly visible
+func (r *Review) Publish(now time.Time) error {
+	if r.Status != ReviewStatusPending {
+		return ErrInvalidReviewTransition
+	}
+
+	r.Status = ReviewStatusPublished
+	r.PublishAt = nil
+	r.PublishedAt = &now
+	r.UpdatedAt = now
+	return nil
+}
+
+// Reject declines a pending review
+func (r *Review) Reject(now time.Time) error {
+	if r.Status != ReviewStatusPending {
+		return ErrInvalidReviewTransition
+	}
+
+	r.Status = ReviewStatusRejected
+	r.PublishAt = nil
+	r.UpdatedAt = now
+	return nil
+}
+
+// IsVisibleTo reports whether the review can be seen by the given viewer. Unpublished reviews and
+// reviews hidden or removed by a moderator are only visible to their author.
+func (r *Review) IsVisibleTo(viewerID uuid.UUID) bool {
+	if viewerID != uuid.Nil && viewerID == r.UserID {
+		return true
+	}
+	return r.Status == ReviewStatusPublished && r.ModerationStatus.IsPublic()
+}
+
 // UpdateContent updates the review content
 func (r *Review) UpdateContent(title, content string) error {
 	if title == "" {
@@ -64,14 +173,66 @@ func (r *Review) UpdateContent(title, content string) error {
 		return errors.New("content cannot be empty")
 	}
 
+	if err := checkReviewLength(title, content); err != nil {
+		return err
+	}
+
 	r.Title = title
 	r.Content = content
 	r.UpdatedAt = time.Now()
 	return nil
 }
 
+// checkReviewLength enforces the configured title and content limits
+func checkReviewLength(title, content string) error {
+	if err := checkLength("title", title, contentLimits.ReviewTitle); err != nil {
+		return err
+	}
+	return checkLength("content", content, contentLimits.ReviewContent)
+}
+
+// SetSentiment records the analyzed sentiment of the review text
+func (r *Review) SetSentiment(score float64) {
+	r.SentimentScore = &score
+}
+
+// DetectSentimentMismatch flags the review when its text sentiment contradicts the star score of
+// its rating
+func (r *Review) DetectSentimentMismatch(stars int) {
+	r.SentimentMismatch = r.SentimentScore != nil && IsSentimentMismatch(stars, *r.SentimentScore)
+}
+
 // ReviewWithRating represents a review with its associated rating
 type ReviewWithRating struct {
 	Review
-	Score int `json:"score"`
+	Score          int        `json:"score"`
+	Verified       bool       `json:"verified"`
+	CommentCount   int        `json:"comment_count"` // Comments that have not been deleted
+	LatestComments []*Comment `json:"latest_comments,omitempty"`
+}
+
+// DetectSentimentMismatch flags the review when its text sentiment contradicts its star score
+func (r *ReviewWithRating) DetectSentimentMismatch() {
+	r.Review.DetectSentimentMismatch(r.Score)
+}
+
+// ReviewFilter narrows the reviews returned by listing queries
+type ReviewFilter struct {
+	VerifiedOnly bool
+	// CommentPreview is the number of latest comments to include with each review, up to MaxCommentPreview
+	CommentPreview int
+}
+
+// MaxCommentPreview is the maximum number of latest comments included with each listed review
+const MaxCommentPreview = 10
+
+// AttachLatestComments assigns comments to the reviews they belong to, keeping their order
+func AttachLatestComments(reviews []*ReviewWithRating, comments []*Comment) {
+	byReview := make(map[uuid.UUID][]*Comment, len(reviews))
+	for _, comment := range comments {
+		byReview[comment.ReviewID] = append(byReview[comment.ReviewID], comment)
+	}
+	for _, review := range reviews {
+		review.LatestComments = byReview[review.ID]
+	}
 }
@@ -0,0 +1,107 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestReview(t *testing.T) *Review {
+	review, err := NewReview(uuid.New(), uuid.New(), uuid.New(), "Title", "Content")
+	assert.NoError(t, err)
+	return review
+}
+
+func TestReviewSubmitAutoPublish(t *testing.T) {
+	review := newTestReview(t)
+	assert.Equal(t, ReviewStatusDraft, review.Status)
+
+	now := time.Now()
+	assert.NoError(t, review.Submit(ReviewPolicy{}, now))
+	assert.Equal(t, ReviewStatusPublished, review.Status)
+	assert.Equal(t, now, *review.PublishedAt)
+
+	// Published reviews cannot be submitted again
+	assert.ErrorIs(t, review.Submit(ReviewPolicy{}, now), ErrInvalidReviewTransition)
+}
+
+func TestReviewSubmitCoolDown(t *testing.T) {
+	review := newTestReview(t)
+
+	now := time.Now()
+	assert.NoError(t, review.Submit(ReviewPolicy{CoolDown: time.Hour}, now))
+	assert.Equal(t, ReviewStatusPending, review.Status)
+	assert.Equal(t, now.Add(time.Hour), *review.PublishAt)
+	assert.Nil(t, review.PublishedAt)
+}
+
+func TestReviewModeration(t *testing.T) {
+	review := newTestReview(t)
+	policy := ReviewPolicy{RequireModeration: true, CoolDown: time.Hour}
+
+	assert.NoError(t, review.Submit(policy, time.Now()))
+	assert.Equal(t, ReviewStatusPending, review.Status)
+	assert.Nil(t, review.PublishAt)
+
+	assert.NoError(t, review.Reject(time.Now()))
+	assert.Equal(t, ReviewStatusRejected, review.Status)
+	assert.ErrorIs(t, review.Publish(time.Now()), ErrInvalidReviewTransition)
+
+	// Rejected reviews can be resubmitted and approved
+	assert.NoError(t, review.Submit(policy, time.Now()))
+	assert.NoError(t, review.Publish(time.Now()))
+	assert.Equal(t, ReviewStatusPublished, review.Status)
+}
+
+func TestReviewVisibility(t *testing.T) {
+	review := newTestReview(t)
+
+	assert.True(t, review.IsVisibleTo(review.UserID))
+	assert.False(t, review.IsVisibleTo(uuid.New()))
+	assert.False(t, review.IsVisibleTo(uuid.Nil))
+
+	assert.NoError(t, review.Submit(ReviewPolicy{}, time.Now()))
+	assert.True(t, review.IsVisibleTo(uuid.Nil))
+
+	review.ModerationStatus = ModerationPendingReview
+	assert.True(t, review.IsVisibleTo(uuid.Nil), "reported reviews stay visible until a decision")
+
+	review.ModerationStatus = ModerationHidden
+	assert.False(t, review.IsVisibleTo(uuid.Nil))
+	assert.True(t, review.IsVisibleTo(review.UserID), "authors still see their hidden reviews")
+}
+
+func TestReviewSentimentMismatch(t *testing.T) {
+	review := ReviewWithRating{Review: *newTestReview(t), Score: 5}
+
+	review.DetectSentimentMismatch()
+	assert.False(t, review.SentimentMismatch, "unscored reviews are never flagged")
+
+	review.SetSentiment(-0.8)
+	review.DetectSentimentMismatch()
+	assert.True(t, review.SentimentMismatch)
+
+	review.Score = 3
+	review.DetectSentimentMismatch()
+	assert.False(t, review.SentimentMismatch, "neutral stars never mismatch")
+
+	review.Score = 1
+	review.SetSentiment(0.6)
+	review.DetectSentimentMismatch()
+	assert.True(t, review.SentimentMismatch)
+}
+
+func TestAttachLatestComments(t *testing.T) {
+	first := &ReviewWithRating{Review: *newTestReview(t)}
+	second := &ReviewWithRating{Review: *newTestReview(t)}
+
+	newer := &Comment{ID: uuid.New(), ReviewID: first.ID}
+	older := &Comment{ID: uuid.New(), ReviewID: first.ID}
+
+	AttachLatestComments([]*ReviewWithRating{first, second}, []*Comment{newer, older})
+
+	assert.Equal(t, []*Comment{newer, older}, first.LatestComments)
+	assert.Empty(t, second.LatestComments)
+}
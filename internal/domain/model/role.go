@@ -0,0 +1,209 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Errors returned when managing roles
+var (
+	ErrInvalidRole        = errors.New("invalid role")
+	ErrRoleAlreadyGranted = errors.New("role already granted to this user")
+	ErrRoleNotGranted     = errors.New("role not granted to this user")
+	ErrLastAdmin          = errors.New("cannot revoke the admin role from the last admin")
+	ErrAdminAlreadyExists = errors.New("an admin already exists")
+)
+
+// Role is a named set of permissions held by a user
+type Role string
+
+// Roles
+const (
+	// RoleUser is held implicitly by every authenticated user and is never stored
+	RoleUser Role = "user"
+	// RoleServiceOwner is held by people running a rated service. Grants are not bound to a service
+	// yet, so the role adds no permissions; it must not expose moderation data about other services.
+	RoleServiceOwner Role = "service_owner"
+	// RoleModerator can decide on reported content and pending reviews
+	RoleModerator Role = "moderator"
+	// RoleAdmin can do everything, including granting and revoking roles
+	RoleAdmin Role = "admin"
+)
+
+// IsValid reports whether the role is a known role
+func (r Role) IsValid() bool {
+	_, ok := rolePermissions[r]
+	return ok
+}
+
+// IsGrantable reports whether the role can be granted and revoked; the user role is implicit
+func (r Role) IsGrantable() bool {
+	return r.IsValid() && r != RoleUser
+}
+
+// IsPrivileged reports whether the role grants permissions beyond those of the user role, such
+// as moderating or administering the service
+func (r Role) IsPrivileged() bool {
+	return r != RoleUser && len(rolePermissions[r]) > 0
+}
+
+// Permission is an action a route or operation requires
+type Permission string
+
+// Permissions
+const (
+	PermissionReadRatings         Permission = "ratings:read"
+	PermissionWriteRatings        Permission = "ratings:write"
+	PermissionReadReviews         Permission = "reviews:read"
+	PermissionWriteReviews        Permission = "reviews:write"
+	PermissionWriteComments       Permission = "comments:write"
+	PermissionReact               Permission = "reactions:write"
+	PermissionReportContent       Permission = "reports:create"
+	PermissionManageNotifications Permission = "notifications:manage"
+	PermissionBlockUsers          Permission = "blocks:manage"
+	PermissionViewModeration      Permission = "moderation:read"
+	PermissionModerate            Permission = "moderation:write"
+	PermissionManageRoles         Permission = "roles:manage"
+	PermissionManageUsers         Permission = "users:manage"
+	PermissionManageAPIKeys       Permission = "api-keys:manage"
+)
+
+// userPermissions are the permissions every authenticated user holds
+var userPermissions = []Permission{
+	PermissionReadRatings,
+	PermissionWriteRatings,
+	PermissionReadReviews,
+	PermissionWriteReviews,
+	PermissionWriteComments,
+	PermissionReact,
+	PermissionReportContent,
+	PermissionManageNotifications,
+	PermissionBlockUsers,
+}
+
+// rolePermissions is the permission matrix. Each role lists the permissions it adds to the
+// implicit user role.
+var rolePermissions = map[Role][]Permission{
+	RoleUser:         userPermissions,
+	RoleServiceOwner: {},
+	RoleModerator:    {PermissionViewModeration, PermissionModerate},
+	RoleAdmin:        {PermissionViewModeration, PermissionModerate, PermissionManageRoles, PermissionManageUsers, PermissionManageAPIKeys},
+}
+
+// IsValid reports whether the permission is a known permission; the admin role holds them all
+func (p Permission) IsValid() bool {
+	return RoleAdmin.HasPermission(p)
+}
+
+// Permissions returns the permissions the role grants, including those of the user role
+func (r Role) Permissions() []Permission {
+	if !r.IsValid() {
+		return nil
+	}
+	permissions := append([]Permission(nil), userPermissions...)
+	if r != RoleUser {
+		permissions = append(permissions, rolePermissions[r]...)
+	}
+	return permissions
+}
+
+// HasPermission reports whether the role grants the permission
+func (r Role) HasPermission(permission Permission) bool {
+	for _, p := range r.Permissions() {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// RoleGrant records that a user holds a role
+type RoleGrant struct {
+	UserID    uuid.UUID  `json:"user_id"`
+	Role      Role       `json:"role"`
+	GrantedBy *uuid.UUID `json:"granted_by,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// NewRoleGrant creates a grant of a role to a user. grantedBy is nil for grants made outside the
+// API, such as bootstrapping the first admin.
+func NewRoleGrant(userID uuid.UUID, role Role, grantedBy *uuid.UUID) (*RoleGrant, error) {
+	if !role.IsGrantable() {
+		return nil, ErrInvalidRole
+	}
+	return &RoleGrant{
+		UserID:    userID,
+		Role:      role,
+		GrantedBy: grantedBy,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// Principal is an authenticated caller and the roles they held when their token was issued, or,
+// for API keys, the roles the key's user holds now
+type Principal struct {
+	UserID uuid.UUID
+	Roles  []Role
+	// Scopes restricts an API key to a subset of the permissions its user's roles grant; it is nil
+	// for access tokens, which carry all of them
+	Scopes []Permission
+	// ServiceID restricts an API key to rating and reviewing one service
+	ServiceID *uuid.UUID
+	APIKeyID  *uuid.UUID
+	// TokenID identifies the access token the caller presented, so it can be revoked on logout
+	TokenID string
+	// Generation is the caller's token generation when the token was issued; tokens of earlier
+	// generations are revoked
+	Generation int
+	ExpiresAt  time.Time
+}
+
+// HasRole reports whether the principal holds any of the roles. Every principal holds the user role.
+func (p *Principal) HasRole(roles ...Role) bool {
+	for _, role := range roles {
+		if role == RoleUser {
+			return true
+		}
+		for _, held := range p.Roles {
+			if held == role {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Can reports whether any of the principal's roles grants the permission and, for API keys, the
+// key's scopes include it
+func (p *Principal) Can(permission Permission) bool {
+	if p.Scopes != nil && !containsPermission(p.Scopes, permission) {
+		return false
+	}
+	if RoleUser.HasPermission(permission) {
+		return true
+	}
+	for _, role := range p.Roles {
+		if role.HasPermission(permission) {
+			return true
+		}
+	}
+	return false
+}
+
+// CanAccessService reports whether the principal may act on the service; only API keys restricted
+// to another service may not
+func (p *Principal) CanAccessService(serviceID uuid.UUID) bool {
+	return p.ServiceID == nil || *p.ServiceID == serviceID
+}
+
+// containsPermission reports whether the permission is in the list
+func containsPermission(permissions []Permission, permission Permission) bool {
+	for _, p := range permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
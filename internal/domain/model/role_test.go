@@ -0,0 +1,106 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRolePermissionMatrix(t *testing.T) {
+	tests := []struct {
+		role       Role
+		permission Permission
+		want       bool
+	}{
+		{RoleUser, PermissionWriteReviews, true},
+		{RoleUser, PermissionReportContent, true},
+		{RoleUser, PermissionBlockUsers, true},
+		{RoleUser, PermissionViewModeration, false},
+		{RoleServiceOwner, PermissionWriteComments, true},
+		{RoleServiceOwner, PermissionViewModeration, false},
+		{RoleServiceOwner, PermissionModerate, false},
+		{RoleModerator, PermissionModerate, true},
+		{RoleModerator, PermissionManageRoles, false},
+		{RoleModerator, PermissionManageUsers, false},
+		{RoleModerator, PermissionManageAPIKeys, false},
+		{RoleUser, PermissionReadReviews, true},
+		{RoleAdmin, PermissionModerate, true},
+		{RoleAdmin, PermissionManageRoles, true},
+		{RoleAdmin, PermissionManageUsers, true},
+		{RoleAdmin, PermissionManageAPIKeys, true},
+		{Role("superuser"), PermissionWriteReviews, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.role)+"/"+string(tt.permission), func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.role.HasPermission(tt.permission))
+		})
+	}
+}
+
+func TestRoleIsGrantable(t *testing.T) {
+	assert.True(t, RoleServiceOwner.IsGrantable())
+	assert.True(t, RoleModerator.IsGrantable())
+	assert.True(t, RoleAdmin.IsGrantable())
+	assert.False(t, RoleUser.IsGrantable())
+	assert.False(t, Role("superuser").IsGrantable())
+}
+
+func TestRoleIsPrivileged(t *testing.T) {
+	assert.True(t, RoleModerator.IsPrivileged())
+	assert.True(t, RoleAdmin.IsPrivileged())
+	assert.False(t, RoleServiceOwner.IsPrivileged(), "service owners hold no extra permissions")
+	assert.False(t, RoleUser.IsPrivileged())
+}
+
+func TestNewRoleGrant(t *testing.T) {
+	userID, adminID := uuid.New(), uuid.New()
+
+	grant, err := NewRoleGrant(userID, RoleModerator, &adminID)
+	require.NoError(t, err)
+	assert.Equal(t, userID, grant.UserID)
+	assert.Equal(t, RoleModerator, grant.Role)
+	assert.Equal(t, &adminID, grant.GrantedBy)
+
+	_, err = NewRoleGrant(userID, RoleUser, &adminID)
+	assert.ErrorIs(t, err, ErrInvalidRole)
+
+	_, err = NewRoleGrant(userID, "superuser", nil)
+	assert.ErrorIs(t, err, ErrInvalidRole)
+}
+
+func TestPrincipal(t *testing.T) {
+	user := &Principal{UserID: uuid.New()}
+	assert.True(t, user.HasRole(RoleUser))
+	assert.False(t, user.HasRole(RoleModerator, RoleAdmin))
+	assert.True(t, user.Can(PermissionWriteRatings))
+	assert.False(t, user.Can(PermissionModerate))
+
+	moderator := &Principal{UserID: uuid.New(), Roles: []Role{RoleServiceOwner, RoleModerator}}
+	assert.True(t, moderator.HasRole(RoleModerator, RoleAdmin))
+	assert.True(t, moderator.Can(PermissionModerate))
+	assert.False(t, moderator.Can(PermissionManageRoles))
+
+	serviceID := uuid.New()
+	key := &Principal{
+		UserID:    uuid.New(),
+		Roles:     []Role{RoleModerator},
+		Scopes:    []Permission{PermissionWriteRatings, PermissionManageRoles},
+		ServiceID: &serviceID,
+	}
+	assert.True(t, key.Can(PermissionWriteRatings))
+	assert.False(t, key.Can(PermissionWriteReviews))
+	assert.False(t, key.Can(PermissionModerate))
+	assert.False(t, key.Can(PermissionManageRoles))
+	assert.True(t, key.CanAccessService(serviceID))
+	assert.False(t, key.CanAccessService(uuid.New()))
+	assert.True(t, user.CanAccessService(serviceID))
+}
+
+func TestPermissionIsValid(t *testing.T) {
+	assert.True(t, PermissionReadReviews.IsValid())
+	assert.True(t, PermissionManageAPIKeys.IsValid())
+	assert.False(t, Permission("reviews:delete").IsValid())
+}
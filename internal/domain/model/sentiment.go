@@ -0,0 +1,36 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SentimentMismatchThreshold is how far a review's sentiment must lean against its star score to be flagged
+const SentimentMismatchThreshold = 0.25
+
+// SentimentSummary represents the aggregated text sentiment of a service's published reviews
+type SentimentSummary struct {
+	ServiceID        uuid.UUID `json:"service_id"`
+	AverageSentiment float64   `json:"average_sentiment"` // In [-1, 1]
+	AnalyzedReviews  int       `json:"analyzed_reviews"`
+	Positive         int       `json:"positive"`
+	Neutral          int       `json:"neutral"`
+	Negative         int       `json:"negative"`
+	CalculatedAt     time.Time `json:"calculated_at"`
+}
+
+// IsSentimentMismatch reports whether a sentiment score in [-1, 1] contradicts a 1-5 star score,
+// e.g. "terrible service" with five stars
+func IsSentimentMismatch(stars int, sentiment float64) bool {
+	switch {
+	case stars >= 4:
+		return sentiment <= -SentimentMismatchThreshold
+	case stars <= 2:
+		return sentiment >= SentimentMismatchThreshold
+	}
+	return false
+}
+
+// SentimentNeutralBand is the distance from zero within which a sentiment score counts as neutral
+const SentimentNeutralBand = 0.05
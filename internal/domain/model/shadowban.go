@@ -0,0 +1,61 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Errors returned when managing shadow-bans
+var (
+	ErrAlreadyShadowBanned = errors.New("user is already shadow-banned")
+	ErrNotShadowBanned     = errors.New("user is not shadow-banned")
+)
+
+// MaxShadowBanReasonLength is the longest reason an admin can record for a shadow-ban
+const MaxShadowBanReasonLength = 500
+
+// ShadowBan records that a user's new ratings, reviews and comments are hidden from everyone but
+// themselves. Content written while banned stays hidden after the ban is lifted.
+type ShadowBan struct {
+	UserID    uuid.UUID  `json:"user_id"`
+	BannedBy  *uuid.UUID `json:"banned_by,omitempty"`
+	Reason    string     `json:"reason,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// NewShadowBan creates a shadow-ban of a user on behalf of an admin
+func NewShadowBan(userID, adminID uuid.UUID, reason string) (*ShadowBan, error) {
+	if len(reason) > MaxShadowBanReasonLength {
+		return nil, errors.New("reason must be at most 500 characters")
+	}
+	return &ShadowBan{
+		UserID:    userID,
+		BannedBy:  &adminID,
+		Reason:    reason,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// Viewer is who a read is made for, deciding which shadowed content it returns
+type Viewer struct {
+	// UserID is the reading user, or uuid.Nil for anonymous readers
+	UserID uuid.UUID
+	// Unrestricted viewers see all shadowed content; they are used for moderation and for
+	// internal lookups that never reach other users
+	Unrestricted bool
+}
+
+// SystemViewer sees all content, shadowed or not
+var SystemViewer = Viewer{Unrestricted: true}
+
+// ViewerOf returns the viewer for a user; uuid.Nil is an anonymous viewer
+func ViewerOf(userID uuid.UUID) Viewer {
+	return Viewer{UserID: userID}
+}
+
+// CanSee reports whether the viewer can see content written by the author
+func (v Viewer) CanSee(authorID uuid.UUID, shadowed bool) bool {
+	return !shadowed || v.Unrestricted || (v.UserID != uuid.Nil && v.UserID == authorID)
+}
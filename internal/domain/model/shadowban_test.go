@@ -0,0 +1,46 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewShadowBan(t *testing.T) {
+	userID, adminID := uuid.New(), uuid.New()
+
+	ban, err := NewShadowBan(userID, adminID, "ban evasion")
+	require.NoError(t, err)
+	assert.Equal(t, userID, ban.UserID)
+	assert.Equal(t, &adminID, ban.BannedBy)
+	assert.Equal(t, "ban evasion", ban.Reason)
+
+	_, err = NewShadowBan(userID, adminID, strings.Repeat("x", MaxShadowBanReasonLength+1))
+	assert.Error(t, err)
+}
+
+func TestViewerCanSee(t *testing.T) {
+	author, other := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name     string
+		viewer   Viewer
+		shadowed bool
+		want     bool
+	}{
+		{"anonymous sees normal content", ViewerOf(uuid.Nil), false, true},
+		{"anonymous cannot see shadowed content", ViewerOf(uuid.Nil), true, false},
+		{"other user cannot see shadowed content", ViewerOf(other), true, false},
+		{"author sees own shadowed content", ViewerOf(author), true, true},
+		{"system sees shadowed content", SystemViewer, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.viewer.CanSee(author, tt.shadowed))
+		})
+	}
+}
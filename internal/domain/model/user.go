@@ -15,8 +15,13 @@ type User struct {
 	Username     string    `json:"username"`
 	Email        string    `json:"email"`
 	PasswordHash string    `json:"-"` // Never expose password hash in JSON
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
+	Roles        []Role    `json:"roles,omitempty"`
+	// EmailVerifiedAt is when the user proved they own their email address
+	EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty"`
+	// LockedUntil is when a lockout after too many failed logins ends
+	LockedUntil *time.Time `json:"locked_until,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
 }
 
 // NewUser creates a new user with validation
@@ -34,7 +39,7 @@ func NewUser(username, email, password string) (*User, error) {
 	}
 
 	// Hash the password
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	hashedPassword, err := HashPassword(password)
 	if err != nil {
 		return nil, err
 	}
@@ -44,7 +49,7 @@ func NewUser(username, email, password string) (*User, error) {
 		ID:           uuid.New(),
 		Username:     username,
 		Email:        email,
-		PasswordHash: string(hashedPassword),
+		PasswordHash: hashedPassword,
 		CreatedAt:    now,
 		UpdatedAt:    now,
 	}, nil
@@ -56,20 +61,34 @@ func (u *User) CheckPassword(password string) bool {
 	return err == nil
 }
 
+// IsLocked reports whether the account is locked out after too many failed logins
+func (u *User) IsLocked(now time.Time) bool {
+	return u.LockedUntil != nil && now.Before(*u.LockedUntil)
+}
+
+// IsEmailVerified reports whether the user verified their email address
+func (u *User) IsEmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
 // UserResponse is the public user information returned from API calls
 type UserResponse struct {
-	ID        uuid.UUID `json:"id"`
-	Username  string    `json:"username"`
-	Email     string    `json:"email"`
-	CreatedAt time.Time `json:"created_at"`
+	ID            uuid.UUID `json:"id"`
+	Username      string    `json:"username"`
+	Email         string    `json:"email"`
+	EmailVerified bool      `json:"email_verified"`
+	Roles         []Role    `json:"roles,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
 }
 
 // ToResponse converts a User to a UserResponse
 func (u *User) ToResponse() UserResponse {
 	return UserResponse{
-		ID:        u.ID,
-		Username:  u.Username,
-		Email:     u.Email,
-		CreatedAt: u.CreatedAt,
+		ID:            u.ID,
+		Username:      u.Username,
+		Email:         u.Email,
+		EmailVerified: u.IsEmailVerified(),
+		Roles:         u.Roles,
+		CreatedAt:     u.CreatedAt,
 	}
 }
\ No newline at end of file
@@ -0,0 +1,77 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Errors returned when verifying email addresses and resetting passwords
+var (
+	ErrInvalidVerificationToken = errors.New("invalid or expired verification token")
+	ErrEmailAlreadyVerified     = errors.New("email address is already verified")
+	// ErrEmailNotVerified is returned when an unverified user tries to post a review
+	ErrEmailNotVerified = errors.New("verify your email address first")
+	// ErrVerificationThrottled is returned when verification emails are requested too often
+	ErrVerificationThrottled = errors.New("verification email sent recently; try again later")
+	ErrInvalidResetToken     = errors.New("invalid or expired password reset token")
+	// ErrUserTokenNotFound is returned by repositories for unknown or already used tokens
+	ErrUserTokenNotFound = errors.New("token not found")
+)
+
+// TokenPurpose is what a user token proves
+type TokenPurpose string
+
+// Token purposes
+const (
+	TokenPurposeEmailVerification TokenPurpose = "email_verification"
+	TokenPurposePasswordReset     TokenPurpose = "password_reset"
+)
+
+// UserToken records a single-use token sent to a user's email address to prove they received it.
+// Tokens are signed and bound to their purpose; only a hash of each is stored, so the record marks
+// it used without the database holding anything that could be presented.
+type UserToken struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	Purpose   TokenPurpose
+	TokenHash string
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+// NewUserToken creates the record of a token issued to a user. The caller generates the token
+// under the record's ID and stores its hash with SetToken.
+func NewUserToken(userID uuid.UUID, purpose TokenPurpose, ttl time.Duration, now time.Time) *UserToken {
+	return &UserToken{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Purpose:   purpose,
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+	}
+}
+
+// SetToken records the hash of the token generated for the record
+func (t *UserToken) SetToken(token string) {
+	t.TokenHash = HashUserToken(token)
+}
+
+// HashUserToken returns the hash a user token is stored and looked up by
+func HashUserToken(token string) string {
+	return hashSecret(token)
+}
+
+// IsActive reports whether the token can still be used
+func (t *UserToken) IsActive(now time.Time) bool {
+	return t.UsedAt == nil && now.Before(t.ExpiresAt)
+}
+
+// EmailMessage is a plain text email
+type EmailMessage struct {
+	To      string
+	Subject string
+	Body    string
+}
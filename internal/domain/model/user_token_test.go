@@ -0,0 +1,38 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewUserToken(t *testing.T) {
+	now := time.Now()
+	userID := uuid.New()
+
+	stored := NewUserToken(userID, TokenPurposeEmailVerification, time.Hour, now)
+	stored.SetToken("token")
+	assert.Equal(t, userID, stored.UserID)
+	assert.Equal(t, TokenPurposeEmailVerification, stored.Purpose)
+	assert.Equal(t, HashUserToken("token"), stored.TokenHash)
+	assert.NotEqual(t, "token", stored.TokenHash)
+	assert.True(t, stored.IsActive(now))
+	assert.False(t, stored.IsActive(now.Add(time.Hour)))
+
+	stored.UsedAt = &now
+	assert.False(t, stored.IsActive(now))
+}
+
+func TestUserEmailVerified(t *testing.T) {
+	user, err := NewUser("alice", "alice@example.com", "password123")
+	require.NoError(t, err)
+	assert.False(t, user.IsEmailVerified())
+	assert.False(t, user.ToResponse().EmailVerified)
+
+	now := time.Now()
+	user.EmailVerifiedAt = &now
+	assert.True(t, user.ToResponse().EmailVerified)
+}
@@ -0,0 +1,19 @@
+package port
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"rating-system/internal/domain/model"
+	"rating-system/pkg/pagination"
+)
+
+// AccountService defines the port for admin actions on user accounts
+type AccountService interface {
+	// ShadowBan hides the user's new ratings, reviews and comments from everyone but themselves
+	ShadowBan(ctx context.Context, adminID, userID uuid.UUID, reason string) (*model.ShadowBan, error)
+	// LiftShadowBan stops hiding the user's new content; content written while banned stays hidden
+	LiftShadowBan(ctx context.Context, adminID, userID uuid.UUID) error
+	GetShadowBans(ctx context.Context, params pagination.Params) ([]*model.ShadowBan, int, error)
+}
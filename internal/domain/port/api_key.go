@@ -0,0 +1,21 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"rating-system/internal/domain/model"
+	"rating-system/pkg/pagination"
+)
+
+// APIKeyService defines the port for admins issuing API keys to integrations
+type APIKeyService interface {
+	// CreateAPIKey issues a key acting as the user, returning it along with the key itself, which is
+	// only ever shown once
+	CreateAPIKey(ctx context.Context, adminID, userID uuid.UUID, name string, scopes []model.Permission, serviceID *uuid.UUID, expiresAt *time.Time) (*model.APIKey, string, error)
+	// GetAPIKeys lists keys, of one user if a user is given, most recent first
+	GetAPIKeys(ctx context.Context, userID *uuid.UUID, params pagination.Params) ([]*model.APIKey, int, error)
+	RevokeAPIKey(ctx context.Context, adminID, keyID uuid.UUID) error
+}
@@ -0,0 +1,13 @@
+package port
+
+import (
+	"github.com/google/uuid"
+
+	"rating-system/internal/domain/model"
+)
+
+// AttestationVerifier verifies signed interaction attestations issued by the order system
+type AttestationVerifier interface {
+	// Verify checks the token's signature and expiry and that it binds the given user and service
+	Verify(token string, userID, serviceID uuid.UUID) (*model.Attestation, error)
+}
@@ -2,6 +2,7 @@ package port
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -10,12 +11,62 @@ import (
 
 // AuthService defines the interface for authentication services
 type AuthService interface {
-	// Register registers a new user
-	Register(ctx context.Context, username, email, password string) (*model.UserResponse, string, error)
+	// Register registers a new user and returns an access token and a refresh token
+	Register(ctx context.Context, username, email, password string) (*model.UserResponse, *model.TokenPair, error)
 	
-	// Login authenticates a user and returns a token
-	Login(ctx context.Context, email, password string) (*model.UserResponse, string, error)
+	// Login authenticates a user and returns an access token and a refresh token. Failed attempts
+	// are throttled per account and per client address.
+	Login(ctx context.Context, email, password, clientIP string) (*model.UserResponse, *model.TokenPair, error)
 	
-	// ValidateToken validates a token and returns the user ID if valid
-	ValidateToken(token string) (uuid.UUID, error)
-}
\ No newline at end of file
+	// VerifyEmail marks the email address a single-use verification token was sent to as verified
+	VerifyEmail(ctx context.Context, token string) (*model.UserResponse, error)
+	
+	// ResendVerification sends the user another verification email, subject to throttling
+	ResendVerification(ctx context.Context, userID uuid.UUID) error
+	
+	// RequestPasswordReset emails a reset link to the user with the address, if there is one,
+	// without revealing whether there is
+	RequestPasswordReset(ctx context.Context, email, clientIP string)
+	
+	// ResetPassword sets a new password with a single-use reset token and revokes every session
+	ResetPassword(ctx context.Context, token, password, clientIP string) error
+	
+	// Refresh exchanges a single-use refresh token for a new token pair
+	Refresh(ctx context.Context, refreshToken string) (*model.TokenPair, error)
+	
+	// Logout revokes the caller's access token and, if given, the session of their refresh token
+	Logout(ctx context.Context, principal *model.Principal, refreshToken string) error
+	
+	// RevokeSessions revokes every access and refresh token issued to a user
+	RevokeSessions(ctx context.Context, adminID, userID uuid.UUID) error
+	
+	// ExpireAccessTokens invalidates the access tokens issued to a user while keeping their
+	// sessions, so the next refresh issues tokens carrying the user's current roles
+	ExpireAccessTokens(ctx context.Context, userID uuid.UUID) error
+	
+	// UnlockAccount ends a lockout after too many failed logins
+	UnlockAccount(ctx context.Context, adminID, userID uuid.UUID, clientIP string) error
+	
+	// ValidateToken validates a token, checks it has not been revoked and returns the user ID and
+	// roles it carries
+	ValidateToken(ctx context.Context, token string) (*model.Principal, error)
+	
+	// ValidateAPIKey checks an API key is active and returns the caller it authenticates
+	ValidateAPIKey(ctx context.Context, key string) (*model.Principal, error)
+	
+	// PublicKeys returns the keys other services can verify access tokens with
+	PublicKeys() *model.JSONWebKeySet
+}
+
+// TokenRevocationStore keeps the IDs of revoked access tokens until the tokens expire, and caches
+// the current token generation of users. A store shared between instances, such as one backed by
+// Redis, makes revocations take effect on all of them at once.
+type TokenRevocationStore interface {
+	// RevokeToken records a token as revoked until it expires
+	RevokeToken(ctx context.Context, tokenID string, expiresAt time.Time) error
+	IsTokenRevoked(ctx context.Context, tokenID string) (bool, error)
+	// Generation returns the cached token generation of a user, reporting false if none is cached
+	Generation(ctx context.Context, userID uuid.UUID) (int, bool, error)
+	// SetGeneration caches the token generation of a user for the given duration
+	SetGeneration(ctx context.Context, userID uuid.UUID, generation int, ttl time.Duration) error
+}
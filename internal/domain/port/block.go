@@ -0,0 +1,19 @@
+package port
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"rating-system/internal/domain/model"
+	"rating-system/pkg/pagination"
+)
+
+// BlockService defines the port for users blocking other users
+type BlockService interface {
+	// BlockUser hides the user's reviews and comments from the blocker's listings and stops the
+	// user commenting on the blocker's reviews, replying to their comments or mentioning them
+	BlockUser(ctx context.Context, blockerID, userID uuid.UUID) (*model.Block, error)
+	UnblockUser(ctx context.Context, blockerID, userID uuid.UUID) error
+	GetBlockedUsers(ctx context.Context, blockerID uuid.UUID, params pagination.Params) ([]*model.Block, int, error)
+}
@@ -0,0 +1,22 @@
+package port
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"rating-system/internal/domain/model"
+	"rating-system/pkg/pagination"
+)
+
+// BrigadingDetector scores a stored rating for signs of a coordinated rating campaign
+type BrigadingDetector interface {
+	Assess(ctx context.Context, rating *model.Rating, client model.ClientInfo) (*model.RatingAssessment, error)
+}
+
+// RatingFlagService defines the port for reviewing ratings flagged by the brigading detector
+type RatingFlagService interface {
+	GetFlags(ctx context.Context, filter model.RatingFlagFilter, params pagination.Params) ([]*model.RatingFlag, int, error)
+	GetFlag(ctx context.Context, id uuid.UUID) (*model.RatingFlag, error)
+	ResolveFlag(ctx context.Context, moderatorID, id uuid.UUID, status model.RatingFlagStatus) (*model.RatingFlag, error)
+}
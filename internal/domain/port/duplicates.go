@@ -0,0 +1,18 @@
+package port
+
+import (
+	"context"
+
+	"rating-system/internal/domain/model"
+	"rating-system/pkg/pagination"
+)
+
+// DuplicateDetector checks a new review for near-duplicates of reviews from other accounts
+type DuplicateDetector interface {
+	Check(ctx context.Context, review *model.Review) (*model.DuplicateCheck, error)
+}
+
+// DuplicateService defines the port for reviewing clusters of near-duplicate reviews
+type DuplicateService interface {
+	GetClusters(ctx context.Context, params pagination.Params) ([]*model.DuplicateCluster, int, error)
+}
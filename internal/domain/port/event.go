@@ -0,0 +1,13 @@
+package port
+
+import (
+	"context"
+
+	"rating-system/internal/domain/model"
+)
+
+// EventPublisher delivers domain events to subscribers off the request path
+type EventPublisher interface {
+	// Publish hands the event to subscribers without waiting for them to handle it
+	Publish(ctx context.Context, event model.Event)
+}
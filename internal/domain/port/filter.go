@@ -0,0 +1,13 @@
+package port
+
+import (
+	"context"
+
+	"rating-system/internal/domain/model"
+)
+
+// ContentFilter screens user-written text for spam and abuse
+type ContentFilter interface {
+	// Check returns whether the text is allowed, flagged for a moderator or rejected, and why
+	Check(ctx context.Context, text string) model.FilterResult
+}
@@ -0,0 +1,14 @@
+package port
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"rating-system/internal/domain/model"
+)
+
+// HighlightsProvider serves pros/cons highlights extracted from a service's reviews
+type HighlightsProvider interface {
+	GetHighlights(ctx context.Context, serviceID uuid.UUID) (*model.ServiceHighlights, error)
+}
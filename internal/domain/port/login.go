@@ -0,0 +1,19 @@
+package port
+
+import (
+	"context"
+	"time"
+)
+
+// LoginAttemptStore counts consecutive failed logins per key, such as an account or a client
+// address. A store shared between instances, such as one backed by Redis, makes attempts on one
+// count on all of them.
+type LoginAttemptStore interface {
+	// Failures returns the failures recorded for a key and when the last one happened
+	Failures(ctx context.Context, key string) (int, time.Time, error)
+	// RecordFailure records a failure and returns the number now recorded. Failures are forgotten
+	// once ttl passes without another.
+	RecordFailure(ctx context.Context, key string, at time.Time, ttl time.Duration) (int, error)
+	// Reset forgets the failures of a key
+	Reset(ctx context.Context, key string) error
+}
@@ -0,0 +1,12 @@
+package port
+
+import (
+	"context"
+
+	"rating-system/internal/domain/model"
+)
+
+// Mailer sends emails to users
+type Mailer interface {
+	Send(ctx context.Context, msg *model.EmailMessage) error
+}
@@ -6,11 +6,12 @@ package mocks
 
 import (
 	context "context"
+	model "rating-system/internal/domain/model"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	uuid "github.com/google/uuid"
-	model "rating-system/internal/domain/model"
 )
 
 // MockAuthService is a mock of AuthService interface.
@@ -36,58 +37,85 @@ func (m *MockAuthService) EXPECT() *MockAuthServiceMockRecorder {
 	return m.recorder
 }
 
-// GenerateToken mocks base method.
-func (m *MockAuthService) GenerateToken(user model.User) (string, error) {
+// ExpireAccessTokens mocks base method.
+func (m *MockAuthService) ExpireAccessTokens(ctx context.Context, userID uuid.UUID) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GenerateToken", user)
-	ret0, _ := ret[0].(string)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "ExpireAccessTokens", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GenerateToken indicates an expected call of GenerateToken.
-func (mr *MockAuthServiceMockRecorder) GenerateToken(user interface{}) *gomock.Call {
+// ExpireAccessTokens indicates an expected call of ExpireAccessTokens.
+func (mr *MockAuthServiceMockRecorder) ExpireAccessTokens(ctx, userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateToken", reflect.TypeOf((*MockAuthService)(nil).GenerateToken), user)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireAccessTokens", reflect.TypeOf((*MockAuthService)(nil).ExpireAccessTokens), ctx, userID)
 }
 
-// GetUserIDFromToken mocks base method.
-func (m *MockAuthService) GetUserIDFromToken(tokenString string) (uuid.UUID, error) {
+// Login mocks base method.
+func (m *MockAuthService) Login(ctx context.Context, email, password, clientIP string) (*model.UserResponse, *model.TokenPair, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserIDFromToken", tokenString)
-	ret0, _ := ret[0].(uuid.UUID)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "Login", ctx, email, password, clientIP)
+	ret0, _ := ret[0].(*model.UserResponse)
+	ret1, _ := ret[1].(*model.TokenPair)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
-// GetUserIDFromToken indicates an expected call of GetUserIDFromToken.
-func (mr *MockAuthServiceMockRecorder) GetUserIDFromToken(tokenString interface{}) *gomock.Call {
+// Login indicates an expected call of Login.
+func (mr *MockAuthServiceMockRecorder) Login(ctx, email, password, clientIP interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserIDFromToken", reflect.TypeOf((*MockAuthService)(nil).GetUserIDFromToken), tokenString)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockAuthService)(nil).Login), ctx, email, password, clientIP)
 }
 
-// Login mocks base method.
-func (m *MockAuthService) Login(ctx context.Context, email, password string) (model.User, string, error) {
+// Logout mocks base method.
+func (m *MockAuthService) Logout(ctx context.Context, principal *model.Principal, refreshToken string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Login", ctx, email, password)
-	ret0, _ := ret[0].(model.User)
-	ret1, _ := ret[1].(string)
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+	ret := m.ctrl.Call(m, "Logout", ctx, principal, refreshToken)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// Login indicates an expected call of Login.
-func (mr *MockAuthServiceMockRecorder) Login(ctx, email, password interface{}) *gomock.Call {
+// Logout indicates an expected call of Logout.
+func (mr *MockAuthServiceMockRecorder) Logout(ctx, principal, refreshToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockAuthService)(nil).Logout), ctx, principal, refreshToken)
+}
+
+// PublicKeys mocks base method.
+func (m *MockAuthService) PublicKeys() *model.JSONWebKeySet {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublicKeys")
+	ret0, _ := ret[0].(*model.JSONWebKeySet)
+	return ret0
+}
+
+// PublicKeys indicates an expected call of PublicKeys.
+func (mr *MockAuthServiceMockRecorder) PublicKeys() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Login", reflect.TypeOf((*MockAuthService)(nil).Login), ctx, email, password)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublicKeys", reflect.TypeOf((*MockAuthService)(nil).PublicKeys))
+}
+
+// Refresh mocks base method.
+func (m *MockAuthService) Refresh(ctx context.Context, refreshToken string) (*model.TokenPair, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh", ctx, refreshToken)
+	ret0, _ := ret[0].(*model.TokenPair)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockAuthServiceMockRecorder) Refresh(ctx, refreshToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockAuthService)(nil).Refresh), ctx, refreshToken)
 }
 
 // Register mocks base method.
-func (m *MockAuthService) Register(ctx context.Context, username, email, password string) (model.User, string, error) {
+func (m *MockAuthService) Register(ctx context.Context, username, email, password string) (*model.UserResponse, *model.TokenPair, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Register", ctx, username, email, password)
-	ret0, _ := ret[0].(model.User)
-	ret1, _ := ret[1].(string)
+	ret0, _ := ret[0].(*model.UserResponse)
+	ret1, _ := ret[1].(*model.TokenPair)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
@@ -98,17 +126,197 @@ func (mr *MockAuthServiceMockRecorder) Register(ctx, username, email, password i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockAuthService)(nil).Register), ctx, username, email, password)
 }
 
+// RequestPasswordReset mocks base method.
+func (m *MockAuthService) RequestPasswordReset(ctx context.Context, email, clientIP string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RequestPasswordReset", ctx, email, clientIP)
+}
+
+// RequestPasswordReset indicates an expected call of RequestPasswordReset.
+func (mr *MockAuthServiceMockRecorder) RequestPasswordReset(ctx, email, clientIP interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestPasswordReset", reflect.TypeOf((*MockAuthService)(nil).RequestPasswordReset), ctx, email, clientIP)
+}
+
+// ResendVerification mocks base method.
+func (m *MockAuthService) ResendVerification(ctx context.Context, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResendVerification", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResendVerification indicates an expected call of ResendVerification.
+func (mr *MockAuthServiceMockRecorder) ResendVerification(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResendVerification", reflect.TypeOf((*MockAuthService)(nil).ResendVerification), ctx, userID)
+}
+
+// ResetPassword mocks base method.
+func (m *MockAuthService) ResetPassword(ctx context.Context, token, password, clientIP string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", ctx, token, password, clientIP)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockAuthServiceMockRecorder) ResetPassword(ctx, token, password, clientIP interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockAuthService)(nil).ResetPassword), ctx, token, password, clientIP)
+}
+
+// RevokeSessions mocks base method.
+func (m *MockAuthService) RevokeSessions(ctx context.Context, adminID, userID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSessions", ctx, adminID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSessions indicates an expected call of RevokeSessions.
+func (mr *MockAuthServiceMockRecorder) RevokeSessions(ctx, adminID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSessions", reflect.TypeOf((*MockAuthService)(nil).RevokeSessions), ctx, adminID, userID)
+}
+
+// UnlockAccount mocks base method.
+func (m *MockAuthService) UnlockAccount(ctx context.Context, adminID, userID uuid.UUID, clientIP string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlockAccount", ctx, adminID, userID, clientIP)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlockAccount indicates an expected call of UnlockAccount.
+func (mr *MockAuthServiceMockRecorder) UnlockAccount(ctx, adminID, userID, clientIP interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockAccount", reflect.TypeOf((*MockAuthService)(nil).UnlockAccount), ctx, adminID, userID, clientIP)
+}
+
+// ValidateAPIKey mocks base method.
+func (m *MockAuthService) ValidateAPIKey(ctx context.Context, key string) (*model.Principal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateAPIKey", ctx, key)
+	ret0, _ := ret[0].(*model.Principal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidateAPIKey indicates an expected call of ValidateAPIKey.
+func (mr *MockAuthServiceMockRecorder) ValidateAPIKey(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateAPIKey", reflect.TypeOf((*MockAuthService)(nil).ValidateAPIKey), ctx, key)
+}
+
 // ValidateToken mocks base method.
-func (m *MockAuthService) ValidateToken(tokenString string) (bool, error) {
+func (m *MockAuthService) ValidateToken(ctx context.Context, token string) (*model.Principal, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ValidateToken", tokenString)
-	ret0, _ := ret[0].(bool)
+	ret := m.ctrl.Call(m, "ValidateToken", ctx, token)
+	ret0, _ := ret[0].(*model.Principal)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ValidateToken indicates an expected call of ValidateToken.
-func (mr *MockAuthServiceMockRecorder) ValidateToken(tokenString interface{}) *gomock.Call {
+func (mr *MockAuthServiceMockRecorder) ValidateToken(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateToken", reflect.TypeOf((*MockAuthService)(nil).ValidateToken), ctx, token)
+}
+
+// VerifyEmail mocks base method.
+func (m *MockAuthService) VerifyEmail(ctx context.Context, token string) (*model.UserResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmail", ctx, token)
+	ret0, _ := ret[0].(*model.UserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyEmail indicates an expected call of VerifyEmail.
+func (mr *MockAuthServiceMockRecorder) VerifyEmail(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*MockAuthService)(nil).VerifyEmail), ctx, token)
+}
+
+// MockTokenRevocationStore is a mock of TokenRevocationStore interface.
+type MockTokenRevocationStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockTokenRevocationStoreMockRecorder
+}
+
+// MockTokenRevocationStoreMockRecorder is the mock recorder for MockTokenRevocationStore.
+type MockTokenRevocationStoreMockRecorder struct {
+	mock *MockTokenRevocationStore
+}
+
+// NewMockTokenRevocationStore creates a new mock instance.
+func NewMockTokenRevocationStore(ctrl *gomock.Controller) *MockTokenRevocationStore {
+	mock := &MockTokenRevocationStore{ctrl: ctrl}
+	mock.recorder = &MockTokenRevocationStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTokenRevocationStore) EXPECT() *MockTokenRevocationStoreMockRecorder {
+	return m.recorder
+}
+
+// Generation mocks base method.
+func (m *MockTokenRevocationStore) Generation(ctx context.Context, userID uuid.UUID) (int, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Generation", ctx, userID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Generation indicates an expected call of Generation.
+func (mr *MockTokenRevocationStoreMockRecorder) Generation(ctx, userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateToken", reflect.TypeOf((*MockAuthService)(nil).ValidateToken), tokenString)
-}
\ No newline at end of file
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Generation", reflect.TypeOf((*MockTokenRevocationStore)(nil).Generation), ctx, userID)
+}
+
+// IsTokenRevoked mocks base method.
+func (m *MockTokenRevocationStore) IsTokenRevoked(ctx context.Context, tokenID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsTokenRevoked", ctx, tokenID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsTokenRevoked indicates an expected call of IsTokenRevoked.
+func (mr *MockTokenRevocationStoreMockRecorder) IsTokenRevoked(ctx, tokenID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsTokenRevoked", reflect.TypeOf((*MockTokenRevocationStore)(nil).IsTokenRevoked), ctx, tokenID)
+}
+
+// RevokeToken mocks base method.
+func (m *MockTokenRevocationStore) RevokeToken(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeToken", ctx, tokenID, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeToken indicates an expected call of RevokeToken.
+func (mr *MockTokenRevocationStoreMockRecorder) RevokeToken(ctx, tokenID, expiresAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeToken", reflect.TypeOf((*MockTokenRevocationStore)(nil).RevokeToken), ctx, tokenID, expiresAt)
+}
+
+// SetGeneration mocks base method.
+func (m *MockTokenRevocationStore) SetGeneration(ctx context.Context, userID uuid.UUID, generation int, ttl time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetGeneration", ctx, userID, generation, ttl)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetGeneration indicates an expected call of SetGeneration.
+func (mr *MockTokenRevocationStoreMockRecorder) SetGeneration(ctx, userID, generation, ttl interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetGeneration", reflect.TypeOf((*MockTokenRevocationStore)(nil).SetGeneration), ctx, userID, generation, ttl)
+}
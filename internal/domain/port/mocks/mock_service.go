@@ -6,12 +6,12 @@ package mocks
 
 import (
 	context "context"
+	model "rating-system/internal/domain/model"
+	pagination "rating-system/pkg/pagination"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
 	uuid "github.com/google/uuid"
-	model "rating-system/internal/domain/model"
-	pagination "rating-system/pkg/pagination"
 )
 
 // MockService is a mock of Service interface.
@@ -37,87 +37,178 @@ func (m *MockService) EXPECT() *MockServiceMockRecorder {
 	return m.recorder
 }
 
+// ApproveReview mocks base method.
+func (m *MockService) ApproveReview(ctx context.Context, id uuid.UUID) (*model.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApproveReview", ctx, id)
+	ret0, _ := ret[0].(*model.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApproveReview indicates an expected call of ApproveReview.
+func (mr *MockServiceMockRecorder) ApproveReview(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveReview", reflect.TypeOf((*MockService)(nil).ApproveReview), ctx, id)
+}
+
 // CreateComment mocks base method.
-func (m *MockService) CreateComment(ctx context.Context, userID, reviewID uuid.UUID, content string) (model.Comment, error) {
+func (m *MockService) CreateComment(ctx context.Context, userID, reviewID, parentID uuid.UUID, content string) (*model.Comment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateComment", ctx, userID, reviewID, content)
-	ret0, _ := ret[0].(model.Comment)
+	ret := m.ctrl.Call(m, "CreateComment", ctx, userID, reviewID, parentID, content)
+	ret0, _ := ret[0].(*model.Comment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateComment indicates an expected call of CreateComment.
-func (mr *MockServiceMockRecorder) CreateComment(ctx, userID, reviewID, content interface{}) *gomock.Call {
+func (mr *MockServiceMockRecorder) CreateComment(ctx, userID, reviewID, parentID, content interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateComment", reflect.TypeOf((*MockService)(nil).CreateComment), ctx, userID, reviewID, content)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateComment", reflect.TypeOf((*MockService)(nil).CreateComment), ctx, userID, reviewID, parentID, content)
 }
 
 // CreateRating mocks base method.
-func (m *MockService) CreateRating(ctx context.Context, userID, serviceID uuid.UUID, score int) (model.Rating, error) {
+func (m *MockService) CreateRating(ctx context.Context, userID, serviceID uuid.UUID, score int, attestationToken string) (*model.Rating, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateRating", ctx, userID, serviceID, score)
-	ret0, _ := ret[0].(model.Rating)
+	ret := m.ctrl.Call(m, "CreateRating", ctx, userID, serviceID, score, attestationToken)
+	ret0, _ := ret[0].(*model.Rating)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateRating indicates an expected call of CreateRating.
-func (mr *MockServiceMockRecorder) CreateRating(ctx, userID, serviceID, score interface{}) *gomock.Call {
+func (mr *MockServiceMockRecorder) CreateRating(ctx, userID, serviceID, score, attestationToken interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRating", reflect.TypeOf((*MockService)(nil).CreateRating), ctx, userID, serviceID, score)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRating", reflect.TypeOf((*MockService)(nil).CreateRating), ctx, userID, serviceID, score, attestationToken)
 }
 
 // CreateReview mocks base method.
-func (m *MockService) CreateReview(ctx context.Context, userID, serviceID, ratingID uuid.UUID, title, content string) (model.Review, error) {
+func (m *MockService) CreateReview(ctx context.Context, userID, serviceID, ratingID uuid.UUID, title, content, attestationToken string, draft bool) (*model.Review, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateReview", ctx, userID, serviceID, ratingID, title, content)
-	ret0, _ := ret[0].(model.Review)
+	ret := m.ctrl.Call(m, "CreateReview", ctx, userID, serviceID, ratingID, title, content, attestationToken, draft)
+	ret0, _ := ret[0].(*model.Review)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateReview indicates an expected call of CreateReview.
-func (mr *MockServiceMockRecorder) CreateReview(ctx, userID, serviceID, ratingID, title, content interface{}) *gomock.Call {
+func (mr *MockServiceMockRecorder) CreateReview(ctx, userID, serviceID, ratingID, title, content, attestationToken, draft interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReview", reflect.TypeOf((*MockService)(nil).CreateReview), ctx, userID, serviceID, ratingID, title, content, attestationToken, draft)
+}
+
+// DeleteComment mocks base method.
+func (m *MockService) DeleteComment(ctx context.Context, userID, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteComment", ctx, userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteComment indicates an expected call of DeleteComment.
+func (mr *MockServiceMockRecorder) DeleteComment(ctx, userID, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReview", reflect.TypeOf((*MockService)(nil).CreateReview), ctx, userID, serviceID, ratingID, title, content)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComment", reflect.TypeOf((*MockService)(nil).DeleteComment), ctx, userID, id)
 }
 
 // GetAverageRating mocks base method.
-func (m *MockService) GetAverageRating(ctx context.Context, serviceID uuid.UUID) (float64, error) {
+func (m *MockService) GetAverageRating(ctx context.Context, serviceID, viewerID uuid.UUID, filter model.RatingFilter) (*model.AverageRating, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAverageRating", ctx, serviceID)
-	ret0, _ := ret[0].(float64)
+	ret := m.ctrl.Call(m, "GetAverageRating", ctx, serviceID, viewerID, filter)
+	ret0, _ := ret[0].(*model.AverageRating)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAverageRating indicates an expected call of GetAverageRating.
-func (mr *MockServiceMockRecorder) GetAverageRating(ctx, serviceID interface{}) *gomock.Call {
+func (mr *MockServiceMockRecorder) GetAverageRating(ctx, serviceID, viewerID, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAverageRating", reflect.TypeOf((*MockService)(nil).GetAverageRating), ctx, serviceID, viewerID, filter)
+}
+
+// GetCommentByID mocks base method.
+func (m *MockService) GetCommentByID(ctx context.Context, id, viewerID uuid.UUID) (*model.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCommentByID", ctx, id, viewerID)
+	ret0, _ := ret[0].(*model.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCommentByID indicates an expected call of GetCommentByID.
+func (mr *MockServiceMockRecorder) GetCommentByID(ctx, id, viewerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommentByID", reflect.TypeOf((*MockService)(nil).GetCommentByID), ctx, id, viewerID)
+}
+
+// GetCommentTree mocks base method.
+func (m *MockService) GetCommentTree(ctx context.Context, reviewID, viewerID uuid.UUID, params pagination.Params) ([]*model.CommentNode, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCommentTree", ctx, reviewID, viewerID, params)
+	ret0, _ := ret[0].([]*model.CommentNode)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetCommentTree indicates an expected call of GetCommentTree.
+func (mr *MockServiceMockRecorder) GetCommentTree(ctx, reviewID, viewerID, params interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAverageRating", reflect.TypeOf((*MockService)(nil).GetAverageRating), ctx, serviceID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommentTree", reflect.TypeOf((*MockService)(nil).GetCommentTree), ctx, reviewID, viewerID, params)
 }
 
 // GetCommentsByReview mocks base method.
-func (m *MockService) GetCommentsByReview(ctx context.Context, reviewID uuid.UUID, params pagination.Params) ([]model.Comment, int, error) {
+func (m *MockService) GetCommentsByReview(ctx context.Context, reviewID, viewerID uuid.UUID, params pagination.Params) ([]*model.Comment, int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetCommentsByReview", ctx, reviewID, params)
-	ret0, _ := ret[0].([]model.Comment)
+	ret := m.ctrl.Call(m, "GetCommentsByReview", ctx, reviewID, viewerID, params)
+	ret0, _ := ret[0].([]*model.Comment)
 	ret1, _ := ret[1].(int)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
 // GetCommentsByReview indicates an expected call of GetCommentsByReview.
-func (mr *MockServiceMockRecorder) GetCommentsByReview(ctx, reviewID, params interface{}) *gomock.Call {
+func (mr *MockServiceMockRecorder) GetCommentsByReview(ctx, reviewID, viewerID, params interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommentsByReview", reflect.TypeOf((*MockService)(nil).GetCommentsByReview), ctx, reviewID, params)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommentsByReview", reflect.TypeOf((*MockService)(nil).GetCommentsByReview), ctx, reviewID, viewerID, params)
+}
+
+// GetPendingReviews mocks base method.
+func (m *MockService) GetPendingReviews(ctx context.Context, params pagination.Params) ([]*model.ReviewWithRating, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingReviews", ctx, params)
+	ret0, _ := ret[0].([]*model.ReviewWithRating)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetPendingReviews indicates an expected call of GetPendingReviews.
+func (mr *MockServiceMockRecorder) GetPendingReviews(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingReviews", reflect.TypeOf((*MockService)(nil).GetPendingReviews), ctx, params)
+}
+
+// GetRatingByID mocks base method.
+func (m *MockService) GetRatingByID(ctx context.Context, id, viewerID uuid.UUID) (*model.Rating, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRatingByID", ctx, id, viewerID)
+	ret0, _ := ret[0].(*model.Rating)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRatingByID indicates an expected call of GetRatingByID.
+func (mr *MockServiceMockRecorder) GetRatingByID(ctx, id, viewerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRatingByID", reflect.TypeOf((*MockService)(nil).GetRatingByID), ctx, id, viewerID)
 }
 
 // GetRatingByUserAndService mocks base method.
-func (m *MockService) GetRatingByUserAndService(ctx context.Context, userID, serviceID uuid.UUID) (model.Rating, error) {
+func (m *MockService) GetRatingByUserAndService(ctx context.Context, userID, serviceID uuid.UUID) (*model.Rating, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetRatingByUserAndService", ctx, userID, serviceID)
-	ret0, _ := ret[0].(model.Rating)
+	ret0, _ := ret[0].(*model.Rating)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -129,48 +220,213 @@ func (mr *MockServiceMockRecorder) GetRatingByUserAndService(ctx, userID, servic
 }
 
 // GetRatingsByService mocks base method.
-func (m *MockService) GetRatingsByService(ctx context.Context, serviceID uuid.UUID, params pagination.Params) ([]model.Rating, int, error) {
+func (m *MockService) GetRatingsByService(ctx context.Context, serviceID, viewerID uuid.UUID, filter model.RatingFilter, params pagination.Params) ([]*model.Rating, int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetRatingsByService", ctx, serviceID, params)
-	ret0, _ := ret[0].([]model.Rating)
+	ret := m.ctrl.Call(m, "GetRatingsByService", ctx, serviceID, viewerID, filter, params)
+	ret0, _ := ret[0].([]*model.Rating)
 	ret1, _ := ret[1].(int)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
 // GetRatingsByService indicates an expected call of GetRatingsByService.
-func (mr *MockServiceMockRecorder) GetRatingsByService(ctx, serviceID, params interface{}) *gomock.Call {
+func (mr *MockServiceMockRecorder) GetRatingsByService(ctx, serviceID, viewerID, filter, params interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRatingsByService", reflect.TypeOf((*MockService)(nil).GetRatingsByService), ctx, serviceID, params)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRatingsByService", reflect.TypeOf((*MockService)(nil).GetRatingsByService), ctx, serviceID, viewerID, filter, params)
+}
+
+// GetReactionEmojis mocks base method.
+func (m *MockService) GetReactionEmojis(ctx context.Context) []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReactionEmojis", ctx)
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// GetReactionEmojis indicates an expected call of GetReactionEmojis.
+func (mr *MockServiceMockRecorder) GetReactionEmojis(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReactionEmojis", reflect.TypeOf((*MockService)(nil).GetReactionEmojis), ctx)
 }
 
 // GetReviewByID mocks base method.
-func (m *MockService) GetReviewByID(ctx context.Context, reviewID uuid.UUID) (model.Review, error) {
+func (m *MockService) GetReviewByID(ctx context.Context, id, viewerID uuid.UUID) (*model.ReviewWithRating, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetReviewByID", ctx, reviewID)
-	ret0, _ := ret[0].(model.Review)
+	ret := m.ctrl.Call(m, "GetReviewByID", ctx, id, viewerID)
+	ret0, _ := ret[0].(*model.ReviewWithRating)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetReviewByID indicates an expected call of GetReviewByID.
-func (mr *MockServiceMockRecorder) GetReviewByID(ctx, reviewID interface{}) *gomock.Call {
+func (mr *MockServiceMockRecorder) GetReviewByID(ctx, id, viewerID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReviewByID", reflect.TypeOf((*MockService)(nil).GetReviewByID), ctx, reviewID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReviewByID", reflect.TypeOf((*MockService)(nil).GetReviewByID), ctx, id, viewerID)
 }
 
 // GetReviewsByService mocks base method.
-func (m *MockService) GetReviewsByService(ctx context.Context, serviceID uuid.UUID, params pagination.Params) ([]model.Review, int, error) {
+func (m *MockService) GetReviewsByService(ctx context.Context, serviceID, viewerID uuid.UUID, filter model.ReviewFilter, params pagination.Params) ([]*model.ReviewWithRating, int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetReviewsByService", ctx, serviceID, params)
-	ret0, _ := ret[0].([]model.Review)
+	ret := m.ctrl.Call(m, "GetReviewsByService", ctx, serviceID, viewerID, filter, params)
+	ret0, _ := ret[0].([]*model.ReviewWithRating)
 	ret1, _ := ret[1].(int)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
 // GetReviewsByService indicates an expected call of GetReviewsByService.
-func (mr *MockServiceMockRecorder) GetReviewsByService(ctx, serviceID, params interface{}) *gomock.Call {
+func (mr *MockServiceMockRecorder) GetReviewsByService(ctx, serviceID, viewerID, filter, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReviewsByService", reflect.TypeOf((*MockService)(nil).GetReviewsByService), ctx, serviceID, viewerID, filter, params)
+}
+
+// GetReviewsByUser mocks base method.
+func (m *MockService) GetReviewsByUser(ctx context.Context, userID uuid.UUID, status model.ReviewStatus, params pagination.Params) ([]*model.ReviewWithRating, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReviewsByUser", ctx, userID, status, params)
+	ret0, _ := ret[0].([]*model.ReviewWithRating)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetReviewsByUser indicates an expected call of GetReviewsByUser.
+func (mr *MockServiceMockRecorder) GetReviewsByUser(ctx, userID, status, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReviewsByUser", reflect.TypeOf((*MockService)(nil).GetReviewsByUser), ctx, userID, status, params)
+}
+
+// GetSentimentSummary mocks base method.
+func (m *MockService) GetSentimentSummary(ctx context.Context, serviceID, viewerID uuid.UUID) (*model.SentimentSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSentimentSummary", ctx, serviceID, viewerID)
+	ret0, _ := ret[0].(*model.SentimentSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSentimentSummary indicates an expected call of GetSentimentSummary.
+func (mr *MockServiceMockRecorder) GetSentimentSummary(ctx, serviceID, viewerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSentimentSummary", reflect.TypeOf((*MockService)(nil).GetSentimentSummary), ctx, serviceID, viewerID)
+}
+
+// GetServiceHighlights mocks base method.
+func (m *MockService) GetServiceHighlights(ctx context.Context, serviceID uuid.UUID) (*model.ServiceHighlights, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetServiceHighlights", ctx, serviceID)
+	ret0, _ := ret[0].(*model.ServiceHighlights)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetServiceHighlights indicates an expected call of GetServiceHighlights.
+func (mr *MockServiceMockRecorder) GetServiceHighlights(ctx, serviceID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceHighlights", reflect.TypeOf((*MockService)(nil).GetServiceHighlights), ctx, serviceID)
+}
+
+// PublishDueReviews mocks base method.
+func (m *MockService) PublishDueReviews(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishDueReviews", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PublishDueReviews indicates an expected call of PublishDueReviews.
+func (mr *MockServiceMockRecorder) PublishDueReviews(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishDueReviews", reflect.TypeOf((*MockService)(nil).PublishDueReviews), ctx)
+}
+
+// RejectReview mocks base method.
+func (m *MockService) RejectReview(ctx context.Context, id uuid.UUID) (*model.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RejectReview", ctx, id)
+	ret0, _ := ret[0].(*model.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RejectReview indicates an expected call of RejectReview.
+func (mr *MockServiceMockRecorder) RejectReview(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RejectReview", reflect.TypeOf((*MockService)(nil).RejectReview), ctx, id)
+}
+
+// SubmitReview mocks base method.
+func (m *MockService) SubmitReview(ctx context.Context, userID, id uuid.UUID) (*model.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitReview", ctx, userID, id)
+	ret0, _ := ret[0].(*model.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SubmitReview indicates an expected call of SubmitReview.
+func (mr *MockServiceMockRecorder) SubmitReview(ctx, userID, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitReview", reflect.TypeOf((*MockService)(nil).SubmitReview), ctx, userID, id)
+}
+
+// ToggleReaction mocks base method.
+func (m *MockService) ToggleReaction(ctx context.Context, userID uuid.UUID, targetType model.ReactionTarget, targetID uuid.UUID, emoji string) (*model.ReactionToggle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ToggleReaction", ctx, userID, targetType, targetID, emoji)
+	ret0, _ := ret[0].(*model.ReactionToggle)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ToggleReaction indicates an expected call of ToggleReaction.
+func (mr *MockServiceMockRecorder) ToggleReaction(ctx, userID, targetType, targetID, emoji interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ToggleReaction", reflect.TypeOf((*MockService)(nil).ToggleReaction), ctx, userID, targetType, targetID, emoji)
+}
+
+// UpdateComment mocks base method.
+func (m *MockService) UpdateComment(ctx context.Context, id uuid.UUID, content string) (*model.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateComment", ctx, id, content)
+	ret0, _ := ret[0].(*model.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateComment indicates an expected call of UpdateComment.
+func (mr *MockServiceMockRecorder) UpdateComment(ctx, id, content interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReviewsByService", reflect.TypeOf((*MockService)(nil).GetReviewsByService), ctx, serviceID, params)
-}
\ No newline at end of file
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateComment", reflect.TypeOf((*MockService)(nil).UpdateComment), ctx, id, content)
+}
+
+// UpdateRating mocks base method.
+func (m *MockService) UpdateRating(ctx context.Context, id uuid.UUID, score int) (*model.Rating, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRating", ctx, id, score)
+	ret0, _ := ret[0].(*model.Rating)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRating indicates an expected call of UpdateRating.
+func (mr *MockServiceMockRecorder) UpdateRating(ctx, id, score interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRating", reflect.TypeOf((*MockService)(nil).UpdateRating), ctx, id, score)
+}
+
+// UpdateReview mocks base method.
+func (m *MockService) UpdateReview(ctx context.Context, id uuid.UUID, title, content string) (*model.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateReview", ctx, id, title, content)
+	ret0, _ := ret[0].(*model.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateReview indicates an expected call of UpdateReview.
+func (mr *MockServiceMockRecorder) UpdateReview(ctx, id, title, content interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateReview", reflect.TypeOf((*MockService)(nil).UpdateReview), ctx, id, title, content)
+}
@@ -0,0 +1,18 @@
+package port
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"rating-system/internal/domain/model"
+	"rating-system/pkg/pagination"
+)
+
+// ModerationService defines the port for content reports and moderator decisions
+type ModerationService interface {
+	ReportContent(ctx context.Context, reporterID uuid.UUID, targetType model.ReportTarget, targetID uuid.UUID, reason model.ReportReason, details string) (*model.Report, error)
+	GetQueue(ctx context.Context, params pagination.Params) ([]*model.ModerationQueueItem, int, error)
+	Decide(ctx context.Context, moderatorID uuid.UUID, targetType model.ReportTarget, targetID uuid.UUID, action model.ModerationAction, reason string) (*model.ModerationDecision, error)
+	GetDecisions(ctx context.Context, targetType model.ReportTarget, targetID uuid.UUID) ([]*model.ModerationDecision, error)
+}
@@ -0,0 +1,22 @@
+package port
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"rating-system/internal/domain/model"
+	"rating-system/pkg/pagination"
+)
+
+// NotificationService defines the port for the notification inbox
+type NotificationService interface {
+	// HandleEvent records notifications for the recipients of a domain event
+	HandleEvent(ctx context.Context, event model.Event)
+
+	GetNotifications(ctx context.Context, userID uuid.UUID, filter model.NotificationFilter, cursor *pagination.Cursor, limit int) (*model.NotificationPage, error)
+	MarkRead(ctx context.Context, userID, id uuid.UUID) error
+	MarkAllRead(ctx context.Context, userID uuid.UUID) (int, error)
+	GetPreferences(ctx context.Context, userID uuid.UUID) (*model.NotificationPreferences, error)
+	UpdatePreferences(ctx context.Context, userID uuid.UUID, enabled map[model.NotificationType]bool) (*model.NotificationPreferences, error)
+}
@@ -0,0 +1,13 @@
+package port
+
+import (
+	"context"
+
+	"rating-system/internal/domain/model"
+)
+
+// RateLimiter counts requests against the limit of a group of routes. The key identifies the
+// caller, such as a user ID or client address.
+type RateLimiter interface {
+	Allow(ctx context.Context, key string) (*model.RateLimitDecision, error)
+}
@@ -2,6 +2,7 @@ package port
 
 import (
         "context"
+        "time"
 
         "github.com/google/uuid"
         
@@ -16,24 +17,135 @@ type Repository interface {
         GetUserByID(ctx context.Context, id uuid.UUID) (*model.User, error)
         GetUserByEmail(ctx context.Context, email string) (*model.User, error)
         GetUserByUsername(ctx context.Context, username string) (*model.User, error)
+        GetTokenGeneration(ctx context.Context, userID uuid.UUID) (int, error)
+        IncrementTokenGeneration(ctx context.Context, userID uuid.UUID) (int, error)
 
-        // Rating operations
+        // Role operations. RevokeRole refuses to remove the last admin with ErrLastAdmin.
+        GetUserRoles(ctx context.Context, userID uuid.UUID) ([]*model.RoleGrant, error)
+        GrantRole(ctx context.Context, grant *model.RoleGrant) error
+        RevokeRole(ctx context.Context, userID uuid.UUID, role model.Role) error
+        CountUsersWithRole(ctx context.Context, role model.Role) (int, error)
+
+        // Shadow-ban operations
+        CreateShadowBan(ctx context.Context, ban *model.ShadowBan) error
+        DeleteShadowBan(ctx context.Context, userID uuid.UUID) error
+        IsShadowBanned(ctx context.Context, userID uuid.UUID) (bool, error)
+        GetShadowBans(ctx context.Context, params pagination.Params) ([]*model.ShadowBan, int, error)
+
+        // Refresh token operations
+        CreateRefreshToken(ctx context.Context, token *model.RefreshToken) error
+        GetRefreshTokenByHash(ctx context.Context, tokenHash string) (*model.RefreshToken, error)
+        RotateRefreshToken(ctx context.Context, id uuid.UUID, next *model.RefreshToken, at time.Time) error
+        RevokeRefreshTokenFamily(ctx context.Context, familyID uuid.UUID, at time.Time) error
+        RevokeUserRefreshTokens(ctx context.Context, userID uuid.UUID, at time.Time) error
+
+        // API key operations
+        CreateAPIKey(ctx context.Context, key *model.APIKey) error
+        GetAPIKeyByHash(ctx context.Context, keyHash string) (*model.APIKey, error)
+        GetAPIKeys(ctx context.Context, userID *uuid.UUID, params pagination.Params) ([]*model.APIKey, int, error)
+        RevokeAPIKey(ctx context.Context, id uuid.UUID, at time.Time) error
+        TouchAPIKey(ctx context.Context, id uuid.UUID, at time.Time) error
+
+        // User token operations
+        CreateUserToken(ctx context.Context, token *model.UserToken) error
+        // GetUserTokenByHash returns model.ErrUserTokenNotFound for unknown tokens
+        GetUserTokenByHash(ctx context.Context, purpose model.TokenPurpose, tokenHash string) (*model.UserToken, error)
+        // CountUserTokensSince counts the tokens issued to a user for a purpose since a time
+        CountUserTokensSince(ctx context.Context, userID uuid.UUID, purpose model.TokenPurpose, since time.Time) (int, error)
+        // VerifyEmail uses a verification token and marks the user's email verified in one
+        // transaction. It returns model.ErrUserTokenNotFound if the token was used concurrently.
+        VerifyEmail(ctx context.Context, tokenID, userID uuid.UUID, at time.Time) error
+        // ResetPassword uses a reset token, voids the user's other reset tokens, sets their password
+        // and ends any lockout in one transaction. It returns model.ErrUserTokenNotFound if the token was used
+        // concurrently.
+        ResetPassword(ctx context.Context, tokenID, userID uuid.UUID, passwordHash string, at time.Time) error
+
+        // Lockout operations
+        LockUser(ctx context.Context, userID uuid.UUID, until time.Time) error
+        UnlockUser(ctx context.Context, userID uuid.UUID) error
+
+        // Audit operations
+        CreateAuditEvent(ctx context.Context, event *model.AuditEvent) error
+
+        // Block operations
+        CreateBlock(ctx context.Context, block *model.Block) error
+        DeleteBlock(ctx context.Context, blockerID, userID uuid.UUID) error
+        IsBlocked(ctx context.Context, blockerID, userID uuid.UUID) (bool, error)
+        GetBlocks(ctx context.Context, blockerID uuid.UUID, params pagination.Params) ([]*model.Block, int, error)
+
+        // Rating operations. Reads take the viewer so shadowed ratings are only returned to their author.
         CreateRating(ctx context.Context, rating *model.Rating) error
-        GetRatingByID(ctx context.Context, id uuid.UUID) (*model.Rating, error)
+        GetRatingByID(ctx context.Context, id uuid.UUID, viewer model.Viewer) (*model.Rating, error)
         GetRatingByUserAndService(ctx context.Context, userID, serviceID uuid.UUID) (*model.Rating, error)
-        GetRatingsByService(ctx context.Context, serviceID uuid.UUID, params pagination.Params) ([]*model.Rating, int, error)
+        GetRatingsByService(ctx context.Context, serviceID uuid.UUID, viewer model.Viewer, filter model.RatingFilter, params pagination.Params) ([]*model.Rating, int, error)
         UpdateRating(ctx context.Context, rating *model.Rating) error
-        CalculateAverageRating(ctx context.Context, serviceID uuid.UUID) (*model.AverageRating, error)
+        CalculateAverageRating(ctx context.Context, serviceID uuid.UUID, viewer model.Viewer, filter model.RatingFilter) (*model.AverageRating, error)
+
+        // Brigading operations
+        RecordRatingClient(ctx context.Context, ratingID uuid.UUID, client model.ClientInfo, at time.Time) error
+        CountRatingsSince(ctx context.Context, serviceID uuid.UUID, since time.Time) (int, error)
+        CountClusteredRaters(ctx context.Context, serviceID, userID uuid.UUID, client model.ClientInfo, since time.Time) (int, int, error)
+        CreateRatingFlag(ctx context.Context, flag *model.RatingFlag) error
+        GetRatingFlagByID(ctx context.Context, id uuid.UUID) (*model.RatingFlag, error)
+        GetRatingFlags(ctx context.Context, filter model.RatingFlagFilter, params pagination.Params) ([]*model.RatingFlag, int, error)
+        ResolveRatingFlag(ctx context.Context, flag *model.RatingFlag) error
+
+        // Attestation operations. An attestation is redeemed in the same transaction as the rating or
+        // review write it verifies; ErrAttestationReused means the token was used before and nothing was saved.
+        CreateRatingWithAttestation(ctx context.Context, rating *model.Rating, attestation *model.Attestation) error
+        UpdateRatingWithAttestation(ctx context.Context, rating *model.Rating, attestation *model.Attestation) error
+        CreateReviewWithAttestation(ctx context.Context, review *model.Review, attestation *model.Attestation) error
         
-        // Review operations
+        // Review operations. Reads take the viewer so shadowed reviews and comments are only returned to
+        // their author; a user's own reviews, the pending queue and published reviews are fixed views.
         CreateReview(ctx context.Context, review *model.Review) error
-        GetReviewByID(ctx context.Context, id uuid.UUID) (*model.ReviewWithRating, error)
-        GetReviewsByService(ctx context.Context, serviceID uuid.UUID, params pagination.Params) ([]*model.ReviewWithRating, int, error)
+        GetReviewByID(ctx context.Context, id uuid.UUID, viewer model.Viewer) (*model.ReviewWithRating, error)
+        GetReviewsByService(ctx context.Context, serviceID uuid.UUID, viewer model.Viewer, filter model.ReviewFilter, params pagination.Params) ([]*model.ReviewWithRating, int, error)
+        GetReviewsByUser(ctx context.Context, userID uuid.UUID, status model.ReviewStatus, params pagination.Params) ([]*model.ReviewWithRating, int, error)
+        GetPendingReviews(ctx context.Context, params pagination.Params) ([]*model.ReviewWithRating, int, error)
         UpdateReview(ctx context.Context, review *model.Review) error
+        PublishDueReviews(ctx context.Context, now time.Time) ([]uuid.UUID, error)
+        GetPublishedReviewsAfter(ctx context.Context, serviceID uuid.UUID, publishedAt time.Time, afterID uuid.UUID, limit int) ([]*model.ReviewWithRating, error)
+        CalculateSentimentSummary(ctx context.Context, serviceID uuid.UUID, viewer model.Viewer) (*model.SentimentSummary, error)
+
+        // Duplicate detection operations
+        SaveReviewSignature(ctx context.Context, signature *model.ReviewSignature) error
+        FindReviewSignatures(ctx context.Context, bands []int64, excludeUserID uuid.UUID, serviceID *uuid.UUID, limit int) ([]*model.ReviewSignature, error)
+        GetDuplicateClusters(ctx context.Context, params pagination.Params) ([]*model.DuplicateCluster, int, error)
         
-        // Comment operations
+        // Comment operations. Reads take the viewer like review reads, except that comment tree reads
+        // mark comments the viewer may not see as withheld: GetCommentReplies returns every reply, and
+        // GetCommentThreads includes withheld threads with a reply the viewer can see.
         CreateComment(ctx context.Context, comment *model.Comment) error
-        GetCommentByID(ctx context.Context, id uuid.UUID) (*model.Comment, error)
-        GetCommentsByReview(ctx context.Context, reviewID uuid.UUID, params pagination.Params) ([]*model.Comment, int, error)
+        GetCommentByID(ctx context.Context, id uuid.UUID, viewer model.Viewer) (*model.Comment, error)
+        GetCommentsByReview(ctx context.Context, reviewID uuid.UUID, viewer model.Viewer, params pagination.Params) ([]*model.Comment, int, error)
+        GetCommentThreads(ctx context.Context, reviewID uuid.UUID, viewer model.Viewer, params pagination.Params) ([]*model.Comment, int, error)
+        GetCommentReplies(ctx context.Context, threadIDs []uuid.UUID, viewer model.Viewer) ([]*model.Comment, error)
+        CountCommentReplies(ctx context.Context, id uuid.UUID) (int, error)
         UpdateComment(ctx context.Context, comment *model.Comment) error
+        DeleteComment(ctx context.Context, id uuid.UUID) error
+
+        // Mention operations
+        ReplaceMentions(ctx context.Context, targetType model.MentionTarget, targetID uuid.UUID, mentions []*model.Mention) error
+        GetMentions(ctx context.Context, targetType model.MentionTarget, targetIDs []uuid.UUID) ([]*model.Mention, error)
+
+        // Reaction operations
+        ToggleReaction(ctx context.Context, reaction *model.Reaction) (bool, error)
+        GetReactionCounts(ctx context.Context, targetType model.ReactionTarget, targetIDs []uuid.UUID, viewerID uuid.UUID) ([]*model.ReactionCount, error)
+
+        // Moderation operations
+        CreateReport(ctx context.Context, report *model.Report) error
+        FlagForModeration(ctx context.Context, targetType model.ReportTarget, targetID uuid.UUID) error
+        ApplyModerationDecision(ctx context.Context, decision *model.ModerationDecision) error
+        GetModerationQueue(ctx context.Context, params pagination.Params) ([]*model.ModerationQueueItem, int, error)
+        GetModerationDecisions(ctx context.Context, targetType model.ReportTarget, targetID uuid.UUID) ([]*model.ModerationDecision, error)
+
+        // Notification operations
+        CreateNotification(ctx context.Context, notification *model.Notification) error
+        GetNotifications(ctx context.Context, userID uuid.UUID, filter model.NotificationFilter, cursor *pagination.Cursor, limit int) ([]*model.Notification, error)
+        CountUnreadNotifications(ctx context.Context, userID uuid.UUID) (int, error)
+        MarkNotificationRead(ctx context.Context, userID, id uuid.UUID, at time.Time) error
+        MarkAllNotificationsRead(ctx context.Context, userID uuid.UUID, at time.Time) (int, error)
+        GetNotificationPreferences(ctx context.Context, userID uuid.UUID) (*model.NotificationPreferences, error)
+        SetNotificationPreference(ctx context.Context, userID uuid.UUID, notificationType model.NotificationType, enabled bool) error
 }
@@ -0,0 +1,26 @@
+package port
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"rating-system/internal/domain/model"
+)
+
+// RoleService defines the port for granting and revoking user roles
+type RoleService interface {
+	GetRoles(ctx context.Context, userID uuid.UUID) ([]*model.RoleGrant, error)
+	GrantRole(ctx context.Context, adminID, userID uuid.UUID, role model.Role) (*model.RoleGrant, error)
+	RevokeRole(ctx context.Context, adminID, userID uuid.UUID, role model.Role) error
+
+	// BootstrapAdmin makes the user with the email the first admin, creating the account with the
+	// username and password if it does not exist. It fails once any admin exists.
+	BootstrapAdmin(ctx context.Context, email, username, password string) (*model.User, error)
+}
+
+// AccessTokenExpirer invalidates a user's access tokens, so that a revoked role stops working
+// before the tokens carrying it expire
+type AccessTokenExpirer interface {
+	ExpireAccessTokens(ctx context.Context, userID uuid.UUID) error
+}
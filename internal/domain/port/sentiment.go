@@ -0,0 +1,9 @@
+package port
+
+import "context"
+
+// SentimentAnalyzer scores the sentiment of free text
+type SentimentAnalyzer interface {
+	// Analyze returns a score in [-1, 1], from most negative to most positive
+	Analyze(ctx context.Context, text string) (float64, error)
+}
@@ -12,22 +12,38 @@ import (
 // Service defines the port for service operations
 type Service interface {
 	// Rating operations
-	CreateRating(ctx context.Context, userID, serviceID uuid.UUID, score int) (*model.Rating, error)
-	GetRatingByID(ctx context.Context, id uuid.UUID) (*model.Rating, error)
+	CreateRating(ctx context.Context, userID, serviceID uuid.UUID, score int, attestationToken string) (*model.Rating, error)
+	GetRatingByID(ctx context.Context, id, viewerID uuid.UUID) (*model.Rating, error)
 	GetRatingByUserAndService(ctx context.Context, userID, serviceID uuid.UUID) (*model.Rating, error)
-	GetRatingsByService(ctx context.Context, serviceID uuid.UUID, params pagination.Params) ([]*model.Rating, int, error)
+	GetRatingsByService(ctx context.Context, serviceID, viewerID uuid.UUID, filter model.RatingFilter, params pagination.Params) ([]*model.Rating, int, error)
 	UpdateRating(ctx context.Context, id uuid.UUID, score int) (*model.Rating, error)
-	GetAverageRating(ctx context.Context, serviceID uuid.UUID) (*model.AverageRating, error)
+	GetAverageRating(ctx context.Context, serviceID, viewerID uuid.UUID, filter model.RatingFilter) (*model.AverageRating, error)
 	
 	// Review operations
-	CreateReview(ctx context.Context, userID, serviceID uuid.UUID, ratingID uuid.UUID, title, content string) (*model.Review, error)
-	GetReviewByID(ctx context.Context, id uuid.UUID) (*model.ReviewWithRating, error)
-	GetReviewsByService(ctx context.Context, serviceID uuid.UUID, params pagination.Params) ([]*model.ReviewWithRating, int, error)
+	CreateReview(ctx context.Context, userID, serviceID uuid.UUID, ratingID uuid.UUID, title, content, attestationToken string, draft bool) (*model.Review, error)
+	GetReviewByID(ctx context.Context, id, viewerID uuid.UUID) (*model.ReviewWithRating, error)
+	GetReviewsByService(ctx context.Context, serviceID, viewerID uuid.UUID, filter model.ReviewFilter, params pagination.Params) ([]*model.ReviewWithRating, int, error)
+	GetReviewsByUser(ctx context.Context, userID uuid.UUID, status model.ReviewStatus, params pagination.Params) ([]*model.ReviewWithRating, int, error)
 	UpdateReview(ctx context.Context, id uuid.UUID, title, content string) (*model.Review, error)
+	GetSentimentSummary(ctx context.Context, serviceID, viewerID uuid.UUID) (*model.SentimentSummary, error)
+	GetServiceHighlights(ctx context.Context, serviceID uuid.UUID) (*model.ServiceHighlights, error)
+
+	// Review lifecycle operations
+	SubmitReview(ctx context.Context, userID, id uuid.UUID) (*model.Review, error)
+	GetPendingReviews(ctx context.Context, params pagination.Params) ([]*model.ReviewWithRating, int, error)
+	ApproveReview(ctx context.Context, id uuid.UUID) (*model.Review, error)
+	RejectReview(ctx context.Context, id uuid.UUID) (*model.Review, error)
+	PublishDueReviews(ctx context.Context) (int, error)
 	
 	// Comment operations
-	CreateComment(ctx context.Context, userID, reviewID uuid.UUID, content string) (*model.Comment, error)
-	GetCommentByID(ctx context.Context, id uuid.UUID) (*model.Comment, error)
-	GetCommentsByReview(ctx context.Context, reviewID uuid.UUID, params pagination.Params) ([]*model.Comment, int, error)
+	CreateComment(ctx context.Context, userID, reviewID, parentID uuid.UUID, content string) (*model.Comment, error)
+	GetCommentByID(ctx context.Context, id, viewerID uuid.UUID) (*model.Comment, error)
+	GetCommentsByReview(ctx context.Context, reviewID, viewerID uuid.UUID, params pagination.Params) ([]*model.Comment, int, error)
+	GetCommentTree(ctx context.Context, reviewID, viewerID uuid.UUID, params pagination.Params) ([]*model.CommentNode, int, error)
 	UpdateComment(ctx context.Context, id uuid.UUID, content string) (*model.Comment, error)
+	DeleteComment(ctx context.Context, userID, id uuid.UUID) error
+
+	// Reaction operations
+	GetReactionEmojis(ctx context.Context) []string
+	ToggleReaction(ctx context.Context, userID uuid.UUID, targetType model.ReactionTarget, targetID uuid.UUID, emoji string) (*model.ReactionToggle, error)
 }
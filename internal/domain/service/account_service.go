@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"rating-system/internal/domain/model"
+	"rating-system/internal/domain/port"
+	"rating-system/pkg/pagination"
+)
+
+// AccountService implements the AccountService port
+type AccountService struct {
+	repo port.Repository
+	log  *logrus.Logger
+}
+
+// NewAccountService creates a new account service
+func NewAccountService(repo port.Repository, log *logrus.Logger) port.AccountService {
+	return &AccountService{
+		repo: repo,
+		log:  log,
+	}
+}
+
+// ShadowBan shadow-bans a user on behalf of an admin. Only content written from now on is
+// shadowed; the rest of the account keeps working normally.
+func (s *AccountService) ShadowBan(ctx context.Context, adminID, userID uuid.UUID, reason string) (*model.ShadowBan, error) {
+	ban, err := model.NewShadowBan(userID, adminID, reason)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.repo.GetUserByID(ctx, userID); err != nil {
+		return nil, ErrUserNotFound
+	}
+
+	if err := s.repo.CreateShadowBan(ctx, ban); err != nil {
+		if !errors.Is(err, model.ErrAlreadyShadowBanned) {
+			s.log.WithError(err).Error("Failed to create shadow-ban in repository")
+		}
+		return nil, err
+	}
+
+	s.log.WithFields(logrus.Fields{"admin_id": adminID, "user_id": userID}).Info("Shadow-banned user")
+	return ban, nil
+}
+
+// LiftShadowBan lifts a user's shadow-ban on behalf of an admin. Content written while banned
+// stays shadowed; moderators can restore it individually if needed.
+func (s *AccountService) LiftShadowBan(ctx context.Context, adminID, userID uuid.UUID) error {
+	if err := s.repo.DeleteShadowBan(ctx, userID); err != nil {
+		if !errors.Is(err, model.ErrNotShadowBanned) {
+			s.log.WithError(err).Error("Failed to delete shadow-ban in repository")
+		}
+		return err
+	}
+
+	s.log.WithFields(logrus.Fields{"admin_id": adminID, "user_id": userID}).Info("Lifted shadow-ban")
+	return nil
+}
+
+// GetShadowBans retrieves the shadow-banned users, newest ban first
+func (s *AccountService) GetShadowBans(ctx context.Context, params pagination.Params) ([]*model.ShadowBan, int, error) {
+	bans, total, err := s.repo.GetShadowBans(ctx, params)
+	if err != nil {
+		s.log.WithError(err).Error("Failed to get shadow-bans")
+		return nil, 0, err
+	}
+	if bans == nil {
+		bans = []*model.ShadowBan{}
+	}
+	return bans, total, nil
+}
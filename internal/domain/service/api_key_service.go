@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"rating-system/internal/domain/model"
+	"rating-system/internal/domain/port"
+	"rating-system/pkg/pagination"
+)
+
+// APIKeyService implements the APIKeyService port
+type APIKeyService struct {
+	repo port.Repository
+	log  *logrus.Logger
+}
+
+// NewAPIKeyService creates a new API key service
+func NewAPIKeyService(repo port.Repository, log *logrus.Logger) port.APIKeyService {
+	return &APIKeyService{
+		repo: repo,
+		log:  log,
+	}
+}
+
+// CreateAPIKey issues a key acting as the user
+func (s *APIKeyService) CreateAPIKey(ctx context.Context, adminID, userID uuid.UUID, name string, scopes []model.Permission, serviceID *uuid.UUID, expiresAt *time.Time) (*model.APIKey, string, error) {
+	key, secret, err := model.NewAPIKey(userID, adminID, name, scopes, serviceID, expiresAt, time.Now())
+	if err != nil {
+		return nil, "", err
+	}
+
+	if _, err := s.repo.GetUserByID(ctx, userID); err != nil {
+		return nil, "", ErrUserNotFound
+	}
+
+	if err := s.repo.CreateAPIKey(ctx, key); err != nil {
+		s.log.WithError(err).Error("Failed to create API key in repository")
+		return nil, "", err
+	}
+
+	s.log.WithFields(logrus.Fields{"admin_id": adminID, "user_id": userID, "key_id": key.ID, "scopes": key.Scopes}).Info("Created API key")
+	return key, secret, nil
+}
+
+// GetAPIKeys lists keys, of one user if a user is given, most recent first
+func (s *APIKeyService) GetAPIKeys(ctx context.Context, userID *uuid.UUID, params pagination.Params) ([]*model.APIKey, int, error) {
+	keys, total, err := s.repo.GetAPIKeys(ctx, userID, params)
+	if err != nil {
+		s.log.WithError(err).Error("Failed to get API keys")
+		return nil, 0, err
+	}
+	if keys == nil {
+		keys = []*model.APIKey{}
+	}
+	return keys, total, nil
+}
+
+// RevokeAPIKey revokes a key; requests made with it are rejected from then on
+func (s *APIKeyService) RevokeAPIKey(ctx context.Context, adminID, keyID uuid.UUID) error {
+	if err := s.repo.RevokeAPIKey(ctx, keyID, time.Now()); err != nil {
+		if !errors.Is(err, model.ErrAPIKeyNotFound) {
+			s.log.WithError(err).Error("Failed to revoke API key in repository")
+		}
+		return err
+	}
+
+	s.log.WithFields(logrus.Fields{"admin_id": adminID, "key_id": keyID}).Info("Revoked API key")
+	return nil
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"rating-system/internal/domain/model"
+	"rating-system/internal/domain/port"
+	"rating-system/pkg/pagination"
+)
+
+// BlockService implements the BlockService port
+type BlockService struct {
+	repo port.Repository
+	log  *logrus.Logger
+}
+
+// NewBlockService creates a new block service
+func NewBlockService(repo port.Repository, log *logrus.Logger) port.BlockService {
+	return &BlockService{
+		repo: repo,
+		log:  log,
+	}
+}
+
+// BlockUser blocks a user on behalf of the blocker
+func (s *BlockService) BlockUser(ctx context.Context, blockerID, userID uuid.UUID) (*model.Block, error) {
+	block, err := model.NewBlock(blockerID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, ErrUserNotFound
+	}
+	block.Username = user.Username
+
+	if err := s.repo.CreateBlock(ctx, block); err != nil {
+		if !errors.Is(err, model.ErrAlreadyBlocked) {
+			s.log.WithError(err).Error("Failed to create block in repository")
+		}
+		return nil, err
+	}
+
+	s.log.WithFields(logrus.Fields{"blocker_id": blockerID, "user_id": userID}).Info("Blocked user")
+	return block, nil
+}
+
+// UnblockUser lifts a block on behalf of the blocker
+func (s *BlockService) UnblockUser(ctx context.Context, blockerID, userID uuid.UUID) error {
+	if err := s.repo.DeleteBlock(ctx, blockerID, userID); err != nil {
+		if !errors.Is(err, model.ErrNotBlocked) {
+			s.log.WithError(err).Error("Failed to delete block in repository")
+		}
+		return err
+	}
+
+	s.log.WithFields(logrus.Fields{"blocker_id": blockerID, "user_id": userID}).Info("Unblocked user")
+	return nil
+}
+
+// GetBlockedUsers retrieves the users the blocker has blocked, most recent first
+func (s *BlockService) GetBlockedUsers(ctx context.Context, blockerID uuid.UUID, params pagination.Params) ([]*model.Block, int, error) {
+	blocks, total, err := s.repo.GetBlocks(ctx, blockerID, params)
+	if err != nil {
+		s.log.WithError(err).Error("Failed to get blocked users")
+		return nil, 0, err
+	}
+	if blocks == nil {
+		blocks = []*model.Block{}
+	}
+	return blocks, total, nil
+}
@@ -0,0 +1,27 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"rating-system/internal/domain/model"
+)
+
+// checkNotBlocked returns model.ErrBlocked if the owner of a review or comment has blocked the
+// user interacting with it
+func (s *RatingService) checkNotBlocked(ctx context.Context, ownerID, userID uuid.UUID) error {
+	if ownerID == userID {
+		return nil
+	}
+
+	blocked, err := s.repo.IsBlocked(ctx, ownerID, userID)
+	if err != nil {
+		s.log.WithError(err).Error("Failed to check block")
+		return err
+	}
+	if blocked {
+		return model.ErrBlocked
+	}
+	return nil
+}
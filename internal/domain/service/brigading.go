@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"rating-system/internal/domain/model"
+)
+
+// screenRating records the client a saved rating came from and runs the brigading detector over
+// it, flagging the rating if it looks suspicious. The rating is already saved, so failures are
+// only logged.
+func (s *RatingService) screenRating(ctx context.Context, rating *model.Rating) {
+	if s.brigading == nil {
+		return
+	}
+
+	client := model.ClientInfoFromContext(ctx)
+	if client.IP != "" || client.DeviceID != "" {
+		if err := s.repo.RecordRatingClient(ctx, rating.ID, client, time.Now()); err != nil {
+			s.log.WithError(err).WithField("rating_id", rating.ID).Error("Failed to record rating client")
+		}
+	}
+
+	assessment, err := s.brigading.Assess(ctx, rating, client)
+	if err != nil {
+		s.log.WithError(err).WithField("rating_id", rating.ID).Error("Failed to assess rating for brigading")
+		return
+	}
+	if !assessment.Flag {
+		return
+	}
+
+	flag := model.NewRatingFlag(rating, assessment, client)
+	if err := s.repo.CreateRatingFlag(ctx, flag); err != nil {
+		s.log.WithError(err).WithField("rating_id", rating.ID).Error("Failed to flag suspicious rating")
+		return
+	}
+	s.log.WithFields(logrus.Fields{
+		"rating_id":   rating.ID,
+		"service_id":  rating.ServiceID,
+		"score":       assessment.Score,
+		"quarantined": flag.Quarantined,
+	}).Warn("Flagged suspicious rating")
+}
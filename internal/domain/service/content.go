@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"rating-system/internal/domain/model"
+	"rating-system/pkg/markdown"
+)
+
+// decorateReviews renders the reviews' content and loads their mentions and the reactions as
+// seen by the viewer, using one query per kind for the whole page
+func (s *RatingService) decorateReviews(ctx context.Context, viewerID uuid.UUID, reviews ...*model.Review) error {
+	for _, review := range reviews {
+		renderReview(review)
+	}
+	if err := s.attachReviewMentions(ctx, reviews...); err != nil {
+		return err
+	}
+	return s.attachReviewReactions(ctx, viewerID, reviews...)
+}
+
+// decorateComments renders the comments' content and loads their mentions and the reactions
+// as seen by the viewer, using one query per kind for the whole page
+func (s *RatingService) decorateComments(ctx context.Context, viewerID uuid.UUID, comments ...*model.Comment) error {
+	for _, comment := range comments {
+		renderComment(comment)
+	}
+	if err := s.attachCommentMentions(ctx, comments...); err != nil {
+		return err
+	}
+	return s.attachCommentReactions(ctx, viewerID, comments...)
+}
+
+// renderReview fills in the sanitised HTML rendering of the review's Markdown content
+func renderReview(review *model.Review) {
+	review.ContentHTML = markdown.Render(review.Content)
+}
+
+// renderComment fills in the sanitised HTML rendering of the comment's Markdown content
+func renderComment(comment *model.Comment) {
+	comment.ContentHTML = markdown.Render(comment.Content)
+}
@@ -0,0 +1,35 @@
+package service
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+
+	"rating-system/internal/domain/model"
+	"rating-system/internal/domain/port"
+	"rating-system/pkg/pagination"
+)
+
+// DuplicateService implements the DuplicateService port
+type DuplicateService struct {
+	repo port.Repository
+	log  *logrus.Logger
+}
+
+// NewDuplicateService creates a new duplicate cluster service
+func NewDuplicateService(repo port.Repository, log *logrus.Logger) port.DuplicateService {
+	return &DuplicateService{
+		repo: repo,
+		log:  log,
+	}
+}
+
+// GetClusters retrieves clusters of near-duplicate reviews, most recently extended first
+func (s *DuplicateService) GetClusters(ctx context.Context, params pagination.Params) ([]*model.DuplicateCluster, int, error) {
+	clusters, total, err := s.repo.GetDuplicateClusters(ctx, params)
+	if err != nil {
+		s.log.WithError(err).Error("Failed to get duplicate clusters")
+		return nil, 0, err
+	}
+	return clusters, total, nil
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+
+	"rating-system/internal/domain/model"
+)
+
+// screenDuplicates checks a new or edited review for near-duplicates of other accounts' reviews,
+// returning an error if the copy is rejected. Detector failures are logged and let the review through.
+func (s *RatingService) screenDuplicates(ctx context.Context, review *model.Review) (*model.DuplicateCheck, error) {
+	allow := &model.DuplicateCheck{Result: model.Allow()}
+	if s.duplicates == nil {
+		return allow, nil
+	}
+
+	check, err := s.duplicates.Check(ctx, review)
+	if err != nil {
+		s.log.WithError(err).WithField("review_id", review.ID).Error("Failed to check review for duplicates")
+		return allow, nil
+	}
+	if len(check.Matches) > 0 {
+		s.log.WithFields(logrus.Fields{
+			"review_id":  review.ID,
+			"user_id":    review.UserID,
+			"matches":    len(check.Matches),
+			"similarity": check.Matches[0].Similarity,
+			"outcome":    check.Result.Outcome.String(),
+		}).Warn("Review nearly matches reviews from other accounts")
+	}
+	if err := check.Result.Err(); err != nil {
+		return nil, err
+	}
+	return check, nil
+}
+
+// saveSignature stores the signature of a saved review so later reviews are compared against it,
+// replacing that of its previous content. The review is already saved, so failures are only logged.
+func (s *RatingService) saveSignature(ctx context.Context, check *model.DuplicateCheck) {
+	if check.Signature == nil {
+		return
+	}
+	if err := s.repo.SaveReviewSignature(ctx, check.Signature); err != nil {
+		s.log.WithError(err).WithField("review_id", check.Signature.ReviewID).Error("Failed to save review signature")
+	}
+}
@@ -0,0 +1,27 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"rating-system/internal/domain/model"
+)
+
+// checkEmailVerified returns model.ErrEmailNotVerified if verified email addresses are required
+// and the user has not verified theirs
+func (s *RatingService) checkEmailVerified(ctx context.Context, userID uuid.UUID) error {
+	if !s.requireVerifiedEmail {
+		return nil
+	}
+
+	user, err := s.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		s.log.WithError(err).WithField("user_id", userID).Error("Failed to get user")
+		return err
+	}
+	if user == nil || !user.IsEmailVerified() {
+		return model.ErrEmailNotVerified
+	}
+	return nil
+}
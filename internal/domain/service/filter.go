@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"rating-system/internal/domain/model"
+)
+
+// screenContent runs the content filter over user-written text, returning an error if the
+// filter rejects it. The result tells whether the content must be flagged once it is saved.
+func (s *RatingService) screenContent(ctx context.Context, text ...string) (model.FilterResult, error) {
+	if s.filter == nil {
+		return model.Allow(), nil
+	}
+
+	result := s.filter.Check(ctx, strings.Join(text, "\n"))
+	if err := result.Err(); err != nil {
+		s.log.WithField("reasons", result.Reasons).Info("Content filter rejected content")
+		return result, err
+	}
+	return result, nil
+}
+
+// flagContent queues content the filter flagged for a moderator, reporting it on the filter's
+// behalf and updating its moderation status. The content is already saved, so failures are
+// only logged.
+func (s *RatingService) flagContent(ctx context.Context, targetType model.ReportTarget, targetID uuid.UUID, result model.FilterResult, status *model.ModerationStatus) {
+	if result.Outcome != model.FilterFlag {
+		return
+	}
+
+	log := s.log.WithField("target_id", targetID)
+	if err := s.repo.CreateReport(ctx, model.NewFilterReport(targetType, targetID, result.Reasons)); err != nil {
+		log.WithError(err).Error("Failed to report flagged content")
+		return
+	}
+	if err := s.repo.FlagForModeration(ctx, targetType, targetID); err != nil {
+		log.WithError(err).Error("Failed to flag content for moderation")
+		return
+	}
+	log.WithField("reasons", result.Reasons).Info("Content filter flagged content for moderation")
+
+	if *status == model.ModerationVisible {
+		*status = model.ModerationPendingReview
+	}
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+
+	"rating-system/internal/domain/model"
+)
+
+// resolveMentions finds the @username mentions in content written by the author and resolves them
+// to users. Usernames that do not resolve, and users who blocked the author, are left as plain text.
+func (s *RatingService) resolveMentions(ctx context.Context, targetType model.MentionTarget, targetID, authorID uuid.UUID, content string) ([]*model.Mention, error) {
+	spans := model.ParseMentions(content)
+	usernames := model.MentionedUsernames(spans)
+	if s.maxMentions > 0 && len(usernames) > s.maxMentions {
+		return nil, model.ErrTooManyMentions
+	}
+
+	users := make(map[string]*model.User, len(usernames))
+	for _, username := range usernames {
+		user, err := s.repo.GetUserByUsername(ctx, username)
+		if err != nil {
+			s.log.WithError(err).WithField("username", username).Debug("Ignoring unresolved mention")
+			continue
+		}
+		if err := s.checkNotBlocked(ctx, user.ID, authorID); err != nil {
+			if !errors.Is(err, model.ErrBlocked) {
+				return nil, err
+			}
+			s.log.WithField("username", username).Debug("Ignoring mention of a user who blocked the author")
+			continue
+		}
+		users[username] = user
+	}
+
+	var mentions []*model.Mention
+	for _, span := range spans {
+		if user, ok := users[span.Username]; ok {
+			mentions = append(mentions, model.NewMention(targetType, targetID, user, span))
+		}
+	}
+	return mentions, nil
+}
+
+// saveMentions replaces the stored mentions of a review or comment
+func (s *RatingService) saveMentions(ctx context.Context, targetType model.MentionTarget, targetID uuid.UUID, mentions []*model.Mention) error {
+	if err := s.repo.ReplaceMentions(ctx, targetType, targetID, mentions); err != nil {
+		s.log.WithError(err).WithField("target_id", targetID).Error("Failed to save mentions")
+		return err
+	}
+	return nil
+}
+
+// publishMentions publishes an event for the users mentioned by the actor, skipping the actor
+// and users that were already mentioned before an edit
+func (s *RatingService) publishMentions(ctx context.Context, actorID, reviewID uuid.UUID, commentID *uuid.UUID, mentions, previous []*model.Mention) {
+	skip := map[uuid.UUID]bool{actorID: true}
+	for _, mention := range previous {
+		skip[mention.UserID] = true
+	}
+
+	var userIDs []uuid.UUID
+	for _, mention := range mentions {
+		if !skip[mention.UserID] {
+			skip[mention.UserID] = true
+			userIDs = append(userIDs, mention.UserID)
+		}
+	}
+	if len(userIDs) == 0 {
+		return
+	}
+
+	s.publish(ctx, model.UsersMentionedEvent{
+		ActorID:   actorID,
+		ReviewID:  reviewID,
+		CommentID: commentID,
+		UserIDs:   userIDs,
+	})
+}
+
+// attachReviewMentions loads the mentions of the reviews with a single query
+func (s *RatingService) attachReviewMentions(ctx context.Context, reviews ...*model.Review) error {
+	ids := make([]uuid.UUID, len(reviews))
+	for i, review := range reviews {
+		ids[i] = review.ID
+	}
+
+	byTarget, err := s.getMentions(ctx, model.MentionTargetReview, ids)
+	if err != nil {
+		return err
+	}
+	for _, review := range reviews {
+		review.Mentions = byTarget[review.ID]
+	}
+	return nil
+}
+
+// attachCommentMentions loads the mentions of the comments with a single query
+func (s *RatingService) attachCommentMentions(ctx context.Context, comments ...*model.Comment) error {
+	ids := make([]uuid.UUID, len(comments))
+	for i, comment := range comments {
+		ids[i] = comment.ID
+	}
+
+	byTarget, err := s.getMentions(ctx, model.MentionTargetComment, ids)
+	if err != nil {
+		return err
+	}
+	for _, comment := range comments {
+		comment.Mentions = byTarget[comment.ID]
+	}
+	return nil
+}
+
+func (s *RatingService) getMentions(ctx context.Context, targetType model.MentionTarget, ids []uuid.UUID) (map[uuid.UUID][]*model.Mention, error) {
+	mentions, err := s.repo.GetMentions(ctx, targetType, ids)
+	if err != nil {
+		s.log.WithError(err).Error("Failed to get mentions")
+		return nil, err
+	}
+
+	byTarget := make(map[uuid.UUID][]*model.Mention)
+	for _, mention := range mentions {
+		byTarget[mention.TargetID] = append(byTarget[mention.TargetID], mention)
+	}
+	return byTarget, nil
+}
+
+// reviewsOf returns the reviews embedded in the joined rows
+func reviewsOf(rows []*model.ReviewWithRating) []*model.Review {
+	reviews := make([]*model.Review, len(rows))
+	for i, row := range rows {
+		reviews[i] = &row.Review
+	}
+	return reviews
+}
@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"rating-system/internal/domain/model"
+	"rating-system/internal/domain/port"
+	"rating-system/pkg/pagination"
+)
+
+// ModerationService implements the ModerationService port
+type ModerationService struct {
+	repo   port.Repository
+	events port.EventPublisher
+	log    *logrus.Logger
+}
+
+// NewModerationService creates a new moderation service publishing its decisions to events, which
+// may be nil
+func NewModerationService(repo port.Repository, events port.EventPublisher, log *logrus.Logger) port.ModerationService {
+	return &ModerationService{
+		repo:   repo,
+		events: events,
+		log:    log,
+	}
+}
+
+// moderatedContent is the review or comment a decision is taken on
+type moderatedContent struct {
+	status   model.ModerationStatus
+	userID   uuid.UUID
+	reviewID uuid.UUID
+}
+
+// ReportContent records a user's report of a review or comment they can see and queues the
+// content for moderation. Reported content stays visible until a moderator decides on it.
+func (s *ModerationService) ReportContent(ctx context.Context, reporterID uuid.UUID, targetType model.ReportTarget, targetID uuid.UUID, reason model.ReportReason, details string) (*model.Report, error) {
+	report, err := model.NewReport(reporterID, targetType, targetID, reason, details)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkVisible(ctx, targetType, targetID, reporterID); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.CreateReport(ctx, report); err != nil {
+		s.log.WithError(err).Error("Failed to create report in repository")
+		return nil, err
+	}
+
+	if err := s.repo.FlagForModeration(ctx, targetType, targetID); err != nil {
+		s.log.WithError(err).WithField("target_id", targetID).Error("Failed to flag reported content for moderation")
+		return nil, err
+	}
+	return report, nil
+}
+
+// GetQueue retrieves the reported content awaiting a decision, most reported first
+func (s *ModerationService) GetQueue(ctx context.Context, params pagination.Params) ([]*model.ModerationQueueItem, int, error) {
+	items, total, err := s.repo.GetModerationQueue(ctx, params)
+	if err != nil {
+		s.log.WithError(err).Error("Failed to get moderation queue")
+		return nil, 0, err
+	}
+	return items, total, nil
+}
+
+// Decide approves, hides or removes a review or comment, records the decision, resolves the
+// open reports about it and lets the content's author know
+func (s *ModerationService) Decide(ctx context.Context, moderatorID uuid.UUID, targetType model.ReportTarget, targetID uuid.UUID, action model.ModerationAction, reason string) (*model.ModerationDecision, error) {
+	content, err := s.getContent(ctx, targetType, targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	decision, err := model.NewModerationDecision(moderatorID, targetType, targetID, action, reason, content.status)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.ApplyModerationDecision(ctx, decision); err != nil {
+		if !errors.Is(err, model.ErrModerationConflict) {
+			s.log.WithError(err).Error("Failed to apply moderation decision in repository")
+		}
+		return nil, err
+	}
+
+	if s.events != nil {
+		s.events.Publish(ctx, model.ContentModeratedEvent{
+			TargetType: targetType,
+			TargetID:   targetID,
+			UserID:     content.userID,
+			ReviewID:   content.reviewID,
+			Action:     decision.Action,
+			Status:     decision.Status,
+		})
+	}
+	return decision, nil
+}
+
+// GetDecisions retrieves the decisions taken on a review or comment, oldest first
+func (s *ModerationService) GetDecisions(ctx context.Context, targetType model.ReportTarget, targetID uuid.UUID) ([]*model.ModerationDecision, error) {
+	if _, err := s.getContent(ctx, targetType, targetID); err != nil {
+		return nil, err
+	}
+
+	decisions, err := s.repo.GetModerationDecisions(ctx, targetType, targetID)
+	if err != nil {
+		s.log.WithError(err).Error("Failed to get moderation decisions")
+		return nil, err
+	}
+	if decisions == nil {
+		decisions = []*model.ModerationDecision{}
+	}
+	return decisions, nil
+}
+
+// getContent returns the moderation status and author of a review or comment
+func (s *ModerationService) getContent(ctx context.Context, targetType model.ReportTarget, targetID uuid.UUID) (*moderatedContent, error) {
+	switch targetType {
+	case model.ReportTargetReview:
+		review, err := s.repo.GetReviewByID(ctx, targetID, model.SystemViewer)
+		if err != nil {
+			return nil, ErrReviewNotFound
+		}
+		return &moderatedContent{status: review.ModerationStatus, userID: review.UserID, reviewID: review.ID}, nil
+	case model.ReportTargetComment:
+		comment, err := s.repo.GetCommentByID(ctx, targetID, model.SystemViewer)
+		if err != nil {
+			return nil, ErrCommentNotFound
+		}
+		return &moderatedContent{status: comment.ModerationStatus, userID: comment.UserID, reviewID: comment.ReviewID}, nil
+	}
+	return nil, model.ErrInvalidReportTarget
+}
+
+// checkVisible verifies that the review or comment exists and can be seen by the viewer
+func (s *ModerationService) checkVisible(ctx context.Context, targetType model.ReportTarget, targetID, viewerID uuid.UUID) error {
+	switch targetType {
+	case model.ReportTargetReview:
+		review, err := s.repo.GetReviewByID(ctx, targetID, model.ViewerOf(viewerID))
+		if err != nil || !review.IsVisibleTo(viewerID) {
+			return ErrReviewNotFound
+		}
+	case model.ReportTargetComment:
+		comment, err := s.repo.GetCommentByID(ctx, targetID, model.ViewerOf(viewerID))
+		if err != nil || !comment.ModerationStatus.IsPublic() {
+			return ErrCommentNotFound
+		}
+		if comment.Deleted {
+			return model.ErrCommentDeleted
+		}
+	default:
+		return model.ErrInvalidReportTarget
+	}
+	return nil
+}
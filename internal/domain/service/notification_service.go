@@ -0,0 +1,266 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"rating-system/internal/domain/model"
+	"rating-system/internal/domain/port"
+	"rating-system/pkg/pagination"
+)
+
+// Notification inbox page sizes
+const (
+	defaultNotificationLimit = 20
+	maxNotificationLimit     = 100
+)
+
+// Errors returned by the notification service
+var (
+	ErrNotificationNotFound    = errors.New("notification not found")
+	ErrInvalidNotificationType = errors.New("invalid notification type")
+)
+
+// NotificationService implements the NotificationService port
+type NotificationService struct {
+	repo port.Repository
+	log  *logrus.Logger
+}
+
+// NewNotificationService creates a new notification service
+func NewNotificationService(repo port.Repository, log *logrus.Logger) port.NotificationService {
+	return &NotificationService{
+		repo: repo,
+		log:  log,
+	}
+}
+
+// HandleEvent records notifications for the recipients of a domain event. It is meant to be
+// subscribed to the event bus, so it runs off the request path and only logs failures.
+func (s *NotificationService) HandleEvent(ctx context.Context, event model.Event) {
+	switch e := event.(type) {
+	case model.CommentCreatedEvent:
+		s.notifyComment(ctx, e)
+	case model.ReviewModeratedEvent:
+		s.notifyModeration(ctx, e)
+	case model.ContentModeratedEvent:
+		s.notifyContentModeration(ctx, e)
+	case model.UsersMentionedEvent:
+		s.notifyMentions(ctx, e)
+	}
+}
+
+// notifyComment notifies the author of the parent comment about a reply and the review's
+// author about a comment. Nobody is notified about their own activity or notified twice.
+func (s *NotificationService) notifyComment(ctx context.Context, e model.CommentCreatedEvent) {
+	review, err := s.repo.GetReviewByID(ctx, e.ReviewID, model.SystemViewer)
+	if err != nil {
+		s.log.WithError(err).WithField("review_id", e.ReviewID).Error("Failed to get review for comment notification")
+		return
+	}
+
+	parentAuthor := uuid.Nil
+	if e.ParentID != nil {
+		parent, err := s.repo.GetCommentByID(ctx, *e.ParentID, model.SystemViewer)
+		if err != nil {
+			s.log.WithError(err).WithField("comment_id", *e.ParentID).Error("Failed to get parent comment for notification")
+		} else {
+			parentAuthor = parent.UserID
+		}
+	}
+
+	if parentAuthor != uuid.Nil && parentAuthor != e.UserID {
+		notificationType := model.NotificationCommentReply
+		if e.UserID == review.UserID {
+			notificationType = model.NotificationOwnerResponse
+		}
+		s.notify(ctx, s.commentNotification(parentAuthor, notificationType, e))
+	}
+
+	if review.UserID != e.UserID && review.UserID != parentAuthor {
+		s.notify(ctx, s.commentNotification(review.UserID, model.NotificationCommentOnReview, e))
+	}
+}
+
+func (s *NotificationService) commentNotification(recipient uuid.UUID, notificationType model.NotificationType, e model.CommentCreatedEvent) *model.Notification {
+	notification := model.NewNotification(recipient, notificationType)
+	actorID, reviewID, commentID := e.UserID, e.ReviewID, e.CommentID
+	notification.ActorID = &actorID
+	notification.ReviewID = &reviewID
+	notification.CommentID = &commentID
+	return notification
+}
+
+// notifyMentions notifies mentioned users. Users mentioned in a comment who are already
+// notified about it as the author of the review or of the parent comment are skipped.
+func (s *NotificationService) notifyMentions(ctx context.Context, e model.UsersMentionedEvent) {
+	skip := make(map[uuid.UUID]bool)
+	if e.CommentID != nil {
+		recipients, err := s.commentRecipients(ctx, *e.CommentID)
+		if err != nil {
+			s.log.WithError(err).WithField("comment_id", *e.CommentID).Error("Failed to get comment for mention notification")
+			return
+		}
+		for _, recipient := range recipients {
+			skip[recipient] = true
+		}
+	}
+
+	for _, userID := range e.UserIDs {
+		if skip[userID] || userID == e.ActorID {
+			continue
+		}
+		notification := model.NewNotification(userID, model.NotificationMention)
+		actorID, reviewID := e.ActorID, e.ReviewID
+		notification.ActorID = &actorID
+		notification.ReviewID = &reviewID
+		notification.CommentID = e.CommentID
+		s.notify(ctx, notification)
+	}
+}
+
+// commentRecipients returns the users notified about a new comment: the review's author and
+// the parent comment's author
+func (s *NotificationService) commentRecipients(ctx context.Context, commentID uuid.UUID) ([]uuid.UUID, error) {
+	comment, err := s.repo.GetCommentByID(ctx, commentID, model.SystemViewer)
+	if err != nil {
+		return nil, err
+	}
+	review, err := s.repo.GetReviewByID(ctx, comment.ReviewID, model.SystemViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	recipients := []uuid.UUID{review.UserID}
+	if comment.ParentID != nil {
+		parent, err := s.repo.GetCommentByID(ctx, *comment.ParentID, model.SystemViewer)
+		if err != nil {
+			return nil, err
+		}
+		recipients = append(recipients, parent.UserID)
+	}
+	return recipients, nil
+}
+
+// notifyModeration notifies a review's author of a moderator's decision
+func (s *NotificationService) notifyModeration(ctx context.Context, e model.ReviewModeratedEvent) {
+	notification := model.NewNotification(e.UserID, model.NotificationModerationOutcome)
+	reviewID := e.ReviewID
+	notification.ReviewID = &reviewID
+	notification.Detail = string(e.Status)
+	s.notify(ctx, notification)
+}
+
+// notifyContentModeration notifies the author of a review or comment of a moderator's decision
+// on it, with the resulting moderation status as the detail
+func (s *NotificationService) notifyContentModeration(ctx context.Context, e model.ContentModeratedEvent) {
+	notification := model.NewNotification(e.UserID, model.NotificationModerationOutcome)
+	reviewID := e.ReviewID
+	notification.ReviewID = &reviewID
+	if e.TargetType == model.ReportTargetComment {
+		commentID := e.TargetID
+		notification.CommentID = &commentID
+	}
+	notification.Detail = string(e.Status)
+	s.notify(ctx, notification)
+}
+
+// notify stores the notification unless the recipient has disabled its type
+func (s *NotificationService) notify(ctx context.Context, notification *model.Notification) {
+	preferences, err := s.repo.GetNotificationPreferences(ctx, notification.UserID)
+	if err != nil {
+		s.log.WithError(err).Error("Failed to get notification preferences")
+		return
+	}
+	if !preferences.Allows(notification.Type) {
+		return
+	}
+
+	if err := s.repo.CreateNotification(ctx, notification); err != nil {
+		s.log.WithError(err).WithField("type", notification.Type).Error("Failed to create notification")
+	}
+}
+
+// GetNotifications retrieves a page of the user's inbox, newest first
+func (s *NotificationService) GetNotifications(ctx context.Context, userID uuid.UUID, filter model.NotificationFilter, cursor *pagination.Cursor, limit int) (*model.NotificationPage, error) {
+	if limit <= 0 {
+		limit = defaultNotificationLimit
+	}
+	if limit > maxNotificationLimit {
+		limit = maxNotificationLimit
+	}
+
+	// Fetch one extra notification to learn whether there is a next page
+	notifications, err := s.repo.GetNotifications(ctx, userID, filter, cursor, limit+1)
+	if err != nil {
+		s.log.WithError(err).Error("Failed to get notifications")
+		return nil, err
+	}
+
+	page := &model.NotificationPage{Notifications: notifications}
+	if len(notifications) > limit {
+		page.Notifications = notifications[:limit]
+		last := page.Notifications[limit-1]
+		page.NextCursor = pagination.Cursor{CreatedAt: last.CreatedAt, ID: last.ID}.Encode()
+	}
+	if page.Notifications == nil {
+		page.Notifications = []*model.Notification{}
+	}
+
+	page.UnreadCount, err = s.repo.CountUnreadNotifications(ctx, userID)
+	if err != nil {
+		s.log.WithError(err).Error("Failed to count unread notifications")
+		return nil, err
+	}
+	return page, nil
+}
+
+// MarkRead marks one of the user's notifications as read
+func (s *NotificationService) MarkRead(ctx context.Context, userID, id uuid.UUID) error {
+	if err := s.repo.MarkNotificationRead(ctx, userID, id, time.Now()); err != nil {
+		s.log.WithError(err).Error("Failed to mark notification read")
+		return ErrNotificationNotFound
+	}
+	return nil
+}
+
+// MarkAllRead marks all of the user's notifications as read and returns how many changed
+func (s *NotificationService) MarkAllRead(ctx context.Context, userID uuid.UUID) (int, error) {
+	updated, err := s.repo.MarkAllNotificationsRead(ctx, userID, time.Now())
+	if err != nil {
+		s.log.WithError(err).Error("Failed to mark all notifications read")
+		return 0, err
+	}
+	return updated, nil
+}
+
+// GetPreferences retrieves the user's notification preferences for every type
+func (s *NotificationService) GetPreferences(ctx context.Context, userID uuid.UUID) (*model.NotificationPreferences, error) {
+	preferences, err := s.repo.GetNotificationPreferences(ctx, userID)
+	if err != nil {
+		s.log.WithError(err).Error("Failed to get notification preferences")
+		return nil, err
+	}
+	return preferences.WithDefaults(), nil
+}
+
+// UpdatePreferences enables or disables the given notification types for the user
+func (s *NotificationService) UpdatePreferences(ctx context.Context, userID uuid.UUID, enabled map[model.NotificationType]bool) (*model.NotificationPreferences, error) {
+	for notificationType := range enabled {
+		if !notificationType.IsValid() {
+			return nil, ErrInvalidNotificationType
+		}
+	}
+
+	for notificationType, on := range enabled {
+		if err := s.repo.SetNotificationPreference(ctx, userID, notificationType, on); err != nil {
+			s.log.WithError(err).Error("Failed to set notification preference")
+			return nil, err
+		}
+	}
+	return s.GetPreferences(ctx, userID)
+}
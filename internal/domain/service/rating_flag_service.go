@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"rating-system/internal/domain/model"
+	"rating-system/internal/domain/port"
+	"rating-system/pkg/pagination"
+)
+
+// RatingFlagService implements the RatingFlagService port
+type RatingFlagService struct {
+	repo port.Repository
+	log  *logrus.Logger
+}
+
+// NewRatingFlagService creates a new rating flag service
+func NewRatingFlagService(repo port.Repository, log *logrus.Logger) port.RatingFlagService {
+	return &RatingFlagService{
+		repo: repo,
+		log:  log,
+	}
+}
+
+// GetFlags retrieves rating flags, newest first
+func (s *RatingFlagService) GetFlags(ctx context.Context, filter model.RatingFlagFilter, params pagination.Params) ([]*model.RatingFlag, int, error) {
+	if filter.Status != "" && !filter.Status.IsValid() {
+		return nil, 0, model.ErrInvalidRatingFlagState
+	}
+
+	flags, total, err := s.repo.GetRatingFlags(ctx, filter, params)
+	if err != nil {
+		s.log.WithError(err).Error("Failed to get rating flags")
+		return nil, 0, err
+	}
+	return flags, total, nil
+}
+
+// GetFlag retrieves a rating flag by ID
+func (s *RatingFlagService) GetFlag(ctx context.Context, id uuid.UUID) (*model.RatingFlag, error) {
+	flag, err := s.repo.GetRatingFlagByID(ctx, id)
+	if err != nil {
+		if !errors.Is(err, model.ErrRatingFlagNotFound) {
+			s.log.WithError(err).WithField("flag_id", id).Error("Failed to get rating flag")
+		}
+		return nil, err
+	}
+	return flag, nil
+}
+
+// ResolveFlag clears or confirms an open flag. Clearing releases the rating from quarantine;
+// confirming keeps it out of public listings and averages.
+func (s *RatingFlagService) ResolveFlag(ctx context.Context, moderatorID, id uuid.UUID, status model.RatingFlagStatus) (*model.RatingFlag, error) {
+	flag, err := s.GetFlag(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := flag.Resolve(moderatorID, status, time.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.ResolveRatingFlag(ctx, flag); err != nil {
+		if !errors.Is(err, model.ErrRatingFlagResolved) {
+			s.log.WithError(err).Error("Failed to resolve rating flag in repository")
+		}
+		return nil, err
+	}
+	return flag, nil
+}
@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
@@ -12,31 +13,171 @@ import (
 	"rating-system/pkg/pagination"
 )
 
+// Errors returned by the rating service
+var (
+	ErrAttestationUnsupported = errors.New("attestation verification is not configured")
+	ErrReviewNotFound         = errors.New("review not found")
+	ErrNotReviewAuthor        = errors.New("only the author can modify this review")
+	ErrHighlightsUnavailable  = errors.New("review highlights are not configured")
+	ErrCommentNotFound        = errors.New("comment not found")
+	ErrNotCommentAuthor       = errors.New("only the author can modify this comment")
+)
+
 // RatingService implements the Service port
 type RatingService struct {
-	repo port.Repository
-	log  *logrus.Logger
+	repo     port.Repository
+	verifier    port.AttestationVerifier
+	sentiment   port.SentimentAnalyzer
+	highlights  port.HighlightsProvider
+	events      port.EventPublisher
+	policy      model.ReviewPolicy
+	maxDepth    int
+	maxMentions int
+	reactions   model.ReactionSet
+	filter      port.ContentFilter
+	brigading   port.BrigadingDetector
+	duplicates  port.DuplicateDetector
+	// requireVerifiedEmail stops users who have not verified their email address posting reviews
+	requireVerifiedEmail bool
+	log                  *logrus.Logger
+}
+
+// Option configures optional collaborators of the rating service
+type Option func(*RatingService)
+
+// WithAttestationVerifier enables verification of interaction attestations
+func WithAttestationVerifier(verifier port.AttestationVerifier) Option {
+	return func(s *RatingService) {
+		s.verifier = verifier
+	}
+}
+
+// WithReviewPolicy sets the policy applied when reviews are submitted for publication
+func WithReviewPolicy(policy model.ReviewPolicy) Option {
+	return func(s *RatingService) {
+		s.policy = policy
+	}
+}
+
+// WithSentimentAnalyzer enables sentiment scoring of review text on create and update
+func WithSentimentAnalyzer(analyzer port.SentimentAnalyzer) Option {
+	return func(s *RatingService) {
+		s.sentiment = analyzer
+	}
+}
+
+// WithHighlightsProvider enables per-service pros/cons highlights
+func WithHighlightsProvider(provider port.HighlightsProvider) Option {
+	return func(s *RatingService) {
+		s.highlights = provider
+	}
+}
+
+// WithEventPublisher publishes domain events, such as reviews being published, to subscribers
+func WithEventPublisher(publisher port.EventPublisher) Option {
+	return func(s *RatingService) {
+		s.events = publisher
+	}
+}
+
+// WithCommentMaxDepth limits how deeply replies can be nested; zero or less disables the limit
+func WithCommentMaxDepth(depth int) Option {
+	return func(s *RatingService) {
+		s.maxDepth = depth
+	}
+}
+
+// WithMaxMentions limits how many distinct users one review or comment can mention; zero or less disables the limit
+func WithMaxMentions(limit int) Option {
+	return func(s *RatingService) {
+		s.maxMentions = limit
+	}
+}
+
+// WithReactionEmojis sets the emojis users can react with
+func WithReactionEmojis(emojis []string) Option {
+	return func(s *RatingService) {
+		s.reactions = model.NewReactionSet(emojis)
+	}
+}
+
+// WithContentFilter screens review and comment text on create and update, rejecting it or
+// flagging it for moderation
+func WithContentFilter(filter port.ContentFilter) Option {
+	return func(s *RatingService) {
+		s.filter = filter
+	}
+}
+
+// WithBrigadingDetector scores new and changed ratings for brigading, flagging suspicious ones
+// for moderators and optionally quarantining them
+func WithBrigadingDetector(detector port.BrigadingDetector) Option {
+	return func(s *RatingService) {
+		s.brigading = detector
+	}
+}
+
+// WithDuplicateDetector checks new and edited reviews for near-duplicates of reviews from other accounts,
+// flagging or rejecting copies
+func WithDuplicateDetector(detector port.DuplicateDetector) Option {
+	return func(s *RatingService) {
+		s.duplicates = detector
+	}
+}
+
+// WithVerifiedEmailRequired sets whether users must verify their email address before posting
+// reviews; drafts can be saved either way
+func WithVerifiedEmailRequired(required bool) Option {
+	return func(s *RatingService) {
+		s.requireVerifiedEmail = required
+	}
 }
 
 // NewRatingService creates a new rating service
-func NewRatingService(repo port.Repository, log *logrus.Logger) port.Service {
-	return &RatingService{
-		repo: repo,
-		log:  log,
+func NewRatingService(repo port.Repository, log *logrus.Logger, opts ...Option) port.Service {
+	s := &RatingService{
+		repo:        repo,
+		maxDepth:    model.DefaultCommentMaxDepth,
+		maxMentions: model.DefaultMaxMentionsPerPost,
+		reactions:   model.NewReactionSet(model.DefaultReactionEmojis),
+		log:         log,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
-// CreateRating creates a new rating
-func (s *RatingService) CreateRating(ctx context.Context, userID, serviceID uuid.UUID, score int) (*model.Rating, error) {
+// CreateRating creates a new rating, marking it verified when a valid attestation token is supplied
+func (s *RatingService) CreateRating(ctx context.Context, userID, serviceID uuid.UUID, score int, attestationToken string) (*model.Rating, error) {
+	var attestation *model.Attestation
+	if attestationToken != "" {
+		var err error
+		attestation, err = s.verifyAttestation(attestationToken, userID, serviceID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Check if user already rated this service
 	existingRating, err := s.repo.GetRatingByUserAndService(ctx, userID, serviceID)
 	if err == nil && existingRating != nil {
 		// Update existing rating instead of creating a new one
 		existingRating.UpdateScore(score)
-		if err := s.repo.UpdateRating(ctx, existingRating); err != nil {
+		if attestation != nil {
+			prepareAttestation(attestation, existingRating.ID)
+			err = s.repo.UpdateRatingWithAttestation(ctx, existingRating, attestation)
+		} else {
+			err = s.repo.UpdateRating(ctx, existingRating)
+		}
+		if err != nil {
 			s.log.WithError(err).Error("Failed to update existing rating")
 			return nil, err
 		}
+		if attestation != nil {
+			existingRating.MarkVerified(attestation.VerifiedAt)
+		}
+		s.screenRating(ctx, existingRating)
 		return existingRating, nil
 	}
 
@@ -46,18 +187,54 @@ func (s *RatingService) CreateRating(ctx context.Context, userID, serviceID uuid
 		s.log.WithError(err).Error("Failed to create rating model")
 		return nil, err
 	}
+	if rating.Shadowed, err = s.isShadowBanned(ctx, userID); err != nil {
+		return nil, err
+	}
 
-	if err := s.repo.CreateRating(ctx, rating); err != nil {
+	if attestation != nil {
+		prepareAttestation(attestation, rating.ID)
+		err = s.repo.CreateRatingWithAttestation(ctx, rating, attestation)
+	} else {
+		err = s.repo.CreateRating(ctx, rating)
+	}
+	if err != nil {
 		s.log.WithError(err).Error("Failed to create rating in repository")
 		return nil, err
 	}
+	if attestation != nil {
+		rating.MarkVerified(attestation.VerifiedAt)
+	}
 
+	s.screenRating(ctx, rating)
 	return rating, nil
 }
 
-// GetRatingByID retrieves a rating by ID
-func (s *RatingService) GetRatingByID(ctx context.Context, id uuid.UUID) (*model.Rating, error) {
-	rating, err := s.repo.GetRatingByID(ctx, id)
+// verifyAttestation checks an attestation token against the user and service being rated
+func (s *RatingService) verifyAttestation(token string, userID, serviceID uuid.UUID) (*model.Attestation, error) {
+	if s.verifier == nil {
+		s.log.Warn("Attestation token supplied but no verifier is configured")
+		return nil, ErrAttestationUnsupported
+	}
+
+	attestation, err := s.verifier.Verify(token, userID, serviceID)
+	if err != nil {
+		s.log.WithError(err).Warn("Attestation verification failed")
+		return nil, model.ErrInvalidAttestation
+	}
+	return attestation, nil
+}
+
+// prepareAttestation assigns a verified attestation to the rating it verifies, ready to be redeemed by
+// the repository in the same transaction as the rating or review write
+func prepareAttestation(attestation *model.Attestation, ratingID uuid.UUID) {
+	attestation.ID = uuid.New()
+	attestation.RatingID = ratingID
+	attestation.VerifiedAt = time.Now()
+}
+
+// GetRatingByID retrieves a rating by ID as seen by the viewer
+func (s *RatingService) GetRatingByID(ctx context.Context, id, viewerID uuid.UUID) (*model.Rating, error) {
+	rating, err := s.repo.GetRatingByID(ctx, id, model.ViewerOf(viewerID))
 	if err != nil {
 		s.log.WithError(err).Error("Failed to get rating by ID")
 		return nil, err
@@ -75,9 +252,9 @@ func (s *RatingService) GetRatingByUserAndService(ctx context.Context, userID, s
 	return rating, nil
 }
 
-// GetRatingsByService retrieves ratings by service ID with pagination
-func (s *RatingService) GetRatingsByService(ctx context.Context, serviceID uuid.UUID, params pagination.Params) ([]*model.Rating, int, error) {
-	ratings, total, err := s.repo.GetRatingsByService(ctx, serviceID, params)
+// GetRatingsByService retrieves ratings by service ID with pagination, as seen by the viewer
+func (s *RatingService) GetRatingsByService(ctx context.Context, serviceID, viewerID uuid.UUID, filter model.RatingFilter, params pagination.Params) ([]*model.Rating, int, error) {
+	ratings, total, err := s.repo.GetRatingsByService(ctx, serviceID, model.ViewerOf(viewerID), filter, params)
 	if err != nil {
 		s.log.WithError(err).Error("Failed to get ratings by service")
 		return nil, 0, err
@@ -87,7 +264,7 @@ func (s *RatingService) GetRatingsByService(ctx context.Context, serviceID uuid.
 
 // UpdateRating updates an existing rating
 func (s *RatingService) UpdateRating(ctx context.Context, id uuid.UUID, score int) (*model.Rating, error) {
-	rating, err := s.repo.GetRatingByID(ctx, id)
+	rating, err := s.repo.GetRatingByID(ctx, id, model.SystemViewer)
 	if err != nil {
 		s.log.WithError(err).Error("Failed to get rating for update")
 		return nil, err
@@ -103,12 +280,14 @@ func (s *RatingService) UpdateRating(ctx context.Context, id uuid.UUID, score in
 		return nil, err
 	}
 
+	s.screenRating(ctx, rating)
 	return rating, nil
 }
 
-// GetAverageRating calculates the average rating for a service
-func (s *RatingService) GetAverageRating(ctx context.Context, serviceID uuid.UUID) (*model.AverageRating, error) {
-	average, err := s.repo.CalculateAverageRating(ctx, serviceID)
+// GetAverageRating calculates the average rating for a service as seen by the viewer, whose own
+// shadowed rating is counted so the ban is not apparent to them
+func (s *RatingService) GetAverageRating(ctx context.Context, serviceID, viewerID uuid.UUID, filter model.RatingFilter) (*model.AverageRating, error) {
+	average, err := s.repo.CalculateAverageRating(ctx, serviceID, model.ViewerOf(viewerID), filter)
 	if err != nil {
 		s.log.WithError(err).Error("Failed to calculate average rating")
 		return nil, err
@@ -116,10 +295,11 @@ func (s *RatingService) GetAverageRating(ctx context.Context, serviceID uuid.UUI
 	return average, nil
 }
 
-// CreateReview creates a new review
-func (s *RatingService) CreateReview(ctx context.Context, userID, serviceID uuid.UUID, ratingID uuid.UUID, title, content string) (*model.Review, error) {
+// CreateReview creates a new review, verifying its rating when an attestation token is supplied.
+// Unless saved as a draft, the review is submitted according to the review policy.
+func (s *RatingService) CreateReview(ctx context.Context, userID, serviceID uuid.UUID, ratingID uuid.UUID, title, content, attestationToken string, draft bool) (*model.Review, error) {
 	// Validate that rating exists and belongs to the user and service
-	rating, err := s.repo.GetRatingByID(ctx, ratingID)
+	rating, err := s.repo.GetRatingByID(ctx, ratingID, model.ViewerOf(userID))
 	if err != nil {
 		s.log.WithError(err).Error("Failed to get rating for review creation")
 		return nil, errors.New("rating not found")
@@ -130,44 +310,141 @@ func (s *RatingService) CreateReview(ctx context.Context, userID, serviceID uuid
 		return nil, errors.New("rating doesn't match user or service")
 	}
 
+	if !draft {
+		if err := s.checkEmailVerified(ctx, userID); err != nil {
+			return nil, err
+		}
+	}
+
 	review, err := model.NewReview(userID, serviceID, ratingID, title, content)
 	if err != nil {
 		s.log.WithError(err).Error("Failed to create review model")
 		return nil, err
 	}
+	if review.Shadowed, err = s.isShadowBanned(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	screening, err := s.screenContent(ctx, review.Title, review.Content)
+	if err != nil {
+		return nil, err
+	}
+	duplicates, err := s.screenDuplicates(ctx, review)
+	if err != nil {
+		return nil, err
+	}
+	screening = screening.Merge(duplicates.Result)
 
-	if err := s.repo.CreateReview(ctx, review); err != nil {
+	if !draft {
+		if err := review.Submit(s.policy, time.Now()); err != nil {
+			s.log.WithError(err).Error("Failed to submit review")
+			return nil, err
+		}
+	}
+
+	var attestation *model.Attestation
+	if attestationToken != "" {
+		if attestation, err = s.verifyAttestation(attestationToken, userID, serviceID); err != nil {
+			return nil, err
+		}
+		prepareAttestation(attestation, rating.ID)
+	}
+
+	mentions, err := s.resolveMentions(ctx, model.MentionTargetReview, review.ID, review.UserID, review.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	s.analyzeSentiment(ctx, review)
+	review.DetectSentimentMismatch(rating.Score)
+
+	if attestation != nil {
+		err = s.repo.CreateReviewWithAttestation(ctx, review, attestation)
+	} else {
+		err = s.repo.CreateReview(ctx, review)
+	}
+	if err != nil {
 		s.log.WithError(err).Error("Failed to create review in repository")
 		return nil, err
 	}
 
+	if err := s.saveMentions(ctx, model.MentionTargetReview, review.ID, mentions); err != nil {
+		return nil, err
+	}
+	review.Mentions = mentions
+	renderReview(review)
+	s.saveSignature(ctx, duplicates)
+	s.flagContent(ctx, model.ReportTargetReview, review.ID, screening, &review.ModerationStatus)
+
+	s.publishReviewEvents(ctx, review, "")
 	return review, nil
 }
 
-// GetReviewByID retrieves a review by ID
-func (s *RatingService) GetReviewByID(ctx context.Context, id uuid.UUID) (*model.ReviewWithRating, error) {
-	review, err := s.repo.GetReviewByID(ctx, id)
+// GetReviewByID retrieves a review by ID; unpublished reviews are only visible to their author
+func (s *RatingService) GetReviewByID(ctx context.Context, id, viewerID uuid.UUID) (*model.ReviewWithRating, error) {
+	review, err := s.repo.GetReviewByID(ctx, id, model.ViewerOf(viewerID))
 	if err != nil {
 		s.log.WithError(err).Error("Failed to get review by ID")
 		return nil, err
 	}
+
+	if !review.IsVisibleTo(viewerID) {
+		return nil, ErrReviewNotFound
+	}
+
+	if err := s.decorateReviews(ctx, viewerID, &review.Review); err != nil {
+		return nil, err
+	}
 	return review, nil
 }
 
-// GetReviewsByService retrieves reviews by service ID with pagination
-func (s *RatingService) GetReviewsByService(ctx context.Context, serviceID uuid.UUID, params pagination.Params) ([]*model.ReviewWithRating, int, error) {
-	reviews, total, err := s.repo.GetReviewsByService(ctx, serviceID, params)
+// GetReviewsByService retrieves reviews by service ID with pagination, with reactions as seen by the viewer
+// and, if requested, a preview of each review's latest comments, loaded with one extra query for the page
+func (s *RatingService) GetReviewsByService(ctx context.Context, serviceID, viewerID uuid.UUID, filter model.ReviewFilter, params pagination.Params) ([]*model.ReviewWithRating, int, error) {
+	if filter.CommentPreview > model.MaxCommentPreview {
+		filter.CommentPreview = model.MaxCommentPreview
+	}
+
+	reviews, total, err := s.repo.GetReviewsByService(ctx, serviceID, model.ViewerOf(viewerID), filter, params)
 	if err != nil {
 		s.log.WithError(err).Error("Failed to get reviews by service")
 		return nil, 0, err
 	}
+
+	if err := s.decorateReviews(ctx, viewerID, reviewsOf(reviews)...); err != nil {
+		return nil, 0, err
+	}
+
+	var previews []*model.Comment
+	for _, review := range reviews {
+		previews = append(previews, review.LatestComments...)
+	}
+	if len(previews) > 0 {
+		if err := s.decorateComments(ctx, viewerID, previews...); err != nil {
+			return nil, 0, err
+		}
+	}
+	return reviews, total, nil
+}
+
+// GetReviewsByUser retrieves a user's own reviews in any state, optionally filtered by status
+func (s *RatingService) GetReviewsByUser(ctx context.Context, userID uuid.UUID, status model.ReviewStatus, params pagination.Params) ([]*model.ReviewWithRating, int, error) {
+	reviews, total, err := s.repo.GetReviewsByUser(ctx, userID, status, params)
+	if err != nil {
+		s.log.WithError(err).Error("Failed to get reviews by user")
+		return nil, 0, err
+	}
+
+	if err := s.decorateReviews(ctx, userID, reviewsOf(reviews)...); err != nil {
+		return nil, 0, err
+	}
 	return reviews, total, nil
 }
 
 // UpdateReview updates an existing review
 func (s *RatingService) UpdateReview(ctx context.Context, id uuid.UUID, title, content string) (*model.Review, error) {
 	// Get the review with rating to ensure it exists
-	reviewWithRating, err := s.repo.GetReviewByID(ctx, id)
+	reviewWithRating, err := s.repo.GetReviewByID(ctx, id, model.SystemViewer)
 	if err != nil {
 		s.log.WithError(err).Error("Failed to get review for update")
 		return nil, err
@@ -175,14 +452,19 @@ func (s *RatingService) UpdateReview(ctx context.Context, id uuid.UUID, title, c
 
 	// Convert to regular review
 	review := &model.Review{
-		ID:        reviewWithRating.ID,
-		UserID:    reviewWithRating.UserID,
-		ServiceID: reviewWithRating.ServiceID,
-		RatingID:  reviewWithRating.RatingID,
-		Title:     reviewWithRating.Title,
-		Content:   reviewWithRating.Content,
-		CreatedAt: reviewWithRating.CreatedAt,
-		UpdatedAt: reviewWithRating.UpdatedAt,
+		ID:               reviewWithRating.ID,
+		UserID:           reviewWithRating.UserID,
+		ServiceID:        reviewWithRating.ServiceID,
+		RatingID:         reviewWithRating.RatingID,
+		Title:            reviewWithRating.Title,
+		Content:          reviewWithRating.Content,
+		Status:           reviewWithRating.Status,
+		ModerationStatus: reviewWithRating.ModerationStatus,
+		PublishAt:        reviewWithRating.PublishAt,
+		PublishedAt:      reviewWithRating.PublishedAt,
+		CreatedAt:        reviewWithRating.CreatedAt,
+		UpdatedAt:        reviewWithRating.UpdatedAt,
+		Shadowed:         reviewWithRating.Shadowed,
 	}
 
 	if err := review.UpdateContent(title, content); err != nil {
@@ -190,21 +472,238 @@ func (s *RatingService) UpdateReview(ctx context.Context, id uuid.UUID, title, c
 		return nil, err
 	}
 
+	screening, err := s.screenContent(ctx, review.Title, review.Content)
+	if err != nil {
+		return nil, err
+	}
+	duplicates, err := s.screenDuplicates(ctx, review)
+	if err != nil {
+		return nil, err
+	}
+	screening = screening.Merge(duplicates.Result)
+	s.analyzeSentiment(ctx, review)
+	review.DetectSentimentMismatch(reviewWithRating.Score)
+
+	previous, err := s.getMentions(ctx, model.MentionTargetReview, []uuid.UUID{review.ID})
+	if err != nil {
+		return nil, err
+	}
+	mentions, err := s.resolveMentions(ctx, model.MentionTargetReview, review.ID, review.UserID, review.Content)
+	if err != nil {
+		return nil, err
+	}
+
 	if err := s.repo.UpdateReview(ctx, review); err != nil {
 		s.log.WithError(err).Error("Failed to update review in repository")
 		return nil, err
 	}
 
+	if err := s.saveMentions(ctx, model.MentionTargetReview, review.ID, mentions); err != nil {
+		return nil, err
+	}
+	review.Mentions = mentions
+	renderReview(review)
+	s.saveSignature(ctx, duplicates)
+	s.flagContent(ctx, model.ReportTargetReview, review.ID, screening, &review.ModerationStatus)
+
+	if review.Status == model.ReviewStatusPublished && !review.Shadowed {
+		s.publishMentions(ctx, review.UserID, review.ID, nil, mentions, previous[review.ID])
+	}
+	return review, nil
+}
+
+// GetSentimentSummary retrieves the aggregated text sentiment of a service's published reviews as
+// seen by the viewer
+func (s *RatingService) GetSentimentSummary(ctx context.Context, serviceID, viewerID uuid.UUID) (*model.SentimentSummary, error) {
+	summary, err := s.repo.CalculateSentimentSummary(ctx, serviceID, model.ViewerOf(viewerID))
+	if err != nil {
+		s.log.WithError(err).Error("Failed to calculate sentiment summary")
+		return nil, err
+	}
+	return summary, nil
+}
+
+// GetServiceHighlights retrieves the pros and cons extracted from a service's published reviews
+func (s *RatingService) GetServiceHighlights(ctx context.Context, serviceID uuid.UUID) (*model.ServiceHighlights, error) {
+	if s.highlights == nil {
+		return nil, ErrHighlightsUnavailable
+	}
+
+	highlights, err := s.highlights.GetHighlights(ctx, serviceID)
+	if err != nil {
+		s.log.WithError(err).Error("Failed to get service highlights")
+		return nil, err
+	}
+	return highlights, nil
+}
+
+// analyzeSentiment scores the review text. Analyzer failures are logged and leave the review
+// unscored rather than failing the write.
+func (s *RatingService) analyzeSentiment(ctx context.Context, review *model.Review) {
+	if s.sentiment == nil {
+		return
+	}
+
+	score, err := s.sentiment.Analyze(ctx, review.Title+"\n"+review.Content)
+	if err != nil {
+		s.log.WithError(err).WithField("review_id", review.ID).Warn("Failed to analyze review sentiment")
+		return
+	}
+	review.SetSentiment(score)
+}
+
+// SubmitReview submits the author's draft or rejected review according to the review policy
+func (s *RatingService) SubmitReview(ctx context.Context, userID, id uuid.UUID) (*model.Review, error) {
+	if err := s.checkEmailVerified(ctx, userID); err != nil {
+		return nil, err
+	}
+	return s.transitionReview(ctx, id, func(review *model.Review) error {
+		if review.UserID != userID {
+			return ErrNotReviewAuthor
+		}
+		return review.Submit(s.policy, time.Now())
+	})
+}
+
+// GetPendingReviews retrieves the queue of reviews awaiting publication
+func (s *RatingService) GetPendingReviews(ctx context.Context, params pagination.Params) ([]*model.ReviewWithRating, int, error) {
+	reviews, total, err := s.repo.GetPendingReviews(ctx, params)
+	if err != nil {
+		s.log.WithError(err).Error("Failed to get pending reviews")
+		return nil, 0, err
+	}
+
+	if err := s.decorateReviews(ctx, uuid.Nil, reviewsOf(reviews)...); err != nil {
+		return nil, 0, err
+	}
+	return reviews, total, nil
+}
+
+// ApproveReview publishes a pending review
+func (s *RatingService) ApproveReview(ctx context.Context, id uuid.UUID) (*model.Review, error) {
+	return s.moderateReview(ctx, id, func(review *model.Review) error {
+		return review.Publish(time.Now())
+	})
+}
+
+// RejectReview declines a pending review
+func (s *RatingService) RejectReview(ctx context.Context, id uuid.UUID) (*model.Review, error) {
+	return s.moderateReview(ctx, id, func(review *model.Review) error {
+		return review.Reject(time.Now())
+	})
+}
+
+// moderateReview applies a moderator's decision and announces the outcome
+func (s *RatingService) moderateReview(ctx context.Context, id uuid.UUID, decide func(*model.Review) error) (*model.Review, error) {
+	review, err := s.transitionReview(ctx, id, decide)
+	if err != nil {
+		return nil, err
+	}
+
+	s.publish(ctx, model.ReviewModeratedEvent{
+		ReviewID: review.ID,
+		UserID:   review.UserID,
+		Status:   review.Status,
+	})
 	return review, nil
 }
 
-// CreateComment creates a new comment
-func (s *RatingService) CreateComment(ctx context.Context, userID, reviewID uuid.UUID, content string) (*model.Comment, error) {
-	// Verify that review exists
-	_, err := s.repo.GetReviewByID(ctx, reviewID)
+// PublishDueReviews publishes pending reviews whose cool-down has elapsed, announcing each one as
+// a review published on its own would be
+func (s *RatingService) PublishDueReviews(ctx context.Context) (int, error) {
+	published, err := s.repo.PublishDueReviews(ctx, time.Now())
+	if err != nil {
+		s.log.WithError(err).Error("Failed to publish due reviews")
+		return 0, err
+	}
+
+	for _, id := range published {
+		s.announcePublished(ctx, id)
+	}
+	return len(published), nil
+}
+
+// announcePublished publishes the events for a pending review the cool-down released. The review
+// is already published, so failing to load it is only logged.
+func (s *RatingService) announcePublished(ctx context.Context, id uuid.UUID) {
+	reviewWithRating, err := s.repo.GetReviewByID(ctx, id, model.SystemViewer)
+	if err != nil {
+		s.log.WithError(err).WithField("review_id", id).Error("Failed to get published review")
+		return
+	}
+
+	review := reviewWithRating.Review
+	if err := s.attachReviewMentions(ctx, &review); err != nil {
+		return
+	}
+	s.publishReviewEvents(ctx, &review, model.ReviewStatusPending)
+}
+
+// transitionReview loads a review, applies a lifecycle change and persists it
+func (s *RatingService) transitionReview(ctx context.Context, id uuid.UUID, change func(*model.Review) error) (*model.Review, error) {
+	reviewWithRating, err := s.repo.GetReviewByID(ctx, id, model.SystemViewer)
+	if err != nil {
+		s.log.WithError(err).Error("Failed to get review for status change")
+		return nil, err
+	}
+
+	review := reviewWithRating.Review
+	previous := review.Status
+	if err := s.attachReviewMentions(ctx, &review); err != nil {
+		return nil, err
+	}
+	renderReview(&review)
+	if err := change(&review); err != nil {
+		s.log.WithError(err).Warn("Review status change refused")
+		return nil, err
+	}
+
+	if err := s.repo.UpdateReview(ctx, &review); err != nil {
+		s.log.WithError(err).Error("Failed to update review status in repository")
+		return nil, err
+	}
+
+	s.publishReviewEvents(ctx, &review, previous)
+	return &review, nil
+}
+
+// publishReviewEvents publishes the events implied by a review moving from the previous status
+// to its current one. Shadowed reviews publish nothing, so nobody is notified about them.
+func (s *RatingService) publishReviewEvents(ctx context.Context, review *model.Review, previous model.ReviewStatus) {
+	if review.Shadowed {
+		return
+	}
+	if review.Status == model.ReviewStatusPublished && previous != model.ReviewStatusPublished {
+		s.publish(ctx, model.ReviewPublishedEvent{
+			ReviewID:    review.ID,
+			UserID:      review.UserID,
+			ServiceID:   review.ServiceID,
+			PublishedAt: *review.PublishedAt,
+		})
+		s.publishMentions(ctx, review.UserID, review.ID, nil, review.Mentions, nil)
+	}
+}
+
+// publish hands an event to the configured publisher, if any
+func (s *RatingService) publish(ctx context.Context, event model.Event) {
+	if s.events != nil {
+		s.events.Publish(ctx, event)
+	}
+}
+
+// CreateComment creates a new comment, as a reply when parentID is set
+func (s *RatingService) CreateComment(ctx context.Context, userID, reviewID, parentID uuid.UUID, content string) (*model.Comment, error) {
+	// Verify that review exists and is visible to the commenter
+	review, err := s.repo.GetReviewByID(ctx, reviewID, model.ViewerOf(userID))
 	if err != nil {
 		s.log.WithError(err).Error("Failed to get review for comment creation")
-		return nil, errors.New("review not found")
+		return nil, ErrReviewNotFound
+	}
+	if !review.IsVisibleTo(userID) {
+		return nil, ErrReviewNotFound
+	}
+	if err := s.checkNotBlocked(ctx, review.UserID, userID); err != nil {
+		return nil, err
 	}
 
 	comment, err := model.NewComment(userID, reviewID, content)
@@ -212,38 +711,173 @@ func (s *RatingService) CreateComment(ctx context.Context, userID, reviewID uuid
 		s.log.WithError(err).Error("Failed to create comment model")
 		return nil, err
 	}
+	if comment.Shadowed, err = s.isShadowBanned(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	screening, err := s.screenContent(ctx, comment.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	if parentID != uuid.Nil {
+		parent, err := s.repo.GetCommentByID(ctx, parentID, model.ViewerOf(userID))
+		if err != nil {
+			s.log.WithError(err).Error("Failed to get parent comment")
+			return nil, ErrCommentNotFound
+		}
+		if !parent.ModerationStatus.IsPublic() {
+			return nil, ErrCommentNotFound
+		}
+		if err := s.checkNotBlocked(ctx, parent.UserID, userID); err != nil {
+			return nil, err
+		}
+		if err := comment.ReplyTo(parent, s.maxDepth); err != nil {
+			return nil, err
+		}
+	}
+
+	mentions, err := s.resolveMentions(ctx, model.MentionTargetComment, comment.ID, comment.UserID, comment.Content)
+	if err != nil {
+		return nil, err
+	}
 
 	if err := s.repo.CreateComment(ctx, comment); err != nil {
 		s.log.WithError(err).Error("Failed to create comment in repository")
 		return nil, err
 	}
 
+	if err := s.saveMentions(ctx, model.MentionTargetComment, comment.ID, mentions); err != nil {
+		return nil, err
+	}
+	comment.Mentions = mentions
+	renderComment(comment)
+	s.flagContent(ctx, model.ReportTargetComment, comment.ID, screening, &comment.ModerationStatus)
+
+	if comment.Shadowed {
+		return comment, nil
+	}
+	s.publish(ctx, model.CommentCreatedEvent{
+		CommentID: comment.ID,
+		UserID:    comment.UserID,
+		ReviewID:  comment.ReviewID,
+		ParentID:  comment.ParentID,
+	})
+	s.publishMentions(ctx, comment.UserID, comment.ReviewID, &comment.ID, mentions, nil)
 	return comment, nil
 }
 
-// GetCommentByID retrieves a comment by ID
-func (s *RatingService) GetCommentByID(ctx context.Context, id uuid.UUID) (*model.Comment, error) {
-	comment, err := s.repo.GetCommentByID(ctx, id)
+// GetCommentByID retrieves a comment by ID as seen by the viewer; comments hidden or removed by a
+// moderator are not found
+func (s *RatingService) GetCommentByID(ctx context.Context, id, viewerID uuid.UUID) (*model.Comment, error) {
+	comment, err := s.repo.GetCommentByID(ctx, id, model.ViewerOf(viewerID))
 	if err != nil {
 		s.log.WithError(err).Error("Failed to get comment by ID")
 		return nil, err
 	}
+	if !comment.ModerationStatus.IsPublic() {
+		return nil, ErrCommentNotFound
+	}
+
+	if err := s.decorateComments(ctx, viewerID, comment); err != nil {
+		return nil, err
+	}
 	return comment, nil
 }
 
-// GetCommentsByReview retrieves comments by review ID with pagination
-func (s *RatingService) GetCommentsByReview(ctx context.Context, reviewID uuid.UUID, params pagination.Params) ([]*model.Comment, int, error) {
-	comments, total, err := s.repo.GetCommentsByReview(ctx, reviewID, params)
+// GetCommentsByReview retrieves comments by review ID with pagination, with reactions as seen by the viewer
+func (s *RatingService) GetCommentsByReview(ctx context.Context, reviewID, viewerID uuid.UUID, params pagination.Params) ([]*model.Comment, int, error) {
+	comments, total, err := s.repo.GetCommentsByReview(ctx, reviewID, model.ViewerOf(viewerID), params)
 	if err != nil {
 		s.log.WithError(err).Error("Failed to get comments by review")
 		return nil, 0, err
 	}
+
+	if err := s.decorateComments(ctx, viewerID, comments...); err != nil {
+		return nil, 0, err
+	}
 	return comments, total, nil
 }
 
+// GetCommentTree retrieves a page of a review's top-level comments with all of their replies nested below them
+func (s *RatingService) GetCommentTree(ctx context.Context, reviewID, viewerID uuid.UUID, params pagination.Params) ([]*model.CommentNode, int, error) {
+	viewer := model.ViewerOf(viewerID)
+	review, err := s.repo.GetReviewByID(ctx, reviewID, viewer)
+	if err != nil || !review.IsVisibleTo(viewerID) {
+		return nil, 0, ErrReviewNotFound
+	}
+
+	threads, total, err := s.repo.GetCommentThreads(ctx, reviewID, viewer, params)
+	if err != nil {
+		s.log.WithError(err).Error("Failed to get comment threads")
+		return nil, 0, err
+	}
+
+	threadIDs := make([]uuid.UUID, len(threads))
+	for i, thread := range threads {
+		threadIDs[i] = thread.ID
+	}
+
+	replies, err := s.repo.GetCommentReplies(ctx, threadIDs, viewer)
+	if err != nil {
+		s.log.WithError(err).Error("Failed to get comment replies")
+		return nil, 0, err
+	}
+
+	// Withheld comments are only placeholders in the tree, so there is nothing to render for them
+	decorated := make([]*model.Comment, 0, len(threads)+len(replies))
+	for _, comment := range append(threads, replies...) {
+		if !comment.Withheld {
+			decorated = append(decorated, comment)
+		}
+	}
+	if err := s.decorateComments(ctx, viewerID, decorated...); err != nil {
+		return nil, 0, err
+	}
+	return model.BuildCommentTree(threads, replies), total, nil
+}
+
+// DeleteComment deletes the author's comment. Comments with replies are tombstoned so the
+// replies stay attached to the thread.
+func (s *RatingService) DeleteComment(ctx context.Context, userID, id uuid.UUID) error {
+	comment, err := s.repo.GetCommentByID(ctx, id, model.ViewerOf(userID))
+	if err != nil {
+		s.log.WithError(err).Error("Failed to get comment for deletion")
+		return ErrCommentNotFound
+	}
+
+	if comment.UserID != userID {
+		return ErrNotCommentAuthor
+	}
+	if comment.Deleted {
+		return model.ErrCommentDeleted
+	}
+
+	replies, err := s.repo.CountCommentReplies(ctx, id)
+	if err != nil {
+		s.log.WithError(err).Error("Failed to count comment replies")
+		return err
+	}
+
+	if replies == 0 {
+		if err := s.repo.DeleteComment(ctx, id); err != nil {
+			s.log.WithError(err).Error("Failed to delete comment in repository")
+			return err
+		}
+		return s.saveMentions(ctx, model.MentionTargetComment, id, nil)
+	}
+
+	comment.Tombstone(time.Now())
+	if err := s.repo.UpdateComment(ctx, comment); err != nil {
+		s.log.WithError(err).Error("Failed to tombstone comment in repository")
+		return err
+	}
+	return s.saveMentions(ctx, model.MentionTargetComment, id, nil)
+}
+
 // UpdateComment updates an existing comment
 func (s *RatingService) UpdateComment(ctx context.Context, id uuid.UUID, content string) (*model.Comment, error) {
-	comment, err := s.repo.GetCommentByID(ctx, id)
+	comment, err := s.repo.GetCommentByID(ctx, id, model.SystemViewer)
 	if err != nil {
 		s.log.WithError(err).Error("Failed to get comment for update")
 		return nil, err
@@ -254,10 +888,34 @@ func (s *RatingService) UpdateComment(ctx context.Context, id uuid.UUID, content
 		return nil, err
 	}
 
+	screening, err := s.screenContent(ctx, comment.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	previous, err := s.getMentions(ctx, model.MentionTargetComment, []uuid.UUID{comment.ID})
+	if err != nil {
+		return nil, err
+	}
+	mentions, err := s.resolveMentions(ctx, model.MentionTargetComment, comment.ID, comment.UserID, comment.Content)
+	if err != nil {
+		return nil, err
+	}
+
 	if err := s.repo.UpdateComment(ctx, comment); err != nil {
 		s.log.WithError(err).Error("Failed to update comment in repository")
 		return nil, err
 	}
 
+	if err := s.saveMentions(ctx, model.MentionTargetComment, comment.ID, mentions); err != nil {
+		return nil, err
+	}
+	comment.Mentions = mentions
+	renderComment(comment)
+	s.flagContent(ctx, model.ReportTargetComment, comment.ID, screening, &comment.ModerationStatus)
+
+	if !comment.Shadowed {
+		s.publishMentions(ctx, comment.UserID, comment.ReviewID, &comment.ID, mentions, previous[comment.ID])
+	}
 	return comment, nil
 }
@@ -12,12 +12,15 @@ import (
 	"github.com/stretchr/testify/mock"
 
 	"rating-system/internal/domain/model"
+	"rating-system/internal/domain/port"
 	"rating-system/pkg/pagination"
 )
 
-// MockRepository is a mock implementation of the Repository interface
+// MockRepository is a mock implementation of the Repository interface. Methods a test does not
+// mock fall through to the embedded nil interface and panic.
 type MockRepository struct {
 	mock.Mock
+	port.Repository
 }
 
 func (m *MockRepository) CreateRating(ctx context.Context, rating *model.Rating) error {
@@ -25,8 +28,18 @@ func (m *MockRepository) CreateRating(ctx context.Context, rating *model.Rating)
 	return args.Error(0)
 }
 
-func (m *MockRepository) GetRatingByID(ctx context.Context, id uuid.UUID) (*model.Rating, error) {
-	args := m.Called(ctx, id)
+func (m *MockRepository) CreateRatingWithAttestation(ctx context.Context, rating *model.Rating, attestation *model.Attestation) error {
+	args := m.Called(ctx, rating, attestation)
+	return args.Error(0)
+}
+
+func (m *MockRepository) UpdateRatingWithAttestation(ctx context.Context, rating *model.Rating, attestation *model.Attestation) error {
+	args := m.Called(ctx, rating, attestation)
+	return args.Error(0)
+}
+
+func (m *MockRepository) GetRatingByID(ctx context.Context, id uuid.UUID, viewer model.Viewer) (*model.Rating, error) {
+	args := m.Called(ctx, id, viewer)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -41,8 +54,8 @@ func (m *MockRepository) GetRatingByUserAndService(ctx context.Context, userID,
 	return args.Get(0).(*model.Rating), args.Error(1)
 }
 
-func (m *MockRepository) GetRatingsByService(ctx context.Context, serviceID uuid.UUID, params pagination.Params) ([]*model.Rating, int, error) {
-	args := m.Called(ctx, serviceID, params)
+func (m *MockRepository) GetRatingsByService(ctx context.Context, serviceID uuid.UUID, viewer model.Viewer, filter model.RatingFilter, params pagination.Params) ([]*model.Rating, int, error) {
+	args := m.Called(ctx, serviceID, viewer, filter, params)
 	if args.Get(0) == nil {
 		return nil, 0, args.Error(2)
 	}
@@ -54,8 +67,8 @@ func (m *MockRepository) UpdateRating(ctx context.Context, rating *model.Rating)
 	return args.Error(0)
 }
 
-func (m *MockRepository) CalculateAverageRating(ctx context.Context, serviceID uuid.UUID) (*model.AverageRating, error) {
-	args := m.Called(ctx, serviceID)
+func (m *MockRepository) CalculateAverageRating(ctx context.Context, serviceID uuid.UUID, viewer model.Viewer, filter model.RatingFilter) (*model.AverageRating, error) {
+	args := m.Called(ctx, serviceID, viewer, filter)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -67,16 +80,21 @@ func (m *MockRepository) CreateReview(ctx context.Context, review *model.Review)
 	return args.Error(0)
 }
 
-func (m *MockRepository) GetReviewByID(ctx context.Context, id uuid.UUID) (*model.ReviewWithRating, error) {
-	args := m.Called(ctx, id)
+func (m *MockRepository) CreateReviewWithAttestation(ctx context.Context, review *model.Review, attestation *model.Attestation) error {
+	args := m.Called(ctx, review, attestation)
+	return args.Error(0)
+}
+
+func (m *MockRepository) GetReviewByID(ctx context.Context, id uuid.UUID, viewer model.Viewer) (*model.ReviewWithRating, error) {
+	args := m.Called(ctx, id, viewer)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*model.ReviewWithRating), args.Error(1)
 }
 
-func (m *MockRepository) GetReviewsByService(ctx context.Context, serviceID uuid.UUID, params pagination.Params) ([]*model.ReviewWithRating, int, error) {
-	args := m.Called(ctx, serviceID, params)
+func (m *MockRepository) GetReviewsByService(ctx context.Context, serviceID uuid.UUID, viewer model.Viewer, filter model.ReviewFilter, params pagination.Params) ([]*model.ReviewWithRating, int, error) {
+	args := m.Called(ctx, serviceID, viewer, filter, params)
 	if args.Get(0) == nil {
 		return nil, 0, args.Error(2)
 	}
@@ -93,16 +111,16 @@ func (m *MockRepository) CreateComment(ctx context.Context, comment *model.Comme
 	return args.Error(0)
 }
 
-func (m *MockRepository) GetCommentByID(ctx context.Context, id uuid.UUID) (*model.Comment, error) {
-	args := m.Called(ctx, id)
+func (m *MockRepository) GetCommentByID(ctx context.Context, id uuid.UUID, viewer model.Viewer) (*model.Comment, error) {
+	args := m.Called(ctx, id, viewer)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*model.Comment), args.Error(1)
 }
 
-func (m *MockRepository) GetCommentsByReview(ctx context.Context, reviewID uuid.UUID, params pagination.Params) ([]*model.Comment, int, error) {
-	args := m.Called(ctx, reviewID, params)
+func (m *MockRepository) GetCommentsByReview(ctx context.Context, reviewID uuid.UUID, viewer model.Viewer, params pagination.Params) ([]*model.Comment, int, error) {
+	args := m.Called(ctx, reviewID, viewer, params)
 	if args.Get(0) == nil {
 		return nil, 0, args.Error(2)
 	}
@@ -114,6 +132,123 @@ func (m *MockRepository) UpdateComment(ctx context.Context, comment *model.Comme
 	return args.Error(0)
 }
 
+func (m *MockRepository) IsShadowBanned(ctx context.Context, userID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRepository) IsBlocked(ctx context.Context, blockerID, userID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, blockerID, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRepository) ReplaceMentions(ctx context.Context, targetType model.MentionTarget, targetID uuid.UUID, mentions []*model.Mention) error {
+	args := m.Called(ctx, targetType, targetID, mentions)
+	return args.Error(0)
+}
+
+func (m *MockRepository) PublishDueReviews(ctx context.Context, now time.Time) ([]uuid.UUID, error) {
+	args := m.Called(ctx, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockRepository) GetMentions(ctx context.Context, targetType model.MentionTarget, targetIDs []uuid.UUID) ([]*model.Mention, error) {
+	args := m.Called(ctx, targetType, targetIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*model.Mention), args.Error(1)
+}
+
+func (m *MockRepository) SaveReviewSignature(ctx context.Context, signature *model.ReviewSignature) error {
+	args := m.Called(ctx, signature)
+	return args.Error(0)
+}
+
+func (m *MockRepository) ApplyModerationDecision(ctx context.Context, decision *model.ModerationDecision) error {
+	args := m.Called(ctx, decision)
+	return args.Error(0)
+}
+
+func (m *MockRepository) GetNotificationPreferences(ctx context.Context, userID uuid.UUID) (*model.NotificationPreferences, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.NotificationPreferences), args.Error(1)
+}
+
+func (m *MockRepository) CreateNotification(ctx context.Context, notification *model.Notification) error {
+	args := m.Called(ctx, notification)
+	return args.Error(0)
+}
+
+func (m *MockRepository) RevokeRole(ctx context.Context, userID uuid.UUID, role model.Role) error {
+	args := m.Called(ctx, userID, role)
+	return args.Error(0)
+}
+
+func (m *MockRepository) CreateRatingFlag(ctx context.Context, flag *model.RatingFlag) error {
+	args := m.Called(ctx, flag)
+	return args.Error(0)
+}
+
+// recordingPublisher collects the events published to it
+type recordingPublisher struct {
+	events []model.Event
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, event model.Event) {
+	p.events = append(p.events, event)
+}
+
+// stubVerifier accepts any attestation token for the user and service being rated
+type stubVerifier struct{}
+
+func (stubVerifier) Verify(token string, userID, serviceID uuid.UUID) (*model.Attestation, error) {
+	return &model.Attestation{TokenID: token, UserID: userID, ServiceID: serviceID}, nil
+}
+
+// stubDuplicates gives every review the same duplicate check result
+type stubDuplicates struct {
+	result model.FilterResult
+}
+
+func (d stubDuplicates) Check(ctx context.Context, review *model.Review) (*model.DuplicateCheck, error) {
+	signature := &model.ReviewSignature{ReviewID: review.ID, UserID: review.UserID, ServiceID: review.ServiceID}
+	return &model.DuplicateCheck{Signature: signature, Result: d.result}, nil
+}
+
+// recordingExpirer collects the users whose access tokens were expired
+type recordingExpirer struct {
+	users []uuid.UUID
+}
+
+func (e *recordingExpirer) ExpireAccessTokens(ctx context.Context, userID uuid.UUID) error {
+	e.users = append(e.users, userID)
+	return nil
+}
+
+// recordingDetector flags every rating it assesses and collects them
+type recordingDetector struct {
+	assessed []*model.Rating
+}
+
+func (d *recordingDetector) Assess(ctx context.Context, rating *model.Rating, client model.ClientInfo) (*model.RatingAssessment, error) {
+	d.assessed = append(d.assessed, rating)
+	return &model.RatingAssessment{Score: 1, Flag: true}, nil
+}
+
+// stubSentiment scores every text the same
+type stubSentiment float64
+
+func (s stubSentiment) Analyze(ctx context.Context, text string) (float64, error) {
+	return float64(s), nil
+}
+
 func TestCreateRating(t *testing.T) {
 	logger := logrus.New()
 	repo := new(MockRepository)
@@ -127,12 +262,13 @@ func TestCreateRating(t *testing.T) {
 	// Test case 1: User has not previously rated this service
 	repo.On("GetRatingByUserAndService", ctx, userID, serviceID).
 		Return(nil, errors.New("not found")).Once()
+	repo.On("IsShadowBanned", ctx, userID).Return(false, nil).Once()
 	
 	repo.On("CreateRating", ctx, mock.MatchedBy(func(r *model.Rating) bool {
 		return r.UserID == userID && r.ServiceID == serviceID && r.Score == score
 	})).Return(nil).Once()
 
-	rating, err := service.CreateRating(ctx, userID, serviceID, score)
+	rating, err := service.CreateRating(ctx, userID, serviceID, score, "")
 	assert.NoError(t, err)
 	assert.NotNil(t, rating)
 	assert.Equal(t, userID, rating.UserID)
@@ -148,7 +284,7 @@ func TestCreateRating(t *testing.T) {
 		return r.ID == existingRating.ID && r.Score == score
 	})).Return(nil).Once()
 
-	updatedRating, err := service.CreateRating(ctx, userID, serviceID, score)
+	updatedRating, err := service.CreateRating(ctx, userID, serviceID, score, "")
 	assert.NoError(t, err)
 	assert.NotNil(t, updatedRating)
 	assert.Equal(t, existingRating.ID, updatedRating.ID)
@@ -157,6 +293,33 @@ func TestCreateRating(t *testing.T) {
 	repo.AssertExpectations(t)
 }
 
+func TestCreateRatingWithReusedAttestation(t *testing.T) {
+	logger := logrus.New()
+	repo := new(MockRepository)
+	service := NewRatingService(repo, logger, WithAttestationVerifier(stubVerifier{}))
+	ctx := context.Background()
+
+	userID := uuid.New()
+	serviceID := uuid.New()
+
+	// The new score is only written together with the attestation, so a reused token leaves the
+	// stored score unchanged rather than saving it and then failing
+	existingRating, _ := model.NewRating(userID, serviceID, 3)
+	repo.On("GetRatingByUserAndService", ctx, userID, serviceID).
+		Return(existingRating, nil).Once()
+	repo.On("UpdateRatingWithAttestation", ctx, existingRating, mock.MatchedBy(func(a *model.Attestation) bool {
+		return a.RatingID == existingRating.ID && a.TokenID == "used-token"
+	})).Return(model.ErrAttestationReused).Once()
+
+	rating, err := service.CreateRating(ctx, userID, serviceID, 5, "used-token")
+	assert.ErrorIs(t, err, model.ErrAttestationReused)
+	assert.Nil(t, rating)
+	assert.False(t, existingRating.Verified)
+
+	repo.AssertExpectations(t)
+	repo.AssertNotCalled(t, "UpdateRating", mock.Anything, mock.Anything)
+}
+
 func TestGetAverageRating(t *testing.T) {
 	logger := logrus.New()
 	repo := new(MockRepository)
@@ -170,9 +333,9 @@ func TestGetAverageRating(t *testing.T) {
 		TotalRatings: 10,
 	}
 
-	repo.On("CalculateAverageRating", ctx, serviceID).Return(expected, nil).Once()
+	repo.On("CalculateAverageRating", ctx, serviceID, model.ViewerOf(uuid.Nil), model.RatingFilter{}).Return(expected, nil).Once()
 
-	average, err := service.GetAverageRating(ctx, serviceID)
+	average, err := service.GetAverageRating(ctx, serviceID, uuid.Nil, model.RatingFilter{})
 	assert.NoError(t, err)
 	assert.Equal(t, expected, average)
 
@@ -195,14 +358,16 @@ func TestCreateReview(t *testing.T) {
 	rating, _ := model.NewRating(userID, serviceID, 5)
 	rating.ID = ratingID
 
-	repo.On("GetRatingByID", ctx, ratingID).Return(rating, nil).Once()
+	repo.On("GetRatingByID", ctx, ratingID, model.ViewerOf(userID)).Return(rating, nil).Once()
+	repo.On("IsShadowBanned", ctx, userID).Return(false, nil).Once()
+	repo.On("ReplaceMentions", ctx, model.MentionTargetReview, mock.Anything, mock.Anything).Return(nil).Once()
 	
 	repo.On("CreateReview", ctx, mock.MatchedBy(func(r *model.Review) bool {
 		return r.UserID == userID && r.ServiceID == serviceID && 
 		       r.RatingID == ratingID && r.Title == title && r.Content == content
 	})).Return(nil).Once()
 
-	review, err := service.CreateReview(ctx, userID, serviceID, ratingID, title, content)
+	review, err := service.CreateReview(ctx, userID, serviceID, ratingID, title, content, "", false)
 	assert.NoError(t, err)
 	assert.NotNil(t, review)
 	assert.Equal(t, userID, review.UserID)
@@ -227,25 +392,30 @@ func TestCreateComment(t *testing.T) {
 	// Setup mocks
 	reviewWithRating := &model.ReviewWithRating{
 		Review: model.Review{
-			ID:        reviewID,
-			UserID:    uuid.New(),
-			ServiceID: uuid.New(),
-			RatingID:  uuid.New(),
-			Title:     "Some Title",
-			Content:   "Some Content",
-			CreatedAt: time.Now(),
-			UpdatedAt: time.Now(),
+			ID:               reviewID,
+			UserID:           uuid.New(),
+			ServiceID:        uuid.New(),
+			RatingID:         uuid.New(),
+			Title:            "Some Title",
+			Content:          "Some Content",
+			Status:           model.ReviewStatusPublished,
+			ModerationStatus: model.ModerationVisible,
+			CreatedAt:        time.Now(),
+			UpdatedAt:        time.Now(),
 		},
 		Score: 4,
 	}
 
-	repo.On("GetReviewByID", ctx, reviewID).Return(reviewWithRating, nil).Once()
+	repo.On("GetReviewByID", ctx, reviewID, model.ViewerOf(userID)).Return(reviewWithRating, nil).Once()
+	repo.On("IsBlocked", ctx, reviewWithRating.UserID, userID).Return(false, nil).Once()
+	repo.On("IsShadowBanned", ctx, userID).Return(false, nil).Once()
+	repo.On("ReplaceMentions", ctx, model.MentionTargetComment, mock.Anything, mock.Anything).Return(nil).Once()
 	
 	repo.On("CreateComment", ctx, mock.MatchedBy(func(c *model.Comment) bool {
 		return c.UserID == userID && c.ReviewID == reviewID && c.Content == content
 	})).Return(nil).Once()
 
-	comment, err := service.CreateComment(ctx, userID, reviewID, content)
+	comment, err := service.CreateComment(ctx, userID, reviewID, uuid.Nil, content)
 	assert.NoError(t, err)
 	assert.NotNil(t, comment)
 	assert.Equal(t, userID, comment.UserID)
@@ -254,3 +424,207 @@ func TestCreateComment(t *testing.T) {
 
 	repo.AssertExpectations(t)
 }
+
+func TestPublishDueReviews(t *testing.T) {
+	logger := logrus.New()
+	repo := new(MockRepository)
+	events := &recordingPublisher{}
+	service := NewRatingService(repo, logger, WithEventPublisher(events))
+	ctx := context.Background()
+
+	// Setup mocks: the sweeper publishes one visible and one shadowed review
+	publishedAt := time.Now()
+	visible := &model.ReviewWithRating{Review: model.Review{
+		ID:          uuid.New(),
+		UserID:      uuid.New(),
+		ServiceID:   uuid.New(),
+		Status:      model.ReviewStatusPublished,
+		PublishedAt: &publishedAt,
+	}}
+	shadowed := &model.ReviewWithRating{Review: model.Review{
+		ID:          uuid.New(),
+		UserID:      uuid.New(),
+		ServiceID:   uuid.New(),
+		Status:      model.ReviewStatusPublished,
+		PublishedAt: &publishedAt,
+		Shadowed:    true,
+	}}
+
+	repo.On("PublishDueReviews", ctx, mock.Anything).Return([]uuid.UUID{visible.ID, shadowed.ID}, nil).Once()
+	repo.On("GetReviewByID", ctx, visible.ID, model.SystemViewer).Return(visible, nil).Once()
+	repo.On("GetReviewByID", ctx, shadowed.ID, model.SystemViewer).Return(shadowed, nil).Once()
+	repo.On("GetMentions", ctx, model.MentionTargetReview, mock.Anything).Return([]*model.Mention{}, nil).Twice()
+
+	published, err := service.PublishDueReviews(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, published)
+
+	// Only the visible review is announced, as it would be when published on its own
+	assert.Equal(t, []model.Event{model.ReviewPublishedEvent{
+		ReviewID:    visible.ID,
+		UserID:      visible.UserID,
+		ServiceID:   visible.ServiceID,
+		PublishedAt: publishedAt,
+	}}, events.events)
+
+	repo.AssertExpectations(t)
+}
+
+func TestUpdateReviewScreensDuplicates(t *testing.T) {
+	ctx := context.Background()
+	existing := &model.ReviewWithRating{Review: model.Review{
+		ID:               uuid.New(),
+		UserID:           uuid.New(),
+		ServiceID:        uuid.New(),
+		RatingID:         uuid.New(),
+		Title:            "Great service",
+		Content:          "This service was really helpful",
+		Status:           model.ReviewStatusPublished,
+		ModerationStatus: model.ModerationVisible,
+	}}
+
+	t.Run("replaces the signature", func(t *testing.T) {
+		repo := new(MockRepository)
+		service := NewRatingService(repo, logrus.New(), WithDuplicateDetector(stubDuplicates{result: model.Allow()}))
+
+		repo.On("GetReviewByID", ctx, existing.ID, model.SystemViewer).Return(existing, nil).Once()
+		repo.On("GetMentions", ctx, model.MentionTargetReview, []uuid.UUID{existing.ID}).Return(nil, nil).Once()
+		repo.On("UpdateReview", ctx, mock.Anything).Return(nil).Once()
+		repo.On("ReplaceMentions", ctx, model.MentionTargetReview, existing.ID, mock.Anything).Return(nil).Once()
+		repo.On("SaveReviewSignature", ctx, mock.MatchedBy(func(s *model.ReviewSignature) bool {
+			return s.ReviewID == existing.ID
+		})).Return(nil).Once()
+
+		_, err := service.UpdateReview(ctx, existing.ID, "Still great", "Rewritten in my own words")
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("rejects a copy", func(t *testing.T) {
+		repo := new(MockRepository)
+		service := NewRatingService(repo, logrus.New(), WithDuplicateDetector(stubDuplicates{result: model.Reject("duplicate")}))
+
+		repo.On("GetReviewByID", ctx, existing.ID, model.SystemViewer).Return(existing, nil).Once()
+
+		_, err := service.UpdateReview(ctx, existing.ID, "Copied", "Text copied from another account")
+		var rejected *model.ContentRejectedError
+		assert.ErrorAs(t, err, &rejected)
+		repo.AssertNotCalled(t, "UpdateReview", mock.Anything, mock.Anything)
+		repo.AssertNotCalled(t, "SaveReviewSignature", mock.Anything, mock.Anything)
+	})
+}
+
+func TestDecideOnCommentNotifiesAuthor(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockRepository)
+	events := &recordingPublisher{}
+	moderation := NewModerationService(repo, events, logrus.New())
+	notifications := NewNotificationService(repo, logrus.New())
+
+	comment, _ := model.NewComment(uuid.New(), uuid.New(), "Rude comment")
+	repo.On("GetCommentByID", ctx, comment.ID, model.SystemViewer).Return(comment, nil).Once()
+	repo.On("ApplyModerationDecision", ctx, mock.Anything).Return(nil).Once()
+
+	_, err := moderation.Decide(ctx, uuid.New(), model.ReportTargetComment, comment.ID, model.ModerationActionHide, "Abusive")
+	assert.NoError(t, err)
+
+	// The decision is published for the comment's author
+	expected := model.ContentModeratedEvent{
+		TargetType: model.ReportTargetComment,
+		TargetID:   comment.ID,
+		UserID:     comment.UserID,
+		ReviewID:   comment.ReviewID,
+		Action:     model.ModerationActionHide,
+		Status:     model.ModerationHidden,
+	}
+	assert.Equal(t, []model.Event{expected}, events.events)
+
+	// and turns into a moderation outcome notification
+	repo.On("GetNotificationPreferences", ctx, comment.UserID).Return(&model.NotificationPreferences{UserID: comment.UserID}, nil).Once()
+	repo.On("CreateNotification", ctx, mock.MatchedBy(func(n *model.Notification) bool {
+		return n.UserID == comment.UserID && n.Type == model.NotificationModerationOutcome &&
+			*n.ReviewID == comment.ReviewID && *n.CommentID == comment.ID && n.Detail == string(model.ModerationHidden)
+	})).Return(nil).Once()
+	notifications.HandleEvent(ctx, events.events[0])
+
+	repo.AssertExpectations(t)
+}
+
+func TestReviewSentimentMismatchInResponses(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockRepository)
+	service := NewRatingService(repo, logrus.New(), WithSentimentAnalyzer(stubSentiment(-0.8)))
+
+	userID := uuid.New()
+	rating, _ := model.NewRating(userID, uuid.New(), 5)
+
+	repo.On("GetRatingByID", ctx, rating.ID, model.ViewerOf(userID)).Return(rating, nil).Once()
+	repo.On("IsShadowBanned", ctx, userID).Return(false, nil).Once()
+	repo.On("CreateReview", ctx, mock.Anything).Return(nil).Once()
+	repo.On("ReplaceMentions", ctx, model.MentionTargetReview, mock.Anything, mock.Anything).Return(nil).Twice()
+
+	// A scathing review of a five star rating is flagged on creation
+	review, err := service.CreateReview(ctx, userID, rating.ServiceID, rating.ID, "Awful", "Terrible, rude and slow", "", true)
+	assert.NoError(t, err)
+	assert.True(t, review.SentimentMismatch)
+
+	// and again when edited
+	existing := &model.ReviewWithRating{Review: *review, Score: rating.Score}
+	repo.On("GetReviewByID", ctx, review.ID, model.SystemViewer).Return(existing, nil).Once()
+	repo.On("GetMentions", ctx, model.MentionTargetReview, []uuid.UUID{review.ID}).Return(nil, nil).Once()
+	repo.On("UpdateReview", ctx, mock.Anything).Return(nil).Once()
+
+	updated, err := service.UpdateReview(ctx, review.ID, "Still awful", "Even worse the second time")
+	assert.NoError(t, err)
+	assert.True(t, updated.SentimentMismatch)
+
+	repo.AssertExpectations(t)
+}
+
+func TestRevokeRoleExpiresAccessTokens(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockRepository)
+	tokens := &recordingExpirer{}
+	service := NewRoleService(repo, tokens, logrus.New())
+
+	adminID := uuid.New()
+	moderatorID := uuid.New()
+	ownerID := uuid.New()
+	lastAdminID := uuid.New()
+
+	repo.On("RevokeRole", ctx, moderatorID, model.RoleModerator).Return(nil).Once()
+	repo.On("RevokeRole", ctx, ownerID, model.RoleServiceOwner).Return(nil).Once()
+	repo.On("RevokeRole", ctx, lastAdminID, model.RoleAdmin).Return(model.ErrLastAdmin).Once()
+
+	assert.NoError(t, service.RevokeRole(ctx, adminID, moderatorID, model.RoleModerator))
+	assert.NoError(t, service.RevokeRole(ctx, adminID, ownerID, model.RoleServiceOwner))
+	assert.ErrorIs(t, service.RevokeRole(ctx, adminID, lastAdminID, model.RoleAdmin), model.ErrLastAdmin)
+
+	// Only losing a privileged role expires the user's access tokens
+	assert.Equal(t, []uuid.UUID{moderatorID}, tokens.users)
+	repo.AssertExpectations(t)
+}
+
+func TestUpdateRatingScreensForBrigading(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockRepository)
+	detector := &recordingDetector{}
+	service := NewRatingService(repo, logrus.New(), WithBrigadingDetector(detector))
+
+	rating, err := model.NewRating(uuid.New(), uuid.New(), 4)
+	assert.NoError(t, err)
+
+	repo.On("GetRatingByID", ctx, rating.ID, model.SystemViewer).Return(rating, nil).Once()
+	repo.On("UpdateRating", ctx, rating).Return(nil).Once()
+	repo.On("CreateRatingFlag", ctx, mock.MatchedBy(func(flag *model.RatingFlag) bool {
+		return flag.RatingID == rating.ID
+	})).Return(nil).Once()
+
+	updated, err := service.UpdateRating(ctx, rating.ID, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, updated.Score)
+
+	// A changed score is assessed just like a new rating
+	assert.Equal(t, []*model.Rating{rating}, detector.assessed)
+	repo.AssertExpectations(t)
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"rating-system/internal/domain/model"
+)
+
+// GetReactionEmojis returns the emojis users can react with
+func (s *RatingService) GetReactionEmojis(ctx context.Context) []string {
+	return s.reactions.Emojis()
+}
+
+// ToggleReaction adds the user's reaction to a review or comment, or removes it if the user
+// already left it, and returns the target's updated reaction counts
+func (s *RatingService) ToggleReaction(ctx context.Context, userID uuid.UUID, targetType model.ReactionTarget, targetID uuid.UUID, emoji string) (*model.ReactionToggle, error) {
+	if err := s.checkReactionTarget(ctx, userID, targetType, targetID); err != nil {
+		return nil, err
+	}
+
+	reaction, err := model.NewReaction(userID, targetType, targetID, emoji, s.reactions)
+	if err != nil {
+		return nil, err
+	}
+
+	reacted, err := s.repo.ToggleReaction(ctx, reaction)
+	if err != nil {
+		s.log.WithError(err).Error("Failed to toggle reaction in repository")
+		return nil, err
+	}
+
+	byTarget, err := s.getReactionCounts(ctx, targetType, []uuid.UUID{targetID}, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	toggle := &model.ReactionToggle{Reacted: reacted, Reactions: byTarget[targetID]}
+	if toggle.Reactions == nil {
+		toggle.Reactions = []*model.ReactionCount{}
+	}
+	return toggle, nil
+}
+
+// checkReactionTarget verifies that the review or comment exists and can be reacted to by the user
+func (s *RatingService) checkReactionTarget(ctx context.Context, userID uuid.UUID, targetType model.ReactionTarget, targetID uuid.UUID) error {
+	switch targetType {
+	case model.ReactionTargetReview:
+		review, err := s.repo.GetReviewByID(ctx, targetID, model.ViewerOf(userID))
+		if err != nil || !review.IsVisibleTo(userID) {
+			return ErrReviewNotFound
+		}
+	case model.ReactionTargetComment:
+		comment, err := s.repo.GetCommentByID(ctx, targetID, model.ViewerOf(userID))
+		if err != nil || !comment.ModerationStatus.IsPublic() {
+			return ErrCommentNotFound
+		}
+		if comment.Deleted {
+			return model.ErrCommentDeleted
+		}
+	default:
+		return model.ErrInvalidReaction
+	}
+	return nil
+}
+
+// attachReviewReactions loads the reaction counts of the reviews with a single query
+func (s *RatingService) attachReviewReactions(ctx context.Context, viewerID uuid.UUID, reviews ...*model.Review) error {
+	ids := make([]uuid.UUID, len(reviews))
+	for i, review := range reviews {
+		ids[i] = review.ID
+	}
+
+	byTarget, err := s.getReactionCounts(ctx, model.ReactionTargetReview, ids, viewerID)
+	if err != nil {
+		return err
+	}
+	for _, review := range reviews {
+		review.Reactions = byTarget[review.ID]
+	}
+	return nil
+}
+
+// attachCommentReactions loads the reaction counts of the comments with a single query
+func (s *RatingService) attachCommentReactions(ctx context.Context, viewerID uuid.UUID, comments ...*model.Comment) error {
+	ids := make([]uuid.UUID, len(comments))
+	for i, comment := range comments {
+		ids[i] = comment.ID
+	}
+
+	byTarget, err := s.getReactionCounts(ctx, model.ReactionTargetComment, ids, viewerID)
+	if err != nil {
+		return err
+	}
+	for _, comment := range comments {
+		comment.Reactions = byTarget[comment.ID]
+	}
+	return nil
+}
+
+func (s *RatingService) getReactionCounts(ctx context.Context, targetType model.ReactionTarget, ids []uuid.UUID, viewerID uuid.UUID) (map[uuid.UUID][]*model.ReactionCount, error) {
+	counts, err := s.repo.GetReactionCounts(ctx, targetType, ids, viewerID)
+	if err != nil {
+		s.log.WithError(err).Error("Failed to get reaction counts")
+		return nil, err
+	}
+
+	byTarget := make(map[uuid.UUID][]*model.ReactionCount)
+	for _, count := range counts {
+		byTarget[count.TargetID] = append(byTarget[count.TargetID], count)
+	}
+	return byTarget, nil
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"rating-system/internal/domain/model"
+	"rating-system/internal/domain/port"
+)
+
+// ErrUserNotFound is returned when a role operation names a user that does not exist
+var ErrUserNotFound = errors.New("user not found")
+
+// RoleService implements the RoleService port
+type RoleService struct {
+	repo   port.Repository
+	tokens port.AccessTokenExpirer
+	log    *logrus.Logger
+}
+
+// NewRoleService creates a new role service. Revoking a privileged role expires the user's access
+// tokens through tokens, which may be nil where no tokens are issued.
+func NewRoleService(repo port.Repository, tokens port.AccessTokenExpirer, log *logrus.Logger) port.RoleService {
+	return &RoleService{
+		repo:   repo,
+		tokens: tokens,
+		log:    log,
+	}
+}
+
+// GetRoles retrieves the roles granted to a user
+func (s *RoleService) GetRoles(ctx context.Context, userID uuid.UUID) ([]*model.RoleGrant, error) {
+	if err := s.checkUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	grants, err := s.repo.GetUserRoles(ctx, userID)
+	if err != nil {
+		s.log.WithError(err).WithField("user_id", userID).Error("Failed to get user roles")
+		return nil, err
+	}
+	return grants, nil
+}
+
+// GrantRole grants a role to a user on behalf of an admin. The role is embedded in tokens the
+// user is issued from then on.
+func (s *RoleService) GrantRole(ctx context.Context, adminID, userID uuid.UUID, role model.Role) (*model.RoleGrant, error) {
+	grant, err := model.NewRoleGrant(userID, role, &adminID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.GrantRole(ctx, grant); err != nil {
+		if !errors.Is(err, model.ErrRoleAlreadyGranted) {
+			s.log.WithError(err).Error("Failed to grant role in repository")
+		}
+		return nil, err
+	}
+
+	s.log.WithFields(logrus.Fields{"admin_id": adminID, "user_id": userID, "role": role}).Info("Granted role")
+	return grant, nil
+}
+
+// RevokeRole revokes a role from a user on behalf of an admin. The last admin cannot lose the
+// admin role, so the service always keeps someone able to manage roles. Access tokens carry the
+// roles they were issued with, so revoking a privileged role also expires the user's access tokens.
+func (s *RoleService) RevokeRole(ctx context.Context, adminID, userID uuid.UUID, role model.Role) error {
+	if !role.IsGrantable() {
+		return model.ErrInvalidRole
+	}
+
+	if err := s.repo.RevokeRole(ctx, userID, role); err != nil {
+		if !errors.Is(err, model.ErrRoleNotGranted) && !errors.Is(err, model.ErrLastAdmin) {
+			s.log.WithError(err).Error("Failed to revoke role in repository")
+		}
+		return err
+	}
+
+	if role.IsPrivileged() && s.tokens != nil {
+		if err := s.tokens.ExpireAccessTokens(ctx, userID); err != nil {
+			s.log.WithError(err).WithField("user_id", userID).Error("Failed to expire access tokens after revoking role")
+			return err
+		}
+	}
+
+	s.log.WithFields(logrus.Fields{"admin_id": adminID, "user_id": userID, "role": role}).Info("Revoked role")
+	return nil
+}
+
+// BootstrapAdmin makes the user with the email the first admin, registering the account first if
+// needed. It refuses to run once an admin exists, so it cannot be used to escalate privileges.
+func (s *RoleService) BootstrapAdmin(ctx context.Context, email, username, password string) (*model.User, error) {
+	admins, err := s.repo.CountUsersWithRole(ctx, model.RoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+	if admins > 0 {
+		return nil, model.ErrAdminAlreadyExists
+	}
+
+	user, err := s.repo.GetUserByEmail(ctx, email)
+	if err != nil {
+		if user, err = model.NewUser(username, email, password); err != nil {
+			return nil, err
+		}
+		// The operator supplied the address, so it needs no verification email
+		verifiedAt := user.CreatedAt
+		user.EmailVerifiedAt = &verifiedAt
+		if err := s.repo.CreateUser(ctx, user); err != nil {
+			return nil, err
+		}
+		s.log.WithField("user_id", user.ID).Info("Created admin account")
+	}
+
+	grant, err := model.NewRoleGrant(user.ID, model.RoleAdmin, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.GrantRole(ctx, grant); err != nil {
+		return nil, err
+	}
+
+	user.Roles = append(user.Roles, model.RoleAdmin)
+	s.log.WithField("user_id", user.ID).Info("Bootstrapped first admin")
+	return user, nil
+}
+
+// checkUser returns ErrUserNotFound if the user does not exist
+func (s *RoleService) checkUser(ctx context.Context, userID uuid.UUID) error {
+	if _, err := s.repo.GetUserByID(ctx, userID); err != nil {
+		return ErrUserNotFound
+	}
+	return nil
+}
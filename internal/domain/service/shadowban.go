@@ -0,0 +1,18 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// isShadowBanned reports whether content the user writes now should be shadowed: stored and
+// shown to the user as usual, but hidden from everyone else and left out of aggregates
+func (s *RatingService) isShadowBanned(ctx context.Context, userID uuid.UUID) (bool, error) {
+	banned, err := s.repo.IsShadowBanned(ctx, userID)
+	if err != nil {
+		s.log.WithError(err).WithField("user_id", userID).Error("Failed to check shadow-ban")
+		return false, err
+	}
+	return banned, nil
+}
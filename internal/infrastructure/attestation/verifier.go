@@ -0,0 +1,158 @@
+package attestation
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+
+	"rating-system/internal/domain/model"
+)
+
+var (
+	// ErrNoKeysConfigured is returned when neither an HMAC secret nor an Ed25519 key is available
+	ErrNoKeysConfigured = errors.New("no attestation keys configured")
+	// ErrSubjectMismatch is returned when the token was issued for a different user or service
+	ErrSubjectMismatch = errors.New("attestation does not match user or service")
+)
+
+// Claims are the claims carried by an attestation token issued by the order system
+type Claims struct {
+	UserID    string `json:"user_id"`
+	ServiceID string `json:"service_id"`
+	OrderID   string `json:"order_id"`
+	jwt.RegisteredClaims
+}
+
+// Verifier verifies attestation tokens signed with HS256 or EdDSA (Ed25519)
+type Verifier struct {
+	hmacSecret []byte
+	publicKey  ed25519.PublicKey
+	issuer     string
+}
+
+// NewVerifier creates a verifier from an HMAC secret and/or an Ed25519 public key.
+// If issuer is non-empty, tokens must carry a matching iss claim.
+func NewVerifier(hmacSecret []byte, publicKey ed25519.PublicKey, issuer string) (*Verifier, error) {
+	if len(hmacSecret) == 0 && len(publicKey) == 0 {
+		return nil, ErrNoKeysConfigured
+	}
+	if len(publicKey) != 0 && len(publicKey) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("invalid Ed25519 public key size: %d", len(publicKey))
+	}
+
+	return &Verifier{
+		hmacSecret: hmacSecret,
+		publicKey:  publicKey,
+		issuer:     issuer,
+	}, nil
+}
+
+// NewVerifierFromEnv creates a verifier from ATTESTATION_HMAC_SECRET,
+// ATTESTATION_ED25519_PUBLIC_KEY (PEM or base64 raw key) and ATTESTATION_ISSUER
+func NewVerifierFromEnv() (*Verifier, error) {
+	var publicKey ed25519.PublicKey
+	if encoded := strings.TrimSpace(os.Getenv("ATTESTATION_ED25519_PUBLIC_KEY")); encoded != "" {
+		key, err := parsePublicKey(encoded)
+		if err != nil {
+			return nil, err
+		}
+		publicKey = key
+	}
+
+	return NewVerifier([]byte(os.Getenv("ATTESTATION_HMAC_SECRET")), publicKey, os.Getenv("ATTESTATION_ISSUER"))
+}
+
+// parsePublicKey accepts either a PEM encoded public key or a base64 encoded raw key
+func parsePublicKey(encoded string) (ed25519.PublicKey, error) {
+	if strings.HasPrefix(encoded, "-----BEGIN") {
+		key, err := jwt.ParseEdPublicKeyFromPEM([]byte(encoded))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse Ed25519 public key: %w", err)
+		}
+		edKey, ok := key.(ed25519.PublicKey)
+		if !ok {
+			return nil, errors.New("public key is not an Ed25519 key")
+		}
+		return edKey, nil
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode Ed25519 public key: %w", err)
+	}
+	return ed25519.PublicKey(raw), nil
+}
+
+// Verify checks the token's signature and expiry and that it binds the given user and service
+func (v *Verifier) Verify(token string, userID, serviceID uuid.UUID) (*model.Attestation, error) {
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods(v.validMethods()),
+		jwt.WithExpirationRequired(),
+	}
+	if v.issuer != "" {
+		opts = append(opts, jwt.WithIssuer(v.issuer))
+	}
+
+	parsed, err := jwt.ParseWithClaims(token, &Claims{}, v.keyFunc, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := parsed.Claims.(*Claims)
+	if !ok || !parsed.Valid {
+		return nil, model.ErrInvalidAttestation
+	}
+
+	if claims.ID == "" || claims.OrderID == "" {
+		return nil, errors.New("attestation is missing jti or order_id")
+	}
+
+	if claims.UserID != userID.String() || claims.ServiceID != serviceID.String() {
+		return nil, ErrSubjectMismatch
+	}
+
+	return &model.Attestation{
+		TokenID:   claims.ID,
+		UserID:    userID,
+		ServiceID: serviceID,
+		OrderID:   claims.OrderID,
+		Algorithm: parsed.Method.Alg(),
+		ExpiresAt: claims.ExpiresAt.Time,
+	}, nil
+}
+
+// keyFunc selects the verification key based on the token's signing method
+func (v *Verifier) keyFunc(token *jwt.Token) (interface{}, error) {
+	switch token.Method.(type) {
+	case *jwt.SigningMethodHMAC:
+		if len(v.hmacSecret) == 0 {
+			return nil, errors.New("HMAC attestations are not accepted")
+		}
+		return v.hmacSecret, nil
+	case *jwt.SigningMethodEd25519:
+		if len(v.publicKey) == 0 {
+			return nil, errors.New("Ed25519 attestations are not accepted")
+		}
+		return v.publicKey, nil
+	default:
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+}
+
+// validMethods lists the signing algorithms accepted with the configured keys
+func (v *Verifier) validMethods() []string {
+	var methods []string
+	if len(v.hmacSecret) != 0 {
+		methods = append(methods, jwt.SigningMethodHS256.Alg())
+	}
+	if len(v.publicKey) != 0 {
+		methods = append(methods, jwt.SigningMethodEdDSA.Alg())
+	}
+	return methods
+}
@@ -0,0 +1,94 @@
+package attestation
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newClaims(userID, serviceID uuid.UUID, expiresAt time.Time) Claims {
+	return Claims{
+		UserID:    userID.String(),
+		ServiceID: serviceID.String(),
+		OrderID:   "order-123",
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+}
+
+func TestVerifyHMAC(t *testing.T) {
+	secret := []byte("attestation-secret")
+	verifier, err := NewVerifier(secret, nil, "")
+	require.NoError(t, err)
+
+	userID := uuid.New()
+	serviceID := uuid.New()
+	claims := newClaims(userID, serviceID, time.Now().Add(time.Hour))
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(secret)
+	require.NoError(t, err)
+
+	attestation, err := verifier.Verify(token, userID, serviceID)
+	assert.NoError(t, err)
+	assert.Equal(t, "order-123", attestation.OrderID)
+	assert.Equal(t, claims.ID, attestation.TokenID)
+	assert.Equal(t, "HS256", attestation.Algorithm)
+
+	// A token bound to another service must be rejected
+	_, err = verifier.Verify(token, userID, uuid.New())
+	assert.ErrorIs(t, err, ErrSubjectMismatch)
+
+	// A token signed with another secret must be rejected
+	forged, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("other"))
+	_, err = verifier.Verify(forged, userID, serviceID)
+	assert.Error(t, err)
+}
+
+func TestVerifyExpired(t *testing.T) {
+	secret := []byte("attestation-secret")
+	verifier, err := NewVerifier(secret, nil, "")
+	require.NoError(t, err)
+
+	userID := uuid.New()
+	serviceID := uuid.New()
+	token, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, newClaims(userID, serviceID, time.Now().Add(-time.Minute))).SignedString(secret)
+
+	_, err = verifier.Verify(token, userID, serviceID)
+	assert.ErrorIs(t, err, jwt.ErrTokenExpired)
+}
+
+func TestVerifyEd25519(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	verifier, err := NewVerifier(nil, publicKey, "orders")
+	require.NoError(t, err)
+
+	userID := uuid.New()
+	serviceID := uuid.New()
+	claims := newClaims(userID, serviceID, time.Now().Add(time.Hour))
+	claims.Issuer = "orders"
+	token, err := jwt.NewWithClaims(jwt.SigningMethodEdDSA, claims).SignedString(privateKey)
+	require.NoError(t, err)
+
+	attestation, err := verifier.Verify(token, userID, serviceID)
+	assert.NoError(t, err)
+	assert.Equal(t, "EdDSA", attestation.Algorithm)
+
+	// HMAC tokens are not accepted when only an Ed25519 key is configured
+	hmacToken, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("secret"))
+	_, err = verifier.Verify(hmacToken, userID, serviceID)
+	assert.Error(t, err)
+}
+
+func TestNewVerifierRequiresKey(t *testing.T) {
+	_, err := NewVerifier(nil, nil, "")
+	assert.ErrorIs(t, err, ErrNoKeysConfigured)
+}
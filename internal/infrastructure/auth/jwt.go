@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
@@ -8,14 +9,14 @@ import (
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
 
 	"rating-system/internal/domain/model"
 )
 
-// JWT token duration
-const (
-	TokenDuration = 24 * time.Hour
-)
+// AccessTokenDuration is the default lifetime of access tokens. Clients keep sessions alive with
+// refresh tokens, so access tokens are short-lived.
+const AccessTokenDuration = 15 * time.Minute
 
 var (
 	// ErrInvalidToken is returned when the token is invalid
@@ -24,19 +25,36 @@ var (
 	ErrExpiredToken = errors.New("token expired")
 )
 
-// JWTService handles JWT token generation and validation
+// JWTService handles JWT token generation and validation. Tokens are signed with HS256 and a
+// shared secret unless a key set is configured, in which case they are signed with RS256 or EdDSA
+// and carry the ID of their key in the kid header.
 type JWTService struct {
 	secretKey []byte
+	keys      *KeySet
+	ttl       time.Duration
 }
 
-// JWTClaims represents the claims in a JWT token
+// JWTClaims represents the claims in a JWT token. The registered jti claim identifies the token so
+// it can be revoked, and gen carries the user's token generation when the token was issued.
 type JWTClaims struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
+	UserID     string   `json:"user_id"`
+	Username   string   `json:"username"`
+	Roles      []string `json:"roles,omitempty"`
+	Generation int      `json:"gen"`
 	jwt.RegisteredClaims
 }
 
-// NewJWTService creates a new JWT authentication service
+// PurposeClaims represents the claims in a single-use token sent to a user by email. The audience
+// is the token's purpose, so such tokens are never accepted as access tokens or for another
+// purpose, and the email address binds the token to the address it was sent to.
+type PurposeClaims struct {
+	Email string `json:"email"`
+	jwt.RegisteredClaims
+}
+
+// NewJWTService creates a new JWT authentication service. If JWT_KEYS_DIR is set, tokens are
+// signed and verified with the keys in that directory instead of the shared secret.
+// ACCESS_TOKEN_TTL overrides the lifetime of access tokens.
 func NewJWTService() (*JWTService, error) {
 	// In a production environment, the secret key should be loaded from environment variables
 	secretKey := os.Getenv("JWT_SECRET_KEY")
@@ -45,48 +63,133 @@ func NewJWTService() (*JWTService, error) {
 		secretKey = "development_jwt_secret_key_please_change_in_production"
 	}
 
+	ttl := AccessTokenDuration
+	if raw := os.Getenv("ACCESS_TOKEN_TTL"); raw != "" {
+		parsed, err := time.ParseDuration(raw)
+		if err != nil || parsed <= 0 {
+			return nil, fmt.Errorf("invalid ACCESS_TOKEN_TTL %q", raw)
+		}
+		ttl = parsed
+	}
+
+	var keys *KeySet
+	if dir := os.Getenv("JWT_KEYS_DIR"); dir != "" {
+		loaded, err := LoadKeySet(dir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load token signing keys: %w", err)
+		}
+		keys = loaded
+	}
+
 	return &JWTService{
 		secretKey: []byte(secretKey),
+		keys:      keys,
+		ttl:       ttl,
 	}, nil
 }
 
-// GenerateToken generates a JWT token for a user
-func (s *JWTService) GenerateToken(user *model.User) (string, error) {
+// Watch reloads the key set every interval until the context is cancelled, so keys can be added
+// and retired without a restart. It returns at once when tokens are signed with the shared secret.
+func (s *JWTService) Watch(ctx context.Context, interval time.Duration, log *logrus.Logger) {
+	if s.keys == nil {
+		return
+	}
+	s.keys.Watch(ctx, interval, log)
+}
+
+// JWKS returns the public keys tokens can be verified with. It is empty when tokens are signed
+// with the shared secret.
+func (s *JWTService) JWKS() *model.JSONWebKeySet {
+	if s.keys == nil {
+		return &model.JSONWebKeySet{Keys: []model.JSONWebKey{}}
+	}
+	return s.keys.JWKS()
+}
+
+// GenerateToken generates a JWT token for a user, embedding the roles they hold and their current
+// token generation, and returns it with its expiry
+func (s *JWTService) GenerateToken(user *model.User, generation int) (string, time.Time, error) {
+	roles := make([]string, len(user.Roles))
+	for i, role := range user.Roles {
+		roles[i] = string(role)
+	}
+
 	// Set claims with user information and expiration time
+	now := time.Now()
+	expiresAt := now.Add(s.ttl)
 	claims := JWTClaims{
-		UserID:   user.ID.String(),
-		Username: user.Username,
+		UserID:     user.ID.String(),
+		Username:   user.Username,
+		Roles:      roles,
+		Generation: generation,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(TokenDuration)),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-			NotBefore: jwt.NewNumericDate(time.Now()),
+			ID:        uuid.NewString(),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
 			Issuer:    "rating-system",
 			Subject:   user.ID.String(),
 		},
 	}
 
-	// Create token with claims
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	tokenString, err := s.sign(claims)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return tokenString, expiresAt, nil
+}
 
-	// Sign the token with the secret key
-	tokenString, err := token.SignedString(s.secretKey)
+// GeneratePurposeToken generates a signed token for a purpose such as verifying an email address.
+// The token ID lets the caller record the token so it can only be used once.
+func (s *JWTService) GeneratePurposeToken(purpose string, tokenID, userID uuid.UUID, email string, expiresAt time.Time) (string, error) {
+	now := time.Now()
+	return s.sign(PurposeClaims{
+		Email: email,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        tokenID.String(),
+			Audience:  jwt.ClaimStrings{purpose},
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			Issuer:    "rating-system",
+			Subject:   userID.String(),
+		},
+	})
+}
+
+// ValidatePurposeToken validates a token generated for the given purpose and returns its claims
+func (s *JWTService) ValidatePurposeToken(purpose, tokenString string) (*PurposeClaims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &PurposeClaims{}, s.keyFunc, jwt.WithAudience(purpose))
 	if err != nil {
-		return "", err
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, ErrExpiredToken
+		}
+		return nil, ErrInvalidToken
+	}
+
+	claims, ok := token.Claims.(*PurposeClaims)
+	if !ok || !token.Valid || claims.ID == "" || claims.ExpiresAt == nil {
+		return nil, ErrInvalidToken
 	}
+	return claims, nil
+}
 
-	return tokenString, nil
+// sign signs claims with the current signing key, or the secret key if no key set is configured
+func (s *JWTService) sign(claims jwt.Claims) (string, error) {
+	if s.keys != nil {
+		key := s.keys.signingKey()
+		token := jwt.NewWithClaims(key.method, claims)
+		token.Header["kid"] = key.id
+		return token.SignedString(key.private)
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(s.secretKey)
 }
 
 // ValidateToken validates a JWT token and returns the claims
 func (s *JWTService) ValidateToken(tokenString string) (*JWTClaims, error) {
 	// Parse the token
-	token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
-		// Validate the signing method
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return s.secretKey, nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, &JWTClaims{}, s.keyFunc)
 
 	// Check for parsing errors
 	if err != nil {
@@ -101,15 +204,37 @@ func (s *JWTService) ValidateToken(tokenString string) (*JWTClaims, error) {
 		return nil, ErrInvalidToken
 	}
 
-	// Extract claims
+	// Extract claims; tokens without an ID cannot be revoked, so they are not accepted, and tokens
+	// with an audience were issued for another purpose
 	claims, ok := token.Claims.(*JWTClaims)
-	if !ok {
+	if !ok || claims.ID == "" || claims.ExpiresAt == nil || len(claims.Audience) > 0 {
 		return nil, ErrInvalidToken
 	}
 
 	return claims, nil
 }
 
+// keyFunc returns the key a token is verified with, checking the token's signing method matches
+// the key so a public key can never be used as an HMAC secret
+func (s *JWTService) keyFunc(token *jwt.Token) (interface{}, error) {
+	if s.keys == nil {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return s.secretKey, nil
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	key := s.keys.verificationKey(kid)
+	if key == nil {
+		return nil, fmt.Errorf("unknown key %q", kid)
+	}
+	if token.Method.Alg() != key.method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+	return key.public, nil
+}
+
 // ExtractUserID extracts the user ID from a validated token
 func (s *JWTService) ExtractUserID(tokenString string) (uuid.UUID, error) {
 	claims, err := s.ValidateToken(tokenString)
@@ -123,4 +248,31 @@ func (s *JWTService) ExtractUserID(tokenString string) (uuid.UUID, error) {
 	}
 
 	return userID, nil
-}
\ No newline at end of file
+}
+
+// ExtractPrincipal extracts the user ID, roles and token details from a validated token. Roles the
+// service no longer knows are dropped.
+func (s *JWTService) ExtractPrincipal(tokenString string) (*model.Principal, error) {
+	claims, err := s.ValidateToken(tokenString)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	principal := &model.Principal{
+		UserID:     userID,
+		TokenID:    claims.ID,
+		Generation: claims.Generation,
+		ExpiresAt:  claims.ExpiresAt.Time,
+	}
+	for _, role := range claims.Roles {
+		if r := model.Role(role); r.IsGrantable() {
+			principal.Roles = append(principal.Roles, r)
+		}
+	}
+	return principal, nil
+}
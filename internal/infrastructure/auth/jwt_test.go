@@ -0,0 +1,43 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPurposeTokens(t *testing.T) {
+	t.Setenv("JWT_KEYS_DIR", "")
+	svc, err := NewJWTService()
+	require.NoError(t, err)
+
+	tokenID, userID := uuid.New(), uuid.New()
+	token, err := svc.GeneratePurposeToken("email_verification", tokenID, userID, "alice@example.com", time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	claims, err := svc.ValidatePurposeToken("email_verification", token)
+	require.NoError(t, err)
+	assert.Equal(t, tokenID.String(), claims.ID)
+	assert.Equal(t, userID.String(), claims.Subject)
+	assert.Equal(t, "alice@example.com", claims.Email)
+
+	// A token for one purpose is accepted neither for another nor as an access token
+	_, err = svc.ValidatePurposeToken("password_reset", token)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+	_, err = svc.ValidateToken(token)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	// Nor is an access token accepted for a purpose
+	access, _, err := svc.GenerateToken(testUser(), 0)
+	require.NoError(t, err)
+	_, err = svc.ValidatePurposeToken("email_verification", access)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	expired, err := svc.GeneratePurposeToken("email_verification", tokenID, userID, "alice@example.com", time.Now().Add(-time.Minute))
+	require.NoError(t, err)
+	_, err = svc.ValidatePurposeToken("email_verification", expired)
+	assert.ErrorIs(t, err, ErrExpiredToken)
+}
@@ -0,0 +1,226 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/sirupsen/logrus"
+
+	"rating-system/internal/domain/model"
+)
+
+// minRSABits is the smallest RSA key accepted for signing or verifying tokens
+const minRSABits = 2048
+
+// ErrNoSigningKey is returned when a key directory holds no private key to sign tokens with
+var ErrNoSigningKey = errors.New("no private key to sign tokens with")
+
+// signingKey is a key tokens are verified with and, if the private key is known, signed with
+type signingKey struct {
+	id      string
+	method  jwt.SigningMethod
+	private crypto.Signer
+	public  crypto.PublicKey
+}
+
+// KeySet holds the asymmetric keys in a directory. Each PEM file holds one RSA or Ed25519 key
+// named by its file name without the .pem or .pub.pem suffix, which becomes the kid of the tokens
+// it signs. Every key verifies tokens, and the private key whose name sorts last signs them, so
+// naming keys by date makes the newest one sign. Public keys verify tokens without ever signing,
+// which lets a key be published before it is used. If the directory becomes unreadable or invalid,
+// the previous keys stay in force.
+type KeySet struct {
+	dir         string
+	mu          sync.RWMutex
+	keys        map[string]*signingKey
+	signing     *signingKey
+	fingerprint string
+}
+
+// LoadKeySet loads the keys in a directory
+func LoadKeySet(dir string) (*KeySet, error) {
+	k := &KeySet{dir: dir}
+	if _, err := k.Reload(); err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+// Reload reloads the keys if files in the directory were added, removed or modified since they
+// were last read, reporting whether the keys changed. Invalid keys are only reported once per
+// change to the directory.
+func (k *KeySet) Reload() (bool, error) {
+	paths, err := filepath.Glob(filepath.Join(k.dir, "*.pem"))
+	if err != nil {
+		return false, err
+	}
+	sort.Strings(paths)
+
+	var fingerprint strings.Builder
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return false, err
+		}
+		fmt.Fprintf(&fingerprint, "%s:%d:%d;", filepath.Base(path), info.Size(), info.ModTime().UnixNano())
+	}
+
+	k.mu.Lock()
+	unchanged := fingerprint.String() == k.fingerprint
+	k.fingerprint = fingerprint.String()
+	k.mu.Unlock()
+	if unchanged {
+		return false, nil
+	}
+
+	keys := make(map[string]*signingKey, len(paths))
+	var signing *signingKey
+	for _, path := range paths {
+		key, err := loadKey(path)
+		if err != nil {
+			return false, fmt.Errorf("%s: %w", filepath.Base(path), err)
+		}
+		// A private key replaces the public key of the same name
+		if existing, ok := keys[key.id]; ok && existing.private != nil {
+			continue
+		}
+		keys[key.id] = key
+	}
+	for _, key := range keys {
+		if key.private != nil && (signing == nil || key.id > signing.id) {
+			signing = key
+		}
+	}
+	if signing == nil {
+		return false, fmt.Errorf("%w in %s", ErrNoSigningKey, k.dir)
+	}
+
+	k.mu.Lock()
+	k.keys = keys
+	k.signing = signing
+	k.mu.Unlock()
+	return true, nil
+}
+
+// Watch checks the directory for key changes every interval until the context is cancelled
+func (k *KeySet) Watch(ctx context.Context, interval time.Duration, log *logrus.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			reloaded, err := k.Reload()
+			if err != nil {
+				log.WithError(err).WithField("dir", k.dir).Error("Failed to reload token signing keys; keeping previous keys")
+				continue
+			}
+			if reloaded {
+				log.WithFields(logrus.Fields{"dir": k.dir, "kid": k.signingKey().id}).Info("Reloaded token signing keys")
+			}
+		}
+	}
+}
+
+// JWKS returns the public keys of the set, sorted by ID
+func (k *KeySet) JWKS() *model.JSONWebKeySet {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	set := &model.JSONWebKeySet{Keys: make([]model.JSONWebKey, 0, len(k.keys))}
+	for _, key := range k.keys {
+		jwk := model.JSONWebKey{KeyID: key.id, Use: "sig", Algorithm: key.method.Alg()}
+		switch public := key.public.(type) {
+		case *rsa.PublicKey:
+			jwk.KeyType = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.KeyType = "OKP"
+			jwk.Curve = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(public)
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+	sort.Slice(set.Keys, func(i, j int) bool { return set.Keys[i].KeyID < set.Keys[j].KeyID })
+	return set
+}
+
+// signingKey returns the key tokens are signed with
+func (k *KeySet) signingKey() *signingKey {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return k.signing
+}
+
+// verificationKey returns the key with the ID, or nil if the set has none
+func (k *KeySet) verificationKey(id string) *signingKey {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return k.keys[id]
+}
+
+// loadKey reads a PEM encoded RSA or Ed25519 key, private or public, from a file
+func loadKey(path string) (*signingKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM data")
+	}
+
+	var parsed interface{}
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		parsed, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	id := strings.TrimSuffix(strings.TrimSuffix(filepath.Base(path), ".pem"), ".pub")
+	key := &signingKey{id: id}
+	switch parsed := parsed.(type) {
+	case *rsa.PrivateKey:
+		key.method, key.private, key.public = jwt.SigningMethodRS256, parsed, &parsed.PublicKey
+	case *rsa.PublicKey:
+		key.method, key.public = jwt.SigningMethodRS256, parsed
+	case ed25519.PrivateKey:
+		key.method, key.private, key.public = jwt.SigningMethodEdDSA, parsed, parsed.Public()
+	case ed25519.PublicKey:
+		key.method, key.public = jwt.SigningMethodEdDSA, parsed
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", parsed)
+	}
+	if public, ok := key.public.(*rsa.PublicKey); ok && public.N.BitLen() < minRSABits {
+		return nil, fmt.Errorf("RSA key has %d bits, at least %d are required", public.N.BitLen(), minRSABits)
+	}
+	return key, nil
+}
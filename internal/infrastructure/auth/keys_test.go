@@ -0,0 +1,185 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"rating-system/internal/domain/model"
+)
+
+// writeKey writes a key to dir as PKCS8 or PKIX PEM
+func writeKey(t *testing.T, dir, name string, key interface{}) {
+	t.Helper()
+	var block *pem.Block
+	switch key.(type) {
+	case *rsa.PublicKey, ed25519.PublicKey:
+		der, err := x509.MarshalPKIXPublicKey(key)
+		require.NoError(t, err)
+		block = &pem.Block{Type: "PUBLIC KEY", Bytes: der}
+	default:
+		der, err := x509.MarshalPKCS8PrivateKey(key)
+		require.NoError(t, err)
+		block = &pem.Block{Type: "PRIVATE KEY", Bytes: der}
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), pem.EncodeToMemory(block), 0o600))
+}
+
+func newRSAKey(t *testing.T, bits int) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, bits)
+	require.NoError(t, err)
+	return key
+}
+
+func newEd25519Key(t *testing.T) ed25519.PrivateKey {
+	t.Helper()
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	return key
+}
+
+func testUser() *model.User {
+	return &model.User{ID: uuid.New(), Username: "alice"}
+}
+
+func TestKeySetSignsWithLastPrivateKey(t *testing.T) {
+	dir := t.TempDir()
+	writeKey(t, dir, "2026-01.pem", newRSAKey(t, 2048))
+	writeKey(t, dir, "2026-02.pem", newEd25519Key(t))
+	// A newer key that is only published must not sign
+	writeKey(t, dir, "2026-03.pub.pem", newEd25519Key(t).Public())
+
+	keys, err := LoadKeySet(dir)
+	require.NoError(t, err)
+	s := &JWTService{keys: keys, ttl: time.Minute}
+
+	token, _, err := s.GenerateToken(testUser(), 0)
+	require.NoError(t, err)
+
+	parsed, _, err := jwt.NewParser().ParseUnverified(token, &JWTClaims{})
+	require.NoError(t, err)
+	assert.Equal(t, "2026-02", parsed.Header["kid"])
+	assert.Equal(t, "EdDSA", parsed.Header["alg"])
+
+	_, err = s.ValidateToken(token)
+	assert.NoError(t, err)
+
+	jwks := s.JWKS()
+	require.Len(t, jwks.Keys, 3)
+	assert.Equal(t, "2026-01", jwks.Keys[0].KeyID)
+	assert.Equal(t, "RSA", jwks.Keys[0].KeyType)
+	assert.Equal(t, "RS256", jwks.Keys[0].Algorithm)
+	assert.Equal(t, "AQAB", jwks.Keys[0].E)
+	assert.NotEmpty(t, jwks.Keys[0].N)
+	assert.Equal(t, "OKP", jwks.Keys[1].KeyType)
+	assert.Equal(t, "Ed25519", jwks.Keys[1].Curve)
+	assert.NotEmpty(t, jwks.Keys[1].X)
+	assert.Equal(t, "2026-03", jwks.Keys[2].KeyID)
+}
+
+func TestKeySetRotation(t *testing.T) {
+	dir := t.TempDir()
+	writeKey(t, dir, "2026-01.pem", newRSAKey(t, 2048))
+
+	keys, err := LoadKeySet(dir)
+	require.NoError(t, err)
+	s := &JWTService{keys: keys, ttl: time.Minute}
+
+	old, _, err := s.GenerateToken(testUser(), 0)
+	require.NoError(t, err)
+
+	// Publish the next key, then let it sign
+	next := newEd25519Key(t)
+	writeKey(t, dir, "2026-02.pub.pem", next.Public())
+	reloaded, err := keys.Reload()
+	require.NoError(t, err)
+	assert.True(t, reloaded)
+	assert.Equal(t, "2026-01", keys.signingKey().id)
+
+	require.NoError(t, os.Remove(filepath.Join(dir, "2026-02.pub.pem")))
+	writeKey(t, dir, "2026-02.pem", next)
+	_, err = keys.Reload()
+	require.NoError(t, err)
+	assert.Equal(t, "2026-02", keys.signingKey().id)
+
+	current, _, err := s.GenerateToken(testUser(), 0)
+	require.NoError(t, err)
+	_, err = s.ValidateToken(old)
+	assert.NoError(t, err)
+	_, err = s.ValidateToken(current)
+	assert.NoError(t, err)
+
+	reloaded, err = keys.Reload()
+	require.NoError(t, err)
+	assert.False(t, reloaded)
+
+	// Retiring the old key invalidates the tokens it signed
+	require.NoError(t, os.Remove(filepath.Join(dir, "2026-01.pem")))
+	_, err = keys.Reload()
+	require.NoError(t, err)
+	_, err = s.ValidateToken(old)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+	_, err = s.ValidateToken(current)
+	assert.NoError(t, err)
+}
+
+func TestKeySetRejectsInvalidKeys(t *testing.T) {
+	dir := t.TempDir()
+	writeKey(t, dir, "2026-01.pub.pem", newEd25519Key(t).Public())
+	_, err := LoadKeySet(dir)
+	assert.ErrorIs(t, err, ErrNoSigningKey)
+
+	dir = t.TempDir()
+	writeKey(t, dir, "weak.pem", newRSAKey(t, 1024))
+	_, err = LoadKeySet(dir)
+	assert.Error(t, err)
+
+	dir = t.TempDir()
+	writeKey(t, dir, "2026-01.pem", newEd25519Key(t))
+	keys, err := LoadKeySet(dir)
+	require.NoError(t, err)
+	// A broken key file keeps the previous keys in force
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "2026-02.pem"), []byte("not a key"), 0o600))
+	_, err = keys.Reload()
+	assert.Error(t, err)
+	assert.Equal(t, "2026-01", keys.signingKey().id)
+}
+
+func TestKeySetRejectsHMACTokens(t *testing.T) {
+	dir := t.TempDir()
+	key := newRSAKey(t, 2048)
+	writeKey(t, dir, "2026-01.pem", key)
+	keys, err := LoadKeySet(dir)
+	require.NoError(t, err)
+	s := &JWTService{keys: keys, ttl: time.Minute}
+
+	// An HS256 token keyed with the published public key must not verify
+	public, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	claims := JWTClaims{
+		UserID: uuid.NewString(),
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+		},
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	token.Header["kid"] = "2026-01"
+	forged, err := token.SignedString(public)
+	require.NoError(t, err)
+
+	_, err = s.ValidateToken(forged)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
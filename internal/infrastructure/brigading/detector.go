@@ -0,0 +1,176 @@
+// Package brigading scores incoming ratings for signs of coordinated review bombing: fresh
+// accounts, bursts of ratings on one service, several accounts sharing an address or device, and
+// scores far from a service's established average.
+package brigading
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+
+	"rating-system/internal/domain/model"
+)
+
+// Store provides the rating history the detector scores against
+type Store interface {
+	GetUserByID(ctx context.Context, id uuid.UUID) (*model.User, error)
+	CountRatingsSince(ctx context.Context, serviceID uuid.UUID, since time.Time) (int, error)
+	CountClusteredRaters(ctx context.Context, serviceID, userID uuid.UUID, client model.ClientInfo, since time.Time) (int, int, error)
+	CalculateAverageRating(ctx context.Context, serviceID uuid.UUID, viewer model.Viewer, filter model.RatingFilter) (*model.AverageRating, error)
+}
+
+// signalWeights is how much each signal adds to a rating's suspicion score. Device clusters are
+// weighted highest since shared devices are rare among genuine raters, while a new account or an
+// outlying score alone is common and only counts alongside another signal.
+var signalWeights = map[model.BrigadingSignal]float64{
+	model.SignalNewAccount:     0.5,
+	model.SignalRatingBurst:    0.75,
+	model.SignalIPCluster:      0.75,
+	model.SignalDeviceCluster:  1.0,
+	model.SignalScoreDeviation: 0.5,
+}
+
+// Config tunes the detector
+type Config struct {
+	// MinAccountAge is the age below which an account counts as new
+	MinAccountAge time.Duration
+	// BurstWindow and BurstThreshold raise a burst when a service receives BurstThreshold ratings
+	// within BurstWindow
+	BurstWindow    time.Duration
+	BurstThreshold int
+	// ClusterWindow and ClusterThreshold raise a cluster when ClusterThreshold other accounts rated
+	// the service from the same address or device within ClusterWindow
+	ClusterWindow    time.Duration
+	ClusterThreshold int
+	// MaxDeviation is how far in stars a score can be from the service average before it counts
+	// as an outlier, once the service has DeviationMinRatings other ratings
+	MaxDeviation        float64
+	DeviationMinRatings int
+	// FlagThreshold is the suspicion score at which a rating is flagged for moderators
+	FlagThreshold float64
+	// QuarantineThreshold is the suspicion score at which a flagged rating is also left out of
+	// public aggregates until reviewed. Zero disables quarantine.
+	QuarantineThreshold float64
+}
+
+// DefaultConfig returns the default configuration, which flags but never quarantines
+func DefaultConfig() Config {
+	return Config{
+		MinAccountAge:       72 * time.Hour,
+		BurstWindow:         10 * time.Minute,
+		BurstThreshold:      20,
+		ClusterWindow:       24 * time.Hour,
+		ClusterThreshold:    2,
+		MaxDeviation:        2.5,
+		DeviationMinRatings: 10,
+		FlagThreshold:       1.0,
+	}
+}
+
+// Detector scores ratings for brigading
+type Detector struct {
+	store Store
+	cfg   Config
+	now   func() time.Time
+}
+
+// NewDetector creates a detector; zero config values other than QuarantineThreshold fall back to
+// the defaults
+func NewDetector(store Store, cfg Config) *Detector {
+	defaults := DefaultConfig()
+	if cfg.MinAccountAge <= 0 {
+		cfg.MinAccountAge = defaults.MinAccountAge
+	}
+	if cfg.BurstWindow <= 0 {
+		cfg.BurstWindow = defaults.BurstWindow
+	}
+	if cfg.BurstThreshold <= 0 {
+		cfg.BurstThreshold = defaults.BurstThreshold
+	}
+	if cfg.ClusterWindow <= 0 {
+		cfg.ClusterWindow = defaults.ClusterWindow
+	}
+	if cfg.ClusterThreshold <= 0 {
+		cfg.ClusterThreshold = defaults.ClusterThreshold
+	}
+	if cfg.MaxDeviation <= 0 {
+		cfg.MaxDeviation = defaults.MaxDeviation
+	}
+	if cfg.DeviationMinRatings <= 0 {
+		cfg.DeviationMinRatings = defaults.DeviationMinRatings
+	}
+	if cfg.FlagThreshold <= 0 {
+		cfg.FlagThreshold = defaults.FlagThreshold
+	}
+
+	return &Detector{store: store, cfg: cfg, now: time.Now}
+}
+
+// Assess implements port.BrigadingDetector. The rating must already be stored, along with the
+// client it came from, so it is counted in the service's history.
+func (d *Detector) Assess(ctx context.Context, rating *model.Rating, client model.ClientInfo) (*model.RatingAssessment, error) {
+	now := d.now()
+	assessment := &model.RatingAssessment{}
+
+	user, err := d.store.GetUserByID(ctx, rating.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rating author: %w", err)
+	}
+	if age := now.Sub(user.CreatedAt); age < d.cfg.MinAccountAge {
+		assessment.Add(model.SignalNewAccount, signalWeights[model.SignalNewAccount],
+			fmt.Sprintf("account is %s old", age.Round(time.Minute)))
+	}
+
+	recent, err := d.store.CountRatingsSince(ctx, rating.ServiceID, now.Add(-d.cfg.BurstWindow))
+	if err != nil {
+		return nil, fmt.Errorf("failed to count recent ratings: %w", err)
+	}
+	if recent >= d.cfg.BurstThreshold {
+		assessment.Add(model.SignalRatingBurst, signalWeights[model.SignalRatingBurst],
+			fmt.Sprintf("service received %d ratings in %s", recent, d.cfg.BurstWindow))
+	}
+
+	if client.IP != "" || client.DeviceID != "" {
+		byIP, byDevice, err := d.store.CountClusteredRaters(ctx, rating.ServiceID, rating.UserID, client, now.Add(-d.cfg.ClusterWindow))
+		if err != nil {
+			return nil, fmt.Errorf("failed to count clustered raters: %w", err)
+		}
+		if byIP >= d.cfg.ClusterThreshold {
+			assessment.Add(model.SignalIPCluster, signalWeights[model.SignalIPCluster],
+				fmt.Sprintf("%d other accounts rated this service from the same address in %s", byIP, d.cfg.ClusterWindow))
+		}
+		if byDevice >= d.cfg.ClusterThreshold {
+			assessment.Add(model.SignalDeviceCluster, signalWeights[model.SignalDeviceCluster],
+				fmt.Sprintf("%d other accounts rated this service from the same device in %s", byDevice, d.cfg.ClusterWindow))
+		}
+	}
+
+	// The average is taken as the rater sees it, so it includes their rating even when it is shadowed
+	average, err := d.store.CalculateAverageRating(ctx, rating.ServiceID, model.ViewerOf(rating.UserID), model.RatingFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service average: %w", err)
+	}
+	if baseline, others, ok := averageWithout(average, rating.Score); ok && others >= d.cfg.DeviationMinRatings {
+		if deviation := math.Abs(float64(rating.Score) - baseline); deviation >= d.cfg.MaxDeviation {
+			assessment.Add(model.SignalScoreDeviation, signalWeights[model.SignalScoreDeviation],
+				fmt.Sprintf("score is %.1f stars from the service average of %.1f", deviation, baseline))
+		}
+	}
+
+	assessment.Flag = assessment.Score >= d.cfg.FlagThreshold
+	assessment.Quarantine = assessment.Flag && d.cfg.QuarantineThreshold > 0 && assessment.Score >= d.cfg.QuarantineThreshold
+	return assessment, nil
+}
+
+// averageWithout removes a score from an average that includes it, returning the average of the
+// other ratings and their count
+func averageWithout(average *model.AverageRating, score int) (float64, int, bool) {
+	others := average.TotalRatings - 1
+	if others <= 0 {
+		return 0, 0, false
+	}
+	return (average.AverageScore*float64(average.TotalRatings) - float64(score)) / float64(others), others, true
+}
@@ -0,0 +1,161 @@
+package brigading
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"rating-system/internal/domain/model"
+)
+
+// fakeStore serves a fixed rating history
+type fakeStore struct {
+	userCreated    time.Time
+	recentRatings  int
+	byIP, byDevice int
+	average        model.AverageRating
+	clusterCalls   int
+}
+
+func (f *fakeStore) GetUserByID(ctx context.Context, id uuid.UUID) (*model.User, error) {
+	return &model.User{ID: id, CreatedAt: f.userCreated}, nil
+}
+
+func (f *fakeStore) CountRatingsSince(ctx context.Context, serviceID uuid.UUID, since time.Time) (int, error) {
+	return f.recentRatings, nil
+}
+
+func (f *fakeStore) CountClusteredRaters(ctx context.Context, serviceID, userID uuid.UUID, client model.ClientInfo, since time.Time) (int, int, error) {
+	f.clusterCalls++
+	return f.byIP, f.byDevice, nil
+}
+
+func (f *fakeStore) CalculateAverageRating(ctx context.Context, serviceID uuid.UUID, viewer model.Viewer, filter model.RatingFilter) (*model.AverageRating, error) {
+	average := f.average
+	return &average, nil
+}
+
+var testNow = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+func newTestDetector(store Store, cfg Config) *Detector {
+	d := NewDetector(store, cfg)
+	d.now = func() time.Time { return testNow }
+	return d
+}
+
+func newTestRating(t *testing.T, score int) *model.Rating {
+	rating, err := model.NewRating(uuid.New(), uuid.New(), score)
+	require.NoError(t, err)
+	return rating
+}
+
+func signalsOf(assessment *model.RatingAssessment) []model.BrigadingSignal {
+	var signals []model.BrigadingSignal
+	for _, hit := range assessment.Signals {
+		signals = append(signals, hit.Signal)
+	}
+	return signals
+}
+
+func TestAssessGenuineRating(t *testing.T) {
+	store := &fakeStore{
+		userCreated:   testNow.Add(-365 * 24 * time.Hour),
+		recentRatings: 2,
+		average:       model.AverageRating{AverageScore: 4.2, TotalRatings: 50},
+	}
+
+	assessment, err := newTestDetector(store, Config{}).Assess(context.Background(), newTestRating(t, 4), model.ClientInfo{IP: "203.0.113.7"})
+	require.NoError(t, err)
+	assert.Empty(t, assessment.Signals)
+	assert.False(t, assessment.Flag)
+	assert.False(t, assessment.Quarantine)
+}
+
+func TestAssessBrigadingRating(t *testing.T) {
+	store := &fakeStore{
+		userCreated:   testNow.Add(-10 * time.Minute),
+		recentRatings: 40,
+		byIP:          5,
+		byDevice:      3,
+		// Fifty ratings averaging 4.8 plus this one-star rating
+		average: model.AverageRating{AverageScore: (4.8*50 + 1) / 51, TotalRatings: 51},
+	}
+
+	assessment, err := newTestDetector(store, Config{}).Assess(context.Background(), newTestRating(t, 1),
+		model.ClientInfo{IP: "203.0.113.7", DeviceID: "device-1"})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []model.BrigadingSignal{
+		model.SignalNewAccount,
+		model.SignalRatingBurst,
+		model.SignalIPCluster,
+		model.SignalDeviceCluster,
+		model.SignalScoreDeviation,
+	}, signalsOf(assessment))
+	assert.InDelta(t, 3.5, assessment.Score, 1e-9)
+	assert.True(t, assessment.Flag)
+	assert.False(t, assessment.Quarantine, "quarantine is disabled by default")
+}
+
+func TestAssessQuarantineThreshold(t *testing.T) {
+	store := &fakeStore{
+		userCreated: testNow.Add(-time.Hour),
+		byIP:        2,
+		average:     model.AverageRating{AverageScore: 3, TotalRatings: 1},
+	}
+	client := model.ClientInfo{IP: "203.0.113.7"}
+
+	assessment, err := newTestDetector(store, Config{QuarantineThreshold: 1.25}).Assess(context.Background(), newTestRating(t, 3), client)
+	require.NoError(t, err)
+	assert.Equal(t, []model.BrigadingSignal{model.SignalNewAccount, model.SignalIPCluster}, signalsOf(assessment))
+	assert.True(t, assessment.Flag)
+	assert.True(t, assessment.Quarantine)
+
+	assessment, err = newTestDetector(store, Config{QuarantineThreshold: 2}).Assess(context.Background(), newTestRating(t, 3), client)
+	require.NoError(t, err)
+	assert.True(t, assessment.Flag)
+	assert.False(t, assessment.Quarantine)
+}
+
+func TestAssessSkipsClustersWithoutClient(t *testing.T) {
+	store := &fakeStore{userCreated: testNow.Add(-365 * 24 * time.Hour), byIP: 10}
+
+	assessment, err := newTestDetector(store, Config{}).Assess(context.Background(), newTestRating(t, 5), model.ClientInfo{})
+	require.NoError(t, err)
+	assert.Empty(t, assessment.Signals)
+	assert.Zero(t, store.clusterCalls)
+}
+
+func TestAssessDeviationNeedsEstablishedAverage(t *testing.T) {
+	store := &fakeStore{
+		userCreated: testNow.Add(-365 * 24 * time.Hour),
+		// Three ratings averaging 5 plus this one-star rating
+		average: model.AverageRating{AverageScore: 4, TotalRatings: 4},
+	}
+
+	assessment, err := newTestDetector(store, Config{}).Assess(context.Background(), newTestRating(t, 1), model.ClientInfo{})
+	require.NoError(t, err)
+	assert.Empty(t, assessment.Signals)
+
+	assessment, err = newTestDetector(store, Config{DeviationMinRatings: 3}).Assess(context.Background(), newTestRating(t, 1), model.ClientInfo{})
+	require.NoError(t, err)
+	assert.Equal(t, []model.BrigadingSignal{model.SignalScoreDeviation}, signalsOf(assessment))
+	assert.Contains(t, assessment.Signals[0].Detail, "average of 5.0")
+}
+
+type failingStore struct{ fakeStore }
+
+func (f *failingStore) CountRatingsSince(ctx context.Context, serviceID uuid.UUID, since time.Time) (int, error) {
+	return 0, errors.New("connection reset")
+}
+
+func TestAssessStoreError(t *testing.T) {
+	store := &failingStore{fakeStore{userCreated: testNow}}
+
+	_, err := newTestDetector(store, Config{}).Assess(context.Background(), newTestRating(t, 5), model.ClientInfo{})
+	assert.ErrorContains(t, err, "connection reset")
+}
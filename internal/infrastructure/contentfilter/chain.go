@@ -0,0 +1,130 @@
+// Package contentfilter screens review and comment text for spam and abuse with a chain of
+// configurable filters.
+package contentfilter
+
+import (
+	"context"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"rating-system/internal/domain/model"
+)
+
+// Chain runs filters in order and combines their results, so content is rejected if any
+// filter rejects it and flagged if any filter flags it
+type Chain struct {
+	filters []Filter
+}
+
+// NewChain creates a chain of the given filters
+func NewChain(filters ...Filter) *Chain {
+	return &Chain{filters: filters}
+}
+
+// NewChainFromRules creates a chain of the filters enabled by the rules
+func NewChainFromRules(rules Rules) *Chain {
+	return NewChain(rules.Filters()...)
+}
+
+// Check implements port.ContentFilter
+func (c *Chain) Check(_ context.Context, text string) model.FilterResult {
+	result := model.Allow()
+	for _, filter := range c.filters {
+		result = result.Merge(filter.Check(text))
+	}
+	return result
+}
+
+// Reloader is a chain built from a rules file that is rebuilt when the file changes. If the
+// file becomes unreadable or invalid, the previous rules stay in force.
+type Reloader struct {
+	path    string
+	log     *logrus.Logger
+	mu      sync.RWMutex
+	chain   *Chain
+	modTime time.Time
+}
+
+// NewReloader loads the rules file and creates a chain from it
+func NewReloader(path string, log *logrus.Logger) (*Reloader, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Reloader{path: path, log: log}
+	if err := r.load(info.ModTime()); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Check implements port.ContentFilter with the current rules
+func (r *Reloader) Check(ctx context.Context, text string) model.FilterResult {
+	r.mu.RLock()
+	chain := r.chain
+	r.mu.RUnlock()
+	return chain.Check(ctx, text)
+}
+
+// Reload rebuilds the chain if the rules file was modified since it was last read, reporting
+// whether the rules changed. An invalid file is only reported once per modification.
+func (r *Reloader) Reload() (bool, error) {
+	info, err := os.Stat(r.path)
+	if err != nil {
+		return false, err
+	}
+
+	r.mu.Lock()
+	unchanged := info.ModTime().Equal(r.modTime)
+	r.modTime = info.ModTime()
+	r.mu.Unlock()
+	if unchanged {
+		return false, nil
+	}
+
+	if err := r.load(info.ModTime()); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Watch checks the rules file for changes every interval until the context is cancelled
+func (r *Reloader) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			reloaded, err := r.Reload()
+			if err != nil {
+				r.log.WithError(err).WithField("path", r.path).Error("Failed to reload content filter rules; keeping previous rules")
+				continue
+			}
+			if reloaded {
+				r.log.WithField("path", r.path).Info("Reloaded content filter rules")
+			}
+		}
+	}
+}
+
+// load reads the rules file, last modified at modTime, and swaps in a chain built from it
+func (r *Reloader) load(modTime time.Time) error {
+	rules, err := LoadRules(r.path)
+	if err != nil {
+		return err
+	}
+
+	chain := NewChainFromRules(rules)
+	r.mu.Lock()
+	r.chain = chain
+	r.modTime = modTime
+	r.mu.Unlock()
+	return nil
+}
@@ -0,0 +1,81 @@
+package contentfilter
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"rating-system/internal/domain/model"
+)
+
+func TestChainCombinesResults(t *testing.T) {
+	chain := NewChain(NewWordList(nil, []string{"refund"}), LinkLimit{Max: 1})
+
+	result := chain.Check(context.Background(), "Refund please")
+	assert.Equal(t, model.FilterFlag, result.Outcome)
+
+	result = chain.Check(context.Background(), "refund http://a.example http://b.example")
+	assert.Equal(t, model.FilterReject, result.Outcome)
+	assert.Len(t, result.Reasons, 2)
+	assert.ErrorIs(t, result.Err(), model.ErrContentRejected)
+}
+
+func TestLoadRulesKeepsDefaults(t *testing.T) {
+	path := writeRules(t, `{"reject_words": ["scam"]}`, time.Now())
+
+	rules, err := LoadRules(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"scam"}, rules.RejectWords)
+	assert.Equal(t, DefaultRules().MaxLinks, rules.MaxLinks)
+
+	_, err = LoadRules(writeRules(t, `{"max_caps_ratio": 2}`, time.Now()))
+	assert.Error(t, err)
+}
+
+func TestReloaderPicksUpChanges(t *testing.T) {
+	modTime := time.Now().Add(-time.Hour)
+	path := writeRules(t, `{"reject_words": ["scam"]}`, modTime)
+
+	reloader, err := NewReloader(path, logrus.New())
+	require.NoError(t, err)
+	ctx := context.Background()
+	assert.Equal(t, model.FilterReject, reloader.Check(ctx, "a scam").Outcome)
+
+	reloaded, err := reloader.Reload()
+	require.NoError(t, err)
+	assert.False(t, reloaded, "unmodified file is not reloaded")
+
+	modTime = modTime.Add(time.Minute)
+	writeRulesAt(t, path, `{"flag_words": ["scam"]}`, modTime)
+	reloaded, err = reloader.Reload()
+	require.NoError(t, err)
+	assert.True(t, reloaded)
+	assert.Equal(t, model.FilterFlag, reloader.Check(ctx, "a scam").Outcome)
+
+	modTime = modTime.Add(time.Minute)
+	writeRulesAt(t, path, `{not json`, modTime)
+	_, err = reloader.Reload()
+	assert.Error(t, err)
+	assert.Equal(t, model.FilterFlag, reloader.Check(ctx, "a scam").Outcome, "invalid rules keep the previous chain")
+
+	reloaded, err = reloader.Reload()
+	assert.NoError(t, err, "an invalid file is only reported once")
+	assert.False(t, reloaded)
+}
+
+func writeRules(t *testing.T, content string, modTime time.Time) string {
+	path := filepath.Join(t.TempDir(), "rules.json")
+	writeRulesAt(t, path, content, modTime)
+	return path
+}
+
+func writeRulesAt(t *testing.T, path, content string, modTime time.Time) {
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+}
@@ -0,0 +1,220 @@
+package contentfilter
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"rating-system/internal/domain/model"
+)
+
+// leet maps characters commonly substituted for letters back to the letters
+var leet = map[rune]rune{
+	'0': 'o', '1': 'i', '3': 'e', '4': 'a', '5': 's', '7': 't', '8': 'b', '9': 'g',
+	'@': 'a', '$': 's', '!': 'i', '|': 'l', '+': 't',
+}
+
+// punctuationLeet lists leet characters that are also common punctuation; they only stand for a
+// letter when another letter or digit follows, so "scam!" is not read as "scami"
+var punctuationLeet = map[rune]bool{'!': true, '|': true, '+': true}
+
+// Normalize lower-cases text and undoes leet-speak substitutions, so "Sp4m" becomes "spam"
+func Normalize(text string) string {
+	runes := []rune(text)
+	for i, r := range runes {
+		letter, ok := leet[r]
+		if ok && punctuationLeet[r] && (i+1 == len(runes) || !isWordRune(runes[i+1])) {
+			ok = false
+		}
+		if ok {
+			runes[i] = letter
+		} else {
+			runes[i] = unicode.ToLower(r)
+		}
+	}
+	return string(runes)
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// words splits normalized text into runs of letters
+func words(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool { return !unicode.IsLetter(r) })
+}
+
+// squeeze collapses runs of the same letter, so "spaaam" becomes "spam"
+func squeeze(word string) string {
+	var b strings.Builder
+	var last rune
+	for i, r := range word {
+		if i == 0 || r != last {
+			b.WriteRune(r)
+		}
+		last = r
+	}
+	return b.String()
+}
+
+// WordList rejects or flags content containing listed words or phrases. Matching ignores case,
+// undoes leet-speak and tolerates letters repeated to dodge the list.
+type WordList struct {
+	reject []string
+	flag   []string
+}
+
+// NewWordList creates a word list from the words that cause rejection and those that cause flagging
+func NewWordList(reject, flag []string) *WordList {
+	return &WordList{reject: normalizeList(reject), flag: normalizeList(flag)}
+}
+
+func normalizeList(list []string) []string {
+	var normalized []string
+	for _, entry := range list {
+		if entry = strings.Join(words(Normalize(entry)), " "); entry != "" {
+			normalized = append(normalized, entry)
+		}
+	}
+	return normalized
+}
+
+// Check implements Filter
+func (w *WordList) Check(text string) model.FilterResult {
+	tokens := words(Normalize(text))
+	result := model.Allow()
+	for _, entry := range w.reject {
+		if containsEntry(tokens, entry) {
+			result = result.Merge(model.Reject(fmt.Sprintf("contains blocked word %q", entry)))
+		}
+	}
+	for _, entry := range w.flag {
+		if containsEntry(tokens, entry) {
+			result = result.Merge(model.Flag(fmt.Sprintf("contains watched word %q", entry)))
+		}
+	}
+	return result
+}
+
+// containsEntry reports whether the tokens contain a word, or a phrase as consecutive tokens
+func containsEntry(tokens []string, entry string) bool {
+	if strings.Contains(entry, " ") {
+		return strings.Contains(" "+strings.Join(tokens, " ")+" ", " "+entry+" ")
+	}
+	for _, token := range tokens {
+		if matchesWord(token, entry) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesWord compares a token to a listed word. A token with letters repeated beyond the
+// word's own spelling still matches, but a shorter token does not, so "as" does not match "ass".
+func matchesWord(token, word string) bool {
+	return token == word || (len(token) > len(word) && squeeze(token) == squeeze(word))
+}
+
+// linkPattern matches URLs with a scheme and bare www. addresses
+var linkPattern = regexp.MustCompile(`(?i)\bhttps?://[^\s<>()\[\]]+|\bwww\.[^\s<>()\[\]]+`)
+
+// LinkLimit rejects content containing more than Max links
+type LinkLimit struct {
+	Max int
+}
+
+// Check implements Filter
+func (l LinkLimit) Check(text string) model.FilterResult {
+	if count := len(linkPattern.FindAllString(text, -1)); count > l.Max {
+		return model.Reject(fmt.Sprintf("contains %d links; at most %d are allowed", count, l.Max))
+	}
+	return model.Allow()
+}
+
+// hostPattern matches domain names, with or without a scheme
+var hostPattern = regexp.MustCompile(`(?i)\b(?:[a-z0-9](?:[a-z0-9-]*[a-z0-9])?\.)+[a-z]{2,}\b`)
+
+// BannedDomains rejects content mentioning a banned domain or one of its subdomains
+type BannedDomains struct {
+	domains []string
+}
+
+// NewBannedDomains creates a banned-domain filter
+func NewBannedDomains(domains []string) *BannedDomains {
+	var normalized []string
+	for _, domain := range domains {
+		if domain = strings.Trim(strings.ToLower(strings.TrimSpace(domain)), "."); domain != "" {
+			normalized = append(normalized, domain)
+		}
+	}
+	return &BannedDomains{domains: normalized}
+}
+
+// Check implements Filter
+func (d *BannedDomains) Check(text string) model.FilterResult {
+	result := model.Allow()
+	reported := make(map[string]bool)
+	for _, host := range hostPattern.FindAllString(text, -1) {
+		host = strings.ToLower(host)
+		for _, domain := range d.domains {
+			if (host == domain || strings.HasSuffix(host, "."+domain)) && !reported[domain] {
+				reported[domain] = true
+				result = result.Merge(model.Reject(fmt.Sprintf("links to banned domain %s", domain)))
+			}
+		}
+	}
+	return result
+}
+
+// Shouting flags content with long runs of one character or written mostly in capitals
+type Shouting struct {
+	MaxRepeatedChars int
+	MaxCapsRatio     float64
+	MinCapsLetters   int
+}
+
+// Check implements Filter
+func (s Shouting) Check(text string) model.FilterResult {
+	result := model.Allow()
+
+	if s.MaxRepeatedChars > 0 {
+		if run := longestRun(text); run > s.MaxRepeatedChars {
+			result = result.Merge(model.Flag(fmt.Sprintf("repeats a character %d times", run)))
+		}
+	}
+
+	if s.MaxCapsRatio > 0 {
+		letters, upper := 0, 0
+		for _, r := range text {
+			if unicode.IsLetter(r) {
+				letters++
+				if unicode.IsUpper(r) {
+					upper++
+				}
+			}
+		}
+		if letters > 0 && letters >= s.MinCapsLetters && float64(upper)/float64(letters) > s.MaxCapsRatio {
+			result = result.Merge(model.Flag("written mostly in capital letters"))
+		}
+	}
+	return result
+}
+
+// longestRun returns the length of the longest run of one non-space character
+func longestRun(text string) int {
+	longest, run := 0, 0
+	var last rune
+	for _, r := range text {
+		if r == last && !unicode.IsSpace(r) {
+			run++
+		} else {
+			run = 1
+		}
+		last = r
+		if run > longest {
+			longest = run
+		}
+	}
+	return longest
+}
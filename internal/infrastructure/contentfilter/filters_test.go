@@ -0,0 +1,77 @@
+package contentfilter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"rating-system/internal/domain/model"
+)
+
+func TestNormalize(t *testing.T) {
+	assert.Equal(t, "spam", Normalize("Sp4m"))
+	assert.Equal(t, "free money", Normalize("FR33 m0n3y"))
+	assert.Equal(t, "sh!", Normalize("Sh!"), "trailing punctuation is kept")
+	assert.Equal(t, "shit", Normalize("sh!t"))
+}
+
+func TestWordList(t *testing.T) {
+	list := NewWordList([]string{"scam", "buy now"}, []string{"ass"})
+
+	tests := []struct {
+		name    string
+		text    string
+		outcome model.FilterOutcome
+	}{
+		{"clean", "Friendly staff and fair prices", model.FilterAllow},
+		{"plain word", "This place is a scam", model.FilterReject},
+		{"leet speak", "total $c4m!", model.FilterReject},
+		{"repeated letters", "scaaaam alert", model.FilterReject},
+		{"phrase", "Buy   NOW while it lasts", model.FilterReject},
+		{"phrase words apart", "buy it now", model.FilterAllow},
+		{"flag word", "what an a$$", model.FilterFlag},
+		{"shorter token", "as good as it gets", model.FilterAllow},
+		{"substring", "first class service", model.FilterAllow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := list.Check(tt.text)
+			assert.Equal(t, tt.outcome, result.Outcome)
+			if tt.outcome != model.FilterAllow {
+				assert.NotEmpty(t, result.Reasons)
+			}
+		})
+	}
+}
+
+func TestLinkLimit(t *testing.T) {
+	limit := LinkLimit{Max: 2}
+
+	assert.Equal(t, model.FilterAllow, limit.Check("See https://a.example and [docs](http://b.example/x)").Outcome)
+
+	result := limit.Check("https://a.example https://b.example www.c.example")
+	assert.Equal(t, model.FilterReject, result.Outcome)
+	assert.Equal(t, []string{"contains 3 links; at most 2 are allowed"}, result.Reasons)
+}
+
+func TestBannedDomains(t *testing.T) {
+	banned := NewBannedDomains([]string{"Spam.example", " "})
+
+	assert.Equal(t, model.FilterAllow, banned.Check("Visit https://notspam.example or good.example").Outcome)
+	assert.Equal(t, model.FilterReject, banned.Check("Go to spam.example now").Outcome)
+
+	result := banned.Check("https://shop.spam.example/a and http://spam.example/b")
+	assert.Equal(t, model.FilterReject, result.Outcome)
+	assert.Len(t, result.Reasons, 1, "each domain is reported once")
+}
+
+func TestShouting(t *testing.T) {
+	shouting := Shouting{MaxRepeatedChars: 4, MaxCapsRatio: 0.7, MinCapsLetters: 10}
+
+	assert.Equal(t, model.FilterAllow, shouting.Check("Great service, would book again!").Outcome)
+	assert.Equal(t, model.FilterAllow, shouting.Check("OK").Outcome, "short text is not checked for capitals")
+	assert.Equal(t, model.FilterAllow, shouting.Check("wait     what").Outcome, "whitespace runs are ignored")
+	assert.Equal(t, model.FilterFlag, shouting.Check("Sooooooo good").Outcome)
+	assert.Equal(t, model.FilterFlag, shouting.Check("THIS IS THE WORST PLACE EVER").Outcome)
+}
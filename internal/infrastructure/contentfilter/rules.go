@@ -0,0 +1,81 @@
+package contentfilter
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"rating-system/internal/domain/model"
+)
+
+// Rules configures the filters of a chain. A zero limit disables the corresponding check.
+type Rules struct {
+	// RejectWords are words that cause content to be rejected
+	RejectWords []string `json:"reject_words"`
+	// FlagWords are words that cause content to be flagged for a moderator
+	FlagWords []string `json:"flag_words"`
+	// MaxLinks is the maximum number of links content can contain before it is rejected
+	MaxLinks int `json:"max_links"`
+	// BannedDomains are domains, including their subdomains, that content cannot mention
+	BannedDomains []string `json:"banned_domains"`
+	// MaxRepeatedChars is the longest run of one character allowed before content is flagged
+	MaxRepeatedChars int `json:"max_repeated_chars"`
+	// MaxCapsRatio is the largest share of upper-case letters allowed before content is flagged
+	MaxCapsRatio float64 `json:"max_caps_ratio"`
+	// MinCapsLetters is the number of letters content needs before the caps ratio is checked
+	MinCapsLetters int `json:"min_caps_letters"`
+}
+
+// DefaultRules returns the rules used when no configuration file is given
+func DefaultRules() Rules {
+	return Rules{
+		MaxLinks:         3,
+		MaxRepeatedChars: 8,
+		MaxCapsRatio:     0.8,
+		MinCapsLetters:   20,
+	}
+}
+
+// LoadRules reads rules from a JSON file. Settings missing from the file keep their defaults.
+func LoadRules(path string) (Rules, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Rules{}, fmt.Errorf("failed to read content filter rules: %w", err)
+	}
+
+	rules := DefaultRules()
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return Rules{}, fmt.Errorf("failed to parse content filter rules: %w", err)
+	}
+	if rules.MaxCapsRatio < 0 || rules.MaxCapsRatio > 1 {
+		return Rules{}, fmt.Errorf("max_caps_ratio must be between 0 and 1, got %v", rules.MaxCapsRatio)
+	}
+	return rules, nil
+}
+
+// Filter is one check of a chain
+type Filter interface {
+	Check(text string) model.FilterResult
+}
+
+// Filters builds the filters enabled by the rules
+func (r Rules) Filters() []Filter {
+	var filters []Filter
+	if len(r.RejectWords) > 0 || len(r.FlagWords) > 0 {
+		filters = append(filters, NewWordList(r.RejectWords, r.FlagWords))
+	}
+	if r.MaxLinks > 0 {
+		filters = append(filters, LinkLimit{Max: r.MaxLinks})
+	}
+	if len(r.BannedDomains) > 0 {
+		filters = append(filters, NewBannedDomains(r.BannedDomains))
+	}
+	if r.MaxRepeatedChars > 0 || r.MaxCapsRatio > 0 {
+		filters = append(filters, Shouting{
+			MaxRepeatedChars: r.MaxRepeatedChars,
+			MaxCapsRatio:     r.MaxCapsRatio,
+			MinCapsLetters:   r.MinCapsLetters,
+		})
+	}
+	return filters
+}
@@ -0,0 +1,149 @@
+// Package duplicates finds reviews whose text nearly matches reviews already posted from other
+// accounts. Review text is broken into character shingles and summarised by a MinHash signature;
+// signatures are split into bands for locality-sensitive hashing so candidates are found by
+// index lookups rather than by comparing every stored review.
+package duplicates
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"rating-system/internal/domain/model"
+)
+
+// Store provides the stored signatures the detector compares against
+type Store interface {
+	// FindReviewSignatures returns signatures sharing at least one band hash, leaving out those of
+	// the given user and, if a service is given, those of other services
+	FindReviewSignatures(ctx context.Context, bands []int64, excludeUserID uuid.UUID, serviceID *uuid.UUID, limit int) ([]*model.ReviewSignature, error)
+}
+
+// Config tunes the detector
+type Config struct {
+	// Threshold is the estimated similarity, from 0 to 1, at which a review counts as a duplicate
+	Threshold float64
+	// Action is what happens to a duplicate: flagged for moderators or rejected
+	Action model.FilterOutcome
+	// Scope is whether duplicates are looked for across all services or within one
+	Scope model.DuplicateScope
+	// MinLength is the normalised length in characters below which text is not checked, since
+	// short reviews such as "great service" are legitimately repeated
+	MinLength int
+	// MaxCandidates caps the stored signatures compared per review
+	MaxCandidates int
+}
+
+// DefaultConfig returns the default configuration, which flags duplicates across all services
+func DefaultConfig() Config {
+	return Config{
+		Threshold:     0.8,
+		Action:        model.FilterFlag,
+		Scope:         model.DuplicateScopeGlobal,
+		MinLength:     80,
+		MaxCandidates: 100,
+	}
+}
+
+// Detector checks reviews for near-duplicates
+type Detector struct {
+	store Store
+	cfg   Config
+	now   func() time.Time
+}
+
+// NewDetector creates a detector; invalid or zero config values fall back to the defaults
+func NewDetector(store Store, cfg Config) *Detector {
+	defaults := DefaultConfig()
+	if cfg.Threshold <= 0 || cfg.Threshold > 1 {
+		cfg.Threshold = defaults.Threshold
+	}
+	if cfg.Action != model.FilterFlag && cfg.Action != model.FilterReject {
+		cfg.Action = defaults.Action
+	}
+	if !cfg.Scope.IsValid() {
+		cfg.Scope = defaults.Scope
+	}
+	if cfg.MinLength <= 0 {
+		cfg.MinLength = defaults.MinLength
+	}
+	if cfg.MaxCandidates <= 0 {
+		cfg.MaxCandidates = defaults.MaxCandidates
+	}
+
+	return &Detector{store: store, cfg: cfg, now: time.Now}
+}
+
+// Check implements port.DuplicateDetector. The returned signature should be stored once the
+// review is saved so later reviews are compared against it.
+func (d *Detector) Check(ctx context.Context, review *model.Review) (*model.DuplicateCheck, error) {
+	check := &model.DuplicateCheck{Result: model.Allow()}
+
+	text := normalize(review.Content)
+	if utf8.RuneCountInString(text) < d.cfg.MinLength {
+		return check, nil
+	}
+
+	signature := &model.ReviewSignature{
+		ReviewID:  review.ID,
+		UserID:    review.UserID,
+		ServiceID: review.ServiceID,
+		MinHash:   minHash(shingles(text)),
+		CreatedAt: d.now(),
+	}
+	signature.Bands = bands(signature.MinHash)
+	check.Signature = signature
+
+	var serviceID *uuid.UUID
+	if d.cfg.Scope == model.DuplicateScopeService {
+		serviceID = &review.ServiceID
+	}
+	candidates, err := d.store.FindReviewSignatures(ctx, signature.Bands, review.UserID, serviceID, d.cfg.MaxCandidates)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find similar reviews: %w", err)
+	}
+
+	for _, candidate := range candidates {
+		similarity := signature.MinHash.Similarity(candidate.MinHash)
+		if similarity < d.cfg.Threshold {
+			continue
+		}
+		check.Matches = append(check.Matches, model.DuplicateMatch{
+			ReviewID:   candidate.ReviewID,
+			UserID:     candidate.UserID,
+			ServiceID:  candidate.ServiceID,
+			ClusterID:  candidate.ClusterID,
+			Similarity: similarity,
+		})
+	}
+	if len(check.Matches) == 0 {
+		return check, nil
+	}
+
+	sort.SliceStable(check.Matches, func(i, j int) bool {
+		return check.Matches[i].Similarity > check.Matches[j].Similarity
+	})
+
+	// The review joins the cluster of the review it most resembles, which starts one if needed
+	best := check.Matches[0]
+	signature.ClusterID = best.ClusterID
+	if signature.ClusterID == nil {
+		signature.ClusterID = &best.ReviewID
+	}
+	signature.Similarity = best.Similarity
+
+	reason := fmt.Sprintf("text is %.0f%% similar to a review from another account", best.Similarity*100)
+	if len(check.Matches) > 1 {
+		reason = fmt.Sprintf("text is up to %.0f%% similar to %d reviews from other accounts", best.Similarity*100, len(check.Matches))
+	}
+	if d.cfg.Action == model.FilterReject {
+		check.Result = model.Reject(reason)
+	} else {
+		check.Result = model.Flag(reason)
+	}
+	return check, nil
+}
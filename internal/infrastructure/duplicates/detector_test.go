@@ -0,0 +1,159 @@
+package duplicates
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"rating-system/internal/domain/model"
+)
+
+// fakeStore keeps signatures in memory and looks candidates up by band like the repositories
+type fakeStore struct {
+	signatures []*model.ReviewSignature
+}
+
+func (f *fakeStore) FindReviewSignatures(ctx context.Context, bands []int64, excludeUserID uuid.UUID, serviceID *uuid.UUID, limit int) ([]*model.ReviewSignature, error) {
+	wanted := make(map[int64]bool, len(bands))
+	for _, b := range bands {
+		wanted[b] = true
+	}
+
+	var found []*model.ReviewSignature
+	for _, sig := range f.signatures {
+		if sig.UserID == excludeUserID || (serviceID != nil && sig.ServiceID != *serviceID) {
+			continue
+		}
+		for _, b := range sig.Bands {
+			if wanted[b] {
+				found = append(found, sig)
+				break
+			}
+		}
+		if len(found) == limit {
+			break
+		}
+	}
+	return found, nil
+}
+
+const sampleReview = "Absolutely the best plumbing company in town. They arrived within the hour, fixed the leak " +
+	"under our sink and cleaned up afterwards. Fair prices and friendly staff, highly recommended!"
+
+var testNow = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+func newTestDetector(store Store, cfg Config) *Detector {
+	d := NewDetector(store, cfg)
+	d.now = func() time.Time { return testNow }
+	return d
+}
+
+func newTestReview(t *testing.T, userID, serviceID uuid.UUID, content string) *model.Review {
+	review, err := model.NewReview(userID, serviceID, uuid.New(), "Review", content)
+	require.NoError(t, err)
+	return review
+}
+
+// post checks a review and stores its signature, as the rating service does once it is saved
+func post(t *testing.T, d *Detector, store *fakeStore, review *model.Review) *model.DuplicateCheck {
+	check, err := d.Check(context.Background(), review)
+	require.NoError(t, err)
+	if check.Signature != nil {
+		store.signatures = append(store.signatures, check.Signature)
+	}
+	return check
+}
+
+func TestNormalize(t *testing.T) {
+	assert.Equal(t, "great food 10 10", normalize("  **Great** food!!! 10/10 "))
+	assert.Equal(t, "", normalize("!!!"))
+}
+
+func TestMinHashEstimatesSimilarity(t *testing.T) {
+	a := minHash(shingles(normalize(sampleReview)))
+	b := minHash(shingles(normalize("absolutely the BEST plumbing company in town - they arrived within the hour, fixed the leak " +
+		"under our sink and cleaned up afterwards. fair prices, friendly staff. highly recommended")))
+	c := minHash(shingles(normalize("Slow to respond and the electrician left a mess in the kitchen. We had to call " +
+		"another company to finish the wiring, would not book again.")))
+
+	assert.Greater(t, a.Similarity(b), 0.9)
+	assert.Less(t, a.Similarity(c), 0.2)
+	assert.Len(t, bands(a), numBands)
+}
+
+func TestCheckFlagsCopiesFromOtherAccounts(t *testing.T) {
+	store := &fakeStore{}
+	d := newTestDetector(store, DefaultConfig())
+
+	first := newTestReview(t, uuid.New(), uuid.New(), sampleReview)
+	check := post(t, d, store, first)
+	assert.Equal(t, model.FilterAllow, check.Result.Outcome)
+	require.NotNil(t, check.Signature)
+	assert.Nil(t, check.Signature.ClusterID)
+
+	// A lightly edited copy on another service from another account joins a cluster started by the original
+	copied := newTestReview(t, uuid.New(), uuid.New(), sampleReview+" Five stars.")
+	check = post(t, d, store, copied)
+	assert.Equal(t, model.FilterFlag, check.Result.Outcome)
+	require.Len(t, check.Matches, 1)
+	assert.Equal(t, first.ID, check.Matches[0].ReviewID)
+	require.NotNil(t, check.Signature.ClusterID)
+	assert.Equal(t, first.ID, *check.Signature.ClusterID)
+	assert.Greater(t, check.Signature.Similarity, 0.8)
+
+	// A third copy joins the same cluster through whichever member it matches best
+	check = post(t, d, store, newTestReview(t, uuid.New(), uuid.New(), sampleReview))
+	assert.Len(t, check.Matches, 2)
+	assert.Equal(t, first.ID, *check.Signature.ClusterID)
+	assert.Contains(t, check.Result.Reasons[0], "2 reviews")
+}
+
+func TestCheckIgnoresOwnReviewsAndShortText(t *testing.T) {
+	store := &fakeStore{}
+	d := newTestDetector(store, DefaultConfig())
+	userID := uuid.New()
+
+	post(t, d, store, newTestReview(t, userID, uuid.New(), sampleReview))
+	check := post(t, d, store, newTestReview(t, userID, uuid.New(), sampleReview))
+	assert.Equal(t, model.FilterAllow, check.Result.Outcome)
+	assert.Empty(t, check.Matches)
+
+	post(t, d, store, newTestReview(t, uuid.New(), uuid.New(), "Great service, would recommend!"))
+	check = post(t, d, store, newTestReview(t, uuid.New(), uuid.New(), "Great service, would recommend!"))
+	assert.Equal(t, model.FilterAllow, check.Result.Outcome)
+	assert.Nil(t, check.Signature)
+}
+
+func TestCheckServiceScopeAndReject(t *testing.T) {
+	store := &fakeStore{}
+	cfg := DefaultConfig()
+	cfg.Scope = model.DuplicateScopeService
+	cfg.Action = model.FilterReject
+	d := newTestDetector(store, cfg)
+	serviceID := uuid.New()
+
+	post(t, d, store, newTestReview(t, uuid.New(), serviceID, sampleReview))
+
+	check := post(t, d, store, newTestReview(t, uuid.New(), uuid.New(), sampleReview))
+	assert.Equal(t, model.FilterAllow, check.Result.Outcome)
+
+	check, err := d.Check(context.Background(), newTestReview(t, uuid.New(), serviceID, sampleReview))
+	require.NoError(t, err)
+	assert.Equal(t, model.FilterReject, check.Result.Outcome)
+	assert.Error(t, check.Result.Err())
+}
+
+func TestCheckThreshold(t *testing.T) {
+	store := &fakeStore{}
+	cfg := DefaultConfig()
+	cfg.Threshold = 0.99
+	d := newTestDetector(store, cfg)
+
+	post(t, d, store, newTestReview(t, uuid.New(), uuid.New(), sampleReview))
+	check := post(t, d, store, newTestReview(t, uuid.New(), uuid.New(), sampleReview+" Will definitely use them again next time."))
+	assert.Equal(t, model.FilterAllow, check.Result.Outcome)
+}
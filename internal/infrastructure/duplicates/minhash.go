@@ -0,0 +1,115 @@
+package duplicates
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"strings"
+	"unicode"
+
+	"rating-system/internal/domain/model"
+)
+
+// Signature parameters. Signatures are stored, so changing these invalidates every stored
+// signature. With 32 bands of 4 rows, texts about 42% similar have even odds of sharing a band,
+// and texts 80% similar almost always do.
+const (
+	shingleSize = 5
+	numHashes   = 128
+	numBands    = 32
+	bandRows    = numHashes / numBands
+)
+
+// hashSeeds holds the fixed seed of each MinHash function
+var hashSeeds = func() [numHashes]uint64 {
+	var seeds [numHashes]uint64
+	state := uint64(0x5eed)
+	for i := range seeds {
+		state += 0x9e3779b97f4a7c15
+		seeds[i] = mix(state)
+	}
+	return seeds
+}()
+
+// normalize lowercases text and reduces it to letters and digits separated by single spaces, so
+// punctuation, formatting and spacing changes do not hide a copy
+func normalize(text string) string {
+	var b strings.Builder
+	space := false
+	for _, r := range strings.ToLower(text) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if space && b.Len() > 0 {
+				b.WriteByte(' ')
+			}
+			b.WriteRune(r)
+			space = false
+			continue
+		}
+		space = true
+	}
+	return b.String()
+}
+
+// shingles hashes every run of shingleSize characters of normalised text
+func shingles(text string) map[uint64]struct{} {
+	runes := []rune(text)
+	set := make(map[uint64]struct{})
+	if len(runes) < shingleSize {
+		if len(runes) > 0 {
+			set[hashString(string(runes))] = struct{}{}
+		}
+		return set
+	}
+	for i := 0; i+shingleSize <= len(runes); i++ {
+		set[hashString(string(runes[i:i+shingleSize]))] = struct{}{}
+	}
+	return set
+}
+
+// minHash computes the MinHash signature of a set of shingle hashes
+func minHash(set map[uint64]struct{}) model.MinHash {
+	sig := make(model.MinHash, numHashes)
+	for i := range sig {
+		sig[i] = ^uint32(0)
+	}
+	for shingle := range set {
+		for i, seed := range hashSeeds {
+			if h := uint32(mix(shingle^seed) >> 32); h < sig[i] {
+				sig[i] = h
+			}
+		}
+	}
+	return sig
+}
+
+// bands hashes each band of rows of a signature for locality-sensitive hashing. The band index
+// is part of the hash, so equal rows in different bands do not collide.
+func bands(sig model.MinHash) []int64 {
+	hashes := make([]int64, 0, numBands)
+	for band := 0; band < numBands; band++ {
+		buf := []byte{byte(band)}
+		for _, v := range sig[band*bandRows : (band+1)*bandRows] {
+			buf = binary.BigEndian.AppendUint32(buf, v)
+		}
+		h := fnv.New64a()
+		h.Write(buf)
+		hashes = append(hashes, int64(h.Sum64()))
+	}
+	return hashes
+}
+
+// hashString returns the 64-bit FNV-1a hash of a string
+func hashString(s string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(s))
+	return h.Sum64()
+}
+
+// mix is the SplitMix64 finaliser, spreading the bits of its input
+func mix(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
@@ -0,0 +1,106 @@
+package events
+
+import (
+	"context"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+
+	"rating-system/internal/domain/model"
+)
+
+// Handler handles a delivered event
+type Handler func(ctx context.Context, event model.Event)
+
+// Bus is an in-process, asynchronous event bus. Events are queued and handled by a
+// fixed pool of workers; when the queue is full, events are dropped and logged.
+type Bus struct {
+	queue    chan model.Event
+	mu       sync.RWMutex
+	handlers map[string][]Handler
+	closed   bool
+	log      *logrus.Logger
+	wg       sync.WaitGroup
+}
+
+// NewBus creates a bus with the given queue size and number of workers and starts the workers
+func NewBus(queueSize, workers int, log *logrus.Logger) *Bus {
+	if queueSize <= 0 {
+		queueSize = 1024
+	}
+	if workers <= 0 {
+		workers = 1
+	}
+
+	b := &Bus{
+		queue:    make(chan model.Event, queueSize),
+		handlers: make(map[string][]Handler),
+		log:      log,
+	}
+	for i := 0; i < workers; i++ {
+		b.wg.Add(1)
+		go b.work()
+	}
+	return b
+}
+
+// Subscribe registers a handler for an event type
+func (b *Bus) Subscribe(eventType string, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[eventType] = append(b.handlers[eventType], handler)
+}
+
+// Publish queues the event for delivery. It never blocks the caller.
+func (b *Bus) Publish(ctx context.Context, event model.Event) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if b.closed {
+		b.log.WithField("event", event.EventType()).Warn("Event bus closed, dropping event")
+		return
+	}
+
+	select {
+	case b.queue <- event:
+	default:
+		b.log.WithField("event", event.EventType()).Warn("Event queue full, dropping event")
+	}
+}
+
+// Close stops accepting events, drains the queue and waits for the workers to finish
+func (b *Bus) Close() {
+	b.mu.Lock()
+	if !b.closed {
+		b.closed = true
+		close(b.queue)
+	}
+	b.mu.Unlock()
+
+	b.wg.Wait()
+}
+
+func (b *Bus) work() {
+	defer b.wg.Done()
+	for event := range b.queue {
+		b.dispatch(event)
+	}
+}
+
+// dispatch runs the event's handlers, isolating the worker from handler panics
+func (b *Bus) dispatch(event model.Event) {
+	b.mu.RLock()
+	handlers := b.handlers[event.EventType()]
+	b.mu.RUnlock()
+
+	for _, handler := range handlers {
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					b.log.WithField("event", event.EventType()).Errorf("Event handler panicked: %v", r)
+				}
+			}()
+			handler(context.Background(), event)
+		}()
+	}
+}
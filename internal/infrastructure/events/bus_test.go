@@ -0,0 +1,68 @@
+package events
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"rating-system/internal/domain/model"
+)
+
+func newTestBus(queueSize, workers int) *Bus {
+	log := logrus.New()
+	log.SetLevel(logrus.PanicLevel)
+	return NewBus(queueSize, workers, log)
+}
+
+func TestBusDeliversToSubscribers(t *testing.T) {
+	bus := newTestBus(16, 2)
+
+	var delivered, other int32
+	bus.Subscribe(model.EventTypeReviewPublished, func(ctx context.Context, event model.Event) {
+		if _, ok := event.(model.ReviewPublishedEvent); ok {
+			atomic.AddInt32(&delivered, 1)
+		}
+	})
+	bus.Subscribe("other", func(ctx context.Context, event model.Event) {
+		atomic.AddInt32(&other, 1)
+	})
+
+	for i := 0; i < 5; i++ {
+		bus.Publish(context.Background(), model.ReviewPublishedEvent{ReviewID: uuid.New()})
+	}
+	bus.Close()
+
+	assert.Equal(t, int32(5), atomic.LoadInt32(&delivered))
+	assert.Equal(t, int32(0), atomic.LoadInt32(&other))
+}
+
+func TestBusSurvivesHandlerPanic(t *testing.T) {
+	bus := newTestBus(16, 1)
+
+	var delivered int32
+	bus.Subscribe(model.EventTypeReviewPublished, func(ctx context.Context, event model.Event) {
+		panic("boom")
+	})
+	bus.Subscribe(model.EventTypeReviewPublished, func(ctx context.Context, event model.Event) {
+		atomic.AddInt32(&delivered, 1)
+	})
+
+	bus.Publish(context.Background(), model.ReviewPublishedEvent{})
+	bus.Publish(context.Background(), model.ReviewPublishedEvent{})
+	bus.Close()
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(&delivered))
+}
+
+func TestBusDropsAfterClose(t *testing.T) {
+	bus := newTestBus(1, 1)
+	bus.Close()
+
+	assert.NotPanics(t, func() {
+		bus.Publish(context.Background(), model.ReviewPublishedEvent{})
+	})
+}
@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"rating-system/internal/domain/model"
+	"rating-system/internal/domain/port"
+	domainService "rating-system/internal/domain/service"
+	"rating-system/pkg/validator"
+)
+
+// AccountHandler handles admin actions on user accounts
+type AccountHandler struct {
+	service port.AccountService
+	log     *logrus.Logger
+}
+
+// NewAccountHandler creates a new account handler
+func NewAccountHandler(service port.AccountService, log *logrus.Logger) *AccountHandler {
+	return &AccountHandler{
+		service: service,
+		log:     log,
+	}
+}
+
+// ShadowBanRequest is the request for shadow-banning a user
+type ShadowBanRequest struct {
+	Reason string `json:"reason" binding:"max=500"`
+}
+
+// GetShadowBans handles listing shadow-banned users
+// @Summary List shadow-banned users
+// @Description Retrieve the shadow-banned users, newest ban first
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Number of items per page" default(10)
+// @Param offset query int false "Offset for pagination" default(0)
+// @Success 200 {object} map[string]interface{} "List of shadow-bans with pagination metadata"
+// @Failure 403 {object} map[string]interface{} "Permission required"
+// @Router /api/v1/admin/shadow-bans [get]
+func (h *AccountHandler) GetShadowBans(c *gin.Context) {
+	params := extractPaginationParams(c)
+
+	bans, total, err := h.service.GetShadowBans(c.Request.Context(), params)
+	if err != nil {
+		h.log.WithError(err).Error("Failed to get shadow-bans")
+		c.JSON(accountErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"items":  bans,
+		"total":  total,
+		"limit":  params.GetLimit(),
+		"offset": params.GetOffset(),
+	})
+}
+
+// ShadowBanUser handles shadow-banning a user
+// @Summary Shadow-ban a user
+// @Description Hide the user's new ratings, reviews and comments from everyone but themselves and leave them out of aggregates; the rest of the account keeps working
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param userID path string true "User ID" format(uuid)
+// @Param request body ShadowBanRequest false "Reason"
+// @Success 201 {object} model.ShadowBan "Shadow-ban"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 403 {object} map[string]interface{} "Permission required"
+// @Failure 404 {object} map[string]interface{} "User not found"
+// @Failure 409 {object} map[string]interface{} "Already shadow-banned"
+// @Router /api/v1/admin/users/{userID}/shadow-ban [post]
+func (h *AccountHandler) ShadowBanUser(c *gin.Context) {
+	adminID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	userID, ok := roleUserID(c)
+	if !ok {
+		return
+	}
+
+	var req ShadowBanRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": validator.FormatValidationErrors(err)})
+			return
+		}
+	}
+
+	ban, err := h.service.ShadowBan(c.Request.Context(), adminID, userID, req.Reason)
+	if err != nil {
+		h.log.WithError(err).Error("Failed to shadow-ban user")
+		c.JSON(accountErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, ban)
+}
+
+// LiftShadowBan handles lifting a user's shadow-ban
+// @Summary Lift a shadow-ban
+// @Description Stop hiding the user's new content; content written while banned stays hidden
+// @Tags admin
+// @Security BearerAuth
+// @Param userID path string true "User ID" format(uuid)
+// @Success 204 "Shadow-ban lifted"
+// @Failure 400 {object} map[string]interface{} "Invalid user ID"
+// @Failure 403 {object} map[string]interface{} "Permission required"
+// @Failure 404 {object} map[string]interface{} "Not shadow-banned"
+// @Router /api/v1/admin/users/{userID}/shadow-ban [delete]
+func (h *AccountHandler) LiftShadowBan(c *gin.Context) {
+	adminID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	userID, ok := roleUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.LiftShadowBan(c.Request.Context(), adminID, userID); err != nil {
+		h.log.WithError(err).Error("Failed to lift shadow-ban")
+		c.JSON(accountErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// accountErrorStatus maps account management errors to HTTP status codes
+func accountErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domainService.ErrUserNotFound), errors.Is(err, model.ErrNotShadowBanned):
+		return http.StatusNotFound
+	case errors.Is(err, model.ErrAlreadyShadowBanned):
+		return http.StatusConflict
+	default:
+		return errorStatus(err)
+	}
+}
@@ -0,0 +1,186 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"rating-system/internal/domain/model"
+	"rating-system/internal/domain/port"
+	domainService "rating-system/internal/domain/service"
+	"rating-system/pkg/validator"
+)
+
+// APIKeyHandler handles admins issuing API keys to integrations
+type APIKeyHandler struct {
+	service port.APIKeyService
+	log     *logrus.Logger
+}
+
+// NewAPIKeyHandler creates a new API key handler
+func NewAPIKeyHandler(service port.APIKeyService, log *logrus.Logger) *APIKeyHandler {
+	return &APIKeyHandler{
+		service: service,
+		log:     log,
+	}
+}
+
+// CreateAPIKeyRequest is the request for issuing an API key
+type CreateAPIKeyRequest struct {
+	UserID    string     `json:"user_id" binding:"required,uuid"`
+	Name      string     `json:"name" binding:"required,max=100"`
+	Scopes    []string   `json:"scopes" binding:"required,min=1"`
+	ServiceID string     `json:"service_id,omitempty" binding:"omitempty,uuid"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// CreateAPIKeyResponse is an issued API key along with the key itself, which is only shown once
+type CreateAPIKeyResponse struct {
+	*model.APIKey
+	Key string `json:"key"`
+}
+
+// CreateAPIKey handles issuing an API key
+// @Summary Issue an API key
+// @Description Issue a key acting as the user with the given scopes, optionally restricted to one service and expiring; the key is only returned in this response. Send it in the X-API-Key header.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body CreateAPIKeyRequest true "API key"
+// @Success 201 {object} CreateAPIKeyResponse "API key"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 403 {object} map[string]interface{} "Permission required"
+// @Failure 404 {object} map[string]interface{} "User not found"
+// @Router /api/v1/admin/api-keys [post]
+func (h *APIKeyHandler) CreateAPIKey(c *gin.Context) {
+	adminID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	var req CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": validator.FormatValidationErrors(err)})
+		return
+	}
+
+	userID, err := uuid.Parse(req.UserID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+	var serviceID *uuid.UUID
+	if req.ServiceID != "" {
+		id, err := uuid.Parse(req.ServiceID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid service ID"})
+			return
+		}
+		serviceID = &id
+	}
+	scopes := make([]model.Permission, len(req.Scopes))
+	for i, scope := range req.Scopes {
+		scopes[i] = model.Permission(scope)
+	}
+
+	key, secret, err := h.service.CreateAPIKey(c.Request.Context(), adminID, userID, req.Name, scopes, serviceID, req.ExpiresAt)
+	if err != nil {
+		h.log.WithError(err).Error("Failed to create API key")
+		c.JSON(apiKeyErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, CreateAPIKeyResponse{APIKey: key, Key: secret})
+}
+
+// GetAPIKeys handles listing API keys
+// @Summary List API keys
+// @Description Retrieve issued API keys, including revoked and expired ones, most recent first
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param user_id query string false "Only keys acting as this user" format(uuid)
+// @Param limit query int false "Number of items per page" default(10)
+// @Param offset query int false "Offset for pagination" default(0)
+// @Success 200 {object} map[string]interface{} "List of API keys with pagination metadata"
+// @Failure 400 {object} map[string]interface{} "Invalid user ID"
+// @Failure 403 {object} map[string]interface{} "Permission required"
+// @Router /api/v1/admin/api-keys [get]
+func (h *APIKeyHandler) GetAPIKeys(c *gin.Context) {
+	var userID *uuid.UUID
+	if raw := c.Query("user_id"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+			return
+		}
+		userID = &id
+	}
+	params := extractPaginationParams(c)
+
+	keys, total, err := h.service.GetAPIKeys(c.Request.Context(), userID, params)
+	if err != nil {
+		h.log.WithError(err).Error("Failed to get API keys")
+		c.JSON(apiKeyErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"items":  keys,
+		"total":  total,
+		"limit":  params.GetLimit(),
+		"offset": params.GetOffset(),
+	})
+}
+
+// RevokeAPIKey handles revoking an API key
+// @Summary Revoke an API key
+// @Description Revoke an API key; requests made with it are rejected from then on
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param keyID path string true "API key ID" format(uuid)
+// @Success 204 "API key revoked"
+// @Failure 400 {object} map[string]interface{} "Invalid API key ID"
+// @Failure 403 {object} map[string]interface{} "Permission required"
+// @Failure 404 {object} map[string]interface{} "API key not found or already revoked"
+// @Router /api/v1/admin/api-keys/{keyID} [delete]
+func (h *APIKeyHandler) RevokeAPIKey(c *gin.Context) {
+	adminID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	keyID, err := uuid.Parse(c.Param("keyID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid API key ID"})
+		return
+	}
+
+	if err := h.service.RevokeAPIKey(c.Request.Context(), adminID, keyID); err != nil {
+		h.log.WithError(err).Error("Failed to revoke API key")
+		c.JSON(apiKeyErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// apiKeyErrorStatus maps API key errors to HTTP status codes
+func apiKeyErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, model.ErrInvalidAPIKeyName), errors.Is(err, model.ErrNoAPIKeyScopes),
+		errors.Is(err, model.ErrInvalidScope), errors.Is(err, model.ErrScopeNotForService),
+		errors.Is(err, model.ErrAPIKeyExpiryPassed):
+		return http.StatusBadRequest
+	case errors.Is(err, domainService.ErrUserNotFound), errors.Is(err, model.ErrAPIKeyNotFound):
+		return http.StatusNotFound
+	default:
+		return errorStatus(err)
+	}
+}
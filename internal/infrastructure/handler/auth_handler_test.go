@@ -38,7 +38,7 @@ func TestRegister(t *testing.T) {
 	userID := uuid.New()
 	token := "jwt-token"
 
-	user := model.User{
+	user := &model.UserResponse{
 		ID:       userID,
 		Username: username,
 		Email:    email,
@@ -47,7 +47,7 @@ func TestRegister(t *testing.T) {
 	// Setup expectations
 	mockAuthService.EXPECT().
 		Register(gomock.Any(), username, email, password).
-		Return(user, token, nil).
+		Return(user, &model.TokenPair{AccessToken: token}, nil).
 		Times(1)
 
 	// Test request
@@ -94,7 +94,7 @@ func TestLogin(t *testing.T) {
 	token := "jwt-token"
 	username := "testuser"
 
-	user := model.User{
+	user := &model.UserResponse{
 		ID:       userID,
 		Username: username,
 		Email:    email,
@@ -102,8 +102,8 @@ func TestLogin(t *testing.T) {
 
 	// Setup expectations
 	mockAuthService.EXPECT().
-		Login(gomock.Any(), email, password).
-		Return(user, token, nil).
+		Login(gomock.Any(), email, password, gomock.Any()).
+		Return(user, &model.TokenPair{AccessToken: token}, nil).
 		Times(1)
 
 	// Test request
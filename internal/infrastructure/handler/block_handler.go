@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"rating-system/internal/domain/model"
+	"rating-system/internal/domain/port"
+	domainService "rating-system/internal/domain/service"
+)
+
+// BlockHandler handles users blocking other users
+type BlockHandler struct {
+	service port.BlockService
+	log     *logrus.Logger
+}
+
+// NewBlockHandler creates a new block handler
+func NewBlockHandler(service port.BlockService, log *logrus.Logger) *BlockHandler {
+	return &BlockHandler{
+		service: service,
+		log:     log,
+	}
+}
+
+// GetBlockedUsers handles listing the users the authenticated user has blocked
+// @Summary List blocked users
+// @Description Retrieve the users the authenticated user has blocked, most recent first
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Number of items per page" default(10)
+// @Param offset query int false "Offset for pagination" default(0)
+// @Success 200 {object} map[string]interface{} "List of blocked users with pagination metadata"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Router /api/v1/users/me/blocks [get]
+func (h *BlockHandler) GetBlockedUsers(c *gin.Context) {
+	blockerID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+	params := extractPaginationParams(c)
+
+	blocks, total, err := h.service.GetBlockedUsers(c.Request.Context(), blockerID, params)
+	if err != nil {
+		h.log.WithError(err).Error("Failed to get blocked users")
+		c.JSON(blockErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"items":  blocks,
+		"total":  total,
+		"limit":  params.GetLimit(),
+		"offset": params.GetOffset(),
+	})
+}
+
+// BlockUser handles blocking a user
+// @Summary Block a user
+// @Description Hide the user's reviews and comments from your listings and stop them commenting on your reviews, replying to your comments or mentioning you
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Param userID path string true "User ID" format(uuid)
+// @Success 201 {object} model.Block "Block"
+// @Failure 400 {object} map[string]interface{} "Invalid user ID"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "User not found"
+// @Failure 409 {object} map[string]interface{} "Already blocked"
+// @Failure 422 {object} map[string]interface{} "Cannot block yourself"
+// @Router /api/v1/users/{userID}/block [post]
+func (h *BlockHandler) BlockUser(c *gin.Context) {
+	blockerID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	userID, ok := roleUserID(c)
+	if !ok {
+		return
+	}
+
+	block, err := h.service.BlockUser(c.Request.Context(), blockerID, userID)
+	if err != nil {
+		h.log.WithError(err).Error("Failed to block user")
+		c.JSON(blockErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, block)
+}
+
+// UnblockUser handles unblocking a user
+// @Summary Unblock a user
+// @Description Show the user's reviews and comments again and let them interact with you
+// @Tags users
+// @Security BearerAuth
+// @Param userID path string true "User ID" format(uuid)
+// @Success 204 "User unblocked"
+// @Failure 400 {object} map[string]interface{} "Invalid user ID"
+// @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 404 {object} map[string]interface{} "Not blocked"
+// @Router /api/v1/users/{userID}/block [delete]
+func (h *BlockHandler) UnblockUser(c *gin.Context) {
+	blockerID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	userID, ok := roleUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.UnblockUser(c.Request.Context(), blockerID, userID); err != nil {
+		h.log.WithError(err).Error("Failed to unblock user")
+		c.JSON(blockErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// blockErrorStatus maps blocking errors to HTTP status codes
+func blockErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, model.ErrCannotBlockSelf):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, domainService.ErrUserNotFound), errors.Is(err, model.ErrNotBlocked):
+		return http.StatusNotFound
+	case errors.Is(err, model.ErrAlreadyBlocked):
+		return http.StatusConflict
+	default:
+		return errorStatus(err)
+	}
+}
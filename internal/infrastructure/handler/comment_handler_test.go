@@ -52,8 +52,8 @@ func TestCreateComment(t *testing.T) {
 
 	// Setup expectations
 	mockService.EXPECT().
-		CreateComment(gomock.Any(), gomock.Any(), reviewID, content).
-		Return(comment, nil).
+		CreateComment(gomock.Any(), gomock.Any(), reviewID, uuid.Nil, content).
+		Return(&comment, nil).
 		Times(1)
 
 	// Test request
@@ -91,7 +91,7 @@ func TestGetCommentsByReview(t *testing.T) {
 
 	// Create test data
 	reviewID := uuid.New()
-	comments := []model.Comment{
+	comments := []*model.Comment{
 		{
 			ID:       uuid.New(),
 			UserID:   uuid.New(),
@@ -110,8 +110,8 @@ func TestGetCommentsByReview(t *testing.T) {
 
 	// Setup expectations
 	mockService.EXPECT().
-		GetCommentsByReview(gomock.Any(), reviewID, gomock.Any()).
-		DoAndReturn(func(_ context.Context, _ uuid.UUID, p pagination.Params) ([]model.Comment, int, error) {
+		GetCommentsByReview(gomock.Any(), reviewID, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _ uuid.UUID, p pagination.Params) ([]*model.Comment, int, error) {
 			assert.Equal(t, params.GetLimit(), p.GetLimit())
 			assert.Equal(t, params.GetOffset(), p.GetOffset())
 			return comments, total, nil
@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// GetCommentTree handles retrieving a review's comments as threads
+// @Summary Get comment threads for a review
+// @Description Retrieve a page of top-level comments, each with its replies nested below it and reply counts per node
+// @Tags comments
+// @Produce json
+// @Param reviewID path string true "Review ID" format(uuid)
+// @Param limit query int false "Number of threads per page" default(10)
+// @Param offset query int false "Offset for pagination" default(0)
+// @Param sort_direction query string false "Thread order by creation time" Enums(asc, desc) default(desc)
+// @Success 200 {object} map[string]interface{} "Comment threads with pagination metadata"
+// @Failure 400 {object} map[string]interface{} "Invalid review ID"
+// @Failure 404 {object} map[string]interface{} "Review not found"
+// @Router /api/v1/comments/review/{reviewID}/tree [get]
+func (h *Handler) GetCommentTree(c *gin.Context) {
+	reviewID, err := uuid.Parse(c.Param("reviewID"))
+	if err != nil {
+		h.log.WithError(err).Error("Invalid review ID")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid review ID"})
+		return
+	}
+
+	params := extractPaginationParams(c)
+
+	threads, total, err := h.service.GetCommentTree(c.Request.Context(), reviewID, optionalUserID(c), params)
+	if err != nil {
+		h.log.WithError(err).Error("Failed to get comment tree")
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"threads": threads,
+		"total":   total,
+		"limit":   params.GetLimit(),
+		"offset":  params.GetOffset(),
+	})
+}
+
+// DeleteComment handles deleting the authenticated user's comment
+// @Summary Delete a comment
+// @Description Delete a comment; comments with replies are replaced by a tombstone so the thread stays intact
+// @Tags comments
+// @Security BearerAuth
+// @Param commentID path string true "Comment ID" format(uuid)
+// @Success 204 "Comment deleted"
+// @Failure 400 {object} map[string]interface{} "Invalid comment ID"
+// @Failure 403 {object} map[string]interface{} "Not the comment author"
+// @Failure 404 {object} map[string]interface{} "Comment not found"
+// @Failure 409 {object} map[string]interface{} "Comment already deleted"
+// @Router /api/v1/comments/{commentID} [delete]
+func (h *Handler) DeleteComment(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	commentID, err := uuid.Parse(c.Param("commentID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid comment ID"})
+		return
+	}
+
+	if err := h.service.DeleteComment(c.Request.Context(), userID, commentID); err != nil {
+		h.log.WithError(err).Error("Failed to delete comment")
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
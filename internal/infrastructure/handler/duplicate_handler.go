@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"rating-system/internal/domain/port"
+)
+
+// DuplicateHandler handles reviewing clusters of near-duplicate reviews
+type DuplicateHandler struct {
+	service port.DuplicateService
+	log     *logrus.Logger
+}
+
+// NewDuplicateHandler creates a new duplicate cluster handler
+func NewDuplicateHandler(service port.DuplicateService, log *logrus.Logger) *DuplicateHandler {
+	return &DuplicateHandler{
+		service: service,
+		log:     log,
+	}
+}
+
+// GetDuplicateClusters handles listing clusters of near-duplicate reviews
+// @Summary List duplicate review clusters
+// @Description Retrieve groups of near-identical reviews posted from several accounts, most recently extended first, with the reviews in each
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Number of items per page" default(10)
+// @Param offset query int false "Offset for pagination" default(0)
+// @Success 200 {object} map[string]interface{} "List of duplicate clusters with pagination metadata"
+// @Failure 403 {object} map[string]interface{} "Permission required"
+// @Router /api/v1/admin/duplicate-clusters [get]
+func (h *DuplicateHandler) GetDuplicateClusters(c *gin.Context) {
+	params := extractPaginationParams(c)
+
+	clusters, total, err := h.service.GetClusters(c.Request.Context(), params)
+	if err != nil {
+		h.log.WithError(err).Error("Failed to get duplicate clusters")
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"items":  clusters,
+		"total":  total,
+		"limit":  params.GetLimit(),
+		"offset": params.GetOffset(),
+	})
+}
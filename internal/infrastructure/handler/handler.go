@@ -4,12 +4,15 @@ import (
         "errors"
         "net/http"
         "strconv"
+        "strings"
 
         "github.com/gin-gonic/gin"
         "github.com/google/uuid"
         "github.com/sirupsen/logrus"
 
+        "rating-system/internal/domain/model"
         "rating-system/internal/domain/port"
+        domainService "rating-system/internal/domain/service"
         "rating-system/pkg/pagination"
         "rating-system/pkg/validator"
 )
@@ -30,8 +33,9 @@ func NewHandler(service port.Service, log *logrus.Logger) *Handler {
 
 // CreateRatingRequest is the request for creating a rating
 type CreateRatingRequest struct {
-        ServiceID string `json:"service_id" binding:"required,uuid4"`
-        Score     int    `json:"score" binding:"required,min=1,max=5"`
+        ServiceID        string `json:"service_id" binding:"required,uuid4"`
+        Score            int    `json:"score" binding:"required,min=1,max=5"`
+        AttestationToken string `json:"attestation_token,omitempty"`
 }
 
 // CreateRating handles the creation of a new rating
@@ -45,6 +49,8 @@ type CreateRatingRequest struct {
 // @Success 201 {object} model.Rating "Rating created successfully"
 // @Failure 400 {object} map[string]interface{} "Invalid input"
 // @Failure 401 {object} map[string]interface{} "Unauthorized"
+// @Failure 409 {object} map[string]interface{} "Attestation already used"
+// @Failure 422 {object} map[string]interface{} "Invalid attestation"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /api/v1/ratings [post]
 func (h *Handler) CreateRating(c *gin.Context) {
@@ -76,11 +82,15 @@ func (h *Handler) CreateRating(c *gin.Context) {
                 c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid service ID"})
                 return
         }
+        if !requireServiceAccess(c, serviceID) {
+                return
+        }
 
-        rating, err := h.service.CreateRating(c.Request.Context(), userID, serviceID, req.Score)
+        ctx := model.WithClientInfo(c.Request.Context(), clientInfo(c))
+        rating, err := h.service.CreateRating(ctx, userID, serviceID, req.Score, req.AttestationToken)
         if err != nil {
                 h.log.WithError(err).Error("Failed to create rating")
-                c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+                c.JSON(errorStatus(err), gin.H{"error": err.Error()})
                 return
         }
 
@@ -98,6 +108,7 @@ func (h *Handler) CreateRating(c *gin.Context) {
 // @Param offset query int false "Offset for pagination" default(0)
 // @Param sort_by query string false "Field to sort by" default(created_at)
 // @Param sort_direction query string false "Sort direction" Enums(asc, desc) default(desc)
+// @Param verified_only query bool false "Only include ratings backed by a verified interaction" default(false)
 // @Success 200 {object} map[string]interface{} "List of ratings with pagination metadata"
 // @Failure 400 {object} map[string]interface{} "Invalid service ID"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
@@ -112,8 +123,9 @@ func (h *Handler) GetRatingsByService(c *gin.Context) {
         }
 
         params := extractPaginationParams(c)
+        filter := model.RatingFilter{VerifiedOnly: extractVerifiedOnly(c)}
         
-        ratings, total, err := h.service.GetRatingsByService(c.Request.Context(), serviceID, params)
+        ratings, total, err := h.service.GetRatingsByService(c.Request.Context(), serviceID, optionalUserID(c), filter, params)
         if err != nil {
                 h.log.WithError(err).Error("Failed to get ratings")
                 c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -135,7 +147,8 @@ func (h *Handler) GetRatingsByService(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param serviceID path string true "Service ID" format(uuid)
-// @Success 200 {object} float64 "Average rating score"
+// @Param verified_only query bool false "Only average ratings backed by a verified interaction" default(false)
+// @Success 200 {object} model.AverageRating "Average rating score"
 // @Failure 400 {object} map[string]interface{} "Invalid service ID"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Router /api/v1/ratings/service/{serviceID}/average [get]
@@ -148,7 +161,9 @@ func (h *Handler) GetAverageRating(c *gin.Context) {
                 return
         }
 
-        average, err := h.service.GetAverageRating(c.Request.Context(), serviceID)
+        filter := model.RatingFilter{VerifiedOnly: extractVerifiedOnly(c)}
+
+        average, err := h.service.GetAverageRating(c.Request.Context(), serviceID, optionalUserID(c), filter)
         if err != nil {
                 h.log.WithError(err).Error("Failed to get average rating")
                 c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -195,6 +210,9 @@ func (h *Handler) GetUserRating(c *gin.Context) {
                 c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid service ID"})
                 return
         }
+        if !requireServiceAccess(c, serviceID) {
+                return
+        }
 
         rating, err := h.service.GetRatingByUserAndService(c.Request.Context(), userID, serviceID)
         if err != nil {
@@ -212,10 +230,12 @@ func (h *Handler) GetUserRating(c *gin.Context) {
 
 // CreateReviewRequest is the request for creating a review
 type CreateReviewRequest struct {
-        ServiceID string `json:"service_id" binding:"required,uuid4"`
-        RatingID  string `json:"rating_id" binding:"required,uuid4"`
-        Title     string `json:"title" binding:"required,min=1,max=255"`
-        Content   string `json:"content" binding:"required,min=1"`
+        ServiceID        string `json:"service_id" binding:"required,uuid4"`
+        RatingID         string `json:"rating_id" binding:"required,uuid4"`
+        Title            string `json:"title" binding:"required,min=1,max=255"`
+        Content          string `json:"content" binding:"required,min=1"`
+        AttestationToken string `json:"attestation_token,omitempty"`
+        Draft            bool   `json:"draft"` // Save without submitting for publication
 }
 
 // CreateReview handles the creation of a new review
@@ -255,11 +275,14 @@ func (h *Handler) CreateReview(c *gin.Context) {
                 c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid rating ID"})
                 return
         }
+        if !requireServiceAccess(c, serviceID) {
+                return
+        }
 
-        review, err := h.service.CreateReview(c.Request.Context(), userID, serviceID, ratingID, req.Title, req.Content)
+        review, err := h.service.CreateReview(c.Request.Context(), userID, serviceID, ratingID, req.Title, req.Content, req.AttestationToken, req.Draft)
         if err != nil {
                 h.log.WithError(err).Error("Failed to create review")
-                c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+                c.JSON(errorStatus(err), gin.H{"error": err.Error()})
                 return
         }
 
@@ -276,9 +299,9 @@ func (h *Handler) GetReviewByID(c *gin.Context) {
                 return
         }
 
-        review, err := h.service.GetReviewByID(c.Request.Context(), reviewID)
+        review, err := h.service.GetReviewByID(c.Request.Context(), reviewID, optionalUserID(c))
         if err != nil {
-                if errors.Is(err, errors.New("review not found")) {
+                if errors.Is(err, domainService.ErrReviewNotFound) {
                         c.JSON(http.StatusNotFound, gin.H{"error": "Review not found"})
                         return
                 }
@@ -301,8 +324,12 @@ func (h *Handler) GetReviewsByService(c *gin.Context) {
         }
 
         params := extractPaginationParams(c)
+        filter := model.ReviewFilter{
+                VerifiedOnly:   extractVerifiedOnly(c),
+                CommentPreview: extractCommentPreview(c),
+        }
         
-        reviews, total, err := h.service.GetReviewsByService(c.Request.Context(), serviceID, params)
+        reviews, total, err := h.service.GetReviewsByService(c.Request.Context(), serviceID, optionalUserID(c), filter, params)
         if err != nil {
                 h.log.WithError(err).Error("Failed to get reviews")
                 c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -320,6 +347,7 @@ func (h *Handler) GetReviewsByService(c *gin.Context) {
 // CreateCommentRequest is the request for creating a comment
 type CreateCommentRequest struct {
         ReviewID string `json:"review_id" binding:"required,uuid4"`
+        ParentID string `json:"parent_id,omitempty" binding:"omitempty,uuid4"`
         Content  string `json:"content" binding:"required,min=1"`
 }
 
@@ -354,10 +382,21 @@ func (h *Handler) CreateComment(c *gin.Context) {
                 return
         }
 
-        comment, err := h.service.CreateComment(c.Request.Context(), userID, reviewID, req.Content)
+        // Replies name their parent comment
+        parentID := uuid.Nil
+        if req.ParentID != "" {
+                parentID, err = uuid.Parse(req.ParentID)
+                if err != nil {
+                        h.log.WithError(err).Error("Invalid parent comment ID")
+                        c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid parent comment ID"})
+                        return
+                }
+        }
+
+        comment, err := h.service.CreateComment(c.Request.Context(), userID, reviewID, parentID, req.Content)
         if err != nil {
                 h.log.WithError(err).Error("Failed to create comment")
-                c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+                c.JSON(errorStatus(err), gin.H{"error": err.Error()})
                 return
         }
 
@@ -376,7 +415,7 @@ func (h *Handler) GetCommentsByReview(c *gin.Context) {
 
         params := extractPaginationParams(c)
         
-        comments, total, err := h.service.GetCommentsByReview(c.Request.Context(), reviewID, params)
+        comments, total, err := h.service.GetCommentsByReview(c.Request.Context(), reviewID, optionalUserID(c), params)
         if err != nil {
                 h.log.WithError(err).Error("Failed to get comments")
                 c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -410,3 +449,89 @@ func extractPaginationParams(c *gin.Context) pagination.Params {
 
         return pagination.NewParamsWithOffset(limit, offset, sortBy, sortDirection)
 }
+
+// extractVerifiedOnly reads the verified_only query flag, defaulting to false
+func extractVerifiedOnly(c *gin.Context) bool {
+        verifiedOnly, err := strconv.ParseBool(c.DefaultQuery("verified_only", "false"))
+        if err != nil {
+                return false
+        }
+        return verifiedOnly
+}
+
+// extractCommentPreview reads the comment_preview query parameter, the number of latest comments
+// to include with each review, defaulting to none
+func extractCommentPreview(c *gin.Context) int {
+        preview, err := strconv.Atoi(c.DefaultQuery("comment_preview", "0"))
+        if err != nil || preview < 0 {
+                return 0
+        }
+        return preview
+}
+
+// errorStatus maps domain errors to HTTP status codes
+func errorStatus(err error) int {
+        switch {
+        case errors.Is(err, model.ErrInvalidAttestation), errors.Is(err, domainService.ErrAttestationUnsupported):
+                return http.StatusUnprocessableEntity
+        case errors.Is(err, model.ErrAttestationReused), errors.Is(err, model.ErrInvalidReviewTransition):
+                return http.StatusConflict
+        case errors.Is(err, domainService.ErrReviewNotFound), errors.Is(err, domainService.ErrCommentNotFound):
+                return http.StatusNotFound
+        case errors.Is(err, domainService.ErrNotReviewAuthor), errors.Is(err, domainService.ErrNotCommentAuthor):
+                return http.StatusForbidden
+        case errors.Is(err, model.ErrBlocked), errors.Is(err, model.ErrEmailNotVerified):
+                return http.StatusForbidden
+        case errors.Is(err, model.ErrCommentDepthExceeded), errors.Is(err, model.ErrInvalidParentComment):
+                return http.StatusUnprocessableEntity
+        case errors.Is(err, model.ErrTooManyMentions), errors.Is(err, model.ErrInvalidReaction):
+                return http.StatusUnprocessableEntity
+        case errors.Is(err, model.ErrContentTooLong), errors.Is(err, model.ErrContentRejected):
+                return http.StatusUnprocessableEntity
+        case errors.Is(err, model.ErrCommentDeleted):
+                return http.StatusConflict
+        case errors.Is(err, domainService.ErrHighlightsUnavailable):
+                return http.StatusServiceUnavailable
+        default:
+                return http.StatusInternalServerError
+        }
+}
+
+// getUserID returns the authenticated user ID, writing an error response if it is missing
+func getUserID(c *gin.Context) (uuid.UUID, bool) {
+        userIDVal, exists := c.Get("userID")
+        if !exists {
+                c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+                return uuid.Nil, false
+        }
+
+        userID, ok := userIDVal.(uuid.UUID)
+        if !ok {
+                c.JSON(http.StatusInternalServerError, gin.H{"error": "Invalid user ID format"})
+                return uuid.Nil, false
+        }
+        return userID, true
+}
+
+// maxDeviceIDLength bounds the X-Device-ID header stored with ratings
+const maxDeviceIDLength = 255
+
+// clientInfo identifies the client a request came from by its address and the optional
+// X-Device-ID header set by first-party apps
+func clientInfo(c *gin.Context) model.ClientInfo {
+        deviceID := strings.TrimSpace(c.GetHeader("X-Device-ID"))
+        if len(deviceID) > maxDeviceIDLength {
+                deviceID = deviceID[:maxDeviceIDLength]
+        }
+        return model.ClientInfo{IP: c.ClientIP(), DeviceID: deviceID}
+}
+
+// optionalUserID returns the authenticated user ID on public routes, or uuid.Nil for anonymous viewers
+func optionalUserID(c *gin.Context) uuid.UUID {
+        if userID, ok := c.Get("userID"); ok {
+                if id, ok := userID.(uuid.UUID); ok {
+                        return id
+                }
+        }
+        return uuid.Nil
+}
@@ -50,8 +50,8 @@ func TestCreateRating(t *testing.T) {
 
 	// Setup expectations
 	mockService.EXPECT().
-		CreateRating(gomock.Any(), gomock.Any(), serviceID, 5).
-		Return(rating, nil).
+		CreateRating(gomock.Any(), gomock.Any(), serviceID, 5, "").
+		Return(&rating, nil).
 		Times(1)
 
 	// Test request
@@ -89,7 +89,7 @@ func TestGetRatingsByService(t *testing.T) {
 
 	// Create test data
 	serviceID := uuid.New()
-	ratings := []model.Rating{
+	ratings := []*model.Rating{
 		{
 			ID:        uuid.New(),
 			UserID:    uuid.New(),
@@ -108,8 +108,8 @@ func TestGetRatingsByService(t *testing.T) {
 
 	// Setup expectations
 	mockService.EXPECT().
-		GetRatingsByService(gomock.Any(), serviceID, gomock.Any()).
-		DoAndReturn(func(_ context.Context, _ uuid.UUID, p pagination.Params) ([]model.Rating, int, error) {
+		GetRatingsByService(gomock.Any(), serviceID, gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _ uuid.UUID, _ model.RatingFilter, p pagination.Params) ([]*model.Rating, int, error) {
 			assert.Equal(t, params.GetLimit(), p.GetLimit())
 			assert.Equal(t, params.GetOffset(), p.GetOffset())
 			return ratings, total, nil
@@ -148,11 +148,15 @@ func TestGetAverageRating(t *testing.T) {
 
 	// Create test data
 	serviceID := uuid.New()
-	avgRating := 4.5
+	avgRating := &model.AverageRating{
+		ServiceID:    serviceID,
+		AverageScore: 4.5,
+		TotalRatings: 2,
+	}
 
 	// Setup expectations
 	mockService.EXPECT().
-		GetAverageRating(gomock.Any(), serviceID).
+		GetAverageRating(gomock.Any(), serviceID, uuid.Nil, model.RatingFilter{}).
 		Return(avgRating, nil).
 		Times(1)
 
@@ -163,10 +167,10 @@ func TestGetAverageRating(t *testing.T) {
 
 	// Verify
 	assert.Equal(t, http.StatusOK, resp.Code)
-	var respBody float64
+	var respBody model.AverageRating
 	err := json.Unmarshal(resp.Body.Bytes(), &respBody)
 	assert.NoError(t, err)
-	assert.Equal(t, avgRating, respBody)
+	assert.Equal(t, *avgRating, respBody)
 }
 
 func TestGetUserRating(t *testing.T) {
@@ -201,7 +205,7 @@ func TestGetUserRating(t *testing.T) {
 	// Setup expectations
 	mockService.EXPECT().
 		GetRatingByUserAndService(gomock.Any(), gomock.Any(), serviceID).
-		Return(rating, nil).
+		Return(&rating, nil).
 		Times(1)
 
 	// Test request
@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// GetServiceHighlights handles retrieving the pros and cons extracted from a service's reviews
+// @Summary Get review highlights for a service
+// @Description Retrieve key phrases common in high-score (pros) and low-score (cons) reviews of a service
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Param serviceID path string true "Service ID" format(uuid)
+// @Success 200 {object} model.ServiceHighlights "Service highlights"
+// @Failure 400 {object} map[string]interface{} "Invalid service ID"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Failure 503 {object} map[string]interface{} "Highlights not configured"
+// @Router /api/v1/services/{serviceID}/highlights [get]
+func (h *Handler) GetServiceHighlights(c *gin.Context) {
+	serviceID, err := uuid.Parse(c.Param("serviceID"))
+	if err != nil {
+		h.log.WithError(err).Error("Invalid service ID")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid service ID"})
+		return
+	}
+
+	highlights, err := h.service.GetServiceHighlights(c.Request.Context(), serviceID)
+	if err != nil {
+		h.log.WithError(err).Error("Failed to get service highlights")
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, highlights)
+}
@@ -0,0 +1,208 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"rating-system/internal/domain/model"
+	"rating-system/internal/domain/port"
+	"rating-system/pkg/validator"
+)
+
+// ModerationHandler handles content reports and moderator decisions
+type ModerationHandler struct {
+	service port.ModerationService
+	log     *logrus.Logger
+}
+
+// NewModerationHandler creates a new moderation handler
+func NewModerationHandler(service port.ModerationService, log *logrus.Logger) *ModerationHandler {
+	return &ModerationHandler{
+		service: service,
+		log:     log,
+	}
+}
+
+// ReportContentRequest is the request for reporting a review or comment
+type ReportContentRequest struct {
+	TargetType string `json:"target_type" binding:"required,oneof=review comment"`
+	TargetID   string `json:"target_id" binding:"required,uuid4"`
+	Reason     string `json:"reason" binding:"required"`
+	Details    string `json:"details,omitempty"`
+}
+
+// ModerationDecisionRequest is the request for deciding on reported content
+type ModerationDecisionRequest struct {
+	Action string `json:"action" binding:"required,oneof=approve hide remove"`
+	Reason string `json:"reason" binding:"required,min=1"`
+}
+
+// ReportContent handles reporting a review or comment
+// @Summary Report content
+// @Description Report a review or comment to the moderators; each user can report a piece of content once
+// @Tags moderation
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body ReportContentRequest true "Report"
+// @Success 201 {object} model.Report "Created report"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 404 {object} map[string]interface{} "Content not found"
+// @Failure 409 {object} map[string]interface{} "Content already reported by this user"
+// @Router /api/v1/reports [post]
+func (h *ModerationHandler) ReportContent(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	var req ReportContentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": validator.FormatValidationErrors(err)})
+		return
+	}
+
+	targetID, _ := uuid.Parse(req.TargetID)
+	report, err := h.service.ReportContent(c.Request.Context(), userID, model.ReportTarget(req.TargetType), targetID,
+		model.ReportReason(req.Reason), req.Details)
+	if err != nil {
+		h.log.WithError(err).Error("Failed to report content")
+		c.JSON(moderationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, report)
+}
+
+// GetModerationQueue handles listing reported content awaiting a decision
+// @Summary List reported content
+// @Description Retrieve the reviews and comments with open reports, most reported first
+// @Tags moderation
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Number of items per page" default(10)
+// @Param offset query int false "Offset for pagination" default(0)
+// @Success 200 {object} map[string]interface{} "List of reported content with pagination metadata"
+// @Failure 403 {object} map[string]interface{} "Moderator access required"
+// @Router /api/v1/moderation/reports [get]
+func (h *ModerationHandler) GetModerationQueue(c *gin.Context) {
+	params := extractPaginationParams(c)
+
+	items, total, err := h.service.GetQueue(c.Request.Context(), params)
+	if err != nil {
+		h.log.WithError(err).Error("Failed to get moderation queue")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"items":  items,
+		"total":  total,
+		"limit":  params.GetLimit(),
+		"offset": params.GetOffset(),
+	})
+}
+
+// DecideContent handles approving, hiding or removing a review or comment
+// @Summary Decide on content
+// @Description Approve, hide or remove a review or comment; the decision is recorded and open reports are resolved
+// @Tags moderation
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param targetType path string true "Content type" Enums(review, comment)
+// @Param targetID path string true "Content ID" format(uuid)
+// @Param request body ModerationDecisionRequest true "Decision"
+// @Success 201 {object} model.ModerationDecision "Recorded decision"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 403 {object} map[string]interface{} "Moderator access required"
+// @Failure 404 {object} map[string]interface{} "Content not found"
+// @Failure 409 {object} map[string]interface{} "Content moderated by someone else in the meantime"
+// @Router /api/v1/moderation/content/{targetType}/{targetID}/decisions [post]
+func (h *ModerationHandler) DecideContent(c *gin.Context) {
+	moderatorID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	targetType, targetID, ok := moderationTarget(c)
+	if !ok {
+		return
+	}
+
+	var req ModerationDecisionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": validator.FormatValidationErrors(err)})
+		return
+	}
+
+	decision, err := h.service.Decide(c.Request.Context(), moderatorID, targetType, targetID, model.ModerationAction(req.Action), req.Reason)
+	if err != nil {
+		h.log.WithError(err).Error("Failed to decide on content")
+		c.JSON(moderationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, decision)
+}
+
+// GetModerationDecisions handles listing the decisions taken on a review or comment
+// @Summary List moderation decisions
+// @Description Retrieve the moderation history of a review or comment, oldest first
+// @Tags moderation
+// @Produce json
+// @Security BearerAuth
+// @Param targetType path string true "Content type" Enums(review, comment)
+// @Param targetID path string true "Content ID" format(uuid)
+// @Success 200 {object} map[string]interface{} "Decisions"
+// @Failure 403 {object} map[string]interface{} "Moderator access required"
+// @Failure 404 {object} map[string]interface{} "Content not found"
+// @Router /api/v1/moderation/content/{targetType}/{targetID}/decisions [get]
+func (h *ModerationHandler) GetModerationDecisions(c *gin.Context) {
+	targetType, targetID, ok := moderationTarget(c)
+	if !ok {
+		return
+	}
+
+	decisions, err := h.service.GetDecisions(c.Request.Context(), targetType, targetID)
+	if err != nil {
+		h.log.WithError(err).Error("Failed to get moderation decisions")
+		c.JSON(moderationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"decisions": decisions})
+}
+
+// moderationTarget reads the content type and ID from the path, writing an error response if they are invalid
+func moderationTarget(c *gin.Context) (model.ReportTarget, uuid.UUID, bool) {
+	targetType := model.ReportTarget(c.Param("targetType"))
+	if !targetType.IsValid() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid content type"})
+		return "", uuid.Nil, false
+	}
+
+	targetID, err := uuid.Parse(c.Param("targetID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid content ID"})
+		return "", uuid.Nil, false
+	}
+	return targetType, targetID, true
+}
+
+// moderationErrorStatus maps report and moderation errors to HTTP status codes
+func moderationErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, model.ErrInvalidReportReason), errors.Is(err, model.ErrInvalidReportTarget),
+		errors.Is(err, model.ErrInvalidModeration), errors.Is(err, model.ErrModerationReasonNeeded):
+		return http.StatusBadRequest
+	case errors.Is(err, model.ErrDuplicateReport), errors.Is(err, model.ErrModerationConflict):
+		return http.StatusConflict
+	default:
+		return errorStatus(err)
+	}
+}
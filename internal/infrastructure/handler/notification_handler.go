@@ -0,0 +1,193 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"rating-system/internal/domain/model"
+	"rating-system/internal/domain/port"
+	domainService "rating-system/internal/domain/service"
+	"rating-system/pkg/pagination"
+	"rating-system/pkg/validator"
+)
+
+// NotificationHandler handles notification inbox requests
+type NotificationHandler struct {
+	service port.NotificationService
+	log     *logrus.Logger
+}
+
+// NewNotificationHandler creates a new notification handler
+func NewNotificationHandler(service port.NotificationService, log *logrus.Logger) *NotificationHandler {
+	return &NotificationHandler{
+		service: service,
+		log:     log,
+	}
+}
+
+// UpdateNotificationPreferencesRequest is the request for updating notification preferences
+type UpdateNotificationPreferencesRequest struct {
+	Enabled map[model.NotificationType]bool `json:"enabled" binding:"required"`
+}
+
+// GetNotifications handles listing the authenticated user's notifications
+// @Summary List my notifications
+// @Description Retrieve the authenticated user's notifications, newest first, with cursor pagination
+// @Tags notifications
+// @Produce json
+// @Security BearerAuth
+// @Param unread query bool false "Only unread notifications" default(false)
+// @Param cursor query string false "Cursor from the previous page's next_cursor"
+// @Param limit query int false "Number of notifications per page" default(20)
+// @Success 200 {object} model.NotificationPage "Page of notifications"
+// @Failure 400 {object} map[string]interface{} "Invalid cursor"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Router /api/v1/notifications [get]
+func (h *NotificationHandler) GetNotifications(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	var cursor *pagination.Cursor
+	if encoded := c.Query("cursor"); encoded != "" {
+		var err error
+		cursor, err = pagination.DecodeCursor(encoded)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid cursor"})
+			return
+		}
+	}
+
+	unread, _ := strconv.ParseBool(c.DefaultQuery("unread", "false"))
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	page, err := h.service.GetNotifications(c.Request.Context(), userID, model.NotificationFilter{UnreadOnly: unread}, cursor, limit)
+	if err != nil {
+		h.log.WithError(err).Error("Failed to get notifications")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+// MarkNotificationRead handles marking a notification as read
+// @Summary Mark a notification as read
+// @Tags notifications
+// @Security BearerAuth
+// @Param notificationID path string true "Notification ID" format(uuid)
+// @Success 204 "Notification marked as read"
+// @Failure 400 {object} map[string]interface{} "Invalid notification ID"
+// @Failure 404 {object} map[string]interface{} "Notification not found"
+// @Router /api/v1/notifications/{notificationID}/read [post]
+func (h *NotificationHandler) MarkNotificationRead(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	notificationID, err := uuid.Parse(c.Param("notificationID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid notification ID"})
+		return
+	}
+
+	if err := h.service.MarkRead(c.Request.Context(), userID, notificationID); err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, domainService.ErrNotificationNotFound) {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// MarkAllNotificationsRead handles marking all notifications as read
+// @Summary Mark all notifications as read
+// @Tags notifications
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} map[string]interface{} "Number of notifications marked as read"
+// @Router /api/v1/notifications/read-all [post]
+func (h *NotificationHandler) MarkAllNotificationsRead(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	updated, err := h.service.MarkAllRead(c.Request.Context(), userID)
+	if err != nil {
+		h.log.WithError(err).Error("Failed to mark all notifications read")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"updated": updated})
+}
+
+// GetNotificationPreferences handles retrieving the user's notification preferences
+// @Summary Get my notification preferences
+// @Tags notifications
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} model.NotificationPreferences "Enabled state per notification type"
+// @Router /api/v1/notifications/preferences [get]
+func (h *NotificationHandler) GetNotificationPreferences(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	preferences, err := h.service.GetPreferences(c.Request.Context(), userID)
+	if err != nil {
+		h.log.WithError(err).Error("Failed to get notification preferences")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, preferences)
+}
+
+// UpdateNotificationPreferences handles enabling or disabling notification types
+// @Summary Update my notification preferences
+// @Description Enable or disable recording of notification types; types not listed are left unchanged
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param preferences body UpdateNotificationPreferencesRequest true "Enabled state per notification type"
+// @Success 200 {object} model.NotificationPreferences "Updated preferences"
+// @Failure 400 {object} map[string]interface{} "Invalid notification type"
+// @Router /api/v1/notifications/preferences [put]
+func (h *NotificationHandler) UpdateNotificationPreferences(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateNotificationPreferencesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": validator.FormatValidationErrors(err)})
+		return
+	}
+
+	preferences, err := h.service.UpdatePreferences(c.Request.Context(), userID, req.Enabled)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, domainService.ErrInvalidNotificationType) {
+			status = http.StatusBadRequest
+		}
+		c.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, preferences)
+}
@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"rating-system/internal/domain/model"
+	"rating-system/internal/domain/port"
+)
+
+// RateLimit counts every request against the limiter. Callers are keyed by API key when
+// AuthMiddleware validated one, otherwise by user ID once authenticated, otherwise by client
+// address. Responses carry the RateLimit-Limit, RateLimit-Remaining, RateLimit-Reset and
+// RateLimit-Policy headers, and requests over the limit are answered with 429 and Retry-After. A nil limiter disables limiting, and
+// requests are let through if the limiter fails.
+func RateLimit(limiter port.RateLimiter, log *logrus.Logger) gin.HandlerFunc {
+	return rateLimit(limiter, log, false)
+}
+
+// RateLimitWrites is RateLimit for requests that change data; reads pass through uncounted
+func RateLimitWrites(limiter port.RateLimiter, log *logrus.Logger) gin.HandlerFunc {
+	return rateLimit(limiter, log, true)
+}
+
+func rateLimit(limiter port.RateLimiter, log *logrus.Logger, writesOnly bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if limiter == nil || (writesOnly && isSafeMethod(c.Request.Method)) {
+			c.Next()
+			return
+		}
+
+		decision, err := limiter.Allow(c.Request.Context(), rateLimitKey(c))
+		if err != nil {
+			log.WithError(err).Error("Failed to check rate limit")
+			c.Next()
+			return
+		}
+
+		setRateLimitHeaders(c, decision)
+		if !decision.Allowed {
+			c.Header("Retry-After", strconv.Itoa(ceilSeconds(decision.RetryAfter)))
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": "Rate limit exceeded"})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// rateLimitKey identifies the caller a request is counted against. Only credentials AuthMiddleware
+// has validated are trusted; a request that merely carries an API key header, such as one to the
+// public auth endpoints, is counted against its client address so rotating keys cannot reset the limit.
+func rateLimitKey(c *gin.Context) string {
+	if value, ok := c.Get("principal"); ok {
+		if principal, ok := value.(*model.Principal); ok && principal.APIKeyID != nil {
+			return "key:" + principal.APIKeyID.String()
+		}
+	}
+	if value, ok := c.Get("userID"); ok {
+		if userID, ok := value.(uuid.UUID); ok {
+			return "user:" + userID.String()
+		}
+	}
+	return "ip:" + c.ClientIP()
+}
+
+// setRateLimitHeaders describes the caller's quota in the RateLimit header fields
+func setRateLimitHeaders(c *gin.Context, decision *model.RateLimitDecision) {
+	c.Header("RateLimit-Limit", strconv.Itoa(decision.Limit))
+	c.Header("RateLimit-Remaining", strconv.Itoa(decision.Remaining))
+	c.Header("RateLimit-Reset", strconv.Itoa(ceilSeconds(decision.Reset)))
+	c.Header("RateLimit-Policy", fmt.Sprintf("%d;w=%d", decision.Limit, ceilSeconds(decision.Window)))
+}
+
+// ceilSeconds rounds a duration up to whole seconds, as the rate limit headers require
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
+
+// isSafeMethod reports whether the HTTP method only reads data
+func isSafeMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"rating-system/internal/domain/model"
+	"rating-system/internal/infrastructure/ratelimit"
+)
+
+func TestRateLimitIgnoresUnvalidatedAPIKey(t *testing.T) {
+	// Setup
+	limiter := ratelimit.NewLimiter(ratelimit.NewMemoryStore(), "auth", ratelimit.Limit{Requests: 2, Window: time.Minute})
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/auth/login", RateLimit(limiter, logrus.New()), func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+
+	// A client rotating made-up API keys is still counted against its address
+	codes := make([]int, 0, 3)
+	for i := 0; i < 3; i++ {
+		req, _ := http.NewRequest("POST", "/auth/login", nil)
+		req.RemoteAddr = "203.0.113.7:40000"
+		req.Header.Set("X-API-Key", uuid.New().String())
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+		codes = append(codes, resp.Code)
+	}
+
+	// Verify
+	assert.Equal(t, []int{http.StatusNoContent, http.StatusNoContent, http.StatusTooManyRequests}, codes)
+}
+
+func TestRateLimitKey(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	userID := uuid.New()
+	keyID := uuid.New()
+
+	tests := []struct {
+		name      string
+		principal *model.Principal
+		apiKey    string
+		want      string
+	}{
+		{name: "anonymous", want: "ip:203.0.113.7"},
+		{name: "unvalidated api key", apiKey: "made-up", want: "ip:203.0.113.7"},
+		{name: "access token", principal: &model.Principal{UserID: userID}, want: "user:" + userID.String()},
+		{name: "validated api key", principal: &model.Principal{UserID: userID, APIKeyID: &keyID}, apiKey: "valid", want: "key:" + keyID.String()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request, _ = http.NewRequest("POST", "/", nil)
+			c.Request.RemoteAddr = "203.0.113.7:40000"
+			if tt.apiKey != "" {
+				c.Request.Header.Set("X-API-Key", tt.apiKey)
+			}
+			if tt.principal != nil {
+				c.Set("userID", tt.principal.UserID)
+				c.Set("principal", tt.principal)
+			}
+
+			assert.Equal(t, tt.want, rateLimitKey(c))
+		})
+	}
+}
+
+func TestRateLimitKeyIgnoresSpoofedForwardedFor(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name    string
+		proxies []string
+		want    string
+	}{
+		{name: "no trusted proxies", want: "ip:203.0.113.7"},
+		{name: "peer is not a trusted proxy", proxies: []string{"10.0.0.0/8"}, want: "ip:203.0.113.7"},
+		{name: "peer is a trusted proxy", proxies: []string{"203.0.113.7"}, want: "ip:198.51.100.23"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			assert.NoError(t, router.SetTrustedProxies(tt.proxies))
+
+			var key string
+			router.POST("/auth/login", func(c *gin.Context) {
+				key = rateLimitKey(c)
+			})
+
+			req, _ := http.NewRequest("POST", "/auth/login", nil)
+			req.RemoteAddr = "203.0.113.7:40000"
+			req.Header.Set("X-Forwarded-For", "198.51.100.23")
+			router.ServeHTTP(httptest.NewRecorder(), req)
+
+			assert.Equal(t, tt.want, key)
+		})
+	}
+}
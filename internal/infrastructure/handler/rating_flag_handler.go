@@ -0,0 +1,167 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"rating-system/internal/domain/model"
+	"rating-system/internal/domain/port"
+	"rating-system/pkg/validator"
+)
+
+// RatingFlagHandler handles reviewing ratings flagged by the brigading detector
+type RatingFlagHandler struct {
+	service port.RatingFlagService
+	log     *logrus.Logger
+}
+
+// NewRatingFlagHandler creates a new rating flag handler
+func NewRatingFlagHandler(service port.RatingFlagService, log *logrus.Logger) *RatingFlagHandler {
+	return &RatingFlagHandler{
+		service: service,
+		log:     log,
+	}
+}
+
+// ResolveRatingFlagRequest is the request for clearing or confirming a rating flag
+type ResolveRatingFlagRequest struct {
+	Status string `json:"status" binding:"required,oneof=cleared confirmed"`
+}
+
+// GetRatingFlags handles listing flagged ratings
+// @Summary List flagged ratings
+// @Description Retrieve ratings the brigading detector flagged, newest first, with the signals raised for each
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Flag status" Enums(open, cleared, confirmed)
+// @Param service_id query string false "Service ID" format(uuid)
+// @Param limit query int false "Number of items per page" default(10)
+// @Param offset query int false "Offset for pagination" default(0)
+// @Success 200 {object} map[string]interface{} "List of rating flags with pagination metadata"
+// @Failure 400 {object} map[string]interface{} "Invalid filter"
+// @Failure 403 {object} map[string]interface{} "Permission required"
+// @Router /api/v1/admin/rating-flags [get]
+func (h *RatingFlagHandler) GetRatingFlags(c *gin.Context) {
+	filter := model.RatingFlagFilter{Status: model.RatingFlagStatus(c.Query("status"))}
+	if raw := c.Query("service_id"); raw != "" {
+		serviceID, err := uuid.Parse(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid service ID"})
+			return
+		}
+		filter.ServiceID = &serviceID
+	}
+	params := extractPaginationParams(c)
+
+	flags, total, err := h.service.GetFlags(c.Request.Context(), filter, params)
+	if err != nil {
+		h.log.WithError(err).Error("Failed to get rating flags")
+		c.JSON(ratingFlagErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"items":  flags,
+		"total":  total,
+		"limit":  params.GetLimit(),
+		"offset": params.GetOffset(),
+	})
+}
+
+// GetRatingFlag handles retrieving a flagged rating
+// @Summary Get a rating flag
+// @Description Retrieve a rating flag with the signals raised and the client the rating came from
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param flagID path string true "Flag ID" format(uuid)
+// @Success 200 {object} model.RatingFlag "Rating flag"
+// @Failure 403 {object} map[string]interface{} "Permission required"
+// @Failure 404 {object} map[string]interface{} "Rating flag not found"
+// @Router /api/v1/admin/rating-flags/{flagID} [get]
+func (h *RatingFlagHandler) GetRatingFlag(c *gin.Context) {
+	flagID, ok := ratingFlagID(c)
+	if !ok {
+		return
+	}
+
+	flag, err := h.service.GetFlag(c.Request.Context(), flagID)
+	if err != nil {
+		h.log.WithError(err).Error("Failed to get rating flag")
+		c.JSON(ratingFlagErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, flag)
+}
+
+// ResolveRatingFlag handles clearing or confirming a flagged rating
+// @Summary Resolve a rating flag
+// @Description Clear a flag to release its rating from quarantine, or confirm it to keep the rating out of public listings and averages
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param flagID path string true "Flag ID" format(uuid)
+// @Param request body ResolveRatingFlagRequest true "Resolution"
+// @Success 200 {object} model.RatingFlag "Resolved flag"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 403 {object} map[string]interface{} "Permission required"
+// @Failure 404 {object} map[string]interface{} "Rating flag not found"
+// @Failure 409 {object} map[string]interface{} "Rating flag already resolved"
+// @Router /api/v1/admin/rating-flags/{flagID}/resolve [post]
+func (h *RatingFlagHandler) ResolveRatingFlag(c *gin.Context) {
+	moderatorID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	flagID, ok := ratingFlagID(c)
+	if !ok {
+		return
+	}
+
+	var req ResolveRatingFlagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": validator.FormatValidationErrors(err)})
+		return
+	}
+
+	flag, err := h.service.ResolveFlag(c.Request.Context(), moderatorID, flagID, model.RatingFlagStatus(req.Status))
+	if err != nil {
+		h.log.WithError(err).Error("Failed to resolve rating flag")
+		c.JSON(ratingFlagErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, flag)
+}
+
+// ratingFlagID reads the flag ID from the path, writing an error response if it is invalid
+func ratingFlagID(c *gin.Context) (uuid.UUID, bool) {
+	flagID, err := uuid.Parse(c.Param("flagID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid flag ID"})
+		return uuid.Nil, false
+	}
+	return flagID, true
+}
+
+// ratingFlagErrorStatus maps rating flag errors to HTTP status codes
+func ratingFlagErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, model.ErrInvalidFlagResolution), errors.Is(err, model.ErrInvalidRatingFlagState):
+		return http.StatusBadRequest
+	case errors.Is(err, model.ErrRatingFlagNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, model.ErrRatingFlagResolved):
+		return http.StatusConflict
+	default:
+		return errorStatus(err)
+	}
+}
@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"rating-system/internal/domain/model"
+	"rating-system/pkg/validator"
+)
+
+// ToggleReactionRequest is the request for toggling a reaction
+type ToggleReactionRequest struct {
+	Emoji string `json:"emoji" binding:"required"`
+}
+
+// GetReactionEmojis handles listing the emojis users can react with
+// @Summary List reaction emojis
+// @Description Retrieve the set of emojis that can be used as reactions
+// @Tags reactions
+// @Produce json
+// @Success 200 {object} map[string]interface{} "Allowed emojis"
+// @Router /api/v1/reactions [get]
+func (h *Handler) GetReactionEmojis(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"emojis": h.service.GetReactionEmojis(c.Request.Context())})
+}
+
+// ToggleReviewReaction handles adding or removing the authenticated user's reaction to a review
+// @Summary Toggle a reaction on a review
+// @Description Add the emoji reaction, or remove it if the user already left it, and return the review's reaction counts
+// @Tags reactions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param reviewID path string true "Review ID" format(uuid)
+// @Param request body ToggleReactionRequest true "Reaction"
+// @Success 200 {object} model.ReactionToggle "Whether the reaction was added, with updated counts"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 404 {object} map[string]interface{} "Review not found"
+// @Failure 422 {object} map[string]interface{} "Reaction not allowed"
+// @Router /api/v1/reviews/{reviewID}/reactions [post]
+func (h *Handler) ToggleReviewReaction(c *gin.Context) {
+	h.toggleReaction(c, model.ReactionTargetReview, "reviewID")
+}
+
+// ToggleCommentReaction handles adding or removing the authenticated user's reaction to a comment
+// @Summary Toggle a reaction on a comment
+// @Description Add the emoji reaction, or remove it if the user already left it, and return the comment's reaction counts
+// @Tags reactions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param commentID path string true "Comment ID" format(uuid)
+// @Param request body ToggleReactionRequest true "Reaction"
+// @Success 200 {object} model.ReactionToggle "Whether the reaction was added, with updated counts"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 404 {object} map[string]interface{} "Comment not found"
+// @Failure 409 {object} map[string]interface{} "Comment has been deleted"
+// @Failure 422 {object} map[string]interface{} "Reaction not allowed"
+// @Router /api/v1/comments/{commentID}/reactions [post]
+func (h *Handler) ToggleCommentReaction(c *gin.Context) {
+	h.toggleReaction(c, model.ReactionTargetComment, "commentID")
+}
+
+func (h *Handler) toggleReaction(c *gin.Context, targetType model.ReactionTarget, param string) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	targetID, err := uuid.Parse(c.Param(param))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + string(targetType) + " ID"})
+		return
+	}
+
+	var req ToggleReactionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.WithError(err).Error("Invalid request body")
+		c.JSON(http.StatusBadRequest, gin.H{"error": validator.FormatValidationErrors(err)})
+		return
+	}
+
+	toggle, err := h.service.ToggleReaction(c.Request.Context(), userID, targetType, targetID, req.Emoji)
+	if err != nil {
+		h.log.WithError(err).Error("Failed to toggle reaction")
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, toggle)
+}
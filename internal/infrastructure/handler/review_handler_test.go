@@ -56,8 +56,8 @@ func TestCreateReview(t *testing.T) {
 
 	// Setup expectations
 	mockService.EXPECT().
-		CreateReview(gomock.Any(), gomock.Any(), serviceID, ratingID, title, content).
-		Return(review, nil).
+		CreateReview(gomock.Any(), gomock.Any(), serviceID, ratingID, title, content, "", false).
+		Return(&review, nil).
 		Times(1)
 
 	// Test request
@@ -109,8 +109,8 @@ func TestGetReviewByID(t *testing.T) {
 
 	// Setup expectations
 	mockService.EXPECT().
-		GetReviewByID(gomock.Any(), reviewID).
-		Return(review, nil).
+		GetReviewByID(gomock.Any(), reviewID, gomock.Any()).
+		Return(&model.ReviewWithRating{Review: review}, nil).
 		Times(1)
 
 	// Test request
@@ -144,31 +144,31 @@ func TestGetReviewsByService(t *testing.T) {
 
 	// Create test data
 	serviceID := uuid.New()
-	reviews := []model.Review{
-		{
+	reviews := []*model.ReviewWithRating{
+		{Review: model.Review{
 			ID:        uuid.New(),
 			UserID:    uuid.New(),
 			ServiceID: serviceID,
 			RatingID:  uuid.New(),
 			Title:     "Great service",
 			Content:   "I was really impressed with the quality of service provided.",
-		},
-		{
+		}},
+		{Review: model.Review{
 			ID:        uuid.New(),
 			UserID:    uuid.New(),
 			ServiceID: serviceID,
 			RatingID:  uuid.New(),
 			Title:     "Good experience",
 			Content:   "I had a good experience using this service.",
-		},
+		}},
 	}
 	total := 2
 	params := pagination.NewParamsWithOffset(10, 0, "created_at", "desc")
 
 	// Setup expectations
 	mockService.EXPECT().
-		GetReviewsByService(gomock.Any(), serviceID, gomock.Any()).
-		DoAndReturn(func(_ context.Context, _ uuid.UUID, p pagination.Params) ([]model.Review, int, error) {
+		GetReviewsByService(gomock.Any(), serviceID, gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _ uuid.UUID, _ model.ReviewFilter, p pagination.Params) ([]*model.ReviewWithRating, int, error) {
 			assert.Equal(t, params.GetLimit(), p.GetLimit())
 			assert.Equal(t, params.GetOffset(), p.GetOffset())
 			return reviews, total, nil
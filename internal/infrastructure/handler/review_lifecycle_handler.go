@@ -0,0 +1,175 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"rating-system/internal/domain/model"
+)
+
+// GetMyReviews handles listing the authenticated user's own reviews in any state
+// @Summary List my reviews
+// @Description Retrieve the authenticated user's reviews, including drafts, optionally filtered by status
+// @Tags reviews
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Review status" Enums(draft, pending, published, rejected)
+// @Param limit query int false "Number of items per page" default(10)
+// @Param offset query int false "Offset for pagination" default(0)
+// @Success 200 {object} map[string]interface{} "List of reviews with pagination metadata"
+// @Failure 400 {object} map[string]interface{} "Invalid status"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Router /api/v1/users/me/reviews [get]
+func (h *Handler) GetMyReviews(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	status := model.ReviewStatus(c.Query("status"))
+	if status != "" && !status.IsValid() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid review status"})
+		return
+	}
+
+	params := extractPaginationParams(c)
+
+	reviews, total, err := h.service.GetReviewsByUser(c.Request.Context(), userID, status, params)
+	if err != nil {
+		h.log.WithError(err).Error("Failed to get user reviews")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"reviews": reviews,
+		"total":   total,
+		"limit":   params.GetLimit(),
+		"offset":  params.GetOffset(),
+	})
+}
+
+// SubmitReview handles submitting a draft or rejected review for publication
+// @Summary Submit a review
+// @Description Submit the author's draft or rejected review; it is published or queued according to the review policy
+// @Tags reviews
+// @Produce json
+// @Security BearerAuth
+// @Param reviewID path string true "Review ID" format(uuid)
+// @Success 200 {object} model.Review "Submitted review"
+// @Failure 403 {object} map[string]interface{} "Not the review author, or email address not verified"
+// @Failure 409 {object} map[string]interface{} "Review cannot be submitted in its current state"
+// @Router /api/v1/reviews/{reviewID}/submit [post]
+func (h *Handler) SubmitReview(c *gin.Context) {
+	userID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	reviewID, err := uuid.Parse(c.Param("reviewID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid review ID"})
+		return
+	}
+
+	// Keys restricted to a service can only submit reviews of that service
+	principal, ok := getPrincipal(c)
+	if !ok {
+		return
+	}
+	if principal.ServiceID != nil {
+		review, err := h.service.GetReviewByID(c.Request.Context(), reviewID, userID)
+		if err != nil {
+			c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+			return
+		}
+		if !requireServiceAccess(c, review.ServiceID) {
+			return
+		}
+	}
+
+	review, err := h.service.SubmitReview(c.Request.Context(), userID, reviewID)
+	if err != nil {
+		h.log.WithError(err).Error("Failed to submit review")
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, review)
+}
+
+// GetPendingReviews handles listing the moderation queue of pending reviews
+// @Summary List pending reviews
+// @Description Retrieve reviews awaiting moderation or the end of their cool-down, oldest first
+// @Tags moderation
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Number of items per page" default(10)
+// @Param offset query int false "Offset for pagination" default(0)
+// @Success 200 {object} map[string]interface{} "List of reviews with pagination metadata"
+// @Failure 403 {object} map[string]interface{} "Moderator access required"
+// @Router /api/v1/moderation/reviews [get]
+func (h *Handler) GetPendingReviews(c *gin.Context) {
+	params := extractPaginationParams(c)
+
+	reviews, total, err := h.service.GetPendingReviews(c.Request.Context(), params)
+	if err != nil {
+		h.log.WithError(err).Error("Failed to get pending reviews")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"reviews": reviews,
+		"total":   total,
+		"limit":   params.GetLimit(),
+		"offset":  params.GetOffset(),
+	})
+}
+
+// ApproveReview handles publishing a pending review
+// @Summary Approve a review
+// @Tags moderation
+// @Produce json
+// @Security BearerAuth
+// @Param reviewID path string true "Review ID" format(uuid)
+// @Success 200 {object} model.Review "Published review"
+// @Failure 409 {object} map[string]interface{} "Review is not pending"
+// @Router /api/v1/moderation/reviews/{reviewID}/approve [post]
+func (h *Handler) ApproveReview(c *gin.Context) {
+	h.moderateReview(c, h.service.ApproveReview)
+}
+
+// RejectReview handles declining a pending review
+// @Summary Reject a review
+// @Tags moderation
+// @Produce json
+// @Security BearerAuth
+// @Param reviewID path string true "Review ID" format(uuid)
+// @Success 200 {object} model.Review "Rejected review"
+// @Failure 409 {object} map[string]interface{} "Review is not pending"
+// @Router /api/v1/moderation/reviews/{reviewID}/reject [post]
+func (h *Handler) RejectReview(c *gin.Context) {
+	h.moderateReview(c, h.service.RejectReview)
+}
+
+// moderateReview applies a moderation decision to the review named in the path
+func (h *Handler) moderateReview(c *gin.Context, decide func(ctx context.Context, id uuid.UUID) (*model.Review, error)) {
+	reviewID, err := uuid.Parse(c.Param("reviewID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid review ID"})
+		return
+	}
+
+	review, err := decide(c.Request.Context(), reviewID)
+	if err != nil {
+		h.log.WithError(err).Error("Failed to moderate review")
+		c.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, review)
+}
@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"rating-system/internal/domain/model"
+	"rating-system/internal/domain/port"
+	domainService "rating-system/internal/domain/service"
+	"rating-system/pkg/validator"
+)
+
+// RoleHandler handles granting and revoking user roles
+type RoleHandler struct {
+	service port.RoleService
+	log     *logrus.Logger
+}
+
+// NewRoleHandler creates a new role handler
+func NewRoleHandler(service port.RoleService, log *logrus.Logger) *RoleHandler {
+	return &RoleHandler{
+		service: service,
+		log:     log,
+	}
+}
+
+// GrantRoleRequest is the request for granting a role to a user
+type GrantRoleRequest struct {
+	Role string `json:"role" binding:"required,oneof=service_owner moderator admin"`
+}
+
+// GetUserRoles handles listing the roles granted to a user
+// @Summary List user roles
+// @Description Retrieve the roles granted to a user; every user also holds the implicit user role
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param userID path string true "User ID" format(uuid)
+// @Success 200 {object} map[string]interface{} "Role grants"
+// @Failure 403 {object} map[string]interface{} "Permission required"
+// @Failure 404 {object} map[string]interface{} "User not found"
+// @Router /api/v1/admin/users/{userID}/roles [get]
+func (h *RoleHandler) GetUserRoles(c *gin.Context) {
+	userID, ok := roleUserID(c)
+	if !ok {
+		return
+	}
+
+	grants, err := h.service.GetRoles(c.Request.Context(), userID)
+	if err != nil {
+		h.log.WithError(err).Error("Failed to get user roles")
+		c.JSON(roleErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"roles": grants})
+}
+
+// GrantRole handles granting a role to a user
+// @Summary Grant a role
+// @Description Grant a role to a user; it is embedded in the tokens the user is issued from their next login
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param userID path string true "User ID" format(uuid)
+// @Param request body GrantRoleRequest true "Role"
+// @Success 201 {object} model.RoleGrant "Granted role"
+// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 403 {object} map[string]interface{} "Permission required"
+// @Failure 404 {object} map[string]interface{} "User not found"
+// @Failure 409 {object} map[string]interface{} "Role already granted"
+// @Router /api/v1/admin/users/{userID}/roles [post]
+func (h *RoleHandler) GrantRole(c *gin.Context) {
+	adminID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	userID, ok := roleUserID(c)
+	if !ok {
+		return
+	}
+
+	var req GrantRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": validator.FormatValidationErrors(err)})
+		return
+	}
+
+	grant, err := h.service.GrantRole(c.Request.Context(), adminID, userID, model.Role(req.Role))
+	if err != nil {
+		h.log.WithError(err).Error("Failed to grant role")
+		c.JSON(roleErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, grant)
+}
+
+// RevokeRole handles revoking a role from a user
+// @Summary Revoke a role
+// @Description Revoke a role from a user; the last admin cannot lose the admin role
+// @Tags admin
+// @Security BearerAuth
+// @Param userID path string true "User ID" format(uuid)
+// @Param role path string true "Role" Enums(service_owner, moderator, admin)
+// @Success 204 "Role revoked"
+// @Failure 400 {object} map[string]interface{} "Invalid role"
+// @Failure 403 {object} map[string]interface{} "Permission required"
+// @Failure 404 {object} map[string]interface{} "Role not granted"
+// @Failure 409 {object} map[string]interface{} "Last admin"
+// @Router /api/v1/admin/users/{userID}/roles/{role} [delete]
+func (h *RoleHandler) RevokeRole(c *gin.Context) {
+	adminID, ok := getUserID(c)
+	if !ok {
+		return
+	}
+
+	userID, ok := roleUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.RevokeRole(c.Request.Context(), adminID, userID, model.Role(c.Param("role"))); err != nil {
+		h.log.WithError(err).Error("Failed to revoke role")
+		c.JSON(roleErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// roleUserID reads the user ID from the path, writing an error response if it is invalid
+func roleUserID(c *gin.Context) (uuid.UUID, bool) {
+	userID, err := uuid.Parse(c.Param("userID"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return uuid.Nil, false
+	}
+	return userID, true
+}
+
+// roleErrorStatus maps role management errors to HTTP status codes
+func roleErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, model.ErrInvalidRole):
+		return http.StatusBadRequest
+	case errors.Is(err, domainService.ErrUserNotFound), errors.Is(err, model.ErrRoleNotGranted):
+		return http.StatusNotFound
+	case errors.Is(err, model.ErrRoleAlreadyGranted), errors.Is(err, model.ErrLastAdmin):
+		return http.StatusConflict
+	default:
+		return errorStatus(err)
+	}
+}
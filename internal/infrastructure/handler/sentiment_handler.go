@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// GetSentimentSummary handles retrieving the aggregated review sentiment for a service
+// @Summary Get review sentiment for a service
+// @Description Retrieve the average text sentiment (-1 to 1) and positive/neutral/negative counts of a service's published reviews
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Param serviceID path string true "Service ID" format(uuid)
+// @Success 200 {object} model.SentimentSummary "Sentiment summary"
+// @Failure 400 {object} map[string]interface{} "Invalid service ID"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Router /api/v1/reviews/service/{serviceID}/sentiment [get]
+func (h *Handler) GetSentimentSummary(c *gin.Context) {
+	serviceID, err := uuid.Parse(c.Param("serviceID"))
+	if err != nil {
+		h.log.WithError(err).Error("Invalid service ID")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid service ID"})
+		return
+	}
+
+	summary, err := h.service.GetSentimentSummary(c.Request.Context(), serviceID, optionalUserID(c))
+	if err != nil {
+		h.log.WithError(err).Error("Failed to get sentiment summary")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, summary)
+}
@@ -0,0 +1,198 @@
+package highlights
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"rating-system/internal/domain/model"
+)
+
+const (
+	// maxNGram is the longest phrase extracted, in words
+	maxNGram = 3
+	// highScore is the lowest star score counted as a high-score review
+	highScore = 4
+	// lowScore is the highest star score counted as a low-score review
+	lowScore = 2
+	// minWordLength is the shortest single word kept as a phrase
+	minWordLength = 3
+)
+
+// stopWords never start or end a phrase
+var stopWords = toSet(`a about above after again against all also am an and any are as at be because been
+before being below between both but by can could did do does doing down during each even ever every few for
+from further get got had has have having he her here hers herself him himself his how i if in into is it its
+itself just let me more most much my myself no nor not now of off on once one only or other our ours
+ourselves out over own really same she should so some still such than that the their theirs them themselves
+then there these they this those through to too under until up us very was way we were what when where which
+while who whom why will with would you your yours yourself yourselves im ive id youre theyre dont didnt doesnt
+isnt wasnt arent werent wont cant couldnt wouldnt shouldnt thats theres its lot lots bit thing things
+something anything everything`)
+
+func toSet(words string) map[string]bool {
+	set := make(map[string]bool)
+	for _, word := range strings.Fields(words) {
+		set[word] = true
+	}
+	return set
+}
+
+// Stats accumulates phrase document frequencies over a service's reviews,
+// split into high-score and low-score reviews. Stats is not safe for concurrent use.
+type Stats struct {
+	docs     int
+	highDocs int
+	lowDocs  int
+	df       map[string]int
+	highDF   map[string]int
+	lowDF    map[string]int
+}
+
+// NewStats creates empty statistics
+func NewStats() *Stats {
+	return &Stats{
+		df:     make(map[string]int),
+		highDF: make(map[string]int),
+		lowDF:  make(map[string]int),
+	}
+}
+
+// Reviews returns the number of reviews added
+func (s *Stats) Reviews() int {
+	return s.docs
+}
+
+// Add counts the phrases of one review with the given star score
+func (s *Stats) Add(text string, score int) {
+	s.docs++
+	high, low := score >= highScore, score <= lowScore
+	if high {
+		s.highDocs++
+	}
+	if low {
+		s.lowDocs++
+	}
+
+	for phrase := range extractPhrases(text) {
+		s.df[phrase]++
+		if high {
+			s.highDF[phrase]++
+		}
+		if low {
+			s.lowDF[phrase]++
+		}
+	}
+}
+
+// Rank returns up to limit pros and cons. A phrase is scored by how much more often it appears
+// in high-score than in low-score reviews (or vice versa), weighted by its inverse document
+// frequency across all of the service's reviews. Phrases mentioned in fewer than minMentions
+// reviews are ignored, as are phrases overlapping a better-ranked one.
+func (s *Stats) Rank(limit, minMentions int) (pros, cons []model.Highlight) {
+	var proCandidates, conCandidates []model.Highlight
+	for phrase, df := range s.df {
+		idf := math.Log(float64(1+s.docs)/float64(1+df)) + 1
+		boost := 1 + 0.25*float64(strings.Count(phrase, " "))
+		diff := frequency(s.highDF[phrase], s.highDocs) - frequency(s.lowDF[phrase], s.lowDocs)
+
+		switch {
+		case diff > 0 && s.highDF[phrase] >= minMentions:
+			proCandidates = append(proCandidates, model.Highlight{Phrase: phrase, Score: round(diff * idf * boost), Mentions: s.highDF[phrase]})
+		case diff < 0 && s.lowDF[phrase] >= minMentions:
+			conCandidates = append(conCandidates, model.Highlight{Phrase: phrase, Score: round(-diff * idf * boost), Mentions: s.lowDF[phrase]})
+		}
+	}
+	return selectTop(proCandidates, limit), selectTop(conCandidates, limit)
+}
+
+func frequency(count, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(count) / float64(total)
+}
+
+func round(score float64) float64 {
+	return math.Round(score*10000) / 10000
+}
+
+// selectTop sorts candidates by score and keeps the best ones that do not overlap each other,
+// so "friendly staff" and "staff" are not both listed
+func selectTop(candidates []model.Highlight, limit int) []model.Highlight {
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].Score != candidates[j].Score {
+			return candidates[i].Score > candidates[j].Score
+		}
+		return candidates[i].Phrase < candidates[j].Phrase
+	})
+
+	selected := make([]model.Highlight, 0, limit)
+	for _, candidate := range candidates {
+		if len(selected) == limit {
+			break
+		}
+		if !overlapsAny(candidate.Phrase, selected) {
+			selected = append(selected, candidate)
+		}
+	}
+	return selected
+}
+
+func overlapsAny(phrase string, selected []model.Highlight) bool {
+	padded := " " + phrase + " "
+	for _, other := range selected {
+		otherPadded := " " + other.Phrase + " "
+		if strings.Contains(otherPadded, padded) || strings.Contains(padded, otherPadded) {
+			return true
+		}
+	}
+	return false
+}
+
+// extractPhrases returns the distinct n-grams of text. Phrases do not cross punctuation,
+// and never start or end with a stop word.
+func extractPhrases(text string) map[string]struct{} {
+	phrases := make(map[string]struct{})
+	for _, segment := range splitSegments(text) {
+		words := tokenize(segment)
+		for n := 1; n <= maxNGram; n++ {
+			for i := 0; i+n <= len(words); i++ {
+				gram := words[i : i+n]
+				if stopWords[gram[0]] || stopWords[gram[n-1]] || isNumber(gram[0]) || isNumber(gram[n-1]) {
+					continue
+				}
+				if n == 1 && len([]rune(gram[0])) < minWordLength {
+					continue
+				}
+				phrases[strings.Join(gram, " ")] = struct{}{}
+			}
+		}
+	}
+	return phrases
+}
+
+// splitSegments splits text at punctuation that ends a clause
+func splitSegments(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return strings.ContainsRune(".,;:!?()[]{}\"\n\r", r)
+	})
+}
+
+// tokenize lower-cases a segment and splits it into words, folding apostrophes ("don't" becomes "dont")
+func tokenize(segment string) []string {
+	segment = strings.NewReplacer("'", "", "’", "").Replace(strings.ToLower(segment))
+	return strings.FieldsFunc(segment, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func isNumber(word string) bool {
+	for _, r := range word {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
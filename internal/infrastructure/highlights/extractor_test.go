@@ -0,0 +1,58 @@
+package highlights
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractPhrases(t *testing.T) {
+	got := extractPhrases("The staff were friendly. Great coffee, but 20 minute wait!")
+
+	for _, want := range []string{"staff", "friendly", "staff were friendly", "great coffee", "coffee", "minute wait"} {
+		assert.Contains(t, got, want)
+	}
+	for _, unwanted := range []string{"the", "the staff", "coffee but", "friendly great", "20", "20 minute"} {
+		assert.NotContains(t, got, unwanted)
+	}
+}
+
+func TestStatsRank(t *testing.T) {
+	stats := NewStats()
+	stats.Add("Friendly staff and great coffee.", 5)
+	stats.Add("Great coffee, friendly staff!", 5)
+	stats.Add("Really friendly staff. Nice location.", 4)
+	stats.Add("Long wait and cold food.", 1)
+	stats.Add("Cold food, long wait for a table.", 2)
+	stats.Add("The location is fine.", 3)
+
+	pros, cons := stats.Rank(5, 2)
+
+	var proPhrases, conPhrases []string
+	for _, h := range pros {
+		proPhrases = append(proPhrases, h.Phrase)
+	}
+	for _, h := range cons {
+		conPhrases = append(conPhrases, h.Phrase)
+	}
+
+	assert.Equal(t, "friendly staff", proPhrases[0])
+	assert.Equal(t, 3, pros[0].Mentions)
+	assert.Contains(t, proPhrases, "great coffee")
+	assert.NotContains(t, proPhrases, "staff", "overlapping phrases are suppressed")
+	assert.NotContains(t, proPhrases, "nice location", "single mentions are ignored")
+
+	assert.Contains(t, conPhrases, "cold food")
+	assert.Contains(t, conPhrases, "long wait")
+	assert.Equal(t, 6, stats.Reviews())
+}
+
+func TestStatsRankWithoutLowScores(t *testing.T) {
+	stats := NewStats()
+	stats.Add("Great coffee", 5)
+	stats.Add("Great coffee", 4)
+
+	pros, cons := stats.Rank(10, 2)
+	assert.Len(t, pros, 1)
+	assert.Empty(t, cons)
+}
@@ -0,0 +1,197 @@
+package highlights
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"rating-system/internal/domain/model"
+)
+
+// ReviewSource pages through a service's published reviews in publication order
+type ReviewSource interface {
+	GetPublishedReviewsAfter(ctx context.Context, serviceID uuid.UUID, publishedAt time.Time, afterID uuid.UUID, limit int) ([]*model.ReviewWithRating, error)
+}
+
+// Config tunes highlight extraction and caching
+type Config struct {
+	// CacheTTL is how long highlights are served before checking for newly published reviews
+	CacheTTL time.Duration
+	// RebuildInterval is how often statistics are rebuilt from scratch to account for edited and removed reviews
+	RebuildInterval time.Duration
+	// Limit is the maximum number of pros and of cons returned
+	Limit int
+	// MinMentions is the minimum number of reviews that must mention a phrase
+	MinMentions int
+	// BatchSize is the number of reviews loaded per query
+	BatchSize int
+}
+
+// DefaultConfig returns the default configuration
+func DefaultConfig() Config {
+	return Config{
+		CacheTTL:        10 * time.Minute,
+		RebuildInterval: 24 * time.Hour,
+		Limit:           10,
+		MinMentions:     2,
+		BatchSize:       500,
+	}
+}
+
+// Service extracts and caches per-service highlights. Statistics are kept per service and
+// updated incrementally: each refresh only reads reviews published after the last one seen.
+type Service struct {
+	source  ReviewSource
+	cfg     Config
+	log     *logrus.Logger
+	now     func() time.Time
+	mu      sync.Mutex
+	entries map[uuid.UUID]*entry
+}
+
+// entry is the cached state of one service
+type entry struct {
+	mu          sync.Mutex
+	stats       *Stats
+	builtAt     time.Time
+	cursorAt    time.Time
+	cursorID    uuid.UUID
+	highlights  *model.ServiceHighlights
+	refreshedAt time.Time
+}
+
+// NewService creates a highlights service; zero config values fall back to the defaults
+func NewService(source ReviewSource, cfg Config, log *logrus.Logger) *Service {
+	defaults := DefaultConfig()
+	if cfg.CacheTTL <= 0 {
+		cfg.CacheTTL = defaults.CacheTTL
+	}
+	if cfg.RebuildInterval <= 0 {
+		cfg.RebuildInterval = defaults.RebuildInterval
+	}
+	if cfg.Limit <= 0 {
+		cfg.Limit = defaults.Limit
+	}
+	if cfg.MinMentions <= 0 {
+		cfg.MinMentions = defaults.MinMentions
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaults.BatchSize
+	}
+
+	return &Service{
+		source:  source,
+		cfg:     cfg,
+		log:     log,
+		now:     time.Now,
+		entries: make(map[uuid.UUID]*entry),
+	}
+}
+
+// GetHighlights returns the cached highlights for a service, refreshing them once the cache
+// has expired. If a refresh fails, the previous highlights are served.
+func (s *Service) GetHighlights(ctx context.Context, serviceID uuid.UUID) (*model.ServiceHighlights, error) {
+	e := s.entry(serviceID, true)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	now := s.now()
+	if e.highlights != nil && now.Sub(e.refreshedAt) < s.cfg.CacheTTL {
+		return e.highlights, nil
+	}
+
+	if err := s.refresh(ctx, serviceID, e, now); err != nil {
+		if e.highlights == nil {
+			return nil, err
+		}
+		s.log.WithError(err).WithField("service_id", serviceID).Warn("Failed to refresh highlights, serving cached result")
+	}
+	return e.highlights, nil
+}
+
+// HandleReviewPublished folds newly published reviews into the highlights of services that
+// are already cached. Services nobody has asked for are built lazily on first request.
+func (s *Service) HandleReviewPublished(ctx context.Context, event model.Event) {
+	published, ok := event.(model.ReviewPublishedEvent)
+	if !ok {
+		return
+	}
+
+	e := s.entry(published.ServiceID, false)
+	if e == nil {
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if err := s.refresh(ctx, published.ServiceID, e, s.now()); err != nil {
+		s.log.WithError(err).WithField("service_id", published.ServiceID).Error("Failed to update highlights")
+	}
+}
+
+// entry returns the state of a service, creating it if requested
+func (s *Service) entry(serviceID uuid.UUID, create bool) *entry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.entries[serviceID]
+	if !ok && create {
+		e = &entry{}
+		s.entries[serviceID] = e
+	}
+	return e
+}
+
+// refresh reads reviews published since the entry's cursor and recomputes its highlights.
+// Statistics are rebuilt from scratch once they are older than the rebuild interval.
+// The caller must hold e.mu.
+func (s *Service) refresh(ctx context.Context, serviceID uuid.UUID, e *entry, now time.Time) error {
+	if e.stats == nil || now.Sub(e.builtAt) >= s.cfg.RebuildInterval {
+		e.stats = NewStats()
+		e.builtAt = now
+		e.cursorAt = time.Time{}
+		e.cursorID = uuid.Nil
+	}
+
+	for {
+		reviews, err := s.source.GetPublishedReviewsAfter(ctx, serviceID, e.cursorAt, e.cursorID, s.cfg.BatchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, review := range reviews {
+			e.stats.Add(review.Title+". "+review.Content, review.Score)
+			e.cursorAt = publishedAt(review)
+			e.cursorID = review.ID
+		}
+
+		if len(reviews) < s.cfg.BatchSize {
+			break
+		}
+	}
+
+	pros, cons := e.stats.Rank(s.cfg.Limit, s.cfg.MinMentions)
+	e.highlights = &model.ServiceHighlights{
+		ServiceID:   serviceID,
+		Pros:        pros,
+		Cons:        cons,
+		ReviewCount: e.stats.Reviews(),
+		GeneratedAt: now,
+	}
+	e.refreshedAt = now
+	return nil
+}
+
+// publishedAt mirrors the repository ordering, which falls back to the creation time for
+// reviews published before publication times were recorded
+func publishedAt(review *model.ReviewWithRating) time.Time {
+	if review.PublishedAt != nil {
+		return *review.PublishedAt
+	}
+	return review.CreatedAt
+}
@@ -0,0 +1,152 @@
+package highlights
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"rating-system/internal/domain/model"
+)
+
+// fakeSource serves reviews in publication order and records how many it has returned
+type fakeSource struct {
+	reviews []*model.ReviewWithRating
+	served  int
+	err     error
+}
+
+func (f *fakeSource) add(serviceID uuid.UUID, publishedAt time.Time, score int, content string) {
+	review := &model.ReviewWithRating{Score: score}
+	review.ID = uuid.New()
+	review.ServiceID = serviceID
+	review.Title = "Review"
+	review.Content = content
+	review.PublishedAt = &publishedAt
+	f.reviews = append(f.reviews, review)
+	sort.Slice(f.reviews, func(i, j int) bool {
+		return f.reviews[i].PublishedAt.Before(*f.reviews[j].PublishedAt)
+	})
+}
+
+func (f *fakeSource) GetPublishedReviewsAfter(ctx context.Context, serviceID uuid.UUID, publishedAt time.Time, afterID uuid.UUID, limit int) ([]*model.ReviewWithRating, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	var page []*model.ReviewWithRating
+	for _, review := range f.reviews {
+		if review.ServiceID != serviceID || !review.PublishedAt.After(publishedAt) {
+			continue
+		}
+		if len(page) == limit {
+			break
+		}
+		page = append(page, review)
+	}
+	f.served += len(page)
+	return page, nil
+}
+
+func newTestService(source ReviewSource, now *time.Time) *Service {
+	log := logrus.New()
+	log.SetLevel(logrus.PanicLevel)
+
+	s := NewService(source, Config{CacheTTL: time.Minute, BatchSize: 2}, log)
+	s.now = func() time.Time { return *now }
+	return s
+}
+
+func TestServiceCachesAndUpdatesIncrementally(t *testing.T) {
+	ctx := context.Background()
+	serviceID := uuid.New()
+	now := time.Now()
+	source := &fakeSource{}
+	base := now.Add(-time.Hour)
+	source.add(serviceID, base.Add(1*time.Second), 5, "Friendly staff")
+	source.add(serviceID, base.Add(2*time.Second), 4, "Friendly staff")
+	source.add(serviceID, base.Add(3*time.Second), 1, "Cold food")
+
+	s := newTestService(source, &now)
+
+	highlights, err := s.GetHighlights(ctx, serviceID)
+	require.NoError(t, err)
+	assert.Equal(t, 3, highlights.ReviewCount)
+	require.Len(t, highlights.Pros, 1)
+	assert.Equal(t, "friendly staff", highlights.Pros[0].Phrase)
+	assert.Empty(t, highlights.Cons)
+	assert.Equal(t, 3, source.served)
+
+	// Served from cache
+	_, err = s.GetHighlights(ctx, serviceID)
+	require.NoError(t, err)
+	assert.Equal(t, 3, source.served)
+
+	// A published review only reads the new review
+	source.add(serviceID, base.Add(4*time.Second), 2, "Cold food again")
+	s.HandleReviewPublished(ctx, model.ReviewPublishedEvent{ServiceID: serviceID})
+	assert.Equal(t, 4, source.served)
+
+	highlights, err = s.GetHighlights(ctx, serviceID)
+	require.NoError(t, err)
+	assert.Equal(t, 4, highlights.ReviewCount)
+	require.Len(t, highlights.Cons, 1)
+	assert.Equal(t, "cold food", highlights.Cons[0].Phrase)
+}
+
+func TestServiceIgnoresUncachedServices(t *testing.T) {
+	now := time.Now()
+	source := &fakeSource{}
+	source.add(uuid.New(), now, 5, "Great")
+
+	s := newTestService(source, &now)
+	s.HandleReviewPublished(context.Background(), model.ReviewPublishedEvent{ServiceID: source.reviews[0].ServiceID})
+
+	assert.Equal(t, 0, source.served)
+}
+
+func TestServiceServesStaleResultOnError(t *testing.T) {
+	ctx := context.Background()
+	serviceID := uuid.New()
+	now := time.Now()
+	source := &fakeSource{}
+	source.add(serviceID, now.Add(-time.Hour), 5, "Friendly staff")
+
+	s := newTestService(source, &now)
+	first, err := s.GetHighlights(ctx, serviceID)
+	require.NoError(t, err)
+
+	now = now.Add(2 * time.Minute)
+	source.err = errors.New("database unavailable")
+
+	second, err := s.GetHighlights(ctx, serviceID)
+	require.NoError(t, err)
+	assert.Same(t, first, second)
+
+	_, err = s.GetHighlights(ctx, uuid.New())
+	assert.Error(t, err)
+}
+
+func TestServiceRebuildsPeriodically(t *testing.T) {
+	ctx := context.Background()
+	serviceID := uuid.New()
+	now := time.Now()
+	source := &fakeSource{}
+	source.add(serviceID, now.Add(-time.Hour), 5, "Friendly staff")
+
+	s := newTestService(source, &now)
+	_, err := s.GetHighlights(ctx, serviceID)
+	require.NoError(t, err)
+
+	now = now.Add(25 * time.Hour)
+	highlights, err := s.GetHighlights(ctx, serviceID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, highlights.ReviewCount)
+	assert.Equal(t, 2, source.served)
+}
@@ -0,0 +1,94 @@
+// Package loginattempts keeps track of failed logins so repeated guessing can be slowed down.
+package loginattempts
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often the memory store drops entries that have expired
+const sweepInterval = time.Minute
+
+// entry is the failure count of a key
+type entry struct {
+	failures int
+	last     time.Time
+	expires  time.Time
+}
+
+// MemoryStore keeps failed login counts in process memory. Each instance of the service keeps its
+// own, so an attacker spreading attempts over instances gets more of them.
+type MemoryStore struct {
+	mu        sync.Mutex
+	entries   map[string]entry
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// NewMemoryStore creates an empty in-process store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		entries: make(map[string]entry),
+		now:     time.Now,
+	}
+}
+
+// Failures implements port.LoginAttemptStore
+func (s *MemoryStore) Failures(ctx context.Context, key string) (int, time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.entries[key]
+	if !ok || !e.expires.After(s.now()) {
+		return 0, time.Time{}, nil
+	}
+	return e.failures, e.last, nil
+}
+
+// RecordFailure implements port.LoginAttemptStore
+func (s *MemoryStore) RecordFailure(ctx context.Context, key string, at time.Time, ttl time.Duration) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.maybeSweep()
+	e := s.entries[key]
+	if !e.expires.After(s.now()) {
+		e = entry{}
+	}
+	e.failures++
+	e.last = at
+	e.expires = at.Add(ttl)
+	s.entries[key] = e
+	return e.failures, nil
+}
+
+// Reset implements port.LoginAttemptStore
+func (s *MemoryStore) Reset(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.entries, key)
+	return nil
+}
+
+// Len returns the number of keys with failures held
+func (s *MemoryStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.entries)
+}
+
+// maybeSweep drops expired entries if the last sweep was long enough ago
+func (s *MemoryStore) maybeSweep() {
+	now := s.now()
+	if now.Sub(s.lastSweep) < sweepInterval {
+		return
+	}
+	for key, e := range s.entries {
+		if !e.expires.After(now) {
+			delete(s.entries, key)
+		}
+	}
+	s.lastSweep = now
+}
@@ -0,0 +1,58 @@
+package loginattempts
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStoreCountsFailures(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	store := NewMemoryStore()
+	store.now = func() time.Time { return now }
+
+	for i := 1; i <= 3; i++ {
+		count, err := store.RecordFailure(ctx, "account:alice@example.com", now, time.Hour)
+		require.NoError(t, err)
+		assert.Equal(t, i, count)
+	}
+
+	failures, last, err := store.Failures(ctx, "account:alice@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, 3, failures)
+	assert.Equal(t, now, last)
+
+	failures, _, err = store.Failures(ctx, "client:192.0.2.1")
+	require.NoError(t, err)
+	assert.Equal(t, 0, failures)
+
+	require.NoError(t, store.Reset(ctx, "account:alice@example.com"))
+	failures, _, err = store.Failures(ctx, "account:alice@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, 0, failures)
+}
+
+func TestMemoryStoreForgetsFailures(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	store := NewMemoryStore()
+	store.now = func() time.Time { return now }
+
+	_, err := store.RecordFailure(ctx, "a", now, time.Hour)
+	require.NoError(t, err)
+
+	// Once the ttl passes without another failure, counting starts over
+	now = now.Add(2 * time.Hour)
+	failures, _, err := store.Failures(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, 0, failures)
+
+	count, err := store.RecordFailure(ctx, "b", now, time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, 1, store.Len())
+}
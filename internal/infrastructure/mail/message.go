@@ -0,0 +1,50 @@
+// Package mail sends emails, either over SMTP or into an outbox directory for development and
+// tests.
+package mail
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"rating-system/internal/domain/model"
+)
+
+// render formats a message as an RFC 5322 email with a plain text body
+func render(from string, msg *model.EmailMessage, now time.Time) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", now.Format(time.RFC1123Z))
+	fmt.Fprintf(&buf, "Message-ID: <%s@%s>\r\n", uuid.New(), domain(from))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	buf.WriteString("\r\n")
+	buf.WriteString(strings.ReplaceAll(strings.ReplaceAll(msg.Body, "\r\n", "\n"), "\n", "\r\n"))
+	return buf.Bytes()
+}
+
+// validate rejects messages whose headers could be used to inject other headers
+func validate(msg *model.EmailMessage) error {
+	if msg.To == "" {
+		return fmt.Errorf("email has no recipient")
+	}
+	if strings.ContainsAny(msg.To, "\r\n") || strings.ContainsAny(msg.Subject, "\r\n") {
+		return fmt.Errorf("email headers must not contain line breaks")
+	}
+	return nil
+}
+
+// domain returns the domain part of an address, used for message IDs
+func domain(address string) string {
+	if i := strings.LastIndex(address, "@"); i >= 0 {
+		return strings.TrimSuffix(address[i+1:], ">")
+	}
+	return "localhost"
+}
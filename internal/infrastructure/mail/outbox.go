@@ -0,0 +1,49 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+
+	"rating-system/internal/domain/model"
+)
+
+// OutboxMailer writes emails to .eml files in a directory instead of sending them, for
+// development and tests. The files can be opened with any mail client.
+type OutboxMailer struct {
+	dir  string
+	from string
+	now  func() time.Time
+}
+
+// NewOutboxMailer creates a mailer writing into dir, creating the directory if needed
+func NewOutboxMailer(dir, from string) (*OutboxMailer, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create mail outbox: %w", err)
+	}
+	return &OutboxMailer{dir: dir, from: from, now: time.Now}, nil
+}
+
+// Send writes a message to the outbox. The file is written under a temporary name and renamed,
+// so readers never see partial messages.
+func (m *OutboxMailer) Send(ctx context.Context, msg *model.EmailMessage) error {
+	if err := validate(msg); err != nil {
+		return err
+	}
+
+	now := m.now()
+	name := fmt.Sprintf("%s-%s.eml", now.UTC().Format("20060102T150405.000000000"), uuid.New())
+	tmp := filepath.Join(m.dir, "."+name)
+	if err := os.WriteFile(tmp, render(m.from, msg, now), 0o600); err != nil {
+		return fmt.Errorf("failed to write email: %w", err)
+	}
+	if err := os.Rename(tmp, filepath.Join(m.dir, name)); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to write email: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package mail
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"rating-system/internal/domain/model"
+)
+
+func TestOutboxMailerWritesMessages(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "outbox")
+	mailer, err := NewOutboxMailer(dir, "Ratings <no-reply@example.com>")
+	require.NoError(t, err)
+
+	err = mailer.Send(context.Background(), &model.EmailMessage{
+		To:      "alice@example.com",
+		Subject: "Verify your email",
+		Body:    "Hello\nhttps://example.com/verify?token=abc\n",
+	})
+	require.NoError(t, err)
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	content, err := os.ReadFile(files[0])
+	require.NoError(t, err)
+	email := string(content)
+	assert.Contains(t, email, "From: Ratings <no-reply@example.com>\r\n")
+	assert.Contains(t, email, "To: alice@example.com\r\n")
+	assert.Contains(t, email, "Subject: Verify your email\r\n")
+	assert.True(t, strings.HasSuffix(email, "\r\n\r\nHello\r\nhttps://example.com/verify?token=abc\r\n"))
+}
+
+func TestOutboxMailerRejectsHeaderInjection(t *testing.T) {
+	mailer, err := NewOutboxMailer(t.TempDir(), "no-reply@example.com")
+	require.NoError(t, err)
+
+	err = mailer.Send(context.Background(), &model.EmailMessage{
+		To:      "alice@example.com\r\nBcc: eve@example.com",
+		Subject: "Hi",
+	})
+	assert.Error(t, err)
+}
+
+func TestNewSMTPMailerValidatesConfig(t *testing.T) {
+	_, err := NewSMTPMailer(SMTPConfig{From: "no-reply@example.com"})
+	assert.Error(t, err)
+
+	_, err = NewSMTPMailer(SMTPConfig{Host: "smtp.example.com", From: "not an address"})
+	assert.Error(t, err)
+
+	mailer, err := NewSMTPMailer(SMTPConfig{Host: "smtp.example.com", From: "Ratings <no-reply@example.com>"})
+	require.NoError(t, err)
+	assert.Equal(t, "smtp.example.com:587", mailer.addr)
+	assert.Equal(t, "no-reply@example.com", mailer.from)
+}
@@ -0,0 +1,80 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"time"
+
+	"rating-system/internal/domain/model"
+)
+
+// SMTPConfig configures the SMTP server emails are sent through
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	// From is the sender address, e.g. "Ratings <no-reply@example.com>"
+	From string
+}
+
+// SMTPMailer sends emails through an SMTP server. Servers that support it are talked to over
+// STARTTLS; credentials are only sent once the connection is encrypted.
+type SMTPMailer struct {
+	cfg  SMTPConfig
+	addr string
+	from string
+}
+
+// NewSMTPMailer creates a mailer sending through the configured server
+func NewSMTPMailer(cfg SMTPConfig) (*SMTPMailer, error) {
+	if cfg.Host == "" {
+		return nil, fmt.Errorf("SMTP host is required")
+	}
+	if cfg.Port == 0 {
+		cfg.Port = 587
+	}
+	from, err := mail.ParseAddress(cfg.From)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sender address %q: %w", cfg.From, err)
+	}
+
+	return &SMTPMailer{
+		cfg:  cfg,
+		addr: net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port)),
+		from: from.Address,
+	}, nil
+}
+
+// Send delivers a message to the SMTP server
+func (m *SMTPMailer) Send(ctx context.Context, msg *model.EmailMessage) error {
+	if err := validate(msg); err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if m.cfg.Username != "" {
+		auth = smtp.PlainAuth("", m.cfg.Username, m.cfg.Password, m.cfg.Host)
+	}
+
+	// net/smtp takes no context, so the send runs in the background and is abandoned if the
+	// context ends first
+	done := make(chan error, 1)
+	go func() {
+		done <- smtp.SendMail(m.addr, auth, m.from, []string{msg.To}, render(m.cfg.From, msg, time.Now()))
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			return fmt.Errorf("failed to send email: %w", err)
+		}
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,92 @@
+// Package ratelimit limits how often callers can hit a group of routes using token buckets. Each
+// caller's bucket holds up to Limit.Requests tokens and is refilled evenly over Limit.Window, so a
+// caller can burst up to the full limit and then continues at the steady rate.
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"rating-system/internal/domain/model"
+)
+
+// ErrInvalidLimit is returned for limits that allow no requests or have no window
+var ErrInvalidLimit = errors.New("invalid rate limit")
+
+// Limit is a number of requests allowed per window
+type Limit struct {
+	Requests int
+	Window   time.Duration
+}
+
+// Valid reports whether the limit allows requests over a positive window
+func (l Limit) Valid() bool {
+	return l.Requests > 0 && l.Window > 0
+}
+
+// String formats the limit the way ParseLimit reads it
+func (l Limit) String() string {
+	return fmt.Sprintf("%d/%s", l.Requests, l.Window)
+}
+
+// ParseLimit parses a limit written as requests/window, such as "60/1m" or "5/s". A window of a
+// bare unit means one of that unit.
+func ParseLimit(s string) (Limit, error) {
+	requests, window, ok := strings.Cut(strings.TrimSpace(s), "/")
+	if !ok {
+		return Limit{}, fmt.Errorf("%w: %q is not requests/window", ErrInvalidLimit, s)
+	}
+
+	n, err := strconv.Atoi(strings.TrimSpace(requests))
+	if err != nil {
+		return Limit{}, fmt.Errorf("%w: %q has no request count", ErrInvalidLimit, s)
+	}
+
+	window = strings.TrimSpace(window)
+	if window != "" && !strings.ContainsAny(window[:1], "0123456789") {
+		window = "1" + window
+	}
+	d, err := time.ParseDuration(window)
+	if err != nil {
+		return Limit{}, fmt.Errorf("%w: %q has no window", ErrInvalidLimit, s)
+	}
+
+	limit := Limit{Requests: n, Window: d}
+	if !limit.Valid() {
+		return Limit{}, fmt.Errorf("%w: %q", ErrInvalidLimit, s)
+	}
+	return limit, nil
+}
+
+// Store keeps the token buckets of callers. Take must refill and take a token atomically, so a
+// store shared between instances, such as one backed by Redis, enforces a single limit across
+// all of them.
+type Store interface {
+	Take(ctx context.Context, key string, limit Limit, now time.Time) (*model.RateLimitDecision, error)
+}
+
+// Limiter limits the callers of one group of routes. Limiters can share a store, since each one
+// keeps its buckets under its own name.
+type Limiter struct {
+	store Store
+	name  string
+	limit Limit
+	now   func() time.Time
+}
+
+// NewLimiter creates a limiter for the named route group
+func NewLimiter(store Store, name string, limit Limit) *Limiter {
+	return &Limiter{store: store, name: name, limit: limit, now: time.Now}
+}
+
+// Allow implements port.RateLimiter
+func (l *Limiter) Allow(ctx context.Context, key string) (*model.RateLimitDecision, error) {
+	if !l.limit.Valid() {
+		return nil, ErrInvalidLimit
+	}
+	return l.store.Take(ctx, l.name+":"+key, l.limit, l.now())
+}
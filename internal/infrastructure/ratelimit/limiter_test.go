@@ -0,0 +1,111 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testNow = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+func TestParseLimit(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Limit
+		wantErr bool
+	}{
+		{"60/1m", Limit{60, time.Minute}, false},
+		{"5/s", Limit{5, time.Second}, false},
+		{" 100 / 1h ", Limit{100, time.Hour}, false},
+		{"10/30s", Limit{10, 30 * time.Second}, false},
+		{"60", Limit{}, true},
+		{"x/1m", Limit{}, true},
+		{"0/1m", Limit{}, true},
+		{"10/0s", Limit{}, true},
+		{"10/soon", Limit{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseLimit(tt.in)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidLimit)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMemoryStoreBurstThenRefill(t *testing.T) {
+	store := NewMemoryStore()
+	limit := Limit{Requests: 3, Window: 3 * time.Second}
+	ctx := context.Background()
+
+	for i := 2; i >= 0; i-- {
+		decision, err := store.Take(ctx, "k", limit, testNow)
+		require.NoError(t, err)
+		assert.True(t, decision.Allowed)
+		assert.Equal(t, i, decision.Remaining)
+	}
+
+	denied, err := store.Take(ctx, "k", limit, testNow)
+	require.NoError(t, err)
+	assert.False(t, denied.Allowed)
+	assert.Equal(t, 0, denied.Remaining)
+	assert.Equal(t, time.Second, denied.RetryAfter)
+	assert.Equal(t, 3*time.Second, denied.Reset)
+
+	// One token is back after a third of the window
+	decision, err := store.Take(ctx, "k", limit, testNow.Add(time.Second))
+	require.NoError(t, err)
+	assert.True(t, decision.Allowed)
+
+	// Other callers have their own buckets
+	other, err := store.Take(ctx, "other", limit, testNow.Add(time.Second))
+	require.NoError(t, err)
+	assert.True(t, other.Allowed)
+	assert.Equal(t, 2, other.Remaining)
+}
+
+func TestMemoryStoreSweepsFullBuckets(t *testing.T) {
+	store := NewMemoryStore()
+	limit := Limit{Requests: 10, Window: time.Second}
+	ctx := context.Background()
+
+	_, err := store.Take(ctx, "a", limit, testNow)
+	require.NoError(t, err)
+	assert.Equal(t, 1, store.Len())
+
+	_, err = store.Take(ctx, "b", limit, testNow.Add(2*sweepInterval))
+	require.NoError(t, err)
+	assert.Equal(t, 1, store.Len(), "the refilled bucket of a is dropped")
+}
+
+func TestLimiterSeparatesGroups(t *testing.T) {
+	store := NewMemoryStore()
+	auth := NewLimiter(store, "auth", Limit{Requests: 1, Window: time.Minute})
+	write := NewLimiter(store, "write", Limit{Requests: 1, Window: time.Minute})
+	auth.now = func() time.Time { return testNow }
+	write.now = auth.now
+	ctx := context.Background()
+
+	decision, err := auth.Allow(ctx, "ip:10.0.0.1")
+	require.NoError(t, err)
+	assert.True(t, decision.Allowed)
+
+	decision, err = auth.Allow(ctx, "ip:10.0.0.1")
+	require.NoError(t, err)
+	assert.False(t, decision.Allowed)
+
+	decision, err = write.Allow(ctx, "ip:10.0.0.1")
+	require.NoError(t, err)
+	assert.True(t, decision.Allowed)
+
+	_, err = NewLimiter(store, "broken", Limit{}).Allow(ctx, "ip:10.0.0.1")
+	assert.ErrorIs(t, err, ErrInvalidLimit)
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"rating-system/internal/domain/model"
+)
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// execer is satisfied by both *sql.DB and *sql.Tx, so a write can run alone or inside a transaction
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// publicModeration lists the moderation states of content shown in public queries and aggregates,
+// matching model.ModerationStatus.IsPublic
+const publicModeration = `('visible', 'pending_review')`
+
+// shadowVisible returns a condition matching the rows of a table or alias that the viewer can see:
+// rows that are not shadowed and, unless anonymous, the viewer's own. The viewer ID is a parsed
+// UUID, so it is written into the query as a literal rather than shifting each query's placeholders.
+func shadowVisible(alias string, viewer model.Viewer) string {
+	if viewer.Unrestricted {
+		return "TRUE"
+	}
+	if viewer.UserID == uuid.Nil {
+		return alias + ".shadowed = FALSE"
+	}
+	return fmt.Sprintf("(%s.shadowed = FALSE OR %s.user_id = '%s')", alias, alias, viewer.UserID)
+}
+
+// listVisible returns the condition for rows of a table or alias shown in the viewer's listings of
+// reviews and comments: rows the viewer can see that were not written by a user the viewer blocked.
+// Anonymous and unrestricted viewers have blocked nobody.
+func listVisible(alias string, viewer model.Viewer) string {
+	visible := shadowVisible(alias, viewer)
+	if viewer.Unrestricted || viewer.UserID == uuid.Nil {
+		return visible
+	}
+	return fmt.Sprintf("%s AND NOT EXISTS (SELECT 1 FROM user_blocks ub WHERE ub.blocker_id = '%s' AND ub.blocked_id = %s.user_id)",
+		visible, viewer.UserID, alias)
+}
+
+// reviewColumns lists the columns selected for a review joined with its rating (aliases r and rt),
+// followed by the number of public comments that have not been deleted and that the viewer's listings show
+func reviewColumns(viewer model.Viewer) string {
+	return `r.id, r.user_id, r.service_id, r.rating_id, r.title, r.content, r.status, r.moderation_status, r.sentiment_score,
+                       r.publish_at, r.published_at, r.shadowed, r.created_at, r.updated_at, rt.score, rt.verified,
+                       (SELECT COUNT(*) FROM comments cc
+                        WHERE cc.review_id = r.id AND cc.deleted = FALSE AND cc.moderation_status IN ` + publicModeration + `
+                          AND ` + listVisible("cc", viewer) + `)`
+}
+
+// commentColumns lists the columns selected for a comment (alias c)
+const commentColumns = `c.id, c.user_id, c.review_id, c.parent_id, c.thread_id, c.depth, c.content, c.deleted, c.deleted_at,
+                        c.moderation_status, c.shadowed, c.created_at, c.updated_at`
+
+// commentVisible returns the condition for comments of a table or alias that are public and shown
+// in the viewer's listings
+func commentVisible(alias string, viewer model.Viewer) string {
+	return alias + ".moderation_status IN " + publicModeration + " AND " + listVisible(alias, viewer)
+}
+
+// treeColumns lists commentColumns followed by whether the comment is withheld from the viewer:
+// not public, or not shown in the viewer's listings. Comment trees keep withheld comments as
+// placeholders for the visible replies below them.
+func treeColumns(viewer model.Viewer) string {
+	return commentColumns + `,
+                        NOT (` + commentVisible("c", viewer) + `)`
+}
+
+// threadShown returns the condition for top-level comments (alias c) in the viewer's comment trees:
+// those the viewer can see, and withheld ones kept as placeholders for a reply the viewer can see
+func threadShown(viewer model.Viewer) string {
+	return `(` + commentVisible("c", viewer) + `
+                       OR EXISTS (SELECT 1 FROM comments v WHERE v.thread_id = c.id AND v.parent_id IS NOT NULL
+                                  AND ` + commentVisible("v", viewer) + `))`
+}
+
+// ratingFlagColumns lists the columns selected for a rating flag
+const ratingFlagColumns = `id, rating_id, user_id, service_id, rating_score, suspicion_score, signals, ip, device_id,
+                           quarantined, status, resolved_by, resolved_at, created_at`
+
+// moderationTable returns the table storing the given kind of moderated content
+func moderationTable(targetType model.ReportTarget) (string, error) {
+	switch targetType {
+	case model.ReportTargetReview:
+		return "reviews", nil
+	case model.ReportTargetComment:
+		return "comments", nil
+	}
+	return "", model.ErrInvalidReportTarget
+}
@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"rating-system/internal/domain/model"
+	"rating-system/pkg/pagination"
+)
+
+// CreateAPIKey stores an API key
+func (r *MySQLRepository) CreateAPIKey(ctx context.Context, key *model.APIKey) error {
+	query := `
+		INSERT INTO api_keys (id, user_id, name, hint, key_hash, scopes, service_id, created_by, expires_at, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	_, err := r.execWithContext(
+		ctx,
+		query,
+		key.ID.String(),
+		key.UserID.String(),
+		key.Name,
+		key.Hint,
+		key.KeyHash,
+		model.FormatScopes(key.Scopes),
+		nullUUIDArg(key.ServiceID),
+		key.CreatedBy.String(),
+		key.ExpiresAt,
+		key.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create API key: %w", err)
+	}
+	return nil
+}
+
+// GetAPIKeyByHash retrieves an API key by the hash of its value
+func (r *MySQLRepository) GetAPIKeyByHash(ctx context.Context, keyHash string) (*model.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE key_hash = ?`
+	key, err := scanMySQLAPIKey(r.db.QueryRowContext(ctx, query, keyHash))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, model.ErrInvalidAPIKey
+		}
+		return nil, fmt.Errorf("failed to get API key: %w", err)
+	}
+	return key, nil
+}
+
+// GetAPIKeys retrieves API keys, of one user if a user is given, most recent first
+func (r *MySQLRepository) GetAPIKeys(ctx context.Context, userID *uuid.UUID, params pagination.Params) ([]*model.APIKey, int, error) {
+	page := pagination.NewPagination(params.GetPage(), params.GetLimit())
+	filterID := ""
+	if userID != nil {
+		filterID = userID.String()
+	}
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM api_keys WHERE (? = '' OR user_id = ?)`
+	if err := r.db.QueryRowContext(ctx, countQuery, filterID, filterID).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count API keys: %w", err)
+	}
+
+	query := `
+		SELECT ` + apiKeyColumns + `
+		FROM api_keys
+		WHERE (? = '' OR user_id = ?)
+		ORDER BY created_at DESC, id DESC
+		LIMIT ? OFFSET ?
+	`
+	rows, err := r.db.QueryContext(ctx, query, filterID, filterID, page.GetLimit(), page.GetOffset())
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get API keys: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []*model.APIKey
+	for rows.Next() {
+		key, err := scanMySQLAPIKey(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan API key: %w", err)
+		}
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating API key rows: %w", err)
+	}
+	return keys, total, nil
+}
+
+// RevokeAPIKey revokes an API key that is not revoked yet
+func (r *MySQLRepository) RevokeAPIKey(ctx context.Context, id uuid.UUID, at time.Time) error {
+	result, err := r.execWithContext(ctx, `UPDATE api_keys SET revoked_at = ? WHERE id = ? AND revoked_at IS NULL`, at, id.String())
+	if err != nil {
+		return fmt.Errorf("failed to revoke API key: %w", err)
+	}
+
+	revoked, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if revoked == 0 {
+		return model.ErrAPIKeyNotFound
+	}
+	return nil
+}
+
+// TouchAPIKey records when an API key was last used
+func (r *MySQLRepository) TouchAPIKey(ctx context.Context, id uuid.UUID, at time.Time) error {
+	if _, err := r.execWithContext(ctx, `UPDATE api_keys SET last_used_at = ? WHERE id = ?`, at, id.String()); err != nil {
+		return fmt.Errorf("failed to record API key use: %w", err)
+	}
+	return nil
+}
+
+// scanMySQLAPIKey scans a row of apiKeyColumns
+func scanMySQLAPIKey(row interface{ Scan(...interface{}) error }) (*model.APIKey, error) {
+	var key model.APIKey
+	var id, userID, createdBy, scopes string
+	var serviceID sql.NullString
+	err := row.Scan(
+		&id,
+		&userID,
+		&key.Name,
+		&key.Hint,
+		&key.KeyHash,
+		&scopes,
+		&serviceID,
+		&createdBy,
+		&key.ExpiresAt,
+		&key.LastUsedAt,
+		&key.RevokedAt,
+		&key.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if key.ID, err = uuid.Parse(id); err != nil {
+		return nil, fmt.Errorf("failed to parse API key ID: %w", err)
+	}
+	if key.UserID, err = uuid.Parse(userID); err != nil {
+		return nil, fmt.Errorf("failed to parse user ID: %w", err)
+	}
+	if key.CreatedBy, err = uuid.Parse(createdBy); err != nil {
+		return nil, fmt.Errorf("failed to parse creator ID: %w", err)
+	}
+	key.ServiceID = parseNullUUID(serviceID)
+	key.Scopes = model.ParseScopes(scopes)
+	return &key, nil
+}
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"rating-system/internal/domain/model"
+)
+
+// CreateAuditEvent records a security relevant change to an account
+func (r *MySQLRepository) CreateAuditEvent(ctx context.Context, event *model.AuditEvent) error {
+	query := `
+		INSERT INTO audit_events (id, user_id, actor_id, action, ip_address, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+	_, err := r.execWithContext(
+		ctx,
+		query,
+		event.ID.String(),
+		event.UserID.String(),
+		nullUUIDArg(event.ActorID),
+		string(event.Action),
+		event.IPAddress,
+		event.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create audit event: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"rating-system/internal/domain/model"
+	"rating-system/pkg/pagination"
+)
+
+// CreateBlock stores a block of a user by another
+func (r *MySQLRepository) CreateBlock(ctx context.Context, block *model.Block) error {
+	query := `
+		INSERT INTO user_blocks (blocker_id, blocked_id, created_at)
+		VALUES (?, ?, ?)
+	`
+	_, err := r.execWithContext(ctx, query, block.BlockerID.String(), block.UserID.String(), block.CreatedAt)
+	if err != nil {
+		if strings.Contains(err.Error(), "Duplicate entry") && strings.Contains(err.Error(), "PRIMARY") {
+			return model.ErrAlreadyBlocked
+		}
+		return fmt.Errorf("failed to create block: %w", err)
+	}
+	return nil
+}
+
+// DeleteBlock lifts a block
+func (r *MySQLRepository) DeleteBlock(ctx context.Context, blockerID, userID uuid.UUID) error {
+	result, err := r.execWithContext(ctx, `DELETE FROM user_blocks WHERE blocker_id = ? AND blocked_id = ?`, blockerID.String(), userID.String())
+	if err != nil {
+		return fmt.Errorf("failed to delete block: %w", err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if deleted == 0 {
+		return model.ErrNotBlocked
+	}
+	return nil
+}
+
+// IsBlocked reports whether the blocker has blocked the user
+func (r *MySQLRepository) IsBlocked(ctx context.Context, blockerID, userID uuid.UUID) (bool, error) {
+	var blocked bool
+	query := `SELECT EXISTS (SELECT 1 FROM user_blocks WHERE blocker_id = ? AND blocked_id = ?)`
+	if err := r.db.QueryRowContext(ctx, query, blockerID.String(), userID.String()).Scan(&blocked); err != nil {
+		return false, fmt.Errorf("failed to check block: %w", err)
+	}
+	return blocked, nil
+}
+
+// GetBlocks retrieves the users a user has blocked, most recent first
+func (r *MySQLRepository) GetBlocks(ctx context.Context, blockerID uuid.UUID, params pagination.Params) ([]*model.Block, int, error) {
+	page := pagination.NewPagination(params.GetPage(), params.GetLimit())
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM user_blocks WHERE blocker_id = ?`, blockerID.String()).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count blocks: %w", err)
+	}
+
+	query := `
+		SELECT b.blocked_id, u.username, b.created_at
+		FROM user_blocks b
+		JOIN users u ON u.id = b.blocked_id
+		WHERE b.blocker_id = ?
+		ORDER BY b.created_at DESC, b.blocked_id DESC
+		LIMIT ? OFFSET ?
+	`
+	rows, err := r.db.QueryContext(ctx, query, blockerID.String(), page.GetLimit(), page.GetOffset())
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get blocks: %w", err)
+	}
+	defer rows.Close()
+
+	var blocks []*model.Block
+	for rows.Next() {
+		block := model.Block{BlockerID: blockerID}
+		var userID string
+		if err := rows.Scan(&userID, &block.Username, &block.CreatedAt); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan block: %w", err)
+		}
+
+		block.UserID, _ = uuid.Parse(userID)
+		blocks = append(blocks, &block)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating block rows: %w", err)
+	}
+	return blocks, total, nil
+}
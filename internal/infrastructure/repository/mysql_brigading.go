@@ -0,0 +1,245 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"rating-system/internal/domain/model"
+	"rating-system/pkg/pagination"
+)
+
+// RecordRatingClient stores the address and device a rating was last submitted from
+func (r *MySQLRepository) RecordRatingClient(ctx context.Context, ratingID uuid.UUID, client model.ClientInfo, at time.Time) error {
+	query := `
+		INSERT INTO rating_clients (rating_id, ip, device_id, created_at)
+		VALUES (?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE ip = VALUES(ip), device_id = VALUES(device_id), created_at = VALUES(created_at)
+	`
+	if _, err := r.execWithContext(ctx, query, ratingID.String(), client.IP, client.DeviceID, at); err != nil {
+		return fmt.Errorf("failed to record rating client: %w", err)
+	}
+	return nil
+}
+
+// CountRatingsSince counts the ratings of a service created since the given time, quarantined or not
+func (r *MySQLRepository) CountRatingsSince(ctx context.Context, serviceID uuid.UUID, since time.Time) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM ratings WHERE service_id = ? AND created_at >= ?`, serviceID.String(), since).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count recent ratings: %w", err)
+	}
+	return count, nil
+}
+
+// CountClusteredRaters counts the other users who rated a service since the given time from the
+// client's address and, separately, from its device. Empty client fields match nobody.
+func (r *MySQLRepository) CountClusteredRaters(ctx context.Context, serviceID, userID uuid.UUID, client model.ClientInfo, since time.Time) (int, int, error) {
+	query := `
+		SELECT COUNT(DISTINCT CASE WHEN c.ip = ? THEN rt.user_id END),
+		       COUNT(DISTINCT CASE WHEN c.device_id = ? THEN rt.user_id END)
+		FROM rating_clients c
+		JOIN ratings rt ON rt.id = c.rating_id
+		WHERE rt.service_id = ? AND rt.user_id <> ? AND c.created_at >= ?
+		  AND ((c.ip = ? AND c.ip <> '') OR (c.device_id = ? AND c.device_id <> ''))
+	`
+	var byIP, byDevice int
+	err := r.db.QueryRowContext(ctx, query,
+		client.IP,
+		client.DeviceID,
+		serviceID.String(),
+		userID.String(),
+		since,
+		client.IP,
+		client.DeviceID,
+	).Scan(&byIP, &byDevice)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to count clustered raters: %w", err)
+	}
+	return byIP, byDevice, nil
+}
+
+// CreateRatingFlag stores a flag and, if it quarantines its rating, removes the rating from
+// public aggregates in one transaction
+func (r *MySQLRepository) CreateRatingFlag(ctx context.Context, flag *model.RatingFlag) error {
+	signals, err := json.Marshal(flag.Signals)
+	if err != nil {
+		return fmt.Errorf("failed to encode rating flag signals: %w", err)
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO rating_flags (` + ratingFlagColumns + `)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	_, err = tx.ExecContext(ctx, query,
+		flag.ID.String(),
+		flag.RatingID.String(),
+		flag.UserID.String(),
+		flag.ServiceID.String(),
+		flag.RatingScore,
+		flag.Score,
+		string(signals),
+		flag.Client.IP,
+		flag.Client.DeviceID,
+		flag.Quarantined,
+		flag.Status,
+		nullUUIDArg(flag.ResolvedBy),
+		flag.ResolvedAt,
+		flag.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create rating flag: %w", err)
+	}
+
+	if flag.Quarantined {
+		if _, err := tx.ExecContext(ctx, `UPDATE ratings SET quarantined = TRUE WHERE id = ?`, flag.RatingID.String()); err != nil {
+			return fmt.Errorf("failed to quarantine rating: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit rating flag: %w", err)
+	}
+	return nil
+}
+
+// GetRatingFlagByID retrieves a rating flag by ID
+func (r *MySQLRepository) GetRatingFlagByID(ctx context.Context, id uuid.UUID) (*model.RatingFlag, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+ratingFlagColumns+` FROM rating_flags WHERE id = ?`, id.String())
+	flag, err := scanMySQLRatingFlag(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, model.ErrRatingFlagNotFound
+		}
+		return nil, fmt.Errorf("failed to get rating flag: %w", err)
+	}
+	return flag, nil
+}
+
+// GetRatingFlags retrieves rating flags, newest first, optionally narrowed to a status and service
+func (r *MySQLRepository) GetRatingFlags(ctx context.Context, filter model.RatingFlagFilter, params pagination.Params) ([]*model.RatingFlag, int, error) {
+	page := pagination.NewPagination(params.GetPage(), params.GetLimit())
+
+	serviceID := ""
+	if filter.ServiceID != nil {
+		serviceID = filter.ServiceID.String()
+	}
+	where := `WHERE (? = '' OR status = ?) AND (? = '' OR service_id = ?)`
+	args := []interface{}{filter.Status, filter.Status, serviceID, serviceID}
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM rating_flags `+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count rating flags: %w", err)
+	}
+
+	query := `SELECT ` + ratingFlagColumns + ` FROM rating_flags ` + where + ` ORDER BY created_at DESC, id DESC LIMIT ? OFFSET ?`
+	rows, err := r.db.QueryContext(ctx, query, append(args, page.GetLimit(), page.GetOffset())...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get rating flags: %w", err)
+	}
+	defer rows.Close()
+
+	var flags []*model.RatingFlag
+	for rows.Next() {
+		flag, err := scanMySQLRatingFlag(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan rating flag: %w", err)
+		}
+		flags = append(flags, flag)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating rating flag rows: %w", err)
+	}
+	return flags, total, nil
+}
+
+// ResolveRatingFlag records a moderator's judgement of an open flag and updates the quarantine of
+// its rating in one transaction. The rating stays quarantined while any other flag holds it.
+func (r *MySQLRepository) ResolveRatingFlag(ctx context.Context, flag *model.RatingFlag) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx,
+		`UPDATE rating_flags SET status = ?, quarantined = ?, resolved_by = ?, resolved_at = ? WHERE id = ? AND status = ?`,
+		flag.Status,
+		flag.Quarantined,
+		nullUUIDArg(flag.ResolvedBy),
+		flag.ResolvedAt,
+		flag.ID.String(),
+		model.RatingFlagOpen,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to resolve rating flag: %w", err)
+	}
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if updated == 0 {
+		return model.ErrRatingFlagResolved
+	}
+
+	query := `
+		UPDATE ratings
+		SET quarantined = EXISTS (
+			SELECT 1 FROM rating_flags WHERE rating_id = ? AND quarantined = TRUE AND status <> ?
+		)
+		WHERE id = ?
+	`
+	if _, err := tx.ExecContext(ctx, query, flag.RatingID.String(), model.RatingFlagCleared, flag.RatingID.String()); err != nil {
+		return fmt.Errorf("failed to update rating quarantine: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit rating flag resolution: %w", err)
+	}
+	return nil
+}
+
+// scanMySQLRatingFlag scans a row of ratingFlagColumns
+func scanMySQLRatingFlag(row rowScanner) (*model.RatingFlag, error) {
+	var flag model.RatingFlag
+	var id, ratingID, userID, serviceID, signals string
+	var resolvedBy sql.NullString
+	if err := row.Scan(
+		&id,
+		&ratingID,
+		&userID,
+		&serviceID,
+		&flag.RatingScore,
+		&flag.Score,
+		&signals,
+		&flag.Client.IP,
+		&flag.Client.DeviceID,
+		&flag.Quarantined,
+		&flag.Status,
+		&resolvedBy,
+		&flag.ResolvedAt,
+		&flag.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	flag.ID, _ = uuid.Parse(id)
+	flag.RatingID, _ = uuid.Parse(ratingID)
+	flag.UserID, _ = uuid.Parse(userID)
+	flag.ServiceID, _ = uuid.Parse(serviceID)
+	flag.ResolvedBy = parseNullUUID(resolvedBy)
+	if err := json.Unmarshal([]byte(signals), &flag.Signals); err != nil {
+		return nil, err
+	}
+	return &flag, nil
+}
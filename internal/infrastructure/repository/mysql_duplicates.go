@@ -0,0 +1,250 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"rating-system/internal/domain/model"
+	"rating-system/pkg/pagination"
+)
+
+// SaveReviewSignature stores a review's signature and band hashes in one transaction, replacing those
+// of an earlier version of the review. If the review joins a duplicate cluster, the review that starts
+// the cluster is marked as its first member.
+func (r *MySQLRepository) SaveReviewSignature(ctx context.Context, signature *model.ReviewSignature) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// The bands are removed along with the signature
+	query := `DELETE FROM review_signatures WHERE review_id = ?`
+	if _, err := tx.ExecContext(ctx, query, signature.ReviewID.String()); err != nil {
+		return fmt.Errorf("failed to remove previous review signature: %w", err)
+	}
+
+	query = `
+		INSERT INTO review_signatures (review_id, user_id, service_id, minhash, cluster_id, similarity, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+	_, err = tx.ExecContext(
+		ctx,
+		query,
+		signature.ReviewID.String(),
+		signature.UserID.String(),
+		signature.ServiceID.String(),
+		signature.MinHash.Encode(),
+		nullUUIDArg(signature.ClusterID),
+		signature.Similarity,
+		signature.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create review signature: %w", err)
+	}
+
+	if len(signature.Bands) > 0 {
+		values := make([]string, len(signature.Bands))
+		args := make([]interface{}, 0, 3*len(signature.Bands))
+		for i, hash := range signature.Bands {
+			values[i] = "(?, ?, ?)"
+			args = append(args, signature.ReviewID.String(), i, hash)
+		}
+		query = `INSERT INTO review_signature_bands (review_id, band, band_hash) VALUES ` + strings.Join(values, ", ")
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("failed to create review signature bands: %w", err)
+		}
+	}
+
+	if signature.ClusterID != nil {
+		clusterID := signature.ClusterID.String()
+		query = `UPDATE review_signatures SET cluster_id = ? WHERE review_id = ? AND cluster_id IS NULL`
+		if _, err := tx.ExecContext(ctx, query, clusterID, clusterID); err != nil {
+			return fmt.Errorf("failed to start duplicate cluster: %w", err)
+		}
+	}
+	return tx.Commit()
+}
+
+// FindReviewSignatures retrieves signatures sharing at least one band hash, those sharing the most
+// bands first, leaving out the given user's and, if a service is given, other services' reviews
+func (r *MySQLRepository) FindReviewSignatures(ctx context.Context, bands []int64, excludeUserID uuid.UUID, serviceID *uuid.UUID, limit int) ([]*model.ReviewSignature, error) {
+	if len(bands) == 0 {
+		return nil, nil
+	}
+
+	placeholders := make([]string, len(bands))
+	args := make([]interface{}, 0, len(bands)+4)
+	for i, hash := range bands {
+		placeholders[i] = "?"
+		args = append(args, hash)
+	}
+	scopeID := ""
+	if serviceID != nil {
+		scopeID = serviceID.String()
+	}
+	args = append(args, excludeUserID.String(), scopeID, scopeID, limit)
+
+	query := `
+		SELECT s.review_id, s.user_id, s.service_id, s.minhash, s.cluster_id, s.similarity, s.created_at
+		FROM review_signatures s
+		JOIN (
+			SELECT review_id, COUNT(*) AS shared
+			FROM review_signature_bands
+			WHERE band_hash IN (` + strings.Join(placeholders, ", ") + `)
+			GROUP BY review_id
+		) b ON b.review_id = s.review_id
+		WHERE s.user_id <> ? AND (? = '' OR s.service_id = ?)
+		ORDER BY b.shared DESC, s.created_at
+		LIMIT ?
+	`
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find review signatures: %w", err)
+	}
+	defer rows.Close()
+
+	var signatures []*model.ReviewSignature
+	for rows.Next() {
+		var signature model.ReviewSignature
+		var reviewID, userID, serviceID, minHash string
+		var clusterID sql.NullString
+		if err := rows.Scan(
+			&reviewID,
+			&userID,
+			&serviceID,
+			&minHash,
+			&clusterID,
+			&signature.Similarity,
+			&signature.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan review signature: %w", err)
+		}
+		if signature.ReviewID, err = uuid.Parse(reviewID); err != nil {
+			return nil, fmt.Errorf("failed to parse review ID: %w", err)
+		}
+		if signature.UserID, err = uuid.Parse(userID); err != nil {
+			return nil, fmt.Errorf("failed to parse user ID: %w", err)
+		}
+		if signature.ServiceID, err = uuid.Parse(serviceID); err != nil {
+			return nil, fmt.Errorf("failed to parse service ID: %w", err)
+		}
+		if signature.MinHash, err = model.DecodeMinHash(minHash); err != nil {
+			return nil, fmt.Errorf("failed to decode review signature: %w", err)
+		}
+		signature.ClusterID = parseNullUUID(clusterID)
+		signatures = append(signatures, &signature)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating review signature rows: %w", err)
+	}
+	return signatures, nil
+}
+
+// GetDuplicateClusters retrieves clusters of near-duplicate reviews with their members, the most
+// recently extended clusters first
+func (r *MySQLRepository) GetDuplicateClusters(ctx context.Context, params pagination.Params) ([]*model.DuplicateCluster, int, error) {
+	page := pagination.NewPagination(params.GetPage(), params.GetLimit())
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(DISTINCT cluster_id) FROM review_signatures`).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count duplicate clusters: %w", err)
+	}
+
+	query := `
+		SELECT cluster_id, COUNT(*), COUNT(DISTINCT user_id), COUNT(DISTINCT service_id), MIN(created_at), MAX(created_at)
+		FROM review_signatures
+		WHERE cluster_id IS NOT NULL
+		GROUP BY cluster_id
+		ORDER BY MAX(created_at) DESC, cluster_id DESC
+		LIMIT ? OFFSET ?
+	`
+	rows, err := r.db.QueryContext(ctx, query, page.GetLimit(), page.GetOffset())
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get duplicate clusters: %w", err)
+	}
+	defer rows.Close()
+
+	var clusters []*model.DuplicateCluster
+	byID := make(map[uuid.UUID]*model.DuplicateCluster)
+	var placeholders []string
+	var args []interface{}
+	for rows.Next() {
+		var cluster model.DuplicateCluster
+		var id string
+		if err := rows.Scan(
+			&id,
+			&cluster.Reviews,
+			&cluster.Accounts,
+			&cluster.Services,
+			&cluster.FirstSeenAt,
+			&cluster.LastSeenAt,
+		); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan duplicate cluster: %w", err)
+		}
+		if cluster.ID, err = uuid.Parse(id); err != nil {
+			return nil, 0, fmt.Errorf("failed to parse cluster ID: %w", err)
+		}
+		clusters = append(clusters, &cluster)
+		byID[cluster.ID] = &cluster
+		placeholders = append(placeholders, "?")
+		args = append(args, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating duplicate cluster rows: %w", err)
+	}
+	if len(clusters) == 0 {
+		return clusters, total, nil
+	}
+
+	query = `
+		SELECT cluster_id, review_id, user_id, service_id, similarity, created_at
+		FROM review_signatures
+		WHERE cluster_id IN (` + strings.Join(placeholders, ", ") + `)
+		ORDER BY created_at, review_id
+	`
+	members, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get duplicate cluster members: %w", err)
+	}
+	defer members.Close()
+
+	for members.Next() {
+		var member model.DuplicateClusterMember
+		var clusterID, reviewID, userID, serviceID string
+		if err := members.Scan(
+			&clusterID,
+			&reviewID,
+			&userID,
+			&serviceID,
+			&member.Similarity,
+			&member.CreatedAt,
+		); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan duplicate cluster member: %w", err)
+		}
+		if member.ReviewID, err = uuid.Parse(reviewID); err != nil {
+			return nil, 0, fmt.Errorf("failed to parse review ID: %w", err)
+		}
+		if member.UserID, err = uuid.Parse(userID); err != nil {
+			return nil, 0, fmt.Errorf("failed to parse user ID: %w", err)
+		}
+		if member.ServiceID, err = uuid.Parse(serviceID); err != nil {
+			return nil, 0, fmt.Errorf("failed to parse service ID: %w", err)
+		}
+		id, err := uuid.Parse(clusterID)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to parse cluster ID: %w", err)
+		}
+		if cluster, ok := byID[id]; ok {
+			cluster.Members = append(cluster.Members, member)
+		}
+	}
+	if err := members.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating duplicate cluster member rows: %w", err)
+	}
+	return clusters, total, nil
+}
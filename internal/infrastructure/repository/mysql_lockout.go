@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LockUser locks a user out of logging in until the given time
+func (r *MySQLRepository) LockUser(ctx context.Context, userID uuid.UUID, until time.Time) error {
+	query := `UPDATE users SET locked_until = ? WHERE id = ?`
+	if _, err := r.execWithContext(ctx, query, until, userID.String()); err != nil {
+		return fmt.Errorf("failed to lock user: %w", err)
+	}
+	return nil
+}
+
+// UnlockUser ends a user's lockout
+func (r *MySQLRepository) UnlockUser(ctx context.Context, userID uuid.UUID) error {
+	query := `UPDATE users SET locked_until = NULL WHERE id = ?`
+	if _, err := r.execWithContext(ctx, query, userID.String()); err != nil {
+		return fmt.Errorf("failed to unlock user: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"rating-system/internal/domain/model"
+)
+
+// ReplaceMentions replaces the stored mentions of a review or comment
+func (r *MySQLRepository) ReplaceMentions(ctx context.Context, targetType model.MentionTarget, targetID uuid.UUID, mentions []*model.Mention) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM mentions WHERE target_type = ? AND target_id = ?`, targetType, targetID.String())
+	if err != nil {
+		return fmt.Errorf("failed to delete mentions: %w", err)
+	}
+
+	query := `
+		INSERT INTO mentions (id, target_type, target_id, user_id, start_offset, end_offset, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+	for _, mention := range mentions {
+		_, err = tx.ExecContext(ctx, query,
+			mention.ID.String(),
+			mention.TargetType,
+			mention.TargetID.String(),
+			mention.UserID.String(),
+			mention.Start,
+			mention.End,
+			mention.CreatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create mention: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetMentions retrieves the mentions of the given reviews or comments, in content order
+func (r *MySQLRepository) GetMentions(ctx context.Context, targetType model.MentionTarget, targetIDs []uuid.UUID) ([]*model.Mention, error) {
+	if len(targetIDs) == 0 {
+		return nil, nil
+	}
+
+	placeholders := make([]string, len(targetIDs))
+	args := []interface{}{targetType}
+	for i, id := range targetIDs {
+		placeholders[i] = "?"
+		args = append(args, id.String())
+	}
+
+	query := `
+		SELECT m.id, m.target_type, m.target_id, m.user_id, u.username, m.start_offset, m.end_offset, m.created_at
+		FROM mentions m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.target_type = ? AND m.target_id IN (` + strings.Join(placeholders, ", ") + `)
+		ORDER BY m.target_id, m.start_offset
+	`
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get mentions: %w", err)
+	}
+	defer rows.Close()
+
+	var mentions []*model.Mention
+	for rows.Next() {
+		var mention model.Mention
+		var id, targetID, userID string
+		if err := rows.Scan(
+			&id,
+			&mention.TargetType,
+			&targetID,
+			&userID,
+			&mention.Username,
+			&mention.Start,
+			&mention.End,
+			&mention.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan mention: %w", err)
+		}
+		if mention.ID, err = uuid.Parse(id); err != nil {
+			return nil, fmt.Errorf("failed to parse mention ID: %w", err)
+		}
+		if mention.TargetID, err = uuid.Parse(targetID); err != nil {
+			return nil, fmt.Errorf("failed to parse mention target ID: %w", err)
+		}
+		if mention.UserID, err = uuid.Parse(userID); err != nil {
+			return nil, fmt.Errorf("failed to parse mentioned user ID: %w", err)
+		}
+		mentions = append(mentions, &mention)
+	}
+	return mentions, rows.Err()
+}
@@ -0,0 +1,209 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"rating-system/internal/domain/model"
+	"rating-system/pkg/pagination"
+)
+
+// CreateReport stores a user's report of a review or comment
+func (r *MySQLRepository) CreateReport(ctx context.Context, report *model.Report) error {
+	query := `
+		INSERT INTO reports (id, reporter_id, target_type, target_id, reason, details, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+	_, err := r.execWithContext(ctx, query,
+		report.ID.String(),
+		nullUUIDArg(report.ReporterID),
+		report.TargetType,
+		report.TargetID.String(),
+		report.Reason,
+		report.Details,
+		report.CreatedAt,
+	)
+	if err != nil {
+		if strings.Contains(err.Error(), "Duplicate entry") && strings.Contains(err.Error(), "unique_user_report") {
+			return model.ErrDuplicateReport
+		}
+		return fmt.Errorf("failed to create report: %w", err)
+	}
+	return nil
+}
+
+// FlagForModeration moves visible content to pending review; content in any other state is left alone
+func (r *MySQLRepository) FlagForModeration(ctx context.Context, targetType model.ReportTarget, targetID uuid.UUID) error {
+	table, err := moderationTable(targetType)
+	if err != nil {
+		return err
+	}
+
+	query := `UPDATE ` + table + ` SET moderation_status = ? WHERE id = ? AND moderation_status = ?`
+	if _, err := r.execWithContext(ctx, query, model.ModerationPendingReview, targetID.String(), model.ModerationVisible); err != nil {
+		return fmt.Errorf("failed to flag content for moderation: %w", err)
+	}
+	return nil
+}
+
+// ApplyModerationDecision sets the moderation status of the content, records the decision and
+// resolves the open reports about the content in one transaction. The status is only changed if it
+// is still the one the decision was based on; otherwise ErrModerationConflict is returned.
+func (r *MySQLRepository) ApplyModerationDecision(ctx context.Context, decision *model.ModerationDecision) error {
+	table, err := moderationTable(decision.TargetType)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// MySQL does not count rows an update leaves unchanged, so a decision keeping the status, such as
+	// approving visible content, is checked against the locked row rather than the affected rows
+	var current model.ModerationStatus
+	err = tx.QueryRowContext(ctx, `SELECT moderation_status FROM `+table+` WHERE id = ? FOR UPDATE`, decision.TargetID.String()).Scan(&current)
+	if err != nil {
+		return fmt.Errorf("failed to get moderation status: %w", err)
+	}
+	if current != decision.PreviousStatus {
+		return model.ErrModerationConflict
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE `+table+` SET moderation_status = ? WHERE id = ? AND moderation_status = ?`,
+		decision.Status, decision.TargetID.String(), decision.PreviousStatus)
+	if err != nil {
+		return fmt.Errorf("failed to set moderation status: %w", err)
+	}
+
+	query := `
+		INSERT INTO moderation_decisions (id, moderator_id, target_type, target_id, action, reason, previous_status, status, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	_, err = tx.ExecContext(ctx, query,
+		decision.ID.String(),
+		decision.ModeratorID.String(),
+		decision.TargetType,
+		decision.TargetID.String(),
+		decision.Action,
+		decision.Reason,
+		decision.PreviousStatus,
+		decision.Status,
+		decision.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record moderation decision: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`UPDATE reports SET resolved_at = ? WHERE target_type = ? AND target_id = ? AND resolved_at IS NULL`,
+		decision.CreatedAt,
+		decision.TargetType,
+		decision.TargetID.String(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to resolve reports: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit moderation decision: %w", err)
+	}
+	return nil
+}
+
+// GetModerationQueue retrieves the content with open reports, most reported first
+func (r *MySQLRepository) GetModerationQueue(ctx context.Context, params pagination.Params) ([]*model.ModerationQueueItem, int, error) {
+	page := pagination.NewPagination(params.GetPage(), params.GetLimit())
+
+	countQuery := `SELECT COUNT(DISTINCT target_type, target_id) FROM reports WHERE resolved_at IS NULL`
+	var total int
+	if err := r.db.QueryRowContext(ctx, countQuery).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count reported content: %w", err)
+	}
+
+	query := `
+		SELECT target_type, target_id, COUNT(*), GROUP_CONCAT(DISTINCT reason ORDER BY reason), MIN(created_at), MAX(created_at)
+		FROM reports
+		WHERE resolved_at IS NULL
+		GROUP BY target_type, target_id
+		ORDER BY COUNT(*) DESC, MIN(created_at) ASC
+		LIMIT ? OFFSET ?
+	`
+	rows, err := r.db.QueryContext(ctx, query, page.GetLimit(), page.GetOffset())
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get moderation queue: %w", err)
+	}
+	defer rows.Close()
+
+	var items []*model.ModerationQueueItem
+	for rows.Next() {
+		var item model.ModerationQueueItem
+		var targetID, reasons string
+		if err := rows.Scan(
+			&item.TargetType,
+			&targetID,
+			&item.ReportCount,
+			&reasons,
+			&item.FirstReportedAt,
+			&item.LastReportedAt,
+		); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan moderation queue item: %w", err)
+		}
+		if item.TargetID, err = uuid.Parse(targetID); err != nil {
+			return nil, 0, fmt.Errorf("failed to parse reported target ID: %w", err)
+		}
+		for _, reason := range strings.Split(reasons, ",") {
+			item.Reasons = append(item.Reasons, model.ReportReason(reason))
+		}
+		items = append(items, &item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating moderation queue rows: %w", err)
+	}
+	return items, total, nil
+}
+
+// GetModerationDecisions retrieves the decisions taken on a review or comment, oldest first
+func (r *MySQLRepository) GetModerationDecisions(ctx context.Context, targetType model.ReportTarget, targetID uuid.UUID) ([]*model.ModerationDecision, error) {
+	query := `
+		SELECT id, moderator_id, target_type, target_id, action, reason, previous_status, status, created_at
+		FROM moderation_decisions
+		WHERE target_type = ? AND target_id = ?
+		ORDER BY created_at ASC, id ASC
+	`
+	rows, err := r.db.QueryContext(ctx, query, targetType, targetID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get moderation decisions: %w", err)
+	}
+	defer rows.Close()
+
+	var decisions []*model.ModerationDecision
+	for rows.Next() {
+		var decision model.ModerationDecision
+		var id, moderatorID, decisionTargetID string
+		if err := rows.Scan(
+			&id,
+			&moderatorID,
+			&decision.TargetType,
+			&decisionTargetID,
+			&decision.Action,
+			&decision.Reason,
+			&decision.PreviousStatus,
+			&decision.Status,
+			&decision.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan moderation decision: %w", err)
+		}
+
+		decision.ID, _ = uuid.Parse(id)
+		decision.ModeratorID, _ = uuid.Parse(moderatorID)
+		decision.TargetID, _ = uuid.Parse(decisionTargetID)
+		decisions = append(decisions, &decision)
+	}
+	return decisions, rows.Err()
+}
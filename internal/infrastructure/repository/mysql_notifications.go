@@ -0,0 +1,188 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"rating-system/internal/domain/model"
+	"rating-system/pkg/pagination"
+)
+
+// CreateNotification stores a notification
+func (r *MySQLRepository) CreateNotification(ctx context.Context, notification *model.Notification) error {
+	query := `
+		INSERT INTO notifications (id, user_id, type, actor_id, review_id, comment_id, detail, read_at, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := r.execWithContext(ctx, query,
+		notification.ID.String(),
+		notification.UserID.String(),
+		notification.Type,
+		nullUUIDArg(notification.ActorID),
+		nullUUIDArg(notification.ReviewID),
+		nullUUIDArg(notification.CommentID),
+		notification.Detail,
+		notification.ReadAt,
+		notification.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create notification: %w", err)
+	}
+	return nil
+}
+
+// GetNotifications retrieves a user's notifications, newest first, starting after the cursor
+func (r *MySQLRepository) GetNotifications(ctx context.Context, userID uuid.UUID, filter model.NotificationFilter, cursor *pagination.Cursor, limit int) ([]*model.Notification, error) {
+	query := `
+		SELECT ` + notificationColumns + `
+		FROM notifications
+		WHERE user_id = ?
+		  AND (? = FALSE OR read_at IS NULL)
+		  AND (? = FALSE OR created_at < ? OR (created_at = ? AND id < ?))
+		ORDER BY created_at DESC, id DESC
+		LIMIT ?
+	`
+
+	var after pagination.Cursor
+	if cursor != nil {
+		after = *cursor
+	}
+
+	rows, err := r.db.QueryContext(ctx, query,
+		userID.String(),
+		filter.UnreadOnly,
+		cursor != nil, after.CreatedAt, after.CreatedAt, after.ID.String(),
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notifications: %w", err)
+	}
+	defer rows.Close()
+
+	var notifications []*model.Notification
+	for rows.Next() {
+		var notification model.Notification
+		var idStr, userIDStr string
+		var actorID, reviewID, commentID sql.NullString
+
+		if err := rows.Scan(
+			&idStr,
+			&userIDStr,
+			&notification.Type,
+			&actorID,
+			&reviewID,
+			&commentID,
+			&notification.Detail,
+			&notification.ReadAt,
+			&notification.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan notification row: %w", err)
+		}
+
+		notification.ID, _ = uuid.Parse(idStr)
+		notification.UserID, _ = uuid.Parse(userIDStr)
+		notification.ActorID = parseNullUUID(actorID)
+		notification.ReviewID = parseNullUUID(reviewID)
+		notification.CommentID = parseNullUUID(commentID)
+
+		notifications = append(notifications, &notification)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating notification rows: %w", err)
+	}
+	return notifications, nil
+}
+
+// CountUnreadNotifications counts a user's unread notifications
+func (r *MySQLRepository) CountUnreadNotifications(ctx context.Context, userID uuid.UUID) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM notifications WHERE user_id = ? AND read_at IS NULL`, userID.String()).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count unread notifications: %w", err)
+	}
+	return count, nil
+}
+
+// MarkNotificationRead marks one of a user's notifications as read
+func (r *MySQLRepository) MarkNotificationRead(ctx context.Context, userID, id uuid.UUID, at time.Time) error {
+	// MySQL reports zero affected rows when nothing changes, so check existence separately
+	var exists bool
+	err := r.db.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM notifications WHERE id = ? AND user_id = ?)`, id.String(), userID.String()).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("failed to get notification: %w", err)
+	}
+	if !exists {
+		return errors.New("notification not found")
+	}
+
+	_, err = r.execWithContext(ctx, `UPDATE notifications SET read_at = COALESCE(read_at, ?) WHERE id = ? AND user_id = ?`, at, id.String(), userID.String())
+	if err != nil {
+		return fmt.Errorf("failed to mark notification read: %w", err)
+	}
+	return nil
+}
+
+// MarkAllNotificationsRead marks all of a user's unread notifications as read
+func (r *MySQLRepository) MarkAllNotificationsRead(ctx context.Context, userID uuid.UUID, at time.Time) (int, error) {
+	result, err := r.execWithContext(ctx, `UPDATE notifications SET read_at = ? WHERE user_id = ? AND read_at IS NULL`, at, userID.String())
+	if err != nil {
+		return 0, fmt.Errorf("failed to mark notifications read: %w", err)
+	}
+
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return int(updated), nil
+}
+
+// GetNotificationPreferences retrieves a user's explicit notification preferences
+func (r *MySQLRepository) GetNotificationPreferences(ctx context.Context, userID uuid.UUID) (*model.NotificationPreferences, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT type, enabled FROM notification_preferences WHERE user_id = ?`, userID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notification preferences: %w", err)
+	}
+	defer rows.Close()
+
+	return scanNotificationPreferences(userID, rows)
+}
+
+// SetNotificationPreference enables or disables a notification type for a user
+func (r *MySQLRepository) SetNotificationPreference(ctx context.Context, userID uuid.UUID, notificationType model.NotificationType, enabled bool) error {
+	query := `
+		INSERT INTO notification_preferences (user_id, type, enabled)
+		VALUES (?, ?, ?)
+		ON DUPLICATE KEY UPDATE enabled = VALUES(enabled)
+	`
+	if _, err := r.execWithContext(ctx, query, userID.String(), notificationType, enabled); err != nil {
+		return fmt.Errorf("failed to set notification preference: %w", err)
+	}
+	return nil
+}
+
+// nullUUIDArg converts an optional ID into a query argument
+func nullUUIDArg(id *uuid.UUID) interface{} {
+	if id == nil {
+		return nil
+	}
+	return id.String()
+}
+
+// parseNullUUID converts a nullable ID column into an optional ID
+func parseNullUUID(s sql.NullString) *uuid.UUID {
+	if !s.Valid {
+		return nil
+	}
+	id, err := uuid.Parse(s.String)
+	if err != nil {
+		return nil
+	}
+	return &id
+}
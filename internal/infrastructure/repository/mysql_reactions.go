@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"rating-system/internal/domain/model"
+)
+
+// ToggleReaction removes the user's reaction if it exists and adds it otherwise, reporting
+// whether it was added
+func (r *MySQLRepository) ToggleReaction(ctx context.Context, reaction *model.Reaction) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx,
+		`DELETE FROM reactions WHERE target_type = ? AND target_id = ? AND user_id = ? AND emoji = ?`,
+		reaction.TargetType,
+		reaction.TargetID.String(),
+		reaction.UserID.String(),
+		reaction.Emoji,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete reaction: %w", err)
+	}
+	removed, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if removed == 0 {
+		query := `
+			INSERT IGNORE INTO reactions (id, target_type, target_id, user_id, emoji, created_at)
+			VALUES (?, ?, ?, ?, ?, ?)
+		`
+		_, err = tx.ExecContext(ctx, query,
+			reaction.ID.String(),
+			reaction.TargetType,
+			reaction.TargetID.String(),
+			reaction.UserID.String(),
+			reaction.Emoji,
+			reaction.CreatedAt,
+		)
+		if err != nil {
+			return false, fmt.Errorf("failed to create reaction: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit reaction: %w", err)
+	}
+	return removed == 0, nil
+}
+
+// GetReactionCounts counts the reactions on the given reviews or comments per emoji, flagging
+// the ones left by the viewer
+func (r *MySQLRepository) GetReactionCounts(ctx context.Context, targetType model.ReactionTarget, targetIDs []uuid.UUID, viewerID uuid.UUID) ([]*model.ReactionCount, error) {
+	if len(targetIDs) == 0 {
+		return nil, nil
+	}
+
+	placeholders := make([]string, len(targetIDs))
+	args := []interface{}{viewerID.String(), targetType}
+	for i, id := range targetIDs {
+		placeholders[i] = "?"
+		args = append(args, id.String())
+	}
+
+	query := `
+		SELECT target_id, emoji, COUNT(*), MAX(user_id = ?)
+		FROM reactions
+		WHERE target_type = ? AND target_id IN (` + strings.Join(placeholders, ", ") + `)
+		GROUP BY target_id, emoji
+		ORDER BY target_id, COUNT(*) DESC, emoji
+	`
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reaction counts: %w", err)
+	}
+	defer rows.Close()
+
+	var counts []*model.ReactionCount
+	for rows.Next() {
+		var count model.ReactionCount
+		var targetID string
+		if err := rows.Scan(&targetID, &count.Emoji, &count.Count, &count.ReactedByMe); err != nil {
+			return nil, fmt.Errorf("failed to scan reaction count: %w", err)
+		}
+		if count.TargetID, err = uuid.Parse(targetID); err != nil {
+			return nil, fmt.Errorf("failed to parse reaction target ID: %w", err)
+		}
+		counts = append(counts, &count)
+	}
+	return counts, rows.Err()
+}
@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"rating-system/internal/domain/model"
+)
+
+// CreateRefreshToken stores a refresh token
+func (r *MySQLRepository) CreateRefreshToken(ctx context.Context, token *model.RefreshToken) error {
+	query := `
+		INSERT INTO refresh_tokens (id, family_id, user_id, token_hash, expires_at, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+	_, err := r.execWithContext(
+		ctx,
+		query,
+		token.ID.String(),
+		token.FamilyID.String(),
+		token.UserID.String(),
+		token.TokenHash,
+		token.ExpiresAt,
+		token.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create refresh token: %w", err)
+	}
+	return nil
+}
+
+// GetRefreshTokenByHash retrieves a refresh token by the hash of its value
+func (r *MySQLRepository) GetRefreshTokenByHash(ctx context.Context, tokenHash string) (*model.RefreshToken, error) {
+	query := `
+		SELECT id, family_id, user_id, token_hash, expires_at, rotated_at, revoked_at, created_at
+		FROM refresh_tokens
+		WHERE token_hash = ?
+	`
+	var token model.RefreshToken
+	var id, familyID, userID string
+	err := r.db.QueryRowContext(ctx, query, tokenHash).Scan(
+		&id,
+		&familyID,
+		&userID,
+		&token.TokenHash,
+		&token.ExpiresAt,
+		&token.RotatedAt,
+		&token.RevokedAt,
+		&token.CreatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, model.ErrInvalidRefreshToken
+		}
+		return nil, fmt.Errorf("failed to get refresh token: %w", err)
+	}
+	if token.ID, err = uuid.Parse(id); err != nil {
+		return nil, fmt.Errorf("failed to parse refresh token ID: %w", err)
+	}
+	if token.FamilyID, err = uuid.Parse(familyID); err != nil {
+		return nil, fmt.Errorf("failed to parse family ID: %w", err)
+	}
+	if token.UserID, err = uuid.Parse(userID); err != nil {
+		return nil, fmt.Errorf("failed to parse user ID: %w", err)
+	}
+	return &token, nil
+}
+
+// RotateRefreshToken marks a refresh token as exchanged and stores its successor in one
+// transaction. It returns model.ErrRefreshTokenReused if the token was exchanged or revoked
+// concurrently.
+func (r *MySQLRepository) RotateRefreshToken(ctx context.Context, id uuid.UUID, next *model.RefreshToken, at time.Time) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE refresh_tokens SET rotated_at = ?
+		WHERE id = ? AND rotated_at IS NULL AND revoked_at IS NULL
+	`, at, id.String())
+	if err != nil {
+		return fmt.Errorf("failed to rotate refresh token: %w", err)
+	}
+	rotated, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rotated == 0 {
+		return model.ErrRefreshTokenReused
+	}
+
+	query := `
+		INSERT INTO refresh_tokens (id, family_id, user_id, token_hash, expires_at, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+	_, err = tx.ExecContext(
+		ctx,
+		query,
+		next.ID.String(),
+		next.FamilyID.String(),
+		next.UserID.String(),
+		next.TokenHash,
+		next.ExpiresAt,
+		next.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create refresh token: %w", err)
+	}
+	return tx.Commit()
+}
+
+// RevokeRefreshTokenFamily revokes every refresh token of a family that is not revoked yet
+func (r *MySQLRepository) RevokeRefreshTokenFamily(ctx context.Context, familyID uuid.UUID, at time.Time) error {
+	query := `UPDATE refresh_tokens SET revoked_at = ? WHERE family_id = ? AND revoked_at IS NULL`
+	if _, err := r.execWithContext(ctx, query, at, familyID.String()); err != nil {
+		return fmt.Errorf("failed to revoke refresh token family: %w", err)
+	}
+	return nil
+}
+
+// RevokeUserRefreshTokens revokes every refresh token of a user that is not revoked yet
+func (r *MySQLRepository) RevokeUserRefreshTokens(ctx context.Context, userID uuid.UUID, at time.Time) error {
+	query := `UPDATE refresh_tokens SET revoked_at = ? WHERE user_id = ? AND revoked_at IS NULL`
+	if _, err := r.execWithContext(ctx, query, at, userID.String()); err != nil {
+		return fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+	return nil
+}
+
+// GetTokenGeneration retrieves the token generation of a user
+func (r *MySQLRepository) GetTokenGeneration(ctx context.Context, userID uuid.UUID) (int, error) {
+	var generation int
+	err := r.db.QueryRowContext(ctx, `SELECT token_generation FROM users WHERE id = ?`, userID.String()).Scan(&generation)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, errors.New("user not found")
+		}
+		return 0, fmt.Errorf("failed to get token generation: %w", err)
+	}
+	return generation, nil
+}
+
+// IncrementTokenGeneration bumps the token generation of a user and returns the new generation
+func (r *MySQLRepository) IncrementTokenGeneration(ctx context.Context, userID uuid.UUID) (int, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `UPDATE users SET token_generation = token_generation + 1 WHERE id = ?`, userID.String())
+	if err != nil {
+		return 0, fmt.Errorf("failed to increment token generation: %w", err)
+	}
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if updated == 0 {
+		return 0, errors.New("user not found")
+	}
+
+	var generation int
+	if err := tx.QueryRowContext(ctx, `SELECT token_generation FROM users WHERE id = ?`, userID.String()).Scan(&generation); err != nil {
+		return 0, fmt.Errorf("failed to get token generation: %w", err)
+	}
+	return generation, tx.Commit()
+}
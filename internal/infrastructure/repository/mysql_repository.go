@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
@@ -31,14 +32,15 @@ func NewMySQLRepository(db *sql.DB, log *logrus.Logger) port.Repository {
 
 func (r *MySQLRepository) CreateUser(ctx context.Context, user *model.User) error {
 	query := `
-		INSERT INTO users (id, email, username, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?)
+		INSERT INTO users (id, email, username, email_verified_at, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)
 	`
 
 	result, err := r.execWithContext(ctx, query,
 		user.ID.String(),
 		user.Email,
 		user.Username,
+		user.EmailVerifiedAt,
 		user.CreatedAt,
 		user.UpdatedAt,
 	)
@@ -61,7 +63,7 @@ func (r *MySQLRepository) CreateUser(ctx context.Context, user *model.User) erro
 // GetUserByID retrieves a user by their ID
 func (r *MySQLRepository) GetUserByID(ctx context.Context, id uuid.UUID) (*model.User, error) {
     query := `
-        SELECT id, email, username, created_at, updated_at
+        SELECT id, email, username, email_verified_at, locked_until, created_at, updated_at
         FROM users
         WHERE id = ?
     `
@@ -73,6 +75,8 @@ func (r *MySQLRepository) GetUserByID(ctx context.Context, id uuid.UUID) (*model
         &userID,
         &user.Email,
         &user.Username,
+        &user.EmailVerifiedAt,
+        &user.LockedUntil,
         &user.CreatedAt,
         &user.UpdatedAt,
     )
@@ -92,7 +96,7 @@ func (r *MySQLRepository) GetUserByID(ctx context.Context, id uuid.UUID) (*model
 // GetUserByUsername retrieves a user by their username
 func (r *MySQLRepository) GetUserByUsername(ctx context.Context, username string) (*model.User, error) {
     query := `
-        SELECT id, email, username, created_at, updated_at
+        SELECT id, email, username, email_verified_at, locked_until, created_at, updated_at
         FROM users
         WHERE username = ?
     `
@@ -104,6 +108,8 @@ func (r *MySQLRepository) GetUserByUsername(ctx context.Context, username string
         &userID,
         &user.Email,
         &user.Username,
+        &user.EmailVerifiedAt,
+        &user.LockedUntil,
         &user.CreatedAt,
         &user.UpdatedAt,
     )
@@ -125,7 +131,7 @@ func (r *MySQLRepository) GetUserByUsername(ctx context.Context, username string
 
 func (r *MySQLRepository) GetUserByEmail(ctx context.Context, email string) (*model.User, error) {
     query := `
-        SELECT id, email, username, created_at, updated_at
+        SELECT id, email, username, email_verified_at, locked_until, created_at, updated_at
         FROM users
         WHERE email = ?
     `
@@ -137,6 +143,8 @@ func (r *MySQLRepository) GetUserByEmail(ctx context.Context, email string) (*mo
         &userID,
         &user.Email,
         &user.Username,
+        &user.EmailVerifiedAt,
+        &user.LockedUntil,
         &user.CreatedAt,
         &user.UpdatedAt,
     )
@@ -176,16 +184,30 @@ func (r *MySQLRepository) execWithContext(ctx context.Context, query string, arg
 
 // CreateRating creates a new rating
 func (r *MySQLRepository) CreateRating(ctx context.Context, rating *model.Rating) error {
+	return insertMySQLRating(ctx, r.db, rating)
+}
+
+// CreateRatingWithAttestation creates a new rating verified by the attestation, in one transaction so
+// neither is saved if the attestation was already redeemed
+func (r *MySQLRepository) CreateRatingWithAttestation(ctx context.Context, rating *model.Rating, attestation *model.Attestation) error {
+	return r.withAttestation(ctx, attestation, func(tx *sql.Tx) error {
+		return insertMySQLRating(ctx, tx, rating)
+	})
+}
+
+// insertMySQLRating inserts a rating using the database or an open transaction
+func insertMySQLRating(ctx context.Context, db execer, rating *model.Rating) error {
 	query := `
-                INSERT INTO ratings (id, user_id, service_id, score, created_at, updated_at)
-                VALUES (?, ?, ?, ?, ?, ?)
+                INSERT INTO ratings (id, user_id, service_id, score, shadowed, created_at, updated_at)
+                VALUES (?, ?, ?, ?, ?, ?, ?)
         `
 
-	_, err := r.execWithContext(ctx, query,
+	_, err := db.ExecContext(ctx, query,
 		rating.ID.String(),
 		rating.UserID.String(),
 		rating.ServiceID.String(),
 		rating.Score,
+		rating.Shadowed,
 		rating.CreatedAt,
 		rating.UpdatedAt,
 	)
@@ -203,13 +225,26 @@ func (r *MySQLRepository) CreateRating(ctx context.Context, rating *model.Rating
 
 // UpdateRating updates an existing rating
 func (r *MySQLRepository) UpdateRating(ctx context.Context, rating *model.Rating) error {
+	return updateMySQLRating(ctx, r.db, rating)
+}
+
+// UpdateRatingWithAttestation updates an existing rating and verifies it with the attestation, in one
+// transaction so the new score is not saved if the attestation was already redeemed
+func (r *MySQLRepository) UpdateRatingWithAttestation(ctx context.Context, rating *model.Rating, attestation *model.Attestation) error {
+	return r.withAttestation(ctx, attestation, func(tx *sql.Tx) error {
+		return updateMySQLRating(ctx, tx, rating)
+	})
+}
+
+// updateMySQLRating updates a rating's score using the database or an open transaction
+func updateMySQLRating(ctx context.Context, db execer, rating *model.Rating) error {
 	query := `
                 UPDATE ratings
                 SET score = ?, updated_at = ?
                 WHERE id = ?
         `
 
-	result, err := r.execWithContext(ctx, query,
+	result, err := db.ExecContext(ctx, query,
 		rating.Score,
 		rating.UpdatedAt,
 		rating.ID.String(),
@@ -231,11 +266,11 @@ func (r *MySQLRepository) UpdateRating(ctx context.Context, rating *model.Rating
 }
 
 // GetRatingByID retrieves a rating by ID
-func (r *MySQLRepository) GetRatingByID(ctx context.Context, id uuid.UUID) (*model.Rating, error) {
+func (r *MySQLRepository) GetRatingByID(ctx context.Context, id uuid.UUID, viewer model.Viewer) (*model.Rating, error) {
 	query := `
-                SELECT id, user_id, service_id, score, created_at, updated_at
+                SELECT id, user_id, service_id, score, verified, verified_at, created_at, updated_at
                 FROM ratings
-                WHERE id = ?
+                WHERE id = ? AND ` + shadowVisible("ratings", viewer) + `
         `
 
 	var rating model.Rating
@@ -246,6 +281,8 @@ func (r *MySQLRepository) GetRatingByID(ctx context.Context, id uuid.UUID) (*mod
 		&userIDStr,
 		&serviceIDStr,
 		&rating.Score,
+		&rating.Verified,
+		&rating.VerifiedAt,
 		&rating.CreatedAt,
 		&rating.UpdatedAt,
 	)
@@ -266,29 +303,31 @@ func (r *MySQLRepository) GetRatingByID(ctx context.Context, id uuid.UUID) (*mod
 }
 
 // GetRatingsByService retrieves ratings for a specific service with pagination
-func (r *MySQLRepository) GetRatingsByService(ctx context.Context, serviceID uuid.UUID, params pagination.Params) ([]*model.Rating, int, error) {
+func (r *MySQLRepository) GetRatingsByService(ctx context.Context, serviceID uuid.UUID, viewer model.Viewer, filter model.RatingFilter, params pagination.Params) ([]*model.Rating, int, error) {
 	// Convert pagination.Params to *pagination.Pagination
 	page := pagination.NewPagination(params.GetPage(), params.GetLimit())
+	visible := shadowVisible("ratings", viewer)
 	// Count total ratings for this service
 	countQuery := `
-                SELECT COUNT(*) FROM ratings WHERE service_id = ?
+                SELECT COUNT(*) FROM ratings WHERE service_id = ? AND (? = FALSE OR verified = TRUE) AND quarantined = FALSE
+                  AND ` + visible + `
         `
 	var total int
-	err := r.db.QueryRowContext(ctx, countQuery, serviceID.String()).Scan(&total)
+	err := r.db.QueryRowContext(ctx, countQuery, serviceID.String(), filter.VerifiedOnly).Scan(&total)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to count ratings: %w", err)
 	}
 
 	// Get paginated ratings
 	query := `
-                SELECT id, user_id, service_id, score, created_at, updated_at
+                SELECT id, user_id, service_id, score, verified, verified_at, created_at, updated_at
                 FROM ratings
-                WHERE service_id = ?
+                WHERE service_id = ? AND (? = FALSE OR verified = TRUE) AND quarantined = FALSE AND ` + visible + `
                 ORDER BY created_at DESC
                 LIMIT ? OFFSET ?
         `
 
-	rows, err := r.db.QueryContext(ctx, query, serviceID.String(), page.GetLimit(), page.GetOffset())
+	rows, err := r.db.QueryContext(ctx, query, serviceID.String(), filter.VerifiedOnly, page.GetLimit(), page.GetOffset())
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to get ratings: %w", err)
 	}
@@ -304,6 +343,8 @@ func (r *MySQLRepository) GetRatingsByService(ctx context.Context, serviceID uui
 			&userIDStr,
 			&serviceIDStr,
 			&rating.Score,
+			&rating.Verified,
+			&rating.VerifiedAt,
 			&rating.CreatedAt,
 			&rating.UpdatedAt,
 		); err != nil {
@@ -326,19 +367,19 @@ func (r *MySQLRepository) GetRatingsByService(ctx context.Context, serviceID uui
 }
 
 // CalculateAverageRating calculates the average rating for a service
-func (r *MySQLRepository) CalculateAverageRating(ctx context.Context, serviceID uuid.UUID) (*model.AverageRating, error) {
+func (r *MySQLRepository) CalculateAverageRating(ctx context.Context, serviceID uuid.UUID, viewer model.Viewer, filter model.RatingFilter) (*model.AverageRating, error) {
 	query := `
                 SELECT 
                         AVG(score) as average_score, 
                         COUNT(*) as total_ratings
                 FROM ratings
-                WHERE service_id = ?
+                WHERE service_id = ? AND (? = FALSE OR verified = TRUE) AND quarantined = FALSE AND ` + shadowVisible("ratings", viewer) + `
         `
 
 	var avg sql.NullFloat64
 	var count int
 
-	err := r.db.QueryRowContext(ctx, query, serviceID.String()).Scan(&avg, &count)
+	err := r.db.QueryRowContext(ctx, query, serviceID.String(), filter.VerifiedOnly).Scan(&avg, &count)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get average rating: %w", err)
 	}
@@ -353,13 +394,14 @@ func (r *MySQLRepository) CalculateAverageRating(ctx context.Context, serviceID
 		ServiceID:    serviceID,
 		AverageScore: averageScore,
 		TotalRatings: count,
+		VerifiedOnly: filter.VerifiedOnly,
 	}, nil
 }
 
 // GetRatingByUserAndService retrieves a rating for a specific user and service
 func (r *MySQLRepository) GetRatingByUserAndService(ctx context.Context, userID, serviceID uuid.UUID) (*model.Rating, error) {
 	query := `
-                SELECT id, user_id, service_id, score, created_at, updated_at
+                SELECT id, user_id, service_id, score, verified, verified_at, created_at, updated_at
                 FROM ratings
                 WHERE user_id = ? AND service_id = ?
         `
@@ -372,6 +414,8 @@ func (r *MySQLRepository) GetRatingByUserAndService(ctx context.Context, userID,
 		&userIDStr,
 		&serviceIDStr,
 		&rating.Score,
+		&rating.Verified,
+		&rating.VerifiedAt,
 		&rating.CreatedAt,
 		&rating.UpdatedAt,
 	)
@@ -391,20 +435,87 @@ func (r *MySQLRepository) GetRatingByUserAndService(ctx context.Context, userID,
 	return &rating, nil
 }
 
+// withAttestation runs a rating or review write, stores the redeemed attestation and marks its rating
+// as verified in one transaction. Attestations are single use, so when the token was redeemed before
+// the transaction is rolled back, undoing the write, and ErrAttestationReused is returned.
+func (r *MySQLRepository) withAttestation(ctx context.Context, attestation *model.Attestation, write func(tx *sql.Tx) error) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := write(tx); err != nil {
+		return err
+	}
+
+	query := `
+                INSERT INTO attestations (id, token_id, rating_id, user_id, service_id, order_id, algorithm, expires_at, verified_at)
+                VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+        `
+
+	_, err = tx.ExecContext(ctx, query,
+		attestation.ID.String(),
+		attestation.TokenID,
+		attestation.RatingID.String(),
+		attestation.UserID.String(),
+		attestation.ServiceID.String(),
+		attestation.OrderID,
+		attestation.Algorithm,
+		attestation.ExpiresAt,
+		attestation.VerifiedAt,
+	)
+	if err != nil {
+		// Check for duplicate key error
+		if strings.Contains(err.Error(), "Duplicate entry") && strings.Contains(err.Error(), "unique_attestation_token") {
+			return model.ErrAttestationReused
+		}
+		return fmt.Errorf("failed to create attestation: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `UPDATE ratings SET verified = TRUE, verified_at = ? WHERE id = ?`,
+		attestation.VerifiedAt,
+		attestation.RatingID.String(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to mark rating verified: %w", err)
+	}
+
+	return tx.Commit()
+}
+
 // CreateReview creates a new review
 func (r *MySQLRepository) CreateReview(ctx context.Context, review *model.Review) error {
+	return insertMySQLReview(ctx, r.db, review)
+}
+
+// CreateReviewWithAttestation creates a new review and verifies its rating with the attestation, in one
+// transaction so the attestation is only consumed if the review is saved
+func (r *MySQLRepository) CreateReviewWithAttestation(ctx context.Context, review *model.Review, attestation *model.Attestation) error {
+	return r.withAttestation(ctx, attestation, func(tx *sql.Tx) error {
+		return insertMySQLReview(ctx, tx, review)
+	})
+}
+
+// insertMySQLReview inserts a review using the database or an open transaction
+func insertMySQLReview(ctx context.Context, db execer, review *model.Review) error {
 	query := `
-                INSERT INTO reviews (id, user_id, service_id, rating_id, title, content, created_at, updated_at)
-                VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+                INSERT INTO reviews (id, user_id, service_id, rating_id, title, content, status, sentiment_score, publish_at, published_at, shadowed, created_at, updated_at)
+                VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
         `
 
-	_, err := r.execWithContext(ctx, query,
+	_, err := db.ExecContext(ctx, query,
 		review.ID.String(),
 		review.UserID.String(),
 		review.ServiceID.String(),
 		review.RatingID.String(),
 		review.Title,
 		review.Content,
+		review.Status,
+		review.SentimentScore,
+		review.PublishAt,
+		review.PublishedAt,
+		review.Shadowed,
 		review.CreatedAt,
 		review.UpdatedAt,
 	)
@@ -421,29 +532,15 @@ func (r *MySQLRepository) CreateReview(ctx context.Context, review *model.Review
 }
 
 // GetReviewByID retrieves a review by ID
-func (r *MySQLRepository) GetReviewByID(ctx context.Context, id uuid.UUID) (*model.ReviewWithRating, error) {
+func (r *MySQLRepository) GetReviewByID(ctx context.Context, id uuid.UUID, viewer model.Viewer) (*model.ReviewWithRating, error) {
 	query := `
-                SELECT r.id, r.user_id, r.service_id, r.rating_id, r.title, r.content, r.created_at, r.updated_at, rt.score
+                SELECT ` + reviewColumns(viewer) + `
                 FROM reviews r
                 JOIN ratings rt ON r.rating_id = rt.id
-                WHERE r.id = ?
+                WHERE r.id = ? AND ` + shadowVisible("r", viewer) + `
         `
 
-	var review model.ReviewWithRating
-	var idStr, userIDStr, serviceIDStr, ratingIDStr string
-
-	err := r.db.QueryRowContext(ctx, query, id.String()).Scan(
-		&idStr,
-		&userIDStr,
-		&serviceIDStr,
-		&ratingIDStr,
-		&review.Title,
-		&review.Content,
-		&review.CreatedAt,
-		&review.UpdatedAt,
-		&review.Score,
-	)
-
+	review, err := scanMySQLReview(r.db.QueryRowContext(ctx, query, id.String()))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, errors.New("review not found")
@@ -451,92 +548,314 @@ func (r *MySQLRepository) GetReviewByID(ctx context.Context, id uuid.UUID) (*mod
 		return nil, fmt.Errorf("failed to get review: %w", err)
 	}
 
-	// Parse UUIDs
-	review.ID, _ = uuid.Parse(idStr)
-	review.UserID, _ = uuid.Parse(userIDStr)
-	review.ServiceID, _ = uuid.Parse(serviceIDStr)
-	review.RatingID, _ = uuid.Parse(ratingIDStr)
-
-	return &review, nil
+	return review, nil
 }
 
-// GetReviewsByService retrieves reviews for a specific service with pagination
-func (r *MySQLRepository) GetReviewsByService(ctx context.Context, serviceID uuid.UUID, params pagination.Params) ([]*model.ReviewWithRating, int, error) {
+// GetReviewsByService retrieves reviews for a specific service with pagination. Comment counts are
+// selected with the reviews; a requested comment preview takes one more query for the page.
+func (r *MySQLRepository) GetReviewsByService(ctx context.Context, serviceID uuid.UUID, viewer model.Viewer, filter model.ReviewFilter, params pagination.Params) ([]*model.ReviewWithRating, int, error) {
 	// Convert pagination.Params to *pagination.Pagination
 	page := pagination.NewPagination(params.GetPage(), params.GetLimit())
 	// Count total reviews for this service
 	countQuery := `
-                SELECT COUNT(*) FROM reviews WHERE service_id = ?
+                SELECT COUNT(*)
+                FROM reviews r
+                JOIN ratings rt ON r.rating_id = rt.id
+                WHERE r.service_id = ? AND r.status = 'published' AND r.moderation_status IN ` + publicModeration + `
+                  AND (? = FALSE OR rt.verified = TRUE) AND ` + listVisible("r", viewer) + `
         `
 	var total int
-	err := r.db.QueryRowContext(ctx, countQuery, serviceID.String()).Scan(&total)
+	err := r.db.QueryRowContext(ctx, countQuery, serviceID.String(), filter.VerifiedOnly).Scan(&total)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to count reviews: %w", err)
 	}
 
 	// Get paginated reviews
 	query := `
-                SELECT r.id, r.user_id, r.service_id, r.rating_id, r.title, r.content, r.created_at, r.updated_at, rt.score
+                SELECT ` + reviewColumns(viewer) + `
                 FROM reviews r
                 JOIN ratings rt ON r.rating_id = rt.id
-                WHERE r.service_id = ?
+                WHERE r.service_id = ? AND r.status = 'published' AND r.moderation_status IN ` + publicModeration + `
+                  AND (? = FALSE OR rt.verified = TRUE) AND ` + listVisible("r", viewer) + `
                 ORDER BY r.created_at DESC
                 LIMIT ? OFFSET ?
         `
 
-	rows, err := r.db.QueryContext(ctx, query, serviceID.String(), page.GetLimit(), page.GetOffset())
+	rows, err := r.db.QueryContext(ctx, query, serviceID.String(), filter.VerifiedOnly, page.GetLimit(), page.GetOffset())
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to get reviews: %w", err)
 	}
 	defer rows.Close()
 
-	var reviews []*model.ReviewWithRating
-	for rows.Next() {
-		var review model.ReviewWithRating
-		var idStr, userIDStr, serviceIDStr, ratingIDStr string
+	reviews, err := scanMySQLReviews(rows)
+	if err != nil {
+		return nil, 0, err
+	}
 
-		if err := rows.Scan(
-			&idStr,
-			&userIDStr,
-			&serviceIDStr,
-			&ratingIDStr,
-			&review.Title,
-			&review.Content,
-			&review.CreatedAt,
-			&review.UpdatedAt,
-			&review.Score,
-		); err != nil {
-			return nil, 0, fmt.Errorf("failed to scan review row: %w", err)
+	if filter.CommentPreview > 0 {
+		comments, err := r.getLatestComments(ctx, reviews, viewer, filter.CommentPreview)
+		if err != nil {
+			return nil, 0, err
 		}
+		model.AttachLatestComments(reviews, comments)
+	}
 
-		// Parse UUIDs
-		review.ID, _ = uuid.Parse(idStr)
-		review.UserID, _ = uuid.Parse(userIDStr)
-		review.ServiceID, _ = uuid.Parse(serviceIDStr)
-		review.RatingID, _ = uuid.Parse(ratingIDStr)
+	return reviews, total, nil
+}
 
-		reviews = append(reviews, &review)
+// GetReviewsByUser retrieves a user's own reviews, optionally restricted to one status
+func (r *MySQLRepository) GetReviewsByUser(ctx context.Context, userID uuid.UUID, status model.ReviewStatus, params pagination.Params) ([]*model.ReviewWithRating, int, error) {
+	page := pagination.NewPagination(params.GetPage(), params.GetLimit())
+	countQuery := `
+                SELECT COUNT(*) FROM reviews WHERE user_id = ? AND (? = '' OR status = ?)
+        `
+	var total int
+	err := r.db.QueryRowContext(ctx, countQuery, userID.String(), string(status), string(status)).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count reviews: %w", err)
 	}
 
-	if err = rows.Err(); err != nil {
-		return nil, 0, fmt.Errorf("error iterating review rows: %w", err)
+	query := `
+                SELECT ` + reviewColumns(model.ViewerOf(userID)) + `
+                FROM reviews r
+                JOIN ratings rt ON r.rating_id = rt.id
+                WHERE r.user_id = ? AND (? = '' OR r.status = ?)
+                ORDER BY r.updated_at DESC
+                LIMIT ? OFFSET ?
+        `
+
+	rows, err := r.db.QueryContext(ctx, query, userID.String(), string(status), string(status), page.GetLimit(), page.GetOffset())
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get reviews: %w", err)
+	}
+	defer rows.Close()
+
+	reviews, err := scanMySQLReviews(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return reviews, total, nil
+}
+
+// GetPendingReviews retrieves reviews awaiting publication, oldest first
+func (r *MySQLRepository) GetPendingReviews(ctx context.Context, params pagination.Params) ([]*model.ReviewWithRating, int, error) {
+	page := pagination.NewPagination(params.GetPage(), params.GetLimit())
+	countQuery := `
+                SELECT COUNT(*) FROM reviews WHERE status = 'pending'
+        `
+	var total int
+	err := r.db.QueryRowContext(ctx, countQuery).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count pending reviews: %w", err)
+	}
+
+	query := `
+                SELECT ` + reviewColumns(model.SystemViewer) + `
+                FROM reviews r
+                JOIN ratings rt ON r.rating_id = rt.id
+                WHERE r.status = 'pending'
+                ORDER BY r.updated_at ASC
+                LIMIT ? OFFSET ?
+        `
+
+	rows, err := r.db.QueryContext(ctx, query, page.GetLimit(), page.GetOffset())
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get pending reviews: %w", err)
+	}
+	defer rows.Close()
+
+	reviews, err := scanMySQLReviews(rows)
+	if err != nil {
+		return nil, 0, err
 	}
 
 	return reviews, total, nil
 }
 
+// GetPublishedReviewsAfter retrieves a service's published reviews in publication order,
+// starting after the given publication time and review ID. Shadowed reviews are left out since
+// the results feed highlights shown to everyone.
+func (r *MySQLRepository) GetPublishedReviewsAfter(ctx context.Context, serviceID uuid.UUID, publishedAt time.Time, afterID uuid.UUID, limit int) ([]*model.ReviewWithRating, error) {
+	query := `
+                SELECT ` + reviewColumns(model.ViewerOf(uuid.Nil)) + `
+                FROM reviews r
+                JOIN ratings rt ON r.rating_id = rt.id
+                WHERE r.service_id = ? AND r.status = 'published' AND r.moderation_status IN ` + publicModeration + `
+                  AND r.shadowed = FALSE
+                  AND (COALESCE(r.published_at, r.created_at) > ?
+                       OR (COALESCE(r.published_at, r.created_at) = ? AND r.id > ?))
+                ORDER BY COALESCE(r.published_at, r.created_at), r.id
+                LIMIT ?
+        `
+
+	rows, err := r.db.QueryContext(ctx, query, serviceID.String(), publishedAt, publishedAt, afterID.String(), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get published reviews: %w", err)
+	}
+	defer rows.Close()
+
+	return scanMySQLReviews(rows)
+}
+
+// PublishDueReviews publishes pending reviews whose cool-down has elapsed and returns their IDs.
+// MySQL cannot return updated rows, so the due reviews are locked and selected first and then
+// updated by ID in the same transaction.
+func (r *MySQLRepository) PublishDueReviews(ctx context.Context, now time.Time) ([]uuid.UUID, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `
+                SELECT id FROM reviews
+                WHERE status = 'pending' AND publish_at IS NOT NULL AND publish_at <= ?
+                FOR UPDATE
+        `, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get due reviews: %w", err)
+	}
+	defer rows.Close()
+
+	var published []uuid.UUID
+	var placeholders []string
+	args := []interface{}{now, now}
+	for rows.Next() {
+		var idStr string
+		if err := rows.Scan(&idStr); err != nil {
+			return nil, fmt.Errorf("failed to scan due review: %w", err)
+		}
+		id, _ := uuid.Parse(idStr)
+		published = append(published, id)
+		placeholders = append(placeholders, "?")
+		args = append(args, idStr)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate due reviews: %w", err)
+	}
+	rows.Close()
+
+	if len(published) == 0 {
+		return nil, nil
+	}
+
+	query := `
+                UPDATE reviews
+                SET status = 'published', published_at = ?, publish_at = NULL, updated_at = ?
+                WHERE id IN (` + strings.Join(placeholders, ", ") + `)
+        `
+	if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to publish due reviews: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return published, nil
+}
+
+// CalculateSentimentSummary aggregates the text sentiment of a service's published reviews
+func (r *MySQLRepository) CalculateSentimentSummary(ctx context.Context, serviceID uuid.UUID, viewer model.Viewer) (*model.SentimentSummary, error) {
+	query := `
+                SELECT
+                        AVG(sentiment_score) AS average_sentiment,
+                        COUNT(sentiment_score) AS analyzed_reviews,
+                        COUNT(CASE WHEN sentiment_score > ? THEN 1 END) AS positive,
+                        COUNT(CASE WHEN sentiment_score < ? THEN 1 END) AS negative
+                FROM reviews
+                WHERE service_id = ? AND status = 'published' AND moderation_status IN ` + publicModeration + `
+                  AND sentiment_score IS NOT NULL AND ` + shadowVisible("reviews", viewer) + `
+        `
+
+	var avg sql.NullFloat64
+	summary := &model.SentimentSummary{ServiceID: serviceID}
+	err := r.db.QueryRowContext(ctx, query, model.SentimentNeutralBand, -model.SentimentNeutralBand, serviceID.String()).
+		Scan(&avg, &summary.AnalyzedReviews, &summary.Positive, &summary.Negative)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sentiment summary: %w", err)
+	}
+
+	// AVG is NULL when no review has been analyzed yet
+	if avg.Valid {
+		summary.AverageSentiment = avg.Float64
+	}
+	summary.Neutral = summary.AnalyzedReviews - summary.Positive - summary.Negative
+	summary.CalculatedAt = time.Now()
+	return summary, nil
+}
+
+// scanMySQLReview scans a row selected with reviewColumns
+func scanMySQLReview(row rowScanner) (*model.ReviewWithRating, error) {
+	var review model.ReviewWithRating
+	var idStr, userIDStr, serviceIDStr, ratingIDStr string
+
+	if err := row.Scan(
+		&idStr,
+		&userIDStr,
+		&serviceIDStr,
+		&ratingIDStr,
+		&review.Title,
+		&review.Content,
+		&review.Status,
+		&review.ModerationStatus,
+		&review.SentimentScore,
+		&review.PublishAt,
+		&review.PublishedAt,
+		&review.Shadowed,
+		&review.CreatedAt,
+		&review.UpdatedAt,
+		&review.Score,
+		&review.Verified,
+		&review.CommentCount,
+	); err != nil {
+		return nil, err
+	}
+
+	// Parse UUIDs
+	review.ID, _ = uuid.Parse(idStr)
+	review.UserID, _ = uuid.Parse(userIDStr)
+	review.ServiceID, _ = uuid.Parse(serviceIDStr)
+	review.RatingID, _ = uuid.Parse(ratingIDStr)
+
+	review.DetectSentimentMismatch()
+	return &review, nil
+}
+
+// scanMySQLReviews scans all rows selected with reviewColumns
+func scanMySQLReviews(rows *sql.Rows) ([]*model.ReviewWithRating, error) {
+	var reviews []*model.ReviewWithRating
+	for rows.Next() {
+		review, err := scanMySQLReview(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan review row: %w", err)
+		}
+		reviews = append(reviews, review)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating review rows: %w", err)
+	}
+
+	return reviews, nil
+}
+
 // CreateComment creates a new comment
 func (r *MySQLRepository) CreateComment(ctx context.Context, comment *model.Comment) error {
 	query := `
-                INSERT INTO comments (id, user_id, review_id, content, created_at, updated_at)
-                VALUES (?, ?, ?, ?, ?, ?)
+                INSERT INTO comments (id, user_id, review_id, parent_id, thread_id, depth, content, shadowed, created_at, updated_at)
+                VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
         `
 
 	_, err := r.execWithContext(ctx, query,
 		comment.ID.String(),
 		comment.UserID.String(),
 		comment.ReviewID.String(),
+		nullUUIDArg(comment.ParentID),
+		comment.ThreadID.String(),
+		comment.Depth,
 		comment.Content,
+		comment.Shadowed,
 		comment.CreatedAt,
 		comment.UpdatedAt,
 	)
@@ -549,12 +868,13 @@ func (r *MySQLRepository) CreateComment(ctx context.Context, comment *model.Comm
 }
 
 // GetCommentsByReview retrieves comments for a specific review with pagination
-func (r *MySQLRepository) GetCommentsByReview(ctx context.Context, reviewID uuid.UUID, params pagination.Params) ([]*model.Comment, int, error) {
+func (r *MySQLRepository) GetCommentsByReview(ctx context.Context, reviewID uuid.UUID, viewer model.Viewer, params pagination.Params) ([]*model.Comment, int, error) {
 	// Convert pagination.Params to *pagination.Pagination
 	page := pagination.NewPagination(params.GetPage(), params.GetLimit())
 	// Count total comments for this review
 	countQuery := `
-                SELECT COUNT(*) FROM comments WHERE review_id = ?
+                SELECT COUNT(*) FROM comments WHERE review_id = ? AND moderation_status IN ` + publicModeration + `
+                  AND ` + listVisible("comments", viewer) + `
         `
 	var total int
 	err := r.db.QueryRowContext(ctx, countQuery, reviewID.String()).Scan(&total)
@@ -564,10 +884,10 @@ func (r *MySQLRepository) GetCommentsByReview(ctx context.Context, reviewID uuid
 
 	// Get paginated comments
 	query := `
-                SELECT id, user_id, review_id, content, created_at, updated_at
-                FROM comments
-                WHERE review_id = ?
-                ORDER BY created_at ASC
+                SELECT ` + commentColumns + `
+                FROM comments c
+                WHERE c.review_id = ? AND c.moderation_status IN ` + publicModeration + ` AND ` + listVisible("c", viewer) + `
+                ORDER BY c.created_at ASC
                 LIMIT ? OFFSET ?
         `
 
@@ -577,57 +897,140 @@ func (r *MySQLRepository) GetCommentsByReview(ctx context.Context, reviewID uuid
 	}
 	defer rows.Close()
 
-	var comments []*model.Comment
-	for rows.Next() {
-		var comment model.Comment
-		var idStr, userIDStr, reviewIDStr string
+	comments, err := scanMySQLComments(rows)
+	if err != nil {
+		return nil, 0, err
+	}
 
-		if err := rows.Scan(
-			&idStr,
-			&userIDStr,
-			&reviewIDStr,
-			&comment.Content,
-			&comment.CreatedAt,
-			&comment.UpdatedAt,
-		); err != nil {
-			return nil, 0, fmt.Errorf("failed to scan comment row: %w", err)
-		}
+	return comments, total, nil
+}
 
-		// Parse UUIDs
-		comment.ID, _ = uuid.Parse(idStr)
-		comment.UserID, _ = uuid.Parse(userIDStr)
-		comment.ReviewID, _ = uuid.Parse(reviewIDStr)
+// GetCommentThreads retrieves the top-level comments of a review with pagination. Comments withheld
+// from the viewer are only included, and marked withheld, when a reply in their thread is visible.
+func (r *MySQLRepository) GetCommentThreads(ctx context.Context, reviewID uuid.UUID, viewer model.Viewer, params pagination.Params) ([]*model.Comment, int, error) {
+	page := pagination.NewPagination(params.GetPage(), params.GetLimit())
 
-		comments = append(comments, &comment)
+	countQuery := `
+                SELECT COUNT(*) FROM comments c WHERE c.review_id = ? AND c.parent_id IS NULL AND ` + threadShown(viewer) + `
+        `
+	var total int
+	err := r.db.QueryRowContext(ctx, countQuery, reviewID.String()).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count comment threads: %w", err)
 	}
 
-	if err = rows.Err(); err != nil {
-		return nil, 0, fmt.Errorf("error iterating comment rows: %w", err)
+	query := `
+                SELECT ` + treeColumns(viewer) + `
+                FROM comments c
+                WHERE c.review_id = ? AND c.parent_id IS NULL AND ` + threadShown(viewer) + `
+        `
+	if params.GetSortDirection() == "asc" {
+		query += " ORDER BY c.created_at ASC, c.id ASC"
+	} else {
+		query += " ORDER BY c.created_at DESC, c.id DESC"
+	}
+	query += " LIMIT ? OFFSET ?"
+
+	rows, err := r.db.QueryContext(ctx, query, reviewID.String(), page.GetLimit(), page.GetOffset())
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get comment threads: %w", err)
+	}
+	defer rows.Close()
+
+	comments, err := scanMySQLTreeComments(rows)
+	if err != nil {
+		return nil, 0, err
 	}
 
 	return comments, total, nil
 }
 
-// GetCommentByID retrieves a comment by ID
-func (r *MySQLRepository) GetCommentByID(ctx context.Context, id uuid.UUID) (*model.Comment, error) {
+// GetCommentReplies retrieves all replies in the given threads, oldest first, marking those withheld
+// from the viewer
+func (r *MySQLRepository) GetCommentReplies(ctx context.Context, threadIDs []uuid.UUID, viewer model.Viewer) ([]*model.Comment, error) {
+	if len(threadIDs) == 0 {
+		return nil, nil
+	}
+
+	placeholders := make([]string, len(threadIDs))
+	args := make([]interface{}, len(threadIDs))
+	for i, id := range threadIDs {
+		placeholders[i] = "?"
+		args[i] = id.String()
+	}
+
 	query := `
-                SELECT id, user_id, review_id, content, created_at, updated_at
-                FROM comments
-                WHERE id = ?
+                SELECT ` + treeColumns(viewer) + `
+                FROM comments c
+                WHERE c.thread_id IN (` + strings.Join(placeholders, ", ") + `) AND c.parent_id IS NOT NULL
+                ORDER BY c.created_at ASC, c.id ASC
         `
 
-	var comment model.Comment
-	var idStr, userIDStr, reviewIDStr string
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comment replies: %w", err)
+	}
+	defer rows.Close()
 
-	err := r.db.QueryRowContext(ctx, query, id.String()).Scan(
-		&idStr,
-		&userIDStr,
-		&reviewIDStr,
-		&comment.Content,
-		&comment.CreatedAt,
-		&comment.UpdatedAt,
-	)
+	return scanMySQLTreeComments(rows)
+}
+
+// getLatestComments retrieves up to limit of the newest public comments that have not been deleted
+// and that the viewer can see for each of the reviews, newest first, ranking them per review with
+// a window function. It is a second query, issued once for the whole page of reviews.
+func (r *MySQLRepository) getLatestComments(ctx context.Context, reviews []*model.ReviewWithRating, viewer model.Viewer, limit int) ([]*model.Comment, error) {
+	if len(reviews) == 0 {
+		return nil, nil
+	}
+
+	placeholders := make([]string, len(reviews))
+	args := make([]interface{}, 0, len(reviews)+1)
+	for i, review := range reviews {
+		placeholders[i] = "?"
+		args = append(args, review.ID.String())
+	}
+	args = append(args, limit)
+
+	query := `
+                SELECT ` + commentColumns + `
+                FROM (
+                        SELECT comments.*, ROW_NUMBER() OVER (PARTITION BY review_id ORDER BY created_at DESC, id DESC) AS recency
+                        FROM comments
+                        WHERE review_id IN (` + strings.Join(placeholders, ", ") + `) AND deleted = FALSE
+                          AND moderation_status IN ` + publicModeration + ` AND ` + listVisible("comments", viewer) + `
+                ) c
+                WHERE c.recency <= ?
+                ORDER BY c.review_id, c.recency
+        `
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest comments: %w", err)
+	}
+	defer rows.Close()
+
+	return scanMySQLComments(rows)
+}
+
+// CountCommentReplies counts the direct replies to a comment
+func (r *MySQLRepository) CountCommentReplies(ctx context.Context, id uuid.UUID) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM comments WHERE parent_id = ?`, id.String()).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count comment replies: %w", err)
+	}
+	return count, nil
+}
+
+// GetCommentByID retrieves a comment by ID
+func (r *MySQLRepository) GetCommentByID(ctx context.Context, id uuid.UUID, viewer model.Viewer) (*model.Comment, error) {
+	query := `
+                SELECT ` + commentColumns + `
+                FROM comments c
+                WHERE c.id = ? AND ` + shadowVisible("c", viewer) + `
+        `
 
+	comment, err := scanMySQLComment(r.db.QueryRowContext(ctx, query, id.String()))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, errors.New("comment not found")
@@ -635,24 +1038,21 @@ func (r *MySQLRepository) GetCommentByID(ctx context.Context, id uuid.UUID) (*mo
 		return nil, fmt.Errorf("failed to get comment: %w", err)
 	}
 
-	// Parse UUIDs
-	comment.ID, _ = uuid.Parse(idStr)
-	comment.UserID, _ = uuid.Parse(userIDStr)
-	comment.ReviewID, _ = uuid.Parse(reviewIDStr)
-
-	return &comment, nil
+	return comment, nil
 }
 
 // UpdateComment updates a comment
 func (r *MySQLRepository) UpdateComment(ctx context.Context, comment *model.Comment) error {
 	query := `
                 UPDATE comments
-                SET content = ?, updated_at = ?
+                SET content = ?, deleted = ?, deleted_at = ?, updated_at = ?
                 WHERE id = ?
         `
 
 	result, err := r.execWithContext(ctx, query,
 		comment.Content,
+		comment.Deleted,
+		comment.DeletedAt,
 		comment.UpdatedAt,
 		comment.ID.String(),
 	)
@@ -672,17 +1072,113 @@ func (r *MySQLRepository) UpdateComment(ctx context.Context, comment *model.Comm
 	return nil
 }
 
+// DeleteComment permanently deletes a comment
+func (r *MySQLRepository) DeleteComment(ctx context.Context, id uuid.UUID) error {
+	result, err := r.execWithContext(ctx, `DELETE FROM comments WHERE id = ?`, id.String())
+	if err != nil {
+		return fmt.Errorf("failed to delete comment: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("comment not found")
+	}
+
+	return nil
+}
+
+// scanMySQLComment scans a row selected with commentColumns, followed by any extra columns
+func scanMySQLComment(row rowScanner, extra ...interface{}) (*model.Comment, error) {
+	var comment model.Comment
+	var idStr, userIDStr, reviewIDStr, threadIDStr string
+	var parentIDStr sql.NullString
+
+	dest := []interface{}{
+		&idStr,
+		&userIDStr,
+		&reviewIDStr,
+		&parentIDStr,
+		&threadIDStr,
+		&comment.Depth,
+		&comment.Content,
+		&comment.Deleted,
+		&comment.DeletedAt,
+		&comment.ModerationStatus,
+		&comment.Shadowed,
+		&comment.CreatedAt,
+		&comment.UpdatedAt,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+
+	// Parse UUIDs
+	comment.ID, _ = uuid.Parse(idStr)
+	comment.UserID, _ = uuid.Parse(userIDStr)
+	comment.ReviewID, _ = uuid.Parse(reviewIDStr)
+	comment.ThreadID, _ = uuid.Parse(threadIDStr)
+	comment.ParentID = parseNullUUID(parentIDStr)
+
+	return &comment, nil
+}
+
+// scanMySQLComments scans all rows selected with commentColumns
+func scanMySQLComments(rows *sql.Rows) ([]*model.Comment, error) {
+	var comments []*model.Comment
+	for rows.Next() {
+		comment, err := scanMySQLComment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan comment row: %w", err)
+		}
+		comments = append(comments, comment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating comment rows: %w", err)
+	}
+
+	return comments, nil
+}
+
+// scanMySQLTreeComments scans all rows selected with treeColumns
+func scanMySQLTreeComments(rows *sql.Rows) ([]*model.Comment, error) {
+	var comments []*model.Comment
+	for rows.Next() {
+		var withheld bool
+		comment, err := scanMySQLComment(rows, &withheld)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan comment row: %w", err)
+		}
+		comment.Withheld = withheld
+		comments = append(comments, comment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating comment rows: %w", err)
+	}
+
+	return comments, nil
+}
+
 // UpdateReview updates a review
 func (r *MySQLRepository) UpdateReview(ctx context.Context, review *model.Review) error {
 	query := `
                 UPDATE reviews
-                SET title = ?, content = ?, updated_at = ?
+                SET title = ?, content = ?, status = ?, sentiment_score = ?, publish_at = ?, published_at = ?, updated_at = ?
                 WHERE id = ?
         `
 
 	result, err := r.execWithContext(ctx, query,
 		review.Title,
 		review.Content,
+		review.Status,
+		review.SentimentScore,
+		review.PublishAt,
+		review.PublishedAt,
 		review.UpdatedAt,
 		review.ID.String(),
 	)
@@ -2,6 +2,7 @@ package repository
 
 import (
         "context"
+        "errors"
         "testing"
         "time"
 
@@ -47,6 +48,7 @@ func TestMySQLRepository_CreateRating(t *testing.T) {
                         rating.UserID.String(),
                         rating.ServiceID.String(),
                         rating.Score,
+                        rating.Shadowed,
                         rating.CreatedAt,
                         rating.UpdatedAt,
                 ).
@@ -60,6 +62,44 @@ func TestMySQLRepository_CreateRating(t *testing.T) {
         assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestMySQLRepository_UpdateRatingWithReusedAttestation(t *testing.T) {
+        // Create a new mock database connection
+        db, mock, err := sqlmock.New()
+        if err != nil {
+                t.Fatalf("Failed to create mock database connection: %v", err)
+        }
+        defer db.Close()
+
+        // Create a test logger
+        logger := logrus.New()
+        logger.SetLevel(logrus.ErrorLevel)
+
+        // Create a new repository with the mock database
+        repo := NewMySQLRepository(db, logger)
+
+        // Create a test rating with a new score and an attestation whose token was redeemed before
+        rating, _ := model.NewRating(uuid.New(), uuid.New(), 2)
+        rating.UpdateScore(5)
+        attestation := testAttestation(rating)
+
+        // Set up expectations: the new score is only written inside the transaction, which is
+        // rolled back so the stored score is unchanged
+        mock.ExpectBegin()
+        mock.ExpectExec("UPDATE ratings SET score").
+                WithArgs(5, rating.UpdatedAt, rating.ID.String()).
+                WillReturnResult(sqlmock.NewResult(0, 1))
+        mock.ExpectExec("INSERT INTO attestations").
+                WillReturnError(errors.New("Error 1062: Duplicate entry 'token-1' for key 'unique_attestation_token'"))
+        mock.ExpectRollback()
+
+        // Call the function being tested
+        err = repo.UpdateRatingWithAttestation(context.Background(), rating, attestation)
+
+        // Assertions
+        assert.ErrorIs(t, err, model.ErrAttestationReused)
+        assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestMySQLRepository_GetRatingByID(t *testing.T) {
         // Create a new mock database connection
         db, mock, err := sqlmock.New()
@@ -84,15 +124,15 @@ func TestMySQLRepository_GetRatingByID(t *testing.T) {
         updatedAt := time.Now()
 
         // Set up expectations
-        rows := sqlmock.NewRows([]string{"id", "user_id", "service_id", "score", "created_at", "updated_at"}).
-                AddRow(ratingID.String(), userID.String(), serviceID.String(), score, createdAt, updatedAt)
+        rows := sqlmock.NewRows([]string{"id", "user_id", "service_id", "score", "verified", "verified_at", "created_at", "updated_at"}).
+                AddRow(ratingID.String(), userID.String(), serviceID.String(), score, false, nil, createdAt, updatedAt)
 
-        mock.ExpectQuery("SELECT id, user_id, service_id, score, created_at, updated_at FROM ratings WHERE id = ?").
+        mock.ExpectQuery("SELECT id, user_id, service_id, score, verified, verified_at, created_at, updated_at FROM ratings WHERE id = \\?").
                 WithArgs(ratingID.String()).
                 WillReturnRows(rows)
 
         // Call the function being tested
-        rating, err := repo.GetRatingByID(context.Background(), ratingID)
+        rating, err := repo.GetRatingByID(context.Background(), ratingID, model.SystemViewer)
 
         // Assertions
         assert.NoError(t, err)
@@ -103,7 +143,7 @@ func TestMySQLRepository_GetRatingByID(t *testing.T) {
         assert.NoError(t, mock.ExpectationsWereMet())
 }
 
-func TestMySQLRepository_GetAverageRatingByService(t *testing.T) {
+func TestMySQLRepository_CalculateAverageRating(t *testing.T) {
         // Create a new mock database connection
         db, mock, err := sqlmock.New()
         if err != nil {
@@ -127,12 +167,12 @@ func TestMySQLRepository_GetAverageRatingByService(t *testing.T) {
         rows := sqlmock.NewRows([]string{"average_score", "total_ratings"}).
                 AddRow(averageScore, totalRatings)
 
-        mock.ExpectQuery("SELECT AVG\\(score\\) as average_score, COUNT\\(\\*\\) as total_ratings FROM ratings WHERE service_id = ?").
-                WithArgs(serviceID.String()).
+        mock.ExpectQuery("SELECT AVG\\(score\\) as average_score, COUNT\\(\\*\\) as total_ratings FROM ratings WHERE service_id = \\?").
+                WithArgs(serviceID.String(), false).
                 WillReturnRows(rows)
 
         // Call the function being tested
-        result, err := repo.GetAverageRatingByService(context.Background(), serviceID)
+        result, err := repo.CalculateAverageRating(context.Background(), serviceID, model.Viewer{}, model.RatingFilter{})
 
         // Assertions
         assert.NoError(t, err)
@@ -179,6 +219,11 @@ func TestMySQLRepository_CreateReview(t *testing.T) {
                         review.RatingID.String(),
                         review.Title,
                         review.Content,
+                        review.Status,
+                        review.SentimentScore,
+                        review.PublishAt,
+                        review.PublishedAt,
+                        review.Shadowed,
                         review.CreatedAt,
                         review.UpdatedAt,
                 ).
@@ -232,13 +277,14 @@ func TestMySQLRepository_GetReviewsByService(t *testing.T) {
 
         // Set up expectations for count query
         countRows := sqlmock.NewRows([]string{"count"}).AddRow(total)
-        mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM reviews WHERE service_id = ?").
-                WithArgs(serviceID.String()).
+        mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM reviews r JOIN ratings rt ON r.rating_id = rt.id WHERE r.service_id = \\?").
+                WithArgs(serviceID.String(), false).
                 WillReturnRows(countRows)
 
         // Set up expectations for the reviews query
         reviewRows := sqlmock.NewRows([]string{
-                "id", "user_id", "service_id", "rating_id", "title", "content", "created_at", "updated_at", "score",
+                "id", "user_id", "service_id", "rating_id", "title", "content", "status", "moderation_status", "sentiment_score",
+                "publish_at", "published_at", "shadowed", "created_at", "updated_at", "score", "verified", "comment_count",
         }).
                 AddRow(
                         review1ID.String(),
@@ -247,9 +293,17 @@ func TestMySQLRepository_GetReviewsByService(t *testing.T) {
                         review1RatingID.String(),
                         review1Title,
                         review1Content,
+                        "published",
+                        "visible",
+                        nil,
+                        nil,
+                        review1CreatedAt,
+                        false,
                         review1CreatedAt,
                         review1UpdatedAt,
                         review1Score,
+                        false,
+                        0,
                 ).
                 AddRow(
                         review2ID.String(),
@@ -258,17 +312,25 @@ func TestMySQLRepository_GetReviewsByService(t *testing.T) {
                         review2RatingID.String(),
                         review2Title,
                         review2Content,
+                        "published",
+                        "visible",
+                        nil,
+                        nil,
+                        review2CreatedAt,
+                        false,
                         review2CreatedAt,
                         review2UpdatedAt,
                         review2Score,
+                        false,
+                        0,
                 )
 
-        mock.ExpectQuery("SELECT r.id, r.user_id, r.service_id, r.rating_id, r.title, r.content, r.created_at, r.updated_at, rt.score FROM reviews r JOIN ratings rt ON r.rating_id = rt.id WHERE r.service_id = ? ORDER BY r.created_at DESC LIMIT ? OFFSET ?").
-                WithArgs(serviceID.String(), params.GetLimit(), params.GetOffset()).
+        mock.ExpectQuery("SELECT r.id, r.user_id, r.service_id, r.rating_id, r.title, r.content, (.+) FROM reviews r JOIN ratings rt ON r.rating_id = rt.id WHERE r.service_id = \\? (.+) ORDER BY r.created_at DESC LIMIT \\? OFFSET \\?").
+                WithArgs(serviceID.String(), false, params.GetLimit(), params.GetOffset()).
                 WillReturnRows(reviewRows)
 
         // Call the function being tested
-        reviews, count, err := repo.GetReviewsByService(context.Background(), serviceID, params)
+        reviews, count, err := repo.GetReviewsByService(context.Background(), serviceID, model.Viewer{}, model.ReviewFilter{}, params)
 
         // Assertions
         assert.NoError(t, err)
@@ -315,7 +377,11 @@ func TestMySQLRepository_CreateComment(t *testing.T) {
                         comment.ID.String(),
                         comment.UserID.String(),
                         comment.ReviewID.String(),
+                        nil,
+                        comment.ThreadID.String(),
+                        comment.Depth,
                         comment.Content,
+                        comment.Shadowed,
                         comment.CreatedAt,
                         comment.UpdatedAt,
                 ).
@@ -363,19 +429,27 @@ func TestMySQLRepository_GetCommentsByReview(t *testing.T) {
 
         // Set up expectations for count query
         countRows := sqlmock.NewRows([]string{"count"}).AddRow(total)
-        mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM comments WHERE review_id = ?").
+        mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM comments WHERE review_id = \\?").
                 WithArgs(reviewID.String()).
                 WillReturnRows(countRows)
 
         // Set up expectations for the comments query
         commentRows := sqlmock.NewRows([]string{
-                "id", "user_id", "review_id", "content", "created_at", "updated_at",
+                "id", "user_id", "review_id", "parent_id", "thread_id", "depth", "content", "deleted", "deleted_at",
+                "moderation_status", "shadowed", "created_at", "updated_at",
         }).
                 AddRow(
                         comment1ID.String(),
                         comment1UserID.String(),
                         reviewID.String(),
+                        nil,
+                        comment1ID.String(),
+                        0,
                         comment1Content,
+                        false,
+                        nil,
+                        "visible",
+                        false,
                         comment1CreatedAt,
                         comment1UpdatedAt,
                 ).
@@ -383,17 +457,24 @@ func TestMySQLRepository_GetCommentsByReview(t *testing.T) {
                         comment2ID.String(),
                         comment2UserID.String(),
                         reviewID.String(),
+                        nil,
+                        comment2ID.String(),
+                        0,
                         comment2Content,
+                        false,
+                        nil,
+                        "visible",
+                        false,
                         comment2CreatedAt,
                         comment2UpdatedAt,
                 )
 
-        mock.ExpectQuery("SELECT id, user_id, review_id, content, created_at, updated_at FROM comments WHERE review_id = ? ORDER BY created_at ASC LIMIT ? OFFSET ?").
+        mock.ExpectQuery("SELECT c.id, c.user_id, c.review_id, (.+) FROM comments c WHERE c.review_id = \\? (.+) ORDER BY c.created_at ASC LIMIT \\? OFFSET \\?").
                 WithArgs(reviewID.String(), params.GetLimit(), params.GetOffset()).
                 WillReturnRows(commentRows)
 
         // Call the function being tested
-        comments, count, err := repo.GetCommentsByReview(context.Background(), reviewID, params)
+        comments, count, err := repo.GetCommentsByReview(context.Background(), reviewID, model.Viewer{}, params)
 
         // Assertions
         assert.NoError(t, err)
@@ -404,4 +485,170 @@ func TestMySQLRepository_GetCommentsByReview(t *testing.T) {
         assert.Equal(t, comment2ID, comments[1].ID)
         assert.Equal(t, comment2Content, comments[1].Content)
         assert.NoError(t, mock.ExpectationsWereMet())
-}
\ No newline at end of file
+}
+
+func TestMySQLRepository_PublishDueReviews(t *testing.T) {
+        // Create a new mock database connection
+        db, mock, err := sqlmock.New()
+        if err != nil {
+                t.Fatalf("Failed to create mock database connection: %v", err)
+        }
+        defer db.Close()
+
+        // Create a test logger
+        logger := logrus.New()
+        logger.SetLevel(logrus.ErrorLevel)
+
+        // Create a new repository with the mock database
+        repo := NewMySQLRepository(db, logger)
+
+        // Test data
+        now := time.Now()
+        review1ID := uuid.New()
+        review2ID := uuid.New()
+
+        // Set up expectations: the due reviews are locked, then published by ID
+        mock.ExpectBegin()
+        mock.ExpectQuery("SELECT id FROM reviews WHERE status = 'pending' (.+) FOR UPDATE").
+                WithArgs(now).
+                WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(review1ID.String()).AddRow(review2ID.String()))
+        mock.ExpectExec("UPDATE reviews SET status = 'published', (.+) WHERE id IN \\(\\?, \\?\\)").
+                WithArgs(now, now, review1ID.String(), review2ID.String()).
+                WillReturnResult(sqlmock.NewResult(0, 2))
+        mock.ExpectCommit()
+
+        // Call the function being tested
+        published, err := repo.PublishDueReviews(context.Background(), now)
+
+        // Assertions
+        assert.NoError(t, err)
+        assert.Equal(t, []uuid.UUID{review1ID, review2ID}, published)
+        assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMySQLRepository_GetCommentRepliesWithheld(t *testing.T) {
+        // Create a new mock database connection
+        db, mock, err := sqlmock.New()
+        if err != nil {
+                t.Fatalf("Failed to create mock database connection: %v", err)
+        }
+        defer db.Close()
+
+        // Create a test logger
+        logger := logrus.New()
+        logger.SetLevel(logrus.ErrorLevel)
+
+        // Create a new repository with the mock database
+        repo := NewMySQLRepository(db, logger)
+
+        // Test data: a hidden reply with a visible reply below it
+        reviewID := uuid.New()
+        threadID := uuid.New()
+        hiddenID := uuid.New()
+        visibleID := uuid.New()
+        now := time.Now()
+
+        // Set up expectations: every reply of the threads is selected along with whether it is withheld
+        replyRows := sqlmock.NewRows([]string{
+                "id", "user_id", "review_id", "parent_id", "thread_id", "depth", "content", "deleted", "deleted_at",
+                "moderation_status", "shadowed", "created_at", "updated_at", "withheld",
+        }).
+                AddRow(hiddenID.String(), uuid.New().String(), reviewID.String(), threadID.String(), threadID.String(), 1,
+                        "Hidden", false, nil, "hidden", false, now, now, 1).
+                AddRow(visibleID.String(), uuid.New().String(), reviewID.String(), hiddenID.String(), threadID.String(), 2,
+                        "Visible", false, nil, "visible", false, now, now, 0)
+
+        mock.ExpectQuery("SELECT c.id, c.user_id, c.review_id, (.+) FROM comments c WHERE c.thread_id IN \\(\\?\\) AND c.parent_id IS NOT NULL ORDER BY").
+                WithArgs(threadID.String()).
+                WillReturnRows(replyRows)
+
+        // Call the function being tested
+        replies, err := repo.GetCommentReplies(context.Background(), []uuid.UUID{threadID}, model.Viewer{})
+
+        // Assertions
+        assert.NoError(t, err)
+        assert.Len(t, replies, 2)
+        assert.True(t, replies[0].Withheld)
+        assert.False(t, replies[1].Withheld)
+        assert.Equal(t, hiddenID, *replies[1].ParentID)
+        assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMySQLRepository_RevokeRoleLastAdmin(t *testing.T) {
+        // Create a new mock database connection
+        db, mock, err := sqlmock.New()
+        if err != nil {
+                t.Fatalf("Failed to create mock database connection: %v", err)
+        }
+        defer db.Close()
+
+        // Create a test logger
+        logger := logrus.New()
+        logger.SetLevel(logrus.ErrorLevel)
+
+        // Create a new repository with the mock database
+        repo := NewMySQLRepository(db, logger)
+
+        // Test data
+        firstAdminID := uuid.New()
+        secondAdminID := uuid.New()
+
+        // Set up expectations: the admin grants are counted under lock before each revocation, and
+        // the last one is kept
+        mock.ExpectBegin()
+        mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM user_roles WHERE role = \\? FOR UPDATE").
+                WithArgs(model.RoleAdmin).
+                WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+        mock.ExpectExec("DELETE FROM user_roles WHERE user_id = \\? AND role = \\?").
+                WithArgs(firstAdminID.String(), model.RoleAdmin).
+                WillReturnResult(sqlmock.NewResult(0, 1))
+        mock.ExpectCommit()
+
+        mock.ExpectBegin()
+        mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM user_roles WHERE role = \\? FOR UPDATE").
+                WithArgs(model.RoleAdmin).
+                WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+        mock.ExpectRollback()
+
+        // Call the function being tested
+        err = repo.RevokeRole(context.Background(), firstAdminID, model.RoleAdmin)
+        assert.NoError(t, err)
+        err = repo.RevokeRole(context.Background(), secondAdminID, model.RoleAdmin)
+
+        // Assertions
+        assert.ErrorIs(t, err, model.ErrLastAdmin)
+        assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMySQLRepository_ApplyModerationDecisionConflict(t *testing.T) {
+        // Create a new mock database connection
+        db, mock, err := sqlmock.New()
+        if err != nil {
+                t.Fatalf("Failed to create mock database connection: %v", err)
+        }
+        defer db.Close()
+
+        // Create a test logger
+        logger := logrus.New()
+        logger.SetLevel(logrus.ErrorLevel)
+
+        // Create a new repository with the mock database
+        repo := NewMySQLRepository(db, logger)
+
+        // Test data: another moderator hid the comment after this decision read it as pending review
+        decision, _ := model.NewModerationDecision(uuid.New(), model.ReportTargetComment, uuid.New(), model.ModerationActionApprove, "Fine", model.ModerationPendingReview)
+
+        // Set up expectations: the locked status no longer matches, so nothing is written
+        mock.ExpectBegin()
+        mock.ExpectQuery("SELECT moderation_status FROM comments WHERE id = \\? FOR UPDATE").
+                WithArgs(decision.TargetID.String()).
+                WillReturnRows(sqlmock.NewRows([]string{"moderation_status"}).AddRow("hidden"))
+        mock.ExpectRollback()
+
+        // Call the function being tested
+        err = repo.ApplyModerationDecision(context.Background(), decision)
+
+        // Assertions
+        assert.ErrorIs(t, err, model.ErrModerationConflict)
+        assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"rating-system/internal/domain/model"
+)
+
+// GetUserRoles retrieves the roles granted to a user, oldest grant first
+func (r *MySQLRepository) GetUserRoles(ctx context.Context, userID uuid.UUID) ([]*model.RoleGrant, error) {
+	query := `
+		SELECT user_id, role, granted_by, created_at
+		FROM user_roles
+		WHERE user_id = ?
+		ORDER BY created_at ASC, role ASC
+	`
+	rows, err := r.db.QueryContext(ctx, query, userID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user roles: %w", err)
+	}
+	defer rows.Close()
+
+	var grants []*model.RoleGrant
+	for rows.Next() {
+		var grant model.RoleGrant
+		var grantUserID string
+		var grantedBy sql.NullString
+		if err := rows.Scan(&grantUserID, &grant.Role, &grantedBy, &grant.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan user role: %w", err)
+		}
+
+		grant.UserID, _ = uuid.Parse(grantUserID)
+		grant.GrantedBy = parseNullUUID(grantedBy)
+		grants = append(grants, &grant)
+	}
+	return grants, rows.Err()
+}
+
+// GrantRole stores a grant of a role to a user
+func (r *MySQLRepository) GrantRole(ctx context.Context, grant *model.RoleGrant) error {
+	query := `
+		INSERT INTO user_roles (user_id, role, granted_by, created_at)
+		VALUES (?, ?, ?, ?)
+	`
+	_, err := r.execWithContext(ctx, query, grant.UserID.String(), grant.Role, nullUUIDArg(grant.GrantedBy), grant.CreatedAt)
+	if err != nil {
+		if strings.Contains(err.Error(), "Duplicate entry") && strings.Contains(err.Error(), "PRIMARY") {
+			return model.ErrRoleAlreadyGranted
+		}
+		return fmt.Errorf("failed to grant role: %w", err)
+	}
+	return nil
+}
+
+// RevokeRole removes a role from a user, refusing to remove the last admin. The admin grants are
+// locked while they are counted, so concurrent revocations cannot both see another admin left.
+func (r *MySQLRepository) RevokeRole(ctx context.Context, userID uuid.UUID, role model.Role) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if role == model.RoleAdmin {
+		var admins int
+		if err := tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM user_roles WHERE role = ? FOR UPDATE`, role).Scan(&admins); err != nil {
+			return fmt.Errorf("failed to count admins: %w", err)
+		}
+		if admins <= 1 {
+			return model.ErrLastAdmin
+		}
+	}
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM user_roles WHERE user_id = ? AND role = ?`, userID.String(), role)
+	if err != nil {
+		return fmt.Errorf("failed to revoke role: %w", err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if deleted == 0 {
+		return model.ErrRoleNotGranted
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// CountUsersWithRole counts the users holding a role
+func (r *MySQLRepository) CountUsersWithRole(ctx context.Context, role model.Role) (int, error) {
+	var count int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM user_roles WHERE role = ?`, role).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count users with role: %w", err)
+	}
+	return count, nil
+}
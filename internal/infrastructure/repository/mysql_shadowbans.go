@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"rating-system/internal/domain/model"
+	"rating-system/pkg/pagination"
+)
+
+// CreateShadowBan stores a shadow-ban of a user
+func (r *MySQLRepository) CreateShadowBan(ctx context.Context, ban *model.ShadowBan) error {
+	query := `
+		INSERT INTO shadow_bans (user_id, banned_by, reason, created_at)
+		VALUES (?, ?, ?, ?)
+	`
+	_, err := r.execWithContext(ctx, query, ban.UserID.String(), nullUUIDArg(ban.BannedBy), ban.Reason, ban.CreatedAt)
+	if err != nil {
+		if strings.Contains(err.Error(), "Duplicate entry") && strings.Contains(err.Error(), "PRIMARY") {
+			return model.ErrAlreadyShadowBanned
+		}
+		return fmt.Errorf("failed to create shadow-ban: %w", err)
+	}
+	return nil
+}
+
+// DeleteShadowBan lifts a user's shadow-ban
+func (r *MySQLRepository) DeleteShadowBan(ctx context.Context, userID uuid.UUID) error {
+	result, err := r.execWithContext(ctx, `DELETE FROM shadow_bans WHERE user_id = ?`, userID.String())
+	if err != nil {
+		return fmt.Errorf("failed to delete shadow-ban: %w", err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if deleted == 0 {
+		return model.ErrNotShadowBanned
+	}
+	return nil
+}
+
+// IsShadowBanned reports whether a user is shadow-banned
+func (r *MySQLRepository) IsShadowBanned(ctx context.Context, userID uuid.UUID) (bool, error) {
+	var banned bool
+	err := r.db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM shadow_bans WHERE user_id = ?)`, userID.String()).Scan(&banned)
+	if err != nil {
+		return false, fmt.Errorf("failed to check shadow-ban: %w", err)
+	}
+	return banned, nil
+}
+
+// GetShadowBans retrieves shadow-bans, newest first
+func (r *MySQLRepository) GetShadowBans(ctx context.Context, params pagination.Params) ([]*model.ShadowBan, int, error) {
+	page := pagination.NewPagination(params.GetPage(), params.GetLimit())
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM shadow_bans`).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count shadow-bans: %w", err)
+	}
+
+	query := `
+		SELECT user_id, banned_by, reason, created_at
+		FROM shadow_bans
+		ORDER BY created_at DESC, user_id DESC
+		LIMIT ? OFFSET ?
+	`
+	rows, err := r.db.QueryContext(ctx, query, page.GetLimit(), page.GetOffset())
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get shadow-bans: %w", err)
+	}
+	defer rows.Close()
+
+	var bans []*model.ShadowBan
+	for rows.Next() {
+		var ban model.ShadowBan
+		var userID string
+		var bannedBy sql.NullString
+		if err := rows.Scan(&userID, &bannedBy, &ban.Reason, &ban.CreatedAt); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan shadow-ban: %w", err)
+		}
+
+		ban.UserID, _ = uuid.Parse(userID)
+		ban.BannedBy = parseNullUUID(bannedBy)
+		bans = append(bans, &ban)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating shadow-ban rows: %w", err)
+	}
+	return bans, total, nil
+}
@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"rating-system/internal/domain/model"
+)
+
+// CreateUserToken stores a single-use user token
+func (r *MySQLRepository) CreateUserToken(ctx context.Context, token *model.UserToken) error {
+	query := `
+		INSERT INTO user_tokens (id, user_id, purpose, token_hash, expires_at, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+	_, err := r.execWithContext(
+		ctx,
+		query,
+		token.ID.String(),
+		token.UserID.String(),
+		string(token.Purpose),
+		token.TokenHash,
+		token.ExpiresAt,
+		token.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create user token: %w", err)
+	}
+	return nil
+}
+
+// GetUserTokenByHash retrieves a user token by its purpose and the hash of its value
+func (r *MySQLRepository) GetUserTokenByHash(ctx context.Context, purpose model.TokenPurpose, tokenHash string) (*model.UserToken, error) {
+	query := `
+		SELECT id, user_id, purpose, token_hash, expires_at, used_at, created_at
+		FROM user_tokens
+		WHERE purpose = ? AND token_hash = ?
+	`
+	var token model.UserToken
+	var id, userID string
+	err := r.db.QueryRowContext(ctx, query, string(purpose), tokenHash).Scan(
+		&id,
+		&userID,
+		&token.Purpose,
+		&token.TokenHash,
+		&token.ExpiresAt,
+		&token.UsedAt,
+		&token.CreatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, model.ErrUserTokenNotFound
+		}
+		return nil, fmt.Errorf("failed to get user token: %w", err)
+	}
+
+	if token.ID, err = uuid.Parse(id); err != nil {
+		return nil, fmt.Errorf("failed to parse user token ID: %w", err)
+	}
+	if token.UserID, err = uuid.Parse(userID); err != nil {
+		return nil, fmt.Errorf("failed to parse user ID: %w", err)
+	}
+	return &token, nil
+}
+
+// CountUserTokensSince counts the tokens issued to a user for a purpose since a time
+func (r *MySQLRepository) CountUserTokensSince(ctx context.Context, userID uuid.UUID, purpose model.TokenPurpose, since time.Time) (int, error) {
+	query := `
+		SELECT COUNT(*) FROM user_tokens
+		WHERE user_id = ? AND purpose = ? AND created_at >= ?
+	`
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, userID.String(), string(purpose), since).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count user tokens: %w", err)
+	}
+	return count, nil
+}
+
+// VerifyEmail marks a verification token as used and the user's email as verified in one
+// transaction
+func (r *MySQLRepository) VerifyEmail(ctx context.Context, tokenID, userID uuid.UUID, at time.Time) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE user_tokens SET used_at = ?
+		WHERE id = ? AND used_at IS NULL
+	`, at, tokenID.String())
+	if err != nil {
+		return fmt.Errorf("failed to use user token: %w", err)
+	}
+	used, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if used == 0 {
+		return model.ErrUserTokenNotFound
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE users SET email_verified_at = COALESCE(email_verified_at, ?), updated_at = ?
+		WHERE id = ?
+	`, at, at, userID.String()); err != nil {
+		return fmt.Errorf("failed to verify email: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// ResetPassword marks a reset token as used, voids the user's other reset tokens and sets their
+// password in one transaction. Receiving the reset email proves the user owns the address, so it
+// is marked verified too, and any lockout ends.
+func (r *MySQLRepository) ResetPassword(ctx context.Context, tokenID, userID uuid.UUID, passwordHash string, at time.Time) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE user_tokens SET used_at = ?
+		WHERE id = ? AND used_at IS NULL
+	`, at, tokenID.String())
+	if err != nil {
+		return fmt.Errorf("failed to use user token: %w", err)
+	}
+	used, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if used == 0 {
+		return model.ErrUserTokenNotFound
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE user_tokens SET used_at = ?
+		WHERE user_id = ? AND purpose = ? AND used_at IS NULL
+	`, at, userID.String(), string(model.TokenPurposePasswordReset)); err != nil {
+		return fmt.Errorf("failed to void reset tokens: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE users
+		SET password_hash = ?, email_verified_at = COALESCE(email_verified_at, ?), locked_until = NULL, updated_at = ?
+		WHERE id = ?
+	`, passwordHash, at, at, userID.String()); err != nil {
+		return fmt.Errorf("failed to reset password: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+
+	"rating-system/internal/domain/model"
+	"rating-system/pkg/pagination"
+)
+
+// apiKeyColumns are the columns scanned by scanPostgresAPIKey
+const apiKeyColumns = `id, user_id, name, hint, key_hash, scopes, service_id, created_by, expires_at, last_used_at, revoked_at, created_at`
+
+// CreateAPIKey stores an API key
+func (r *PostgresRepository) CreateAPIKey(ctx context.Context, key *model.APIKey) error {
+	query := `
+		INSERT INTO api_keys (id, user_id, name, hint, key_hash, scopes, service_id, created_by, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+	_, err := r.execWithContext(
+		ctx,
+		query,
+		key.ID,
+		key.UserID,
+		key.Name,
+		key.Hint,
+		key.KeyHash,
+		model.FormatScopes(key.Scopes),
+		key.ServiceID,
+		key.CreatedBy,
+		key.ExpiresAt,
+		key.CreatedAt,
+	)
+	return err
+}
+
+// GetAPIKeyByHash retrieves an API key by the hash of its value
+func (r *PostgresRepository) GetAPIKeyByHash(ctx context.Context, keyHash string) (*model.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE key_hash = $1`
+	key, err := scanPostgresAPIKey(r.queryRowWithContext(ctx, query, keyHash))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, model.ErrInvalidAPIKey
+		}
+		return nil, err
+	}
+	return key, nil
+}
+
+// GetAPIKeys retrieves API keys, of one user if a user is given, most recent first
+func (r *PostgresRepository) GetAPIKeys(ctx context.Context, userID *uuid.UUID, params pagination.Params) ([]*model.APIKey, int, error) {
+	var filterID uuid.UUID
+	if userID != nil {
+		filterID = *userID
+	}
+
+	var total int
+	countQuery := `SELECT COUNT(*) FROM api_keys WHERE ($1 = FALSE OR user_id = $2)`
+	if err := r.queryRowWithContext(ctx, countQuery, userID != nil, filterID).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+		SELECT ` + apiKeyColumns + `
+		FROM api_keys
+		WHERE ($1 = FALSE OR user_id = $2)
+		ORDER BY created_at DESC, id DESC
+		LIMIT $3 OFFSET $4
+	`
+	rows, err := r.queryWithContext(ctx, query, userID != nil, filterID, params.GetLimit(), params.GetOffset())
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var keys []*model.APIKey
+	for rows.Next() {
+		key, err := scanPostgresAPIKey(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	return keys, total, nil
+}
+
+// RevokeAPIKey revokes an API key that is not revoked yet
+func (r *PostgresRepository) RevokeAPIKey(ctx context.Context, id uuid.UUID, at time.Time) error {
+	result, err := r.execWithContext(ctx, `UPDATE api_keys SET revoked_at = $1 WHERE id = $2 AND revoked_at IS NULL`, at, id)
+	if err != nil {
+		return err
+	}
+
+	revoked, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if revoked == 0 {
+		return model.ErrAPIKeyNotFound
+	}
+	return nil
+}
+
+// TouchAPIKey records when an API key was last used
+func (r *PostgresRepository) TouchAPIKey(ctx context.Context, id uuid.UUID, at time.Time) error {
+	_, err := r.execWithContext(ctx, `UPDATE api_keys SET last_used_at = $1 WHERE id = $2`, at, id)
+	return err
+}
+
+// scanPostgresAPIKey scans a row of apiKeyColumns
+func scanPostgresAPIKey(row interface{ Scan(...interface{}) error }) (*model.APIKey, error) {
+	var key model.APIKey
+	var scopes string
+	err := row.Scan(
+		&key.ID,
+		&key.UserID,
+		&key.Name,
+		&key.Hint,
+		&key.KeyHash,
+		&scopes,
+		&key.ServiceID,
+		&key.CreatedBy,
+		&key.ExpiresAt,
+		&key.LastUsedAt,
+		&key.RevokedAt,
+		&key.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	key.Scopes = model.ParseScopes(scopes)
+	return &key, nil
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"rating-system/internal/domain/model"
+)
+
+// CreateAuditEvent records a security relevant change to an account
+func (r *PostgresRepository) CreateAuditEvent(ctx context.Context, event *model.AuditEvent) error {
+	query := `
+		INSERT INTO audit_events (id, user_id, actor_id, action, ip_address, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	_, err := r.execWithContext(ctx, query, event.ID, event.UserID, event.ActorID, event.Action, event.IPAddress, event.CreatedAt)
+	return err
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"rating-system/internal/domain/model"
+	"rating-system/pkg/pagination"
+)
+
+// CreateBlock stores a block of a user by another
+func (r *PostgresRepository) CreateBlock(ctx context.Context, block *model.Block) error {
+	query := `
+		INSERT INTO user_blocks (blocker_id, blocked_id, created_at)
+		VALUES ($1, $2, $3)
+	`
+	_, err := r.execWithContext(ctx, query, block.BlockerID, block.UserID, block.CreatedAt)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" { // unique_violation
+			return model.ErrAlreadyBlocked
+		}
+		return err
+	}
+	return nil
+}
+
+// DeleteBlock lifts a block
+func (r *PostgresRepository) DeleteBlock(ctx context.Context, blockerID, userID uuid.UUID) error {
+	result, err := r.execWithContext(ctx, `DELETE FROM user_blocks WHERE blocker_id = $1 AND blocked_id = $2`, blockerID, userID)
+	if err != nil {
+		return err
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return model.ErrNotBlocked
+	}
+	return nil
+}
+
+// IsBlocked reports whether the blocker has blocked the user
+func (r *PostgresRepository) IsBlocked(ctx context.Context, blockerID, userID uuid.UUID) (bool, error) {
+	var blocked bool
+	query := `SELECT EXISTS (SELECT 1 FROM user_blocks WHERE blocker_id = $1 AND blocked_id = $2)`
+	err := r.queryRowWithContext(ctx, query, blockerID, userID).Scan(&blocked)
+	return blocked, err
+}
+
+// GetBlocks retrieves the users a user has blocked, most recent first
+func (r *PostgresRepository) GetBlocks(ctx context.Context, blockerID uuid.UUID, params pagination.Params) ([]*model.Block, int, error) {
+	var total int
+	if err := r.queryRowWithContext(ctx, `SELECT COUNT(*) FROM user_blocks WHERE blocker_id = $1`, blockerID).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+		SELECT b.blocker_id, b.blocked_id, u.username, b.created_at
+		FROM user_blocks b
+		JOIN users u ON u.id = b.blocked_id
+		WHERE b.blocker_id = $1
+		ORDER BY b.created_at DESC, b.blocked_id DESC
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := r.queryWithContext(ctx, query, blockerID, params.GetLimit(), params.GetOffset())
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var blocks []*model.Block
+	for rows.Next() {
+		var block model.Block
+		if err := rows.Scan(&block.BlockerID, &block.UserID, &block.Username, &block.CreatedAt); err != nil {
+			return nil, 0, err
+		}
+		blocks = append(blocks, &block)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	return blocks, total, nil
+}
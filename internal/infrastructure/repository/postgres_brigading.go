@@ -0,0 +1,214 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+
+	"rating-system/internal/domain/model"
+	"rating-system/pkg/pagination"
+)
+
+// RecordRatingClient stores the address and device a rating was last submitted from
+func (r *PostgresRepository) RecordRatingClient(ctx context.Context, ratingID uuid.UUID, client model.ClientInfo, at time.Time) error {
+	query := `
+		INSERT INTO rating_clients (rating_id, ip, device_id, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (rating_id) DO UPDATE SET ip = EXCLUDED.ip, device_id = EXCLUDED.device_id, created_at = EXCLUDED.created_at
+	`
+	_, err := r.execWithContext(ctx, query, ratingID, client.IP, client.DeviceID, at)
+	return err
+}
+
+// CountRatingsSince counts the ratings of a service created since the given time, quarantined or not
+func (r *PostgresRepository) CountRatingsSince(ctx context.Context, serviceID uuid.UUID, since time.Time) (int, error) {
+	var count int
+	err := r.queryRowWithContext(ctx, `SELECT COUNT(*) FROM ratings WHERE service_id = $1 AND created_at >= $2`, serviceID, since).Scan(&count)
+	return count, err
+}
+
+// CountClusteredRaters counts the other users who rated a service since the given time from the
+// client's address and, separately, from its device. Empty client fields match nobody.
+func (r *PostgresRepository) CountClusteredRaters(ctx context.Context, serviceID, userID uuid.UUID, client model.ClientInfo, since time.Time) (int, int, error) {
+	query := `
+		SELECT COUNT(DISTINCT CASE WHEN c.ip = $4 THEN rt.user_id END),
+		       COUNT(DISTINCT CASE WHEN c.device_id = $5 THEN rt.user_id END)
+		FROM rating_clients c
+		JOIN ratings rt ON rt.id = c.rating_id
+		WHERE rt.service_id = $1 AND rt.user_id <> $2 AND c.created_at >= $3
+		  AND ((c.ip = $4 AND c.ip <> '') OR (c.device_id = $5 AND c.device_id <> ''))
+	`
+	var byIP, byDevice int
+	err := r.queryRowWithContext(ctx, query, serviceID, userID, since, client.IP, client.DeviceID).Scan(&byIP, &byDevice)
+	if err != nil {
+		return 0, 0, err
+	}
+	return byIP, byDevice, nil
+}
+
+// CreateRatingFlag stores a flag and, if it quarantines its rating, removes the rating from
+// public aggregates in one transaction
+func (r *PostgresRepository) CreateRatingFlag(ctx context.Context, flag *model.RatingFlag) error {
+	signals, err := json.Marshal(flag.Signals)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO rating_flags (` + ratingFlagColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+	`
+	_, err = tx.ExecContext(
+		ctx,
+		query,
+		flag.ID,
+		flag.RatingID,
+		flag.UserID,
+		flag.ServiceID,
+		flag.RatingScore,
+		flag.Score,
+		string(signals),
+		flag.Client.IP,
+		flag.Client.DeviceID,
+		flag.Quarantined,
+		flag.Status,
+		flag.ResolvedBy,
+		flag.ResolvedAt,
+		flag.CreatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	if flag.Quarantined {
+		if _, err := tx.ExecContext(ctx, `UPDATE ratings SET quarantined = TRUE WHERE id = $1`, flag.RatingID); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// GetRatingFlagByID retrieves a rating flag by ID
+func (r *PostgresRepository) GetRatingFlagByID(ctx context.Context, id uuid.UUID) (*model.RatingFlag, error) {
+	row := r.queryRowWithContext(ctx, `SELECT `+ratingFlagColumns+` FROM rating_flags WHERE id = $1`, id)
+	flag, err := scanPostgresRatingFlag(row)
+	if err == sql.ErrNoRows {
+		return nil, model.ErrRatingFlagNotFound
+	}
+	return flag, err
+}
+
+// GetRatingFlags retrieves rating flags, newest first, optionally narrowed to a status and service
+func (r *PostgresRepository) GetRatingFlags(ctx context.Context, filter model.RatingFlagFilter, params pagination.Params) ([]*model.RatingFlag, int, error) {
+	var serviceID uuid.UUID
+	if filter.ServiceID != nil {
+		serviceID = *filter.ServiceID
+	}
+	where := `WHERE ($1 = '' OR status = $1) AND ($2 = FALSE OR service_id = $3)`
+	args := []interface{}{string(filter.Status), filter.ServiceID != nil, serviceID}
+
+	var total int
+	if err := r.queryRowWithContext(ctx, `SELECT COUNT(*) FROM rating_flags `+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `SELECT ` + ratingFlagColumns + ` FROM rating_flags ` + where + ` ORDER BY created_at DESC, id DESC LIMIT $4 OFFSET $5`
+	rows, err := r.queryWithContext(ctx, query, append(args, params.GetLimit(), params.GetOffset())...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var flags []*model.RatingFlag
+	for rows.Next() {
+		flag, err := scanPostgresRatingFlag(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		flags = append(flags, flag)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	return flags, total, nil
+}
+
+// ResolveRatingFlag records a moderator's judgement of an open flag and updates the quarantine of
+// its rating in one transaction. The rating stays quarantined while any other flag holds it.
+func (r *PostgresRepository) ResolveRatingFlag(ctx context.Context, flag *model.RatingFlag) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(
+		ctx,
+		`UPDATE rating_flags SET status = $1, quarantined = $2, resolved_by = $3, resolved_at = $4 WHERE id = $5 AND status = $6`,
+		flag.Status,
+		flag.Quarantined,
+		flag.ResolvedBy,
+		flag.ResolvedAt,
+		flag.ID,
+		model.RatingFlagOpen,
+	)
+	if err != nil {
+		return err
+	}
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if updated == 0 {
+		return model.ErrRatingFlagResolved
+	}
+
+	query := `
+		UPDATE ratings
+		SET quarantined = EXISTS (
+			SELECT 1 FROM rating_flags WHERE rating_id = $1 AND quarantined = TRUE AND status <> $2
+		)
+		WHERE id = $1
+	`
+	if _, err := tx.ExecContext(ctx, query, flag.RatingID, model.RatingFlagCleared); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// scanPostgresRatingFlag scans a row of ratingFlagColumns
+func scanPostgresRatingFlag(row rowScanner) (*model.RatingFlag, error) {
+	var flag model.RatingFlag
+	var signals string
+	if err := row.Scan(
+		&flag.ID,
+		&flag.RatingID,
+		&flag.UserID,
+		&flag.ServiceID,
+		&flag.RatingScore,
+		&flag.Score,
+		&signals,
+		&flag.Client.IP,
+		&flag.Client.DeviceID,
+		&flag.Quarantined,
+		&flag.Status,
+		&flag.ResolvedBy,
+		&flag.ResolvedAt,
+		&flag.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(signals), &flag.Signals); err != nil {
+		return nil, err
+	}
+	return &flag, nil
+}
@@ -0,0 +1,199 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"rating-system/internal/domain/model"
+	"rating-system/pkg/pagination"
+)
+
+// SaveReviewSignature stores a review's signature and band hashes in one transaction, replacing those
+// of an earlier version of the review. If the review joins a duplicate cluster, the review that starts
+// the cluster is marked as its first member.
+func (r *PostgresRepository) SaveReviewSignature(ctx context.Context, signature *model.ReviewSignature) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// The bands are removed along with the signature
+	query := `DELETE FROM review_signatures WHERE review_id = $1`
+	if _, err := tx.ExecContext(ctx, query, signature.ReviewID); err != nil {
+		return err
+	}
+
+	query = `
+		INSERT INTO review_signatures (review_id, user_id, service_id, minhash, cluster_id, similarity, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+	_, err = tx.ExecContext(
+		ctx,
+		query,
+		signature.ReviewID,
+		signature.UserID,
+		signature.ServiceID,
+		signature.MinHash.Encode(),
+		signature.ClusterID,
+		signature.Similarity,
+		signature.CreatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	query = `
+		INSERT INTO review_signature_bands (review_id, band, band_hash)
+		SELECT $1, b.ord - 1, b.hash FROM unnest($2::BIGINT[]) WITH ORDINALITY AS b(hash, ord)
+	`
+	if _, err := tx.ExecContext(ctx, query, signature.ReviewID, pq.Array(signature.Bands)); err != nil {
+		return err
+	}
+
+	if signature.ClusterID != nil {
+		query = `UPDATE review_signatures SET cluster_id = $1 WHERE review_id = $1 AND cluster_id IS NULL`
+		if _, err := tx.ExecContext(ctx, query, *signature.ClusterID); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// FindReviewSignatures retrieves signatures sharing at least one band hash, those sharing the most
+// bands first, leaving out the given user's and, if a service is given, other services' reviews
+func (r *PostgresRepository) FindReviewSignatures(ctx context.Context, bands []int64, excludeUserID uuid.UUID, serviceID *uuid.UUID, limit int) ([]*model.ReviewSignature, error) {
+	if len(bands) == 0 {
+		return nil, nil
+	}
+
+	var scopeID uuid.UUID
+	if serviceID != nil {
+		scopeID = *serviceID
+	}
+	query := `
+		SELECT s.review_id, s.user_id, s.service_id, s.minhash, s.cluster_id, s.similarity, s.created_at
+		FROM review_signatures s
+		JOIN (
+			SELECT review_id, COUNT(*) AS shared
+			FROM review_signature_bands
+			WHERE band_hash = ANY($1)
+			GROUP BY review_id
+		) b ON b.review_id = s.review_id
+		WHERE s.user_id <> $2 AND ($3 = FALSE OR s.service_id = $4)
+		ORDER BY b.shared DESC, s.created_at
+		LIMIT $5
+	`
+	rows, err := r.queryWithContext(ctx, query, pq.Array(bands), excludeUserID, serviceID != nil, scopeID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var signatures []*model.ReviewSignature
+	for rows.Next() {
+		var signature model.ReviewSignature
+		var minHash string
+		if err := rows.Scan(
+			&signature.ReviewID,
+			&signature.UserID,
+			&signature.ServiceID,
+			&minHash,
+			&signature.ClusterID,
+			&signature.Similarity,
+			&signature.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		if signature.MinHash, err = model.DecodeMinHash(minHash); err != nil {
+			return nil, err
+		}
+		signatures = append(signatures, &signature)
+	}
+	return signatures, rows.Err()
+}
+
+// GetDuplicateClusters retrieves clusters of near-duplicate reviews with their members, the most
+// recently extended clusters first
+func (r *PostgresRepository) GetDuplicateClusters(ctx context.Context, params pagination.Params) ([]*model.DuplicateCluster, int, error) {
+	var total int
+	if err := r.queryRowWithContext(ctx, `SELECT COUNT(DISTINCT cluster_id) FROM review_signatures`).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+		SELECT cluster_id, COUNT(*), COUNT(DISTINCT user_id), COUNT(DISTINCT service_id), MIN(created_at), MAX(created_at)
+		FROM review_signatures
+		WHERE cluster_id IS NOT NULL
+		GROUP BY cluster_id
+		ORDER BY MAX(created_at) DESC, cluster_id DESC
+		LIMIT $1 OFFSET $2
+	`
+	rows, err := r.queryWithContext(ctx, query, params.GetLimit(), params.GetOffset())
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var clusters []*model.DuplicateCluster
+	byID := make(map[uuid.UUID]*model.DuplicateCluster)
+	var ids []string
+	for rows.Next() {
+		var cluster model.DuplicateCluster
+		if err := rows.Scan(
+			&cluster.ID,
+			&cluster.Reviews,
+			&cluster.Accounts,
+			&cluster.Services,
+			&cluster.FirstSeenAt,
+			&cluster.LastSeenAt,
+		); err != nil {
+			return nil, 0, err
+		}
+		clusters = append(clusters, &cluster)
+		byID[cluster.ID] = &cluster
+		ids = append(ids, cluster.ID.String())
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	if len(clusters) == 0 {
+		return clusters, total, nil
+	}
+
+	query = `
+		SELECT cluster_id, review_id, user_id, service_id, similarity, created_at
+		FROM review_signatures
+		WHERE cluster_id = ANY($1)
+		ORDER BY created_at, review_id
+	`
+	members, err := r.queryWithContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return nil, 0, err
+	}
+	defer members.Close()
+
+	for members.Next() {
+		var clusterID uuid.UUID
+		var member model.DuplicateClusterMember
+		if err := members.Scan(
+			&clusterID,
+			&member.ReviewID,
+			&member.UserID,
+			&member.ServiceID,
+			&member.Similarity,
+			&member.CreatedAt,
+		); err != nil {
+			return nil, 0, err
+		}
+		if cluster, ok := byID[clusterID]; ok {
+			cluster.Members = append(cluster.Members, member)
+		}
+	}
+	if err := members.Err(); err != nil {
+		return nil, 0, err
+	}
+	return clusters, total, nil
+}
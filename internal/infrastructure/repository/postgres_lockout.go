@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LockUser locks a user out of logging in until the given time
+func (r *PostgresRepository) LockUser(ctx context.Context, userID uuid.UUID, until time.Time) error {
+	query := `UPDATE users SET locked_until = $1 WHERE id = $2`
+	_, err := r.execWithContext(ctx, query, until, userID)
+	return err
+}
+
+// UnlockUser ends a user's lockout
+func (r *PostgresRepository) UnlockUser(ctx context.Context, userID uuid.UUID) error {
+	query := `UPDATE users SET locked_until = NULL WHERE id = $1`
+	_, err := r.execWithContext(ctx, query, userID)
+	return err
+}
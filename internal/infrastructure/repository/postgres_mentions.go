@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"rating-system/internal/domain/model"
+)
+
+// ReplaceMentions replaces the stored mentions of a review or comment
+func (r *PostgresRepository) ReplaceMentions(ctx context.Context, targetType model.MentionTarget, targetID uuid.UUID, mentions []*model.Mention) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM mentions WHERE target_type = $1 AND target_id = $2`, targetType, targetID)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO mentions (id, target_type, target_id, user_id, start_offset, end_offset, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+	for _, mention := range mentions {
+		_, err = tx.ExecContext(
+			ctx,
+			query,
+			mention.ID,
+			mention.TargetType,
+			mention.TargetID,
+			mention.UserID,
+			mention.Start,
+			mention.End,
+			mention.CreatedAt,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetMentions retrieves the mentions of the given reviews or comments, in content order
+func (r *PostgresRepository) GetMentions(ctx context.Context, targetType model.MentionTarget, targetIDs []uuid.UUID) ([]*model.Mention, error) {
+	if len(targetIDs) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]string, len(targetIDs))
+	for i, id := range targetIDs {
+		ids[i] = id.String()
+	}
+
+	query := `
+		SELECT m.id, m.target_type, m.target_id, m.user_id, u.username, m.start_offset, m.end_offset, m.created_at
+		FROM mentions m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.target_type = $1 AND m.target_id = ANY($2)
+		ORDER BY m.target_id, m.start_offset
+	`
+	rows, err := r.queryWithContext(ctx, query, targetType, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var mentions []*model.Mention
+	for rows.Next() {
+		var mention model.Mention
+		if err := rows.Scan(
+			&mention.ID,
+			&mention.TargetType,
+			&mention.TargetID,
+			&mention.UserID,
+			&mention.Username,
+			&mention.Start,
+			&mention.End,
+			&mention.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		mentions = append(mentions, &mention)
+	}
+	return mentions, rows.Err()
+}
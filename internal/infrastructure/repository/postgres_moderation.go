@@ -0,0 +1,200 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"rating-system/internal/domain/model"
+	"rating-system/pkg/pagination"
+)
+
+// CreateReport stores a user's report of a review or comment
+func (r *PostgresRepository) CreateReport(ctx context.Context, report *model.Report) error {
+	query := `
+		INSERT INTO reports (id, reporter_id, target_type, target_id, reason, details, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+	_, err := r.execWithContext(
+		ctx,
+		query,
+		report.ID,
+		report.ReporterID,
+		report.TargetType,
+		report.TargetID,
+		report.Reason,
+		report.Details,
+		report.CreatedAt,
+	)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" { // unique_violation
+			return model.ErrDuplicateReport
+		}
+		return err
+	}
+	return nil
+}
+
+// FlagForModeration moves visible content to pending review; content in any other state is left alone
+func (r *PostgresRepository) FlagForModeration(ctx context.Context, targetType model.ReportTarget, targetID uuid.UUID) error {
+	table, err := moderationTable(targetType)
+	if err != nil {
+		return err
+	}
+
+	query := `UPDATE ` + table + ` SET moderation_status = $1 WHERE id = $2 AND moderation_status = $3`
+	_, err = r.execWithContext(ctx, query, model.ModerationPendingReview, targetID, model.ModerationVisible)
+	return err
+}
+
+// ApplyModerationDecision sets the moderation status of the content, records the decision and
+// resolves the open reports about the content in one transaction. The status is only changed if it
+// is still the one the decision was based on; otherwise ErrModerationConflict is returned.
+func (r *PostgresRepository) ApplyModerationDecision(ctx context.Context, decision *model.ModerationDecision) error {
+	table, err := moderationTable(decision.TargetType)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(
+		ctx,
+		`UPDATE `+table+` SET moderation_status = $1 WHERE id = $2 AND moderation_status = $3`,
+		decision.Status,
+		decision.TargetID,
+		decision.PreviousStatus,
+	)
+	if err != nil {
+		return err
+	}
+
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if updated == 0 {
+		return model.ErrModerationConflict
+	}
+
+	query := `
+		INSERT INTO moderation_decisions (id, moderator_id, target_type, target_id, action, reason, previous_status, status, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+	_, err = tx.ExecContext(
+		ctx,
+		query,
+		decision.ID,
+		decision.ModeratorID,
+		decision.TargetType,
+		decision.TargetID,
+		decision.Action,
+		decision.Reason,
+		decision.PreviousStatus,
+		decision.Status,
+		decision.CreatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		`UPDATE reports SET resolved_at = $1 WHERE target_type = $2 AND target_id = $3 AND resolved_at IS NULL`,
+		decision.CreatedAt,
+		decision.TargetType,
+		decision.TargetID,
+	)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// GetModerationQueue retrieves the content with open reports, most reported first
+func (r *PostgresRepository) GetModerationQueue(ctx context.Context, params pagination.Params) ([]*model.ModerationQueueItem, int, error) {
+	countQuery := `SELECT COUNT(DISTINCT (target_type, target_id)) FROM reports WHERE resolved_at IS NULL`
+	var total int
+	if err := r.queryRowWithContext(ctx, countQuery).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+		SELECT target_type, target_id, COUNT(*), ARRAY_AGG(DISTINCT reason ORDER BY reason), MIN(created_at), MAX(created_at)
+		FROM reports
+		WHERE resolved_at IS NULL
+		GROUP BY target_type, target_id
+		ORDER BY COUNT(*) DESC, MIN(created_at) ASC
+		LIMIT $1 OFFSET $2
+	`
+	rows, err := r.queryWithContext(ctx, query, params.GetLimit(), params.GetOffset())
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var items []*model.ModerationQueueItem
+	for rows.Next() {
+		var item model.ModerationQueueItem
+		var reasons []string
+		if err := rows.Scan(
+			&item.TargetType,
+			&item.TargetID,
+			&item.ReportCount,
+			pq.Array(&reasons),
+			&item.FirstReportedAt,
+			&item.LastReportedAt,
+		); err != nil {
+			return nil, 0, err
+		}
+		for _, reason := range reasons {
+			item.Reasons = append(item.Reasons, model.ReportReason(reason))
+		}
+		items = append(items, &item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	return items, total, nil
+}
+
+// GetModerationDecisions retrieves the decisions taken on a review or comment, oldest first
+func (r *PostgresRepository) GetModerationDecisions(ctx context.Context, targetType model.ReportTarget, targetID uuid.UUID) ([]*model.ModerationDecision, error) {
+	query := `
+		SELECT id, moderator_id, target_type, target_id, action, reason, previous_status, status, created_at
+		FROM moderation_decisions
+		WHERE target_type = $1 AND target_id = $2
+		ORDER BY created_at ASC, id ASC
+	`
+	rows, err := r.queryWithContext(ctx, query, targetType, targetID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var decisions []*model.ModerationDecision
+	for rows.Next() {
+		var decision model.ModerationDecision
+		if err := rows.Scan(
+			&decision.ID,
+			&decision.ModeratorID,
+			&decision.TargetType,
+			&decision.TargetID,
+			&decision.Action,
+			&decision.Reason,
+			&decision.PreviousStatus,
+			&decision.Status,
+			&decision.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		decisions = append(decisions, &decision)
+	}
+	return decisions, rows.Err()
+}
@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"rating-system/internal/domain/model"
+	"rating-system/pkg/pagination"
+)
+
+// notificationColumns lists the columns selected for a notification
+const notificationColumns = `id, user_id, type, actor_id, review_id, comment_id, detail, read_at, created_at`
+
+// CreateNotification stores a notification
+func (r *PostgresRepository) CreateNotification(ctx context.Context, notification *model.Notification) error {
+	query := `
+		INSERT INTO notifications (id, user_id, type, actor_id, review_id, comment_id, detail, read_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+	_, err := r.execWithContext(
+		ctx,
+		query,
+		notification.ID,
+		notification.UserID,
+		notification.Type,
+		notification.ActorID,
+		notification.ReviewID,
+		notification.CommentID,
+		notification.Detail,
+		notification.ReadAt,
+		notification.CreatedAt,
+	)
+	return err
+}
+
+// GetNotifications retrieves a user's notifications, newest first, starting after the cursor
+func (r *PostgresRepository) GetNotifications(ctx context.Context, userID uuid.UUID, filter model.NotificationFilter, cursor *pagination.Cursor, limit int) ([]*model.Notification, error) {
+	query := `
+		SELECT ` + notificationColumns + `
+		FROM notifications
+		WHERE user_id = $1
+		  AND ($2 = FALSE OR read_at IS NULL)
+		  AND ($3 = FALSE OR created_at < $4 OR (created_at = $4 AND id < $5))
+		ORDER BY created_at DESC, id DESC
+		LIMIT $6
+	`
+
+	var after pagination.Cursor
+	if cursor != nil {
+		after = *cursor
+	}
+
+	rows, err := r.queryWithContext(ctx, query, userID, filter.UnreadOnly, cursor != nil, after.CreatedAt, after.ID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var notifications []*model.Notification
+	for rows.Next() {
+		var notification model.Notification
+		if err := rows.Scan(
+			&notification.ID,
+			&notification.UserID,
+			&notification.Type,
+			&notification.ActorID,
+			&notification.ReviewID,
+			&notification.CommentID,
+			&notification.Detail,
+			&notification.ReadAt,
+			&notification.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		notifications = append(notifications, &notification)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return notifications, nil
+}
+
+// CountUnreadNotifications counts a user's unread notifications
+func (r *PostgresRepository) CountUnreadNotifications(ctx context.Context, userID uuid.UUID) (int, error) {
+	var count int
+	err := r.queryRowWithContext(ctx, `SELECT COUNT(*) FROM notifications WHERE user_id = $1 AND read_at IS NULL`, userID).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// MarkNotificationRead marks one of a user's notifications as read
+func (r *PostgresRepository) MarkNotificationRead(ctx context.Context, userID, id uuid.UUID, at time.Time) error {
+	result, err := r.execWithContext(ctx, `UPDATE notifications SET read_at = COALESCE(read_at, $1) WHERE id = $2 AND user_id = $3`, at, id, userID)
+	if err != nil {
+		return err
+	}
+
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if updated == 0 {
+		return errors.New("notification not found")
+	}
+	return nil
+}
+
+// MarkAllNotificationsRead marks all of a user's unread notifications as read
+func (r *PostgresRepository) MarkAllNotificationsRead(ctx context.Context, userID uuid.UUID, at time.Time) (int, error) {
+	result, err := r.execWithContext(ctx, `UPDATE notifications SET read_at = $1 WHERE user_id = $2 AND read_at IS NULL`, at, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(updated), nil
+}
+
+// GetNotificationPreferences retrieves a user's explicit notification preferences
+func (r *PostgresRepository) GetNotificationPreferences(ctx context.Context, userID uuid.UUID) (*model.NotificationPreferences, error) {
+	rows, err := r.queryWithContext(ctx, `SELECT type, enabled FROM notification_preferences WHERE user_id = $1`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanNotificationPreferences(userID, rows)
+}
+
+// SetNotificationPreference enables or disables a notification type for a user
+func (r *PostgresRepository) SetNotificationPreference(ctx context.Context, userID uuid.UUID, notificationType model.NotificationType, enabled bool) error {
+	query := `
+		INSERT INTO notification_preferences (user_id, type, enabled)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, type) DO UPDATE SET enabled = EXCLUDED.enabled
+	`
+	_, err := r.execWithContext(ctx, query, userID, notificationType, enabled)
+	return err
+}
+
+// scanNotificationPreferences collects (type, enabled) rows into preferences
+func scanNotificationPreferences(userID uuid.UUID, rows *sql.Rows) (*model.NotificationPreferences, error) {
+	preferences := &model.NotificationPreferences{
+		UserID:  userID,
+		Enabled: make(map[model.NotificationType]bool),
+	}
+	for rows.Next() {
+		var notificationType model.NotificationType
+		var enabled bool
+		if err := rows.Scan(&notificationType, &enabled); err != nil {
+			return nil, err
+		}
+		preferences.Enabled[notificationType] = enabled
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return preferences, nil
+}
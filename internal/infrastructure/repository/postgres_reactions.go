@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"rating-system/internal/domain/model"
+)
+
+// ToggleReaction removes the user's reaction if it exists and adds it otherwise, reporting
+// whether it was added
+func (r *PostgresRepository) ToggleReaction(ctx context.Context, reaction *model.Reaction) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(
+		ctx,
+		`DELETE FROM reactions WHERE target_type = $1 AND target_id = $2 AND user_id = $3 AND emoji = $4`,
+		reaction.TargetType,
+		reaction.TargetID,
+		reaction.UserID,
+		reaction.Emoji,
+	)
+	if err != nil {
+		return false, err
+	}
+	removed, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	if removed == 0 {
+		query := `
+			INSERT INTO reactions (id, target_type, target_id, user_id, emoji, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			ON CONFLICT (target_type, target_id, user_id, emoji) DO NOTHING
+		`
+		_, err = tx.ExecContext(
+			ctx,
+			query,
+			reaction.ID,
+			reaction.TargetType,
+			reaction.TargetID,
+			reaction.UserID,
+			reaction.Emoji,
+			reaction.CreatedAt,
+		)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, err
+	}
+	return removed == 0, nil
+}
+
+// GetReactionCounts counts the reactions on the given reviews or comments per emoji, flagging
+// the ones left by the viewer
+func (r *PostgresRepository) GetReactionCounts(ctx context.Context, targetType model.ReactionTarget, targetIDs []uuid.UUID, viewerID uuid.UUID) ([]*model.ReactionCount, error) {
+	if len(targetIDs) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]string, len(targetIDs))
+	for i, id := range targetIDs {
+		ids[i] = id.String()
+	}
+
+	query := `
+		SELECT target_id, emoji, COUNT(*), BOOL_OR(user_id = $3)
+		FROM reactions
+		WHERE target_type = $1 AND target_id = ANY($2)
+		GROUP BY target_id, emoji
+		ORDER BY target_id, COUNT(*) DESC, emoji
+	`
+	rows, err := r.queryWithContext(ctx, query, targetType, pq.Array(ids), viewerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var counts []*model.ReactionCount
+	for rows.Next() {
+		var count model.ReactionCount
+		if err := rows.Scan(&count.TargetID, &count.Emoji, &count.Count, &count.ReactedByMe); err != nil {
+			return nil, err
+		}
+		counts = append(counts, &count)
+	}
+	return counts, rows.Err()
+}
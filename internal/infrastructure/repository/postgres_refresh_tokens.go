@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"rating-system/internal/domain/model"
+)
+
+// CreateRefreshToken stores a refresh token
+func (r *PostgresRepository) CreateRefreshToken(ctx context.Context, token *model.RefreshToken) error {
+	query := `
+		INSERT INTO refresh_tokens (id, family_id, user_id, token_hash, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	_, err := r.execWithContext(ctx, query, token.ID, token.FamilyID, token.UserID, token.TokenHash, token.ExpiresAt, token.CreatedAt)
+	return err
+}
+
+// GetRefreshTokenByHash retrieves a refresh token by the hash of its value
+func (r *PostgresRepository) GetRefreshTokenByHash(ctx context.Context, tokenHash string) (*model.RefreshToken, error) {
+	query := `
+		SELECT id, family_id, user_id, token_hash, expires_at, rotated_at, revoked_at, created_at
+		FROM refresh_tokens
+		WHERE token_hash = $1
+	`
+	var token model.RefreshToken
+	err := r.queryRowWithContext(ctx, query, tokenHash).Scan(
+		&token.ID,
+		&token.FamilyID,
+		&token.UserID,
+		&token.TokenHash,
+		&token.ExpiresAt,
+		&token.RotatedAt,
+		&token.RevokedAt,
+		&token.CreatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, model.ErrInvalidRefreshToken
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
+// RotateRefreshToken marks a refresh token as exchanged and stores its successor in one
+// transaction. It returns model.ErrRefreshTokenReused if the token was exchanged or revoked
+// concurrently.
+func (r *PostgresRepository) RotateRefreshToken(ctx context.Context, id uuid.UUID, next *model.RefreshToken, at time.Time) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE refresh_tokens SET rotated_at = $1
+		WHERE id = $2 AND rotated_at IS NULL AND revoked_at IS NULL
+	`, at, id)
+	if err != nil {
+		return err
+	}
+	rotated, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rotated == 0 {
+		return model.ErrRefreshTokenReused
+	}
+
+	query := `
+		INSERT INTO refresh_tokens (id, family_id, user_id, token_hash, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	if _, err := tx.ExecContext(ctx, query, next.ID, next.FamilyID, next.UserID, next.TokenHash, next.ExpiresAt, next.CreatedAt); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// RevokeRefreshTokenFamily revokes every refresh token of a family that is not revoked yet
+func (r *PostgresRepository) RevokeRefreshTokenFamily(ctx context.Context, familyID uuid.UUID, at time.Time) error {
+	_, err := r.execWithContext(ctx, `UPDATE refresh_tokens SET revoked_at = $1 WHERE family_id = $2 AND revoked_at IS NULL`, at, familyID)
+	return err
+}
+
+// RevokeUserRefreshTokens revokes every refresh token of a user that is not revoked yet
+func (r *PostgresRepository) RevokeUserRefreshTokens(ctx context.Context, userID uuid.UUID, at time.Time) error {
+	_, err := r.execWithContext(ctx, `UPDATE refresh_tokens SET revoked_at = $1 WHERE user_id = $2 AND revoked_at IS NULL`, at, userID)
+	return err
+}
+
+// GetTokenGeneration retrieves the token generation of a user
+func (r *PostgresRepository) GetTokenGeneration(ctx context.Context, userID uuid.UUID) (int, error) {
+	var generation int
+	err := r.queryRowWithContext(ctx, `SELECT token_generation FROM users WHERE id = $1`, userID).Scan(&generation)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, errors.New("user not found")
+		}
+		return 0, err
+	}
+	return generation, nil
+}
+
+// IncrementTokenGeneration bumps the token generation of a user and returns the new generation
+func (r *PostgresRepository) IncrementTokenGeneration(ctx context.Context, userID uuid.UUID) (int, error) {
+	var generation int
+	query := `UPDATE users SET token_generation = token_generation + 1 WHERE id = $1 RETURNING token_generation`
+	if err := r.queryRowWithContext(ctx, query, userID).Scan(&generation); err != nil {
+		if err == sql.ErrNoRows {
+			return 0, errors.New("user not found")
+		}
+		return 0, err
+	}
+	return generation, nil
+}
@@ -6,6 +6,7 @@ import (
         "errors"
         "fmt"
         "strings"
+        "time"
 
         "github.com/google/uuid"
         "github.com/lib/pq"
@@ -78,17 +79,31 @@ func (r *PostgresRepository) queryWithContext(ctx context.Context, query string,
 
 // CreateRating creates a new rating in the database
 func (r *PostgresRepository) CreateRating(ctx context.Context, rating *model.Rating) error {
+        return insertPostgresRating(ctx, r.db, rating)
+}
+
+// CreateRatingWithAttestation creates a new rating verified by the attestation, in one transaction so
+// neither is saved if the attestation was already redeemed
+func (r *PostgresRepository) CreateRatingWithAttestation(ctx context.Context, rating *model.Rating, attestation *model.Attestation) error {
+        return r.withAttestation(ctx, attestation, func(tx *sql.Tx) error {
+                return insertPostgresRating(ctx, tx, rating)
+        })
+}
+
+// insertPostgresRating inserts a rating using the database or an open transaction
+func insertPostgresRating(ctx context.Context, db execer, rating *model.Rating) error {
         query := `
-                INSERT INTO ratings (id, user_id, service_id, score, created_at, updated_at)
-                VALUES ($1, $2, $3, $4, $5, $6)
+                INSERT INTO ratings (id, user_id, service_id, score, shadowed, created_at, updated_at)
+                VALUES ($1, $2, $3, $4, $5, $6, $7)
         `
-        _, err := r.execWithContext(
+        _, err := db.ExecContext(
                 ctx,
                 query,
                 rating.ID,
                 rating.UserID,
                 rating.ServiceID,
                 rating.Score,
+                rating.Shadowed,
                 rating.CreatedAt,
                 rating.UpdatedAt,
         )
@@ -105,11 +120,11 @@ func (r *PostgresRepository) CreateRating(ctx context.Context, rating *model.Rat
 }
 
 // GetRatingByID retrieves a rating by ID
-func (r *PostgresRepository) GetRatingByID(ctx context.Context, id uuid.UUID) (*model.Rating, error) {
+func (r *PostgresRepository) GetRatingByID(ctx context.Context, id uuid.UUID, viewer model.Viewer) (*model.Rating, error) {
         query := `
-                SELECT id, user_id, service_id, score, created_at, updated_at
+                SELECT id, user_id, service_id, score, verified, verified_at, created_at, updated_at
                 FROM ratings
-                WHERE id = $1
+                WHERE id = $1 AND ` + shadowVisible("ratings", viewer) + `
         `
         row := r.queryRowWithContext(ctx, query, id)
 
@@ -119,6 +134,8 @@ func (r *PostgresRepository) GetRatingByID(ctx context.Context, id uuid.UUID) (*
                 &rating.UserID,
                 &rating.ServiceID,
                 &rating.Score,
+                &rating.Verified,
+                &rating.VerifiedAt,
                 &rating.CreatedAt,
                 &rating.UpdatedAt,
         )
@@ -134,7 +151,7 @@ func (r *PostgresRepository) GetRatingByID(ctx context.Context, id uuid.UUID) (*
 // GetRatingByUserAndService retrieves a rating by user and service
 func (r *PostgresRepository) GetRatingByUserAndService(ctx context.Context, userID, serviceID uuid.UUID) (*model.Rating, error) {
         query := `
-                SELECT id, user_id, service_id, score, created_at, updated_at
+                SELECT id, user_id, service_id, score, verified, verified_at, created_at, updated_at
                 FROM ratings
                 WHERE user_id = $1 AND service_id = $2
         `
@@ -146,6 +163,8 @@ func (r *PostgresRepository) GetRatingByUserAndService(ctx context.Context, user
                 &rating.UserID,
                 &rating.ServiceID,
                 &rating.Score,
+                &rating.Verified,
+                &rating.VerifiedAt,
                 &rating.CreatedAt,
                 &rating.UpdatedAt,
         )
@@ -159,20 +178,22 @@ func (r *PostgresRepository) GetRatingByUserAndService(ctx context.Context, user
 }
 
 // GetRatingsByService retrieves ratings by service ID with pagination
-func (r *PostgresRepository) GetRatingsByService(ctx context.Context, serviceID uuid.UUID, params pagination.Params) ([]*model.Rating, int, error) {
+func (r *PostgresRepository) GetRatingsByService(ctx context.Context, serviceID uuid.UUID, viewer model.Viewer, filter model.RatingFilter, params pagination.Params) ([]*model.Rating, int, error) {
+        visible := shadowVisible("ratings", viewer)
+
         // Get total count
-        countQuery := `SELECT COUNT(*) FROM ratings WHERE service_id = $1`
+        countQuery := `SELECT COUNT(*) FROM ratings WHERE service_id = $1 AND ($2 = FALSE OR verified = TRUE) AND quarantined = FALSE AND ` + visible
         var total int
-        err := r.queryRowWithContext(ctx, countQuery, serviceID).Scan(&total)
+        err := r.queryRowWithContext(ctx, countQuery, serviceID, filter.VerifiedOnly).Scan(&total)
         if err != nil {
                 return nil, 0, err
         }
 
         // Build the query with sorting and pagination
         baseQuery := `
-                SELECT id, user_id, service_id, score, created_at, updated_at
+                SELECT id, user_id, service_id, score, verified, verified_at, created_at, updated_at
                 FROM ratings
-                WHERE service_id = $1
+                WHERE service_id = $1 AND ($2 = FALSE OR verified = TRUE) AND quarantined = FALSE AND ` + visible + `
         `
 
         // Add sorting
@@ -190,13 +211,14 @@ func (r *PostgresRepository) GetRatingsByService(ctx context.Context, serviceID
         }
 
         // Add pagination
-        baseQuery += " LIMIT $2 OFFSET $3"
+        baseQuery += " LIMIT $3 OFFSET $4"
 
         // Execute the query
         rows, err := r.queryWithContext(
                 ctx,
                 baseQuery,
                 serviceID,
+                filter.VerifiedOnly,
                 params.GetLimit(),
                 params.GetOffset(),
         )
@@ -213,6 +235,8 @@ func (r *PostgresRepository) GetRatingsByService(ctx context.Context, serviceID
                         &rating.UserID,
                         &rating.ServiceID,
                         &rating.Score,
+                        &rating.Verified,
+                        &rating.VerifiedAt,
                         &rating.CreatedAt,
                         &rating.UpdatedAt,
                 )
@@ -231,12 +255,25 @@ func (r *PostgresRepository) GetRatingsByService(ctx context.Context, serviceID
 
 // UpdateRating updates an existing rating
 func (r *PostgresRepository) UpdateRating(ctx context.Context, rating *model.Rating) error {
+        return updatePostgresRating(ctx, r.db, rating)
+}
+
+// UpdateRatingWithAttestation updates an existing rating and verifies it with the attestation, in one
+// transaction so the new score is not saved if the attestation was already redeemed
+func (r *PostgresRepository) UpdateRatingWithAttestation(ctx context.Context, rating *model.Rating, attestation *model.Attestation) error {
+        return r.withAttestation(ctx, attestation, func(tx *sql.Tx) error {
+                return updatePostgresRating(ctx, tx, rating)
+        })
+}
+
+// updatePostgresRating updates a rating's score using the database or an open transaction
+func updatePostgresRating(ctx context.Context, db execer, rating *model.Rating) error {
         query := `
                 UPDATE ratings
                 SET score = $1, updated_at = $2
                 WHERE id = $3
         `
-        _, err := r.execWithContext(
+        _, err := db.ExecContext(
                 ctx,
                 query,
                 rating.Score,
@@ -250,13 +287,13 @@ func (r *PostgresRepository) UpdateRating(ctx context.Context, rating *model.Rat
 }
 
 // CalculateAverageRating calculates the average rating for a service
-func (r *PostgresRepository) CalculateAverageRating(ctx context.Context, serviceID uuid.UUID) (*model.AverageRating, error) {
+func (r *PostgresRepository) CalculateAverageRating(ctx context.Context, serviceID uuid.UUID, viewer model.Viewer, filter model.RatingFilter) (*model.AverageRating, error) {
         query := `
                 SELECT AVG(score) AS average_score, COUNT(*) AS total_ratings
                 FROM ratings
-                WHERE service_id = $1
+                WHERE service_id = $1 AND ($2 = FALSE OR verified = TRUE) AND quarantined = FALSE AND ` + shadowVisible("ratings", viewer) + `
         `
-        row := r.queryRowWithContext(ctx, query, serviceID)
+        row := r.queryRowWithContext(ctx, query, serviceID, filter.VerifiedOnly)
 
         var avgRating model.AverageRating
         var avgScore sql.NullFloat64
@@ -269,6 +306,7 @@ func (r *PostgresRepository) CalculateAverageRating(ctx context.Context, service
 
         avgRating.ServiceID = serviceID
         avgRating.TotalRatings = totalRatings
+        avgRating.VerifiedOnly = filter.VerifiedOnly
         
         if avgScore.Valid {
                 avgRating.AverageScore = avgScore.Float64
@@ -279,13 +317,80 @@ func (r *PostgresRepository) CalculateAverageRating(ctx context.Context, service
         return &avgRating, nil
 }
 
+// withAttestation runs a rating or review write, stores the redeemed attestation and marks its rating
+// as verified in one transaction. Attestations are single use, so when the token was redeemed before
+// the transaction is rolled back, undoing the write, and ErrAttestationReused is returned.
+func (r *PostgresRepository) withAttestation(ctx context.Context, attestation *model.Attestation, write func(tx *sql.Tx) error) error {
+        tx, err := r.db.BeginTx(ctx, nil)
+        if err != nil {
+                return err
+        }
+        defer tx.Rollback()
+
+        if err := write(tx); err != nil {
+                return err
+        }
+
+        query := `
+                INSERT INTO attestations (id, token_id, rating_id, user_id, service_id, order_id, algorithm, expires_at, verified_at)
+                VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+        `
+        _, err = tx.ExecContext(
+                ctx,
+                query,
+                attestation.ID,
+                attestation.TokenID,
+                attestation.RatingID,
+                attestation.UserID,
+                attestation.ServiceID,
+                attestation.OrderID,
+                attestation.Algorithm,
+                attestation.ExpiresAt,
+                attestation.VerifiedAt,
+        )
+        if err != nil {
+                // Check for unique constraint violation
+                if pqErr, ok := err.(*pq.Error); ok {
+                        if pqErr.Code == "23505" { // unique_violation
+                                return model.ErrAttestationReused
+                        }
+                }
+                return err
+        }
+
+        _, err = tx.ExecContext(
+                ctx,
+                `UPDATE ratings SET verified = TRUE, verified_at = $1 WHERE id = $2`,
+                attestation.VerifiedAt,
+                attestation.RatingID,
+        )
+        if err != nil {
+                return err
+        }
+
+        return tx.Commit()
+}
+
 // CreateReview creates a new review in the database
 func (r *PostgresRepository) CreateReview(ctx context.Context, review *model.Review) error {
+        return insertPostgresReview(ctx, r.db, review)
+}
+
+// CreateReviewWithAttestation creates a new review and verifies its rating with the attestation, in one
+// transaction so the attestation is only consumed if the review is saved
+func (r *PostgresRepository) CreateReviewWithAttestation(ctx context.Context, review *model.Review, attestation *model.Attestation) error {
+        return r.withAttestation(ctx, attestation, func(tx *sql.Tx) error {
+                return insertPostgresReview(ctx, tx, review)
+        })
+}
+
+// insertPostgresReview inserts a review using the database or an open transaction
+func insertPostgresReview(ctx context.Context, db execer, review *model.Review) error {
         query := `
-                INSERT INTO reviews (id, user_id, service_id, rating_id, title, content, created_at, updated_at)
-                VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+                INSERT INTO reviews (id, user_id, service_id, rating_id, title, content, status, sentiment_score, publish_at, published_at, shadowed, created_at, updated_at)
+                VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
         `
-        _, err := r.execWithContext(
+        _, err := db.ExecContext(
                 ctx,
                 query,
                 review.ID,
@@ -294,6 +399,11 @@ func (r *PostgresRepository) CreateReview(ctx context.Context, review *model.Rev
                 review.RatingID,
                 review.Title,
                 review.Content,
+                review.Status,
+                review.SentimentScore,
+                review.PublishAt,
+                review.PublishedAt,
+                review.Shadowed,
                 review.CreatedAt,
                 review.UpdatedAt,
         )
@@ -310,52 +420,49 @@ func (r *PostgresRepository) CreateReview(ctx context.Context, review *model.Rev
 }
 
 // GetReviewByID retrieves a review by ID
-func (r *PostgresRepository) GetReviewByID(ctx context.Context, id uuid.UUID) (*model.ReviewWithRating, error) {
+func (r *PostgresRepository) GetReviewByID(ctx context.Context, id uuid.UUID, viewer model.Viewer) (*model.ReviewWithRating, error) {
         query := `
-                SELECT r.id, r.user_id, r.service_id, r.rating_id, r.title, r.content, r.created_at, r.updated_at, rt.score
+                SELECT ` + reviewColumns(viewer) + `
                 FROM reviews r
                 JOIN ratings rt ON r.rating_id = rt.id
-                WHERE r.id = $1
+                WHERE r.id = $1 AND ` + shadowVisible("r", viewer) + `
         `
         row := r.queryRowWithContext(ctx, query, id)
 
-        var review model.ReviewWithRating
-        err := row.Scan(
-                &review.ID,
-                &review.UserID,
-                &review.ServiceID,
-                &review.RatingID,
-                &review.Title,
-                &review.Content,
-                &review.CreatedAt,
-                &review.UpdatedAt,
-                &review.Score,
-        )
+        review, err := scanPostgresReview(row)
         if err != nil {
                 if err == sql.ErrNoRows {
                         return nil, errors.New("review not found")
                 }
                 return nil, err
         }
-        return &review, nil
+        return review, nil
 }
 
-// GetReviewsByService retrieves reviews by service ID with pagination
-func (r *PostgresRepository) GetReviewsByService(ctx context.Context, serviceID uuid.UUID, params pagination.Params) ([]*model.ReviewWithRating, int, error) {
+// GetReviewsByService retrieves reviews by service ID with pagination. Comment counts are selected
+// with the reviews; a requested comment preview takes one more query for the page.
+func (r *PostgresRepository) GetReviewsByService(ctx context.Context, serviceID uuid.UUID, viewer model.Viewer, filter model.ReviewFilter, params pagination.Params) ([]*model.ReviewWithRating, int, error) {
         // Get total count
-        countQuery := `SELECT COUNT(*) FROM reviews WHERE service_id = $1`
+        countQuery := `
+                SELECT COUNT(*)
+                FROM reviews r
+                JOIN ratings rt ON r.rating_id = rt.id
+                WHERE r.service_id = $1 AND r.status = 'published' AND r.moderation_status IN ` + publicModeration + `
+                  AND ($2 = FALSE OR rt.verified = TRUE) AND ` + listVisible("r", viewer) + `
+        `
         var total int
-        err := r.queryRowWithContext(ctx, countQuery, serviceID).Scan(&total)
+        err := r.queryRowWithContext(ctx, countQuery, serviceID, filter.VerifiedOnly).Scan(&total)
         if err != nil {
                 return nil, 0, err
         }
 
         // Build the query with sorting and pagination
         baseQuery := `
-                SELECT r.id, r.user_id, r.service_id, r.rating_id, r.title, r.content, r.created_at, r.updated_at, rt.score
+                SELECT ` + reviewColumns(viewer) + `
                 FROM reviews r
                 JOIN ratings rt ON r.rating_id = rt.id
-                WHERE r.service_id = $1
+                WHERE r.service_id = $1 AND r.status = 'published' AND r.moderation_status IN ` + publicModeration + `
+                  AND ($2 = FALSE OR rt.verified = TRUE) AND ` + listVisible("r", viewer) + `
         `
 
         // Add sorting
@@ -380,13 +487,14 @@ func (r *PostgresRepository) GetReviewsByService(ctx context.Context, serviceID
         }
 
         // Add pagination
-        baseQuery += " LIMIT $2 OFFSET $3"
+        baseQuery += " LIMIT $3 OFFSET $4"
 
         // Execute the query
         rows, err := r.queryWithContext(
                 ctx,
                 baseQuery,
                 serviceID,
+                filter.VerifiedOnly,
                 params.GetLimit(),
                 params.GetOffset(),
         )
@@ -395,45 +503,182 @@ func (r *PostgresRepository) GetReviewsByService(ctx context.Context, serviceID
         }
         defer rows.Close()
 
-        var reviews []*model.ReviewWithRating
-        for rows.Next() {
-                var review model.ReviewWithRating
-                err := rows.Scan(
-                        &review.ID,
-                        &review.UserID,
-                        &review.ServiceID,
-                        &review.RatingID,
-                        &review.Title,
-                        &review.Content,
-                        &review.CreatedAt,
-                        &review.UpdatedAt,
-                        &review.Score,
-                )
+        reviews, err := scanPostgresReviews(rows)
+        if err != nil {
+                return nil, 0, err
+        }
+
+        if filter.CommentPreview > 0 {
+                comments, err := r.getLatestComments(ctx, reviews, viewer, filter.CommentPreview)
                 if err != nil {
                         return nil, 0, err
                 }
-                reviews = append(reviews, &review)
+                model.AttachLatestComments(reviews, comments)
         }
 
-        if err = rows.Err(); err != nil {
+        return reviews, total, nil
+}
+
+// GetReviewsByUser retrieves a user's own reviews, optionally restricted to one status
+func (r *PostgresRepository) GetReviewsByUser(ctx context.Context, userID uuid.UUID, status model.ReviewStatus, params pagination.Params) ([]*model.ReviewWithRating, int, error) {
+        countQuery := `SELECT COUNT(*) FROM reviews WHERE user_id = $1 AND ($2 = '' OR status = $2)`
+        var total int
+        err := r.queryRowWithContext(ctx, countQuery, userID, string(status)).Scan(&total)
+        if err != nil {
+                return nil, 0, err
+        }
+
+        query := `
+                SELECT ` + reviewColumns(model.ViewerOf(userID)) + `
+                FROM reviews r
+                JOIN ratings rt ON r.rating_id = rt.id
+                WHERE r.user_id = $1 AND ($2 = '' OR r.status = $2)
+                ORDER BY r.updated_at DESC
+                LIMIT $3 OFFSET $4
+        `
+        rows, err := r.queryWithContext(ctx, query, userID, string(status), params.GetLimit(), params.GetOffset())
+        if err != nil {
+                return nil, 0, err
+        }
+        defer rows.Close()
+
+        reviews, err := scanPostgresReviews(rows)
+        if err != nil {
                 return nil, 0, err
         }
 
         return reviews, total, nil
 }
 
+// GetPendingReviews retrieves reviews awaiting publication, oldest first
+func (r *PostgresRepository) GetPendingReviews(ctx context.Context, params pagination.Params) ([]*model.ReviewWithRating, int, error) {
+        countQuery := `SELECT COUNT(*) FROM reviews WHERE status = 'pending'`
+        var total int
+        err := r.queryRowWithContext(ctx, countQuery).Scan(&total)
+        if err != nil {
+                return nil, 0, err
+        }
+
+        query := `
+                SELECT ` + reviewColumns(model.SystemViewer) + `
+                FROM reviews r
+                JOIN ratings rt ON r.rating_id = rt.id
+                WHERE r.status = 'pending'
+                ORDER BY r.updated_at ASC
+                LIMIT $1 OFFSET $2
+        `
+        rows, err := r.queryWithContext(ctx, query, params.GetLimit(), params.GetOffset())
+        if err != nil {
+                return nil, 0, err
+        }
+        defer rows.Close()
+
+        reviews, err := scanPostgresReviews(rows)
+        if err != nil {
+                return nil, 0, err
+        }
+
+        return reviews, total, nil
+}
+
+// GetPublishedReviewsAfter retrieves a service's published reviews in publication order,
+// starting after the given publication time and review ID. Shadowed reviews are left out since
+// the results feed highlights shown to everyone.
+func (r *PostgresRepository) GetPublishedReviewsAfter(ctx context.Context, serviceID uuid.UUID, publishedAt time.Time, afterID uuid.UUID, limit int) ([]*model.ReviewWithRating, error) {
+        query := `
+                SELECT ` + reviewColumns(model.ViewerOf(uuid.Nil)) + `
+                FROM reviews r
+                JOIN ratings rt ON r.rating_id = rt.id
+                WHERE r.service_id = $1 AND r.status = 'published' AND r.moderation_status IN ` + publicModeration + `
+                  AND r.shadowed = FALSE
+                  AND (COALESCE(r.published_at, r.created_at) > $2
+                       OR (COALESCE(r.published_at, r.created_at) = $2 AND r.id > $3))
+                ORDER BY COALESCE(r.published_at, r.created_at), r.id
+                LIMIT $4
+        `
+        rows, err := r.queryWithContext(ctx, query, serviceID, publishedAt, afterID, limit)
+        if err != nil {
+                return nil, err
+        }
+        defer rows.Close()
+
+        return scanPostgresReviews(rows)
+}
+
+// PublishDueReviews publishes pending reviews whose cool-down has elapsed and returns their IDs
+func (r *PostgresRepository) PublishDueReviews(ctx context.Context, now time.Time) ([]uuid.UUID, error) {
+        query := `
+                UPDATE reviews
+                SET status = 'published', published_at = $1, publish_at = NULL, updated_at = $1
+                WHERE status = 'pending' AND publish_at IS NOT NULL AND publish_at <= $1
+                RETURNING id
+        `
+        rows, err := r.queryWithContext(ctx, query, now)
+        if err != nil {
+                return nil, err
+        }
+        defer rows.Close()
+
+        var published []uuid.UUID
+        for rows.Next() {
+                var id uuid.UUID
+                if err := rows.Scan(&id); err != nil {
+                        return nil, err
+                }
+                published = append(published, id)
+        }
+        if err = rows.Err(); err != nil {
+                return nil, err
+        }
+        return published, nil
+}
+
+// CalculateSentimentSummary aggregates the text sentiment of a service's published reviews
+func (r *PostgresRepository) CalculateSentimentSummary(ctx context.Context, serviceID uuid.UUID, viewer model.Viewer) (*model.SentimentSummary, error) {
+        query := `
+                SELECT
+                        AVG(sentiment_score) AS average_sentiment,
+                        COUNT(sentiment_score) AS analyzed_reviews,
+                        COUNT(CASE WHEN sentiment_score > $2 THEN 1 END) AS positive,
+                        COUNT(CASE WHEN sentiment_score < $3 THEN 1 END) AS negative
+                FROM reviews
+                WHERE service_id = $1 AND status = 'published' AND moderation_status IN ` + publicModeration + `
+                  AND sentiment_score IS NOT NULL AND ` + shadowVisible("reviews", viewer) + `
+        `
+
+        var avg sql.NullFloat64
+        summary := &model.SentimentSummary{ServiceID: serviceID}
+        err := r.queryRowWithContext(ctx, query, serviceID, model.SentimentNeutralBand, -model.SentimentNeutralBand).
+                Scan(&avg, &summary.AnalyzedReviews, &summary.Positive, &summary.Negative)
+        if err != nil {
+                return nil, err
+        }
+
+        // AVG is NULL when no review has been analyzed yet
+        if avg.Valid {
+                summary.AverageSentiment = avg.Float64
+        }
+        summary.Neutral = summary.AnalyzedReviews - summary.Positive - summary.Negative
+        summary.CalculatedAt = time.Now()
+        return summary, nil
+}
+
 // UpdateReview updates an existing review
 func (r *PostgresRepository) UpdateReview(ctx context.Context, review *model.Review) error {
         query := `
                 UPDATE reviews
-                SET title = $1, content = $2, updated_at = $3
-                WHERE id = $4
+                SET title = $1, content = $2, status = $3, sentiment_score = $4, publish_at = $5, published_at = $6, updated_at = $7
+                WHERE id = $8
         `
         _, err := r.execWithContext(
                 ctx,
                 query,
                 review.Title,
                 review.Content,
+                review.Status,
+                review.SentimentScore,
+                review.PublishAt,
+                review.PublishedAt,
                 review.UpdatedAt,
                 review.ID,
         )
@@ -446,8 +691,8 @@ func (r *PostgresRepository) UpdateReview(ctx context.Context, review *model.Rev
 // CreateComment creates a new comment in the database
 func (r *PostgresRepository) CreateComment(ctx context.Context, comment *model.Comment) error {
         query := `
-                INSERT INTO comments (id, user_id, review_id, content, created_at, updated_at)
-                VALUES ($1, $2, $3, $4, $5, $6)
+                INSERT INTO comments (id, user_id, review_id, parent_id, thread_id, depth, content, shadowed, created_at, updated_at)
+                VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
         `
         _, err := r.execWithContext(
                 ctx,
@@ -455,7 +700,11 @@ func (r *PostgresRepository) CreateComment(ctx context.Context, comment *model.C
                 comment.ID,
                 comment.UserID,
                 comment.ReviewID,
+                comment.ParentID,
+                comment.ThreadID,
+                comment.Depth,
                 comment.Content,
+                comment.Shadowed,
                 comment.CreatedAt,
                 comment.UpdatedAt,
         )
@@ -466,36 +715,29 @@ func (r *PostgresRepository) CreateComment(ctx context.Context, comment *model.C
 }
 
 // GetCommentByID retrieves a comment by ID
-func (r *PostgresRepository) GetCommentByID(ctx context.Context, id uuid.UUID) (*model.Comment, error) {
+func (r *PostgresRepository) GetCommentByID(ctx context.Context, id uuid.UUID, viewer model.Viewer) (*model.Comment, error) {
         query := `
-                SELECT id, user_id, review_id, content, created_at, updated_at
-                FROM comments
-                WHERE id = $1
+                SELECT ` + commentColumns + `
+                FROM comments c
+                WHERE c.id = $1 AND ` + shadowVisible("c", viewer) + `
         `
         row := r.queryRowWithContext(ctx, query, id)
 
-        var comment model.Comment
-        err := row.Scan(
-                &comment.ID,
-                &comment.UserID,
-                &comment.ReviewID,
-                &comment.Content,
-                &comment.CreatedAt,
-                &comment.UpdatedAt,
-        )
+        comment, err := scanPostgresComment(row)
         if err != nil {
                 if err == sql.ErrNoRows {
                         return nil, errors.New("comment not found")
                 }
                 return nil, err
         }
-        return &comment, nil
+        return comment, nil
 }
 
 // GetCommentsByReview retrieves comments by review ID with pagination
-func (r *PostgresRepository) GetCommentsByReview(ctx context.Context, reviewID uuid.UUID, params pagination.Params) ([]*model.Comment, int, error) {
+func (r *PostgresRepository) GetCommentsByReview(ctx context.Context, reviewID uuid.UUID, viewer model.Viewer, params pagination.Params) ([]*model.Comment, int, error) {
         // Get total count
-        countQuery := `SELECT COUNT(*) FROM comments WHERE review_id = $1`
+        countQuery := `SELECT COUNT(*) FROM comments WHERE review_id = $1 AND moderation_status IN ` + publicModeration + `
+                AND ` + listVisible("comments", viewer)
         var total int
         err := r.queryRowWithContext(ctx, countQuery, reviewID).Scan(&total)
         if err != nil {
@@ -504,14 +746,14 @@ func (r *PostgresRepository) GetCommentsByReview(ctx context.Context, reviewID u
 
         // Build the query with sorting and pagination
         baseQuery := `
-                SELECT id, user_id, review_id, content, created_at, updated_at
-                FROM comments
-                WHERE review_id = $1
+                SELECT ` + commentColumns + `
+                FROM comments c
+                WHERE c.review_id = $1 AND c.moderation_status IN ` + publicModeration + ` AND ` + listVisible("c", viewer) + `
         `
 
         // Add sorting
         if params.GetSortBy() != "" {
-                baseQuery += fmt.Sprintf(" ORDER BY %s", sanitizeSortField(params.GetSortBy()))
+                baseQuery += fmt.Sprintf(" ORDER BY c.%s", sanitizeSortField(params.GetSortBy()))
                 if params.GetSortDirection() == "desc" {
                         baseQuery += " DESC"
                 } else {
@@ -519,7 +761,7 @@ func (r *PostgresRepository) GetCommentsByReview(ctx context.Context, reviewID u
                 }
         } else {
                 // Default sort
-                baseQuery += " ORDER BY created_at ASC"
+                baseQuery += " ORDER BY c.created_at ASC"
         }
 
         // Add pagination
@@ -538,41 +780,133 @@ func (r *PostgresRepository) GetCommentsByReview(ctx context.Context, reviewID u
         }
         defer rows.Close()
 
-        var comments []*model.Comment
-        for rows.Next() {
-                var comment model.Comment
-                err := rows.Scan(
-                        &comment.ID,
-                        &comment.UserID,
-                        &comment.ReviewID,
-                        &comment.Content,
-                        &comment.CreatedAt,
-                        &comment.UpdatedAt,
-                )
-                if err != nil {
-                        return nil, 0, err
-                }
-                comments = append(comments, &comment)
+        comments, err := scanPostgresComments(rows)
+        if err != nil {
+                return nil, 0, err
         }
 
-        if err = rows.Err(); err != nil {
+        return comments, total, nil
+}
+
+// GetCommentThreads retrieves the top-level comments of a review with pagination. Comments withheld
+// from the viewer are only included, and marked withheld, when a reply in their thread is visible.
+func (r *PostgresRepository) GetCommentThreads(ctx context.Context, reviewID uuid.UUID, viewer model.Viewer, params pagination.Params) ([]*model.Comment, int, error) {
+        countQuery := `SELECT COUNT(*) FROM comments c WHERE c.review_id = $1 AND c.parent_id IS NULL AND ` + threadShown(viewer)
+        var total int
+        err := r.queryRowWithContext(ctx, countQuery, reviewID).Scan(&total)
+        if err != nil {
+                return nil, 0, err
+        }
+
+        query := `
+                SELECT ` + treeColumns(viewer) + `
+                FROM comments c
+                WHERE c.review_id = $1 AND c.parent_id IS NULL AND ` + threadShown(viewer) + `
+        `
+        if params.GetSortDirection() == "asc" {
+                query += " ORDER BY c.created_at ASC, c.id ASC"
+        } else {
+                query += " ORDER BY c.created_at DESC, c.id DESC"
+        }
+        query += " LIMIT $2 OFFSET $3"
+
+        rows, err := r.queryWithContext(ctx, query, reviewID, params.GetLimit(), params.GetOffset())
+        if err != nil {
+                return nil, 0, err
+        }
+        defer rows.Close()
+
+        comments, err := scanPostgresTreeComments(rows)
+        if err != nil {
                 return nil, 0, err
         }
 
         return comments, total, nil
 }
 
+// GetCommentReplies retrieves all replies in the given threads, oldest first, marking those withheld
+// from the viewer
+func (r *PostgresRepository) GetCommentReplies(ctx context.Context, threadIDs []uuid.UUID, viewer model.Viewer) ([]*model.Comment, error) {
+        if len(threadIDs) == 0 {
+                return nil, nil
+        }
+
+        ids := make([]string, len(threadIDs))
+        for i, id := range threadIDs {
+                ids[i] = id.String()
+        }
+
+        query := `
+                SELECT ` + treeColumns(viewer) + `
+                FROM comments c
+                WHERE c.thread_id = ANY($1) AND c.parent_id IS NOT NULL
+                ORDER BY c.created_at ASC, c.id ASC
+        `
+        rows, err := r.queryWithContext(ctx, query, pq.Array(ids))
+        if err != nil {
+                return nil, err
+        }
+        defer rows.Close()
+
+        return scanPostgresTreeComments(rows)
+}
+
+// getLatestComments retrieves up to limit of the newest public comments that have not been deleted
+// and that the viewer can see for each of the reviews, newest first, ranking them per review with
+// a window function. It is a second query, issued once for the whole page of reviews.
+func (r *PostgresRepository) getLatestComments(ctx context.Context, reviews []*model.ReviewWithRating, viewer model.Viewer, limit int) ([]*model.Comment, error) {
+        if len(reviews) == 0 {
+                return nil, nil
+        }
+
+        ids := make([]string, len(reviews))
+        for i, review := range reviews {
+                ids[i] = review.ID.String()
+        }
+
+        query := `
+                SELECT ` + commentColumns + `
+                FROM (
+                        SELECT comments.*, ROW_NUMBER() OVER (PARTITION BY review_id ORDER BY created_at DESC, id DESC) AS recency
+                        FROM comments
+                        WHERE review_id = ANY($1) AND deleted = FALSE AND moderation_status IN ` + publicModeration + `
+                          AND ` + listVisible("comments", viewer) + `
+                ) c
+                WHERE c.recency <= $2
+                ORDER BY c.review_id, c.recency
+        `
+        rows, err := r.queryWithContext(ctx, query, pq.Array(ids), limit)
+        if err != nil {
+                return nil, err
+        }
+        defer rows.Close()
+
+        return scanPostgresComments(rows)
+}
+
+// CountCommentReplies counts the direct replies to a comment
+func (r *PostgresRepository) CountCommentReplies(ctx context.Context, id uuid.UUID) (int, error) {
+        var count int
+        err := r.queryRowWithContext(ctx, `SELECT COUNT(*) FROM comments WHERE parent_id = $1`, id).Scan(&count)
+        if err != nil {
+                return 0, err
+        }
+        return count, nil
+}
+
 // UpdateComment updates an existing comment
 func (r *PostgresRepository) UpdateComment(ctx context.Context, comment *model.Comment) error {
         query := `
                 UPDATE comments
-                SET content = $1, updated_at = $2
-                WHERE id = $3
+                SET content = $1, deleted = $2, deleted_at = $3, updated_at = $4
+                WHERE id = $5
         `
         _, err := r.execWithContext(
                 ctx,
                 query,
                 comment.Content,
+                comment.Deleted,
+                comment.DeletedAt,
                 comment.UpdatedAt,
                 comment.ID,
         )
@@ -582,6 +916,130 @@ func (r *PostgresRepository) UpdateComment(ctx context.Context, comment *model.C
         return nil
 }
 
+// DeleteComment permanently deletes a comment
+func (r *PostgresRepository) DeleteComment(ctx context.Context, id uuid.UUID) error {
+        result, err := r.execWithContext(ctx, `DELETE FROM comments WHERE id = $1`, id)
+        if err != nil {
+                return err
+        }
+
+        deleted, err := result.RowsAffected()
+        if err != nil {
+                return err
+        }
+        if deleted == 0 {
+                return errors.New("comment not found")
+        }
+        return nil
+}
+
+// scanPostgresComment scans a row selected with commentColumns, followed by any extra columns
+func scanPostgresComment(row rowScanner, extra ...interface{}) (*model.Comment, error) {
+        var comment model.Comment
+        dest := []interface{}{
+                &comment.ID,
+                &comment.UserID,
+                &comment.ReviewID,
+                &comment.ParentID,
+                &comment.ThreadID,
+                &comment.Depth,
+                &comment.Content,
+                &comment.Deleted,
+                &comment.DeletedAt,
+                &comment.ModerationStatus,
+                &comment.Shadowed,
+                &comment.CreatedAt,
+                &comment.UpdatedAt,
+        }
+        if err := row.Scan(append(dest, extra...)...); err != nil {
+                return nil, err
+        }
+        return &comment, nil
+}
+
+// scanPostgresComments scans all rows selected with commentColumns
+func scanPostgresComments(rows *sql.Rows) ([]*model.Comment, error) {
+        var comments []*model.Comment
+        for rows.Next() {
+                comment, err := scanPostgresComment(rows)
+                if err != nil {
+                        return nil, err
+                }
+                comments = append(comments, comment)
+        }
+
+        if err := rows.Err(); err != nil {
+                return nil, err
+        }
+        return comments, nil
+}
+
+// scanPostgresTreeComments scans all rows selected with treeColumns
+func scanPostgresTreeComments(rows *sql.Rows) ([]*model.Comment, error) {
+        var comments []*model.Comment
+        for rows.Next() {
+                var withheld bool
+                comment, err := scanPostgresComment(rows, &withheld)
+                if err != nil {
+                        return nil, err
+                }
+                comment.Withheld = withheld
+                comments = append(comments, comment)
+        }
+
+        if err := rows.Err(); err != nil {
+                return nil, err
+        }
+        return comments, nil
+}
+
+// scanPostgresReview scans a row selected with reviewColumns
+func scanPostgresReview(row rowScanner) (*model.ReviewWithRating, error) {
+        var review model.ReviewWithRating
+        err := row.Scan(
+                &review.ID,
+                &review.UserID,
+                &review.ServiceID,
+                &review.RatingID,
+                &review.Title,
+                &review.Content,
+                &review.Status,
+                &review.ModerationStatus,
+                &review.SentimentScore,
+                &review.PublishAt,
+                &review.PublishedAt,
+                &review.Shadowed,
+                &review.CreatedAt,
+                &review.UpdatedAt,
+                &review.Score,
+                &review.Verified,
+                &review.CommentCount,
+        )
+        if err != nil {
+                return nil, err
+        }
+
+        review.DetectSentimentMismatch()
+        return &review, nil
+}
+
+// scanPostgresReviews scans all rows selected with reviewColumns
+func scanPostgresReviews(rows *sql.Rows) ([]*model.ReviewWithRating, error) {
+        var reviews []*model.ReviewWithRating
+        for rows.Next() {
+                review, err := scanPostgresReview(rows)
+                if err != nil {
+                        return nil, err
+                }
+                reviews = append(reviews, review)
+        }
+
+        if err := rows.Err(); err != nil {
+                return nil, err
+        }
+        return reviews, nil
+}
+
 // sanitizeSortField ensures sort field is safe and exists in the database
 func sanitizeSortField(field string) string {
         // List of allowed sort fields
@@ -608,8 +1066,8 @@ func sanitizeSortField(field string) string {
 // CreateUser creates a new user in the database
 func (r *PostgresRepository) CreateUser(ctx context.Context, user *model.User) error {
         query := `
-                INSERT INTO users (id, username, email, password_hash, created_at, updated_at)
-                VALUES ($1, $2, $3, $4, $5, $6)
+                INSERT INTO users (id, username, email, password_hash, email_verified_at, created_at, updated_at)
+                VALUES ($1, $2, $3, $4, $5, $6, $7)
         `
         _, err := r.execWithContext(
                 ctx,
@@ -618,6 +1076,7 @@ func (r *PostgresRepository) CreateUser(ctx context.Context, user *model.User) e
                 user.Username,
                 user.Email,
                 user.PasswordHash,
+                user.EmailVerifiedAt,
                 user.CreatedAt,
                 user.UpdatedAt,
         )
@@ -643,7 +1102,7 @@ func (r *PostgresRepository) CreateUser(ctx context.Context, user *model.User) e
 // GetUserByID retrieves a user by ID
 func (r *PostgresRepository) GetUserByID(ctx context.Context, id uuid.UUID) (*model.User, error) {
         query := `
-                SELECT id, username, email, password_hash, created_at, updated_at
+                SELECT id, username, email, password_hash, email_verified_at, locked_until, created_at, updated_at
                 FROM users
                 WHERE id = $1
         `
@@ -655,6 +1114,8 @@ func (r *PostgresRepository) GetUserByID(ctx context.Context, id uuid.UUID) (*mo
                 &user.Username,
                 &user.Email,
                 &user.PasswordHash,
+                &user.EmailVerifiedAt,
+                &user.LockedUntil,
                 &user.CreatedAt,
                 &user.UpdatedAt,
         )
@@ -670,7 +1131,7 @@ func (r *PostgresRepository) GetUserByID(ctx context.Context, id uuid.UUID) (*mo
 // GetUserByEmail retrieves a user by email
 func (r *PostgresRepository) GetUserByEmail(ctx context.Context, email string) (*model.User, error) {
         query := `
-                SELECT id, username, email, password_hash, created_at, updated_at
+                SELECT id, username, email, password_hash, email_verified_at, locked_until, created_at, updated_at
                 FROM users
                 WHERE email = $1
         `
@@ -682,6 +1143,8 @@ func (r *PostgresRepository) GetUserByEmail(ctx context.Context, email string) (
                 &user.Username,
                 &user.Email,
                 &user.PasswordHash,
+                &user.EmailVerifiedAt,
+                &user.LockedUntil,
                 &user.CreatedAt,
                 &user.UpdatedAt,
         )
@@ -697,7 +1160,7 @@ func (r *PostgresRepository) GetUserByEmail(ctx context.Context, email string) (
 // GetUserByUsername retrieves a user by username
 func (r *PostgresRepository) GetUserByUsername(ctx context.Context, username string) (*model.User, error) {
         query := `
-                SELECT id, username, email, password_hash, created_at, updated_at
+                SELECT id, username, email, password_hash, email_verified_at, locked_until, created_at, updated_at
                 FROM users
                 WHERE username = $1
         `
@@ -709,6 +1172,8 @@ func (r *PostgresRepository) GetUserByUsername(ctx context.Context, username str
                 &user.Username,
                 &user.Email,
                 &user.PasswordHash,
+                &user.EmailVerifiedAt,
+                &user.LockedUntil,
                 &user.CreatedAt,
                 &user.UpdatedAt,
         )
@@ -7,6 +7,7 @@ import (
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 
@@ -38,7 +39,7 @@ func TestCreateRating(t *testing.T) {
 	rating, _ := model.NewRating(uuid.New(), uuid.New(), 5)
 
 	mock.ExpectExec("INSERT INTO ratings").
-		WithArgs(rating.ID, rating.UserID, rating.ServiceID, rating.Score, rating.CreatedAt, rating.UpdatedAt).
+		WithArgs(rating.ID, rating.UserID, rating.ServiceID, rating.Score, rating.Shadowed, rating.CreatedAt, rating.UpdatedAt).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
 	err := repo.CreateRating(ctx, rating)
@@ -48,6 +49,113 @@ func TestCreateRating(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestCreateRatingWithAttestation(t *testing.T) {
+	repo, mock := setupMock(t)
+	ctx := context.Background()
+
+	rating, _ := model.NewRating(uuid.New(), uuid.New(), 5)
+	attestation := testAttestation(rating)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO ratings").
+		WithArgs(rating.ID, rating.UserID, rating.ServiceID, rating.Score, rating.Shadowed, rating.CreatedAt, rating.UpdatedAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO attestations").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("UPDATE ratings SET verified = TRUE").
+		WithArgs(attestation.VerifiedAt, rating.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err := repo.CreateRatingWithAttestation(ctx, rating, attestation)
+	assert.NoError(t, err)
+
+	err = mock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestUpdateRatingWithReusedAttestation(t *testing.T) {
+	repo, mock := setupMock(t)
+	ctx := context.Background()
+
+	rating, _ := model.NewRating(uuid.New(), uuid.New(), 2)
+	rating.UpdateScore(5)
+	attestation := testAttestation(rating)
+
+	// The new score is written inside the transaction, which is rolled back rather than committed
+	// when the attestation token has already been redeemed, so the stored score is unchanged
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE ratings SET score").
+		WithArgs(5, rating.UpdatedAt, rating.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO attestations").
+		WillReturnError(&pq.Error{Code: "23505"})
+	mock.ExpectRollback()
+
+	err := repo.UpdateRatingWithAttestation(ctx, rating, attestation)
+	assert.ErrorIs(t, err, model.ErrAttestationReused)
+
+	err = mock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestCreateReviewWithReusedAttestation(t *testing.T) {
+	repo, mock := setupMock(t)
+	ctx := context.Background()
+
+	rating, _ := model.NewRating(uuid.New(), uuid.New(), 4)
+	review, _ := model.NewReview(rating.UserID, rating.ServiceID, rating.ID, "Title", "Content")
+	attestation := testAttestation(rating)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO reviews").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO attestations").
+		WillReturnError(&pq.Error{Code: "23505"})
+	mock.ExpectRollback()
+
+	err := repo.CreateReviewWithAttestation(ctx, review, attestation)
+	assert.ErrorIs(t, err, model.ErrAttestationReused)
+
+	err = mock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestCreateReviewWithAttestationFailedInsert(t *testing.T) {
+	repo, mock := setupMock(t)
+	ctx := context.Background()
+
+	rating, _ := model.NewRating(uuid.New(), uuid.New(), 4)
+	review, _ := model.NewReview(rating.UserID, rating.ServiceID, rating.ID, "Title", "Content")
+	attestation := testAttestation(rating)
+
+	// A review that cannot be saved must not consume the attestation
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO reviews").
+		WillReturnError(&pq.Error{Code: "23505"})
+	mock.ExpectRollback()
+
+	err := repo.CreateReviewWithAttestation(ctx, review, attestation)
+	assert.Error(t, err)
+
+	err = mock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func testAttestation(rating *model.Rating) *model.Attestation {
+	return &model.Attestation{
+		ID:         uuid.New(),
+		TokenID:    "token-1",
+		RatingID:   rating.ID,
+		UserID:     rating.UserID,
+		ServiceID:  rating.ServiceID,
+		OrderID:    "order-1",
+		Algorithm:  "HS256",
+		ExpiresAt:  time.Now().Add(time.Hour),
+		VerifiedAt: time.Now(),
+	}
+}
+
 func TestGetRatingByID(t *testing.T) {
 	repo, mock := setupMock(t)
 	ctx := context.Background()
@@ -58,14 +166,14 @@ func TestGetRatingByID(t *testing.T) {
 	score := 4
 	now := time.Now()
 
-	rows := sqlmock.NewRows([]string{"id", "user_id", "service_id", "score", "created_at", "updated_at"}).
-		AddRow(ratingID, userID, serviceID, score, now, now)
+	rows := sqlmock.NewRows([]string{"id", "user_id", "service_id", "score", "verified", "verified_at", "created_at", "updated_at"}).
+		AddRow(ratingID, userID, serviceID, score, false, nil, now, now)
 
 	mock.ExpectQuery("SELECT (.+) FROM ratings WHERE id = (.+)").
 		WithArgs(ratingID).
 		WillReturnRows(rows)
 
-	rating, err := repo.GetRatingByID(ctx, ratingID)
+	rating, err := repo.GetRatingByID(ctx, ratingID, model.SystemViewer)
 	assert.NoError(t, err)
 	assert.Equal(t, ratingID, rating.ID)
 	assert.Equal(t, userID, rating.UserID)
@@ -88,10 +196,10 @@ func TestCalculateAverageRating(t *testing.T) {
 		AddRow(avgScore, totalRatings)
 
 	mock.ExpectQuery("SELECT AVG\\(score\\) AS average_score, COUNT\\(\\*\\) AS total_ratings FROM ratings WHERE service_id = (.+)").
-		WithArgs(serviceID).
+		WithArgs(serviceID, false).
 		WillReturnRows(rows)
 
-	avgRating, err := repo.CalculateAverageRating(ctx, serviceID)
+	avgRating, err := repo.CalculateAverageRating(ctx, serviceID, model.Viewer{}, model.RatingFilter{})
 	assert.NoError(t, err)
 	assert.Equal(t, serviceID, avgRating.ServiceID)
 	assert.Equal(t, avgScore, avgRating.AverageScore)
@@ -116,27 +224,26 @@ func TestGetReviewsByService(t *testing.T) {
 
 	// Mock count query
 	countRows := sqlmock.NewRows([]string{"count"}).AddRow(1)
-	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM reviews WHERE service_id = (.+)").
-		WithArgs(serviceID).
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM reviews r JOIN ratings rt ON r.rating_id = rt.id WHERE r.service_id = (.+)").
+		WithArgs(serviceID, false).
 		WillReturnRows(countRows)
 
 	// Mock data query
 	rows := sqlmock.NewRows([]string{
-		"id", "user_id", "service_id", "rating_id", "title", "content", "created_at", "updated_at", "score",
+		"id", "user_id", "service_id", "rating_id", "title", "content", "status", "moderation_status", "sentiment_score",
+		"publish_at", "published_at", "shadowed", "created_at", "updated_at", "score", "verified", "comment_count",
 	}).AddRow(
-		reviewID, userID, serviceID, ratingID, title, content, now, now, score,
+		reviewID, userID, serviceID, ratingID, title, content, "published", "visible", nil,
+		nil, now, false, now, now, score, false, 0,
 	)
 
-	mock.ExpectQuery("SELECT r.id, r.user_id, r.service_id, r.rating_id, r.title, r.content, r.created_at, r.updated_at, rt.score FROM reviews r").
-		WithArgs(serviceID, 10, 0).
+	mock.ExpectQuery("SELECT r.id, r.user_id, r.service_id, r.rating_id, r.title, r.content, (.+) FROM reviews r").
+		WithArgs(serviceID, false, 10, 0).
 		WillReturnRows(rows)
 
-	params := pagination.Params{
-		Limit:  10,
-		Offset: 0,
-	}
+	params := pagination.NewParamsWithOffset(10, 0, "", "")
 
-	reviews, total, err := repo.GetReviewsByService(ctx, serviceID, params)
+	reviews, total, err := repo.GetReviewsByService(ctx, serviceID, model.Viewer{}, model.ReviewFilter{}, params)
 	assert.NoError(t, err)
 	assert.Equal(t, 1, total)
 	assert.Len(t, reviews, 1)
@@ -155,7 +262,7 @@ func TestCreateComment(t *testing.T) {
 	comment, _ := model.NewComment(uuid.New(), uuid.New(), "Test comment")
 
 	mock.ExpectExec("INSERT INTO comments").
-		WithArgs(comment.ID, comment.UserID, comment.ReviewID, comment.Content, comment.CreatedAt, comment.UpdatedAt).
+		WithArgs(comment.ID, comment.UserID, comment.ReviewID, nil, comment.ThreadID, comment.Depth, comment.Content, comment.Shadowed, comment.CreatedAt, comment.UpdatedAt).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
 	err := repo.CreateComment(ctx, comment)
@@ -164,3 +271,72 @@ func TestCreateComment(t *testing.T) {
 	err = mock.ExpectationsWereMet()
 	assert.NoError(t, err)
 }
+
+func TestGetCommentThreadsWithheldRoot(t *testing.T) {
+	repo, mock := setupMock(t)
+	ctx := context.Background()
+
+	// The viewer blocked the author of a thread that someone else replied to
+	viewer := model.ViewerOf(uuid.New())
+	root, _ := model.NewComment(uuid.New(), uuid.New(), "From a blocked user")
+	reply, _ := model.NewComment(uuid.New(), root.ReviewID, "Reply")
+	assert.NoError(t, reply.ReplyTo(root, 0))
+	params := pagination.NewParams(1, 10, "", "")
+
+	columns := []string{
+		"id", "user_id", "review_id", "parent_id", "thread_id", "depth", "content", "deleted", "deleted_at",
+		"moderation_status", "shadowed", "created_at", "updated_at", "withheld",
+	}
+
+	// Withheld roots are kept, and counted, when a reply in their thread is visible
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM comments c WHERE c.review_id = \\$1 AND c.parent_id IS NULL AND \\(c.moderation_status IN (.+) OR EXISTS \\(SELECT 1 FROM comments v WHERE v.thread_id = c.id").
+		WithArgs(root.ReviewID).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery("SELECT c.id, (.+) FROM comments c WHERE c.review_id = \\$1 AND c.parent_id IS NULL AND \\(c.moderation_status IN (.+) OR EXISTS (.+) LIMIT \\$2 OFFSET \\$3").
+		WithArgs(root.ReviewID, params.GetLimit(), params.GetOffset()).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(root.ID, root.UserID, root.ReviewID, nil, root.ThreadID, 0, root.Content,
+			false, nil, "visible", false, root.CreatedAt, root.UpdatedAt, true))
+	mock.ExpectQuery("SELECT c.id, (.+) FROM comments c WHERE c.thread_id = ANY\\(\\$1\\) AND c.parent_id IS NOT NULL").
+		WithArgs(pq.Array([]string{root.ID.String()})).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(reply.ID, reply.UserID, reply.ReviewID, root.ID, root.ID, 1, reply.Content,
+			false, nil, "visible", false, reply.CreatedAt, reply.UpdatedAt, false))
+
+	threads, total, err := repo.GetCommentThreads(ctx, root.ReviewID, viewer, params)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, total)
+	replies, err := repo.GetCommentReplies(ctx, []uuid.UUID{root.ID}, viewer)
+	assert.NoError(t, err)
+
+	tree := model.BuildCommentTree(threads, replies)
+	if assert.Len(t, tree, 1) {
+		assert.True(t, tree[0].Withheld)
+		assert.Empty(t, tree[0].Content)
+		if assert.Len(t, tree[0].Replies, 1) {
+			assert.Equal(t, reply.ID, tree[0].Replies[0].ID)
+			assert.Equal(t, "Reply", tree[0].Replies[0].Content)
+		}
+	}
+
+	err = mock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestApplyModerationDecisionConflict(t *testing.T) {
+	repo, mock := setupMock(t)
+	ctx := context.Background()
+
+	// Another moderator hid the review after this decision read it as pending review
+	decision, _ := model.NewModerationDecision(uuid.New(), model.ReportTargetReview, uuid.New(), model.ModerationActionApprove, "Fine", model.ModerationPendingReview)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE reviews SET moderation_status = \\$1 WHERE id = \\$2 AND moderation_status = \\$3").
+		WithArgs(decision.Status, decision.TargetID, model.ModerationPendingReview).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	err := repo.ApplyModerationDecision(ctx, decision)
+	assert.ErrorIs(t, err, model.ErrModerationConflict)
+
+	err = mock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
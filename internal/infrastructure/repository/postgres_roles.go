@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"rating-system/internal/domain/model"
+)
+
+// GetUserRoles retrieves the roles granted to a user, oldest grant first
+func (r *PostgresRepository) GetUserRoles(ctx context.Context, userID uuid.UUID) ([]*model.RoleGrant, error) {
+	query := `
+		SELECT user_id, role, granted_by, created_at
+		FROM user_roles
+		WHERE user_id = $1
+		ORDER BY created_at ASC, role ASC
+	`
+	rows, err := r.queryWithContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var grants []*model.RoleGrant
+	for rows.Next() {
+		var grant model.RoleGrant
+		if err := rows.Scan(&grant.UserID, &grant.Role, &grant.GrantedBy, &grant.CreatedAt); err != nil {
+			return nil, err
+		}
+		grants = append(grants, &grant)
+	}
+	return grants, rows.Err()
+}
+
+// GrantRole stores a grant of a role to a user
+func (r *PostgresRepository) GrantRole(ctx context.Context, grant *model.RoleGrant) error {
+	query := `
+		INSERT INTO user_roles (user_id, role, granted_by, created_at)
+		VALUES ($1, $2, $3, $4)
+	`
+	_, err := r.execWithContext(ctx, query, grant.UserID, grant.Role, grant.GrantedBy, grant.CreatedAt)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" { // unique_violation
+			return model.ErrRoleAlreadyGranted
+		}
+		return err
+	}
+	return nil
+}
+
+// RevokeRole removes a role from a user, refusing to remove the last admin. The admin grants are
+// locked while they are counted, so concurrent revocations cannot both see another admin left.
+func (r *PostgresRepository) RevokeRole(ctx context.Context, userID uuid.UUID, role model.Role) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if role == model.RoleAdmin {
+		var admins int
+		query := `SELECT COUNT(*) FROM (SELECT 1 FROM user_roles WHERE role = $1 FOR UPDATE) admins`
+		if err := tx.QueryRowContext(ctx, query, role).Scan(&admins); err != nil {
+			return err
+		}
+		if admins <= 1 {
+			return model.ErrLastAdmin
+		}
+	}
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM user_roles WHERE user_id = $1 AND role = $2`, userID, role)
+	if err != nil {
+		return err
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return model.ErrRoleNotGranted
+	}
+	return tx.Commit()
+}
+
+// CountUsersWithRole counts the users holding a role
+func (r *PostgresRepository) CountUsersWithRole(ctx context.Context, role model.Role) (int, error) {
+	var count int
+	err := r.queryRowWithContext(ctx, `SELECT COUNT(*) FROM user_roles WHERE role = $1`, role).Scan(&count)
+	return count, err
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"rating-system/internal/domain/model"
+	"rating-system/pkg/pagination"
+)
+
+// CreateShadowBan stores a shadow-ban of a user
+func (r *PostgresRepository) CreateShadowBan(ctx context.Context, ban *model.ShadowBan) error {
+	query := `
+		INSERT INTO shadow_bans (user_id, banned_by, reason, created_at)
+		VALUES ($1, $2, $3, $4)
+	`
+	_, err := r.execWithContext(ctx, query, ban.UserID, ban.BannedBy, ban.Reason, ban.CreatedAt)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" { // unique_violation
+			return model.ErrAlreadyShadowBanned
+		}
+		return err
+	}
+	return nil
+}
+
+// DeleteShadowBan lifts a user's shadow-ban
+func (r *PostgresRepository) DeleteShadowBan(ctx context.Context, userID uuid.UUID) error {
+	result, err := r.execWithContext(ctx, `DELETE FROM shadow_bans WHERE user_id = $1`, userID)
+	if err != nil {
+		return err
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return model.ErrNotShadowBanned
+	}
+	return nil
+}
+
+// IsShadowBanned reports whether a user is shadow-banned
+func (r *PostgresRepository) IsShadowBanned(ctx context.Context, userID uuid.UUID) (bool, error) {
+	var banned bool
+	err := r.queryRowWithContext(ctx, `SELECT EXISTS (SELECT 1 FROM shadow_bans WHERE user_id = $1)`, userID).Scan(&banned)
+	return banned, err
+}
+
+// GetShadowBans retrieves shadow-bans, newest first
+func (r *PostgresRepository) GetShadowBans(ctx context.Context, params pagination.Params) ([]*model.ShadowBan, int, error) {
+	var total int
+	if err := r.queryRowWithContext(ctx, `SELECT COUNT(*) FROM shadow_bans`).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+		SELECT user_id, banned_by, reason, created_at
+		FROM shadow_bans
+		ORDER BY created_at DESC, user_id DESC
+		LIMIT $1 OFFSET $2
+	`
+	rows, err := r.queryWithContext(ctx, query, params.GetLimit(), params.GetOffset())
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var bans []*model.ShadowBan
+	for rows.Next() {
+		var ban model.ShadowBan
+		if err := rows.Scan(&ban.UserID, &ban.BannedBy, &ban.Reason, &ban.CreatedAt); err != nil {
+			return nil, 0, err
+		}
+		bans = append(bans, &ban)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	return bans, total, nil
+}
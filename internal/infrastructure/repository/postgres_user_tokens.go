@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+
+	"rating-system/internal/domain/model"
+)
+
+// CreateUserToken stores a single-use user token
+func (r *PostgresRepository) CreateUserToken(ctx context.Context, token *model.UserToken) error {
+	query := `
+		INSERT INTO user_tokens (id, user_id, purpose, token_hash, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	_, err := r.execWithContext(ctx, query, token.ID, token.UserID, token.Purpose, token.TokenHash, token.ExpiresAt, token.CreatedAt)
+	return err
+}
+
+// GetUserTokenByHash retrieves a user token by its purpose and the hash of its value
+func (r *PostgresRepository) GetUserTokenByHash(ctx context.Context, purpose model.TokenPurpose, tokenHash string) (*model.UserToken, error) {
+	query := `
+		SELECT id, user_id, purpose, token_hash, expires_at, used_at, created_at
+		FROM user_tokens
+		WHERE purpose = $1 AND token_hash = $2
+	`
+	var token model.UserToken
+	err := r.queryRowWithContext(ctx, query, purpose, tokenHash).Scan(
+		&token.ID,
+		&token.UserID,
+		&token.Purpose,
+		&token.TokenHash,
+		&token.ExpiresAt,
+		&token.UsedAt,
+		&token.CreatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, model.ErrUserTokenNotFound
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
+// CountUserTokensSince counts the tokens issued to a user for a purpose since a time
+func (r *PostgresRepository) CountUserTokensSince(ctx context.Context, userID uuid.UUID, purpose model.TokenPurpose, since time.Time) (int, error) {
+	query := `
+		SELECT COUNT(*) FROM user_tokens
+		WHERE user_id = $1 AND purpose = $2 AND created_at >= $3
+	`
+	var count int
+	if err := r.queryRowWithContext(ctx, query, userID, purpose, since).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// VerifyEmail marks a verification token as used and the user's email as verified in one
+// transaction
+func (r *PostgresRepository) VerifyEmail(ctx context.Context, tokenID, userID uuid.UUID, at time.Time) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE user_tokens SET used_at = $1
+		WHERE id = $2 AND used_at IS NULL
+	`, at, tokenID)
+	if err != nil {
+		return err
+	}
+	used, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if used == 0 {
+		return model.ErrUserTokenNotFound
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE users SET email_verified_at = COALESCE(email_verified_at, $1), updated_at = $1
+		WHERE id = $2
+	`, at, userID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ResetPassword marks a reset token as used, voids the user's other reset tokens and sets their
+// password in one transaction. Receiving the reset email proves the user owns the address, so it
+// is marked verified too, and any lockout ends.
+func (r *PostgresRepository) ResetPassword(ctx context.Context, tokenID, userID uuid.UUID, passwordHash string, at time.Time) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE user_tokens SET used_at = $1
+		WHERE id = $2 AND used_at IS NULL
+	`, at, tokenID)
+	if err != nil {
+		return err
+	}
+	used, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if used == 0 {
+		return model.ErrUserTokenNotFound
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE user_tokens SET used_at = $1
+		WHERE user_id = $2 AND purpose = $3 AND used_at IS NULL
+	`, at, userID, model.TokenPurposePasswordReset); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE users
+		SET password_hash = $1, email_verified_at = COALESCE(email_verified_at, $2), locked_until = NULL, updated_at = $2
+		WHERE id = $3
+	`, passwordHash, at, userID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
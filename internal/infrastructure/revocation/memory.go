@@ -0,0 +1,109 @@
+// Package revocation keeps track of revoked access tokens. Access tokens are stateless, so a
+// revoked token is remembered until it would have expired anyway.
+package revocation
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// sweepInterval is how often the memory store drops entries that have expired
+const sweepInterval = time.Minute
+
+// generation is a cached token generation of a user
+type generation struct {
+	value   int
+	expires time.Time
+}
+
+// MemoryStore keeps revoked tokens and cached token generations in process memory. Each instance
+// of the service keeps its own, so a token revoked on one instance stays valid on the others, and
+// a generation bumped on one is only seen by the others once their cached copy expires.
+type MemoryStore struct {
+	mu          sync.Mutex
+	revoked     map[string]time.Time
+	generations map[uuid.UUID]generation
+	lastSweep   time.Time
+	now         func() time.Time
+}
+
+// NewMemoryStore creates an empty in-process store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		revoked:     make(map[string]time.Time),
+		generations: make(map[uuid.UUID]generation),
+		now:         time.Now,
+	}
+}
+
+// RevokeToken implements port.TokenRevocationStore
+func (s *MemoryStore) RevokeToken(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.maybeSweep()
+	if expiresAt.After(s.revoked[tokenID]) {
+		s.revoked[tokenID] = expiresAt
+	}
+	return nil
+}
+
+// IsTokenRevoked implements port.TokenRevocationStore
+func (s *MemoryStore) IsTokenRevoked(ctx context.Context, tokenID string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	expiresAt, ok := s.revoked[tokenID]
+	return ok && expiresAt.After(s.now()), nil
+}
+
+// Generation implements port.TokenRevocationStore
+func (s *MemoryStore) Generation(ctx context.Context, userID uuid.UUID) (int, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	g, ok := s.generations[userID]
+	if !ok || !g.expires.After(s.now()) {
+		return 0, false, nil
+	}
+	return g.value, true, nil
+}
+
+// SetGeneration implements port.TokenRevocationStore
+func (s *MemoryStore) SetGeneration(ctx context.Context, userID uuid.UUID, value int, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.maybeSweep()
+	s.generations[userID] = generation{value: value, expires: s.now().Add(ttl)}
+	return nil
+}
+
+// Len returns the number of revoked tokens and cached generations held
+func (s *MemoryStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.revoked) + len(s.generations)
+}
+
+// maybeSweep drops expired entries if the last sweep was long enough ago
+func (s *MemoryStore) maybeSweep() {
+	now := s.now()
+	if now.Sub(s.lastSweep) < sweepInterval {
+		return
+	}
+	for tokenID, expiresAt := range s.revoked {
+		if !expiresAt.After(now) {
+			delete(s.revoked, tokenID)
+		}
+	}
+	for userID, g := range s.generations {
+		if !g.expires.After(now) {
+			delete(s.generations, userID)
+		}
+	}
+	s.lastSweep = now
+}
@@ -0,0 +1,60 @@
+package revocation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStoreRevokeToken(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	store := NewMemoryStore()
+	store.now = func() time.Time { return now }
+
+	require.NoError(t, store.RevokeToken(ctx, "a", now.Add(time.Minute)))
+
+	revoked, err := store.IsTokenRevoked(ctx, "a")
+	require.NoError(t, err)
+	assert.True(t, revoked)
+
+	revoked, err = store.IsTokenRevoked(ctx, "b")
+	require.NoError(t, err)
+	assert.False(t, revoked)
+
+	// Once the token has expired it no longer needs to be remembered
+	now = now.Add(2 * time.Minute)
+	revoked, err = store.IsTokenRevoked(ctx, "a")
+	require.NoError(t, err)
+	assert.False(t, revoked)
+
+	require.NoError(t, store.RevokeToken(ctx, "c", now.Add(time.Minute)))
+	assert.Equal(t, 1, store.Len())
+}
+
+func TestMemoryStoreGeneration(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	store := NewMemoryStore()
+	store.now = func() time.Time { return now }
+	userID := uuid.New()
+
+	_, ok, err := store.Generation(ctx, userID)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	require.NoError(t, store.SetGeneration(ctx, userID, 3, time.Minute))
+	generation, ok, err := store.Generation(ctx, userID)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, 3, generation)
+
+	now = now.Add(2 * time.Minute)
+	_, ok, err = store.Generation(ctx, userID)
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
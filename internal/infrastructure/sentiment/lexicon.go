@@ -0,0 +1,159 @@
+package sentiment
+
+import (
+	"context"
+	"math"
+	"strings"
+	"unicode"
+)
+
+const (
+	// normalizationAlpha controls how quickly the summed valence approaches ±1
+	normalizationAlpha = 15.0
+	// negationScalar flips and dampens the valence of words following a negator
+	negationScalar = -0.74
+	// negationWindow is the number of following tokens affected by a negator
+	negationWindow = 3
+	// contrastWeight scales the clause after "but" up and the clause before it down
+	contrastWeight = 1.5
+)
+
+// defaultLexicon maps words to a valence between -4 and 4
+var defaultLexicon = map[string]float64{
+	// Positive
+	"amazing": 3.1, "awesome": 3.1, "excellent": 3.2, "exceptional": 3.0, "fantastic": 3.3,
+	"outstanding": 3.2, "perfect": 3.0, "superb": 3.1, "wonderful": 3.0, "brilliant": 2.9,
+	"great": 2.6, "love": 3.0, "loved": 2.9, "loves": 2.7, "best": 3.0, "delighted": 2.9,
+	"good": 1.9, "nice": 1.8, "friendly": 1.8, "helpful": 1.9, "happy": 2.2, "pleased": 2.0,
+	"recommend": 1.8, "recommended": 1.8, "reliable": 1.7, "fast": 1.2, "quick": 1.2,
+	"professional": 1.6, "clean": 1.3, "polite": 1.6, "satisfied": 1.8, "enjoyed": 2.1,
+	"easy": 1.3, "smooth": 1.4, "fair": 1.0, "fine": 0.8, "ok": 0.6, "okay": 0.6,
+	"worth": 1.2, "efficient": 1.6, "courteous": 1.7, "impressed": 2.2, "thanks": 1.5,
+	"thank": 1.5, "affordable": 1.2, "responsive": 1.4, "quality": 0.9,
+
+	// Negative
+	"terrible": -3.2, "horrible": -3.2, "awful": -3.1, "worst": -3.4, "disgusting": -3.0,
+	"appalling": -3.1, "atrocious": -3.3, "useless": -2.6, "scam": -3.0, "hate": -3.0,
+	"hated": -2.9, "bad": -2.5, "poor": -2.1, "rude": -2.3, "slow": -1.4, "late": -1.2,
+	"dirty": -1.9, "broken": -1.9, "disappointed": -2.2, "disappointing": -2.2,
+	"unprofessional": -2.2, "unreliable": -2.0, "overpriced": -1.8, "expensive": -1.0,
+	"annoying": -1.9, "frustrating": -2.1, "frustrated": -2.0, "angry": -2.3, "waste": -2.2,
+	"wasted": -2.2, "avoid": -2.0, "problem": -1.4, "problems": -1.4,
+	"issue": -1.0, "issues": -1.0, "mediocre": -1.3, "unhelpful": -2.0, "ignored": -1.8,
+	"refund": -0.8, "complaint": -1.6, "mess": -1.8, "fail": -2.2, "failed": -2.2,
+	"nightmare": -3.0, "unacceptable": -2.6, "regret": -2.2, "wrong": -1.5,
+}
+
+// negators invert the valence of the words that follow them
+var negators = map[string]bool{
+	"not": true, "no": true, "never": true, "nothing": true, "nobody": true, "none": true,
+	"neither": true, "nor": true, "without": true, "hardly": true, "barely": true,
+	"cannot": true, "dont": true, "doesnt": true, "didnt": true, "isnt": true, "wasnt": true,
+	"arent": true, "werent": true, "wont": true, "wouldnt": true, "cant": true, "couldnt": true,
+	"shouldnt": true, "aint": true,
+}
+
+// boosters scale the valence of the next sentiment word
+var boosters = map[string]float64{
+	"very": 1.3, "really": 1.3, "extremely": 1.5, "incredibly": 1.5, "absolutely": 1.5,
+	"so": 1.2, "super": 1.3, "truly": 1.3, "totally": 1.3, "completely": 1.4,
+	"slightly": 0.6, "somewhat": 0.7, "kinda": 0.7, "fairly": 0.8, "quite": 1.1,
+}
+
+// LexiconAnalyzer scores text offline with a weighted word list, handling negation,
+// intensifiers and "but" contrast in the style of rule-based analyzers such as VADER
+type LexiconAnalyzer struct {
+	lexicon map[string]float64
+}
+
+// NewLexiconAnalyzer creates an analyzer using the built-in English lexicon.
+// Entries in overrides replace or extend the built-in valences.
+func NewLexiconAnalyzer(overrides map[string]float64) *LexiconAnalyzer {
+	lexicon := make(map[string]float64, len(defaultLexicon)+len(overrides))
+	for word, valence := range defaultLexicon {
+		lexicon[word] = valence
+	}
+	for word, valence := range overrides {
+		lexicon[strings.ToLower(word)] = valence
+	}
+	return &LexiconAnalyzer{lexicon: lexicon}
+}
+
+// Analyze returns the sentiment of text in [-1, 1]; text without sentiment words scores 0
+func (a *LexiconAnalyzer) Analyze(ctx context.Context, text string) (float64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	var sum float64
+	for _, clause := range splitClauses(text) {
+		sum += a.scoreTokens(tokenize(clause.text)) * clause.weight
+	}
+
+	if sum == 0 {
+		return 0, nil
+	}
+	return sum / math.Sqrt(sum*sum+normalizationAlpha), nil
+}
+
+// scoreTokens sums the valence of a clause's tokens
+func (a *LexiconAnalyzer) scoreTokens(tokens []string) float64 {
+	var sum float64
+	negatedFor := 0
+	boost := 1.0
+
+	for _, token := range tokens {
+		if negators[token] {
+			negatedFor = negationWindow
+			continue
+		}
+		if scale, ok := boosters[token]; ok {
+			boost *= scale
+			continue
+		}
+
+		if valence, ok := a.lexicon[token]; ok {
+			valence *= boost
+			if negatedFor > 0 {
+				valence *= negationScalar
+			}
+			sum += valence
+			boost = 1.0
+		}
+
+		if negatedFor > 0 {
+			negatedFor--
+		}
+	}
+	return sum
+}
+
+type clause struct {
+	text   string
+	weight float64
+}
+
+// splitClauses splits text on "but" so the contrasting clause dominates, e.g. "good food but rude staff"
+func splitClauses(text string) []clause {
+	lower := strings.ToLower(text)
+	idx := -1
+	for _, sep := range []string{" but ", " however "} {
+		if i := strings.LastIndex(lower, sep); i > idx {
+			idx = i
+		}
+	}
+	if idx < 0 {
+		return []clause{{text: lower, weight: 1}}
+	}
+	return []clause{
+		{text: lower[:idx], weight: 1 / contrastWeight},
+		{text: lower[idx:], weight: contrastWeight},
+	}
+}
+
+// tokenize lower-cases text and splits it into words, folding contractions such as "don't" into "dont"
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.NewReplacer("'", "", "’", "").Replace(strings.ToLower(text)), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+}
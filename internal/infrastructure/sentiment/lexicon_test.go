@@ -0,0 +1,57 @@
+package sentiment
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func analyze(t *testing.T, a *LexiconAnalyzer, text string) float64 {
+	score, err := a.Analyze(context.Background(), text)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, score, -1.0)
+	assert.LessOrEqual(t, score, 1.0)
+	return score
+}
+
+func TestLexiconAnalyzerPolarity(t *testing.T) {
+	a := NewLexiconAnalyzer(nil)
+
+	assert.Greater(t, analyze(t, a, "Excellent service, friendly and professional staff. Highly recommend!"), 0.5)
+	assert.Less(t, analyze(t, a, "Terrible experience. Rude staff and a total waste of money."), -0.5)
+	assert.Equal(t, 0.0, analyze(t, a, "We visited on a Tuesday afternoon."))
+	assert.Equal(t, 0.0, analyze(t, a, ""))
+}
+
+func TestLexiconAnalyzerNegation(t *testing.T) {
+	a := NewLexiconAnalyzer(nil)
+
+	assert.Greater(t, analyze(t, a, "good"), 0.0)
+	assert.Less(t, analyze(t, a, "not good"), 0.0)
+	assert.Less(t, analyze(t, a, "It wasn't good at all"), 0.0)
+	assert.Greater(t, analyze(t, a, "Honestly not bad"), 0.0)
+}
+
+func TestLexiconAnalyzerBoostersAndContrast(t *testing.T) {
+	a := NewLexiconAnalyzer(nil)
+
+	assert.Greater(t, analyze(t, a, "very good"), analyze(t, a, "good"))
+	assert.Less(t, analyze(t, a, "slightly good"), analyze(t, a, "good"))
+	assert.Less(t, analyze(t, a, "The food was great but the staff were rude"), 0.0)
+}
+
+func TestLexiconAnalyzerOverrides(t *testing.T) {
+	a := NewLexiconAnalyzer(map[string]float64{"Meh": -1.5})
+
+	assert.Less(t, analyze(t, a, "meh"), 0.0)
+}
+
+func TestLexiconAnalyzerCancelledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := NewLexiconAnalyzer(nil).Analyze(ctx, "great")
+	assert.ErrorIs(t, err, context.Canceled)
+}
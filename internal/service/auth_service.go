@@ -3,9 +3,16 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
+	"golang.org/x/crypto/bcrypt"
 
 	"rating-system/internal/domain/model"
 	"rating-system/internal/domain/port"
@@ -17,92 +24,865 @@ var (
 	ErrInvalidCredentials = errors.New("invalid credentials")
 	ErrUserAlreadyExists  = errors.New("user already exists")
 	ErrInvalidToken       = errors.New("invalid token")
+	ErrTokenRevoked       = errors.New("token revoked")
+	ErrUserNotFound       = errors.New("user not found")
 )
 
+// RefreshTokenDuration is the default lifetime of refresh tokens. Each refresh issues a successor
+// with a full lifetime, so sessions in use stay alive.
+const RefreshTokenDuration = 30 * 24 * time.Hour
+
+// generationCacheTTL is how long a user's token generation is cached in the revocation store. An
+// instance that did not revoke a user's sessions itself may accept their old tokens for this long
+// unless the store is shared.
+const generationCacheTTL = time.Minute
+
+// Defaults for email verification: how long a verification link stays valid, how long a user must
+// wait before requesting another, and how many they can request a day
+const (
+	EmailVerificationDuration  = 24 * time.Hour
+	verificationResendInterval = time.Minute
+	verificationDailyLimit     = 5
+	defaultVerificationURL     = "http://localhost:8080/verify-email"
+)
+
+// Defaults for password reset: reset links are short-lived, and a user is sent at most one a
+// minute and a few a day whoever asks for them
+const (
+	PasswordResetDuration   = 30 * time.Minute
+	passwordResetInterval   = time.Minute
+	passwordResetDailyLimit = 5
+	defaultPasswordResetURL = "http://localhost:8080/reset-password"
+)
+
+// passwordResetSendTimeout bounds sending a reset email, which happens after the request has been
+// answered
+const passwordResetSendTimeout = 30 * time.Second
+
+// apiKeyTouchInterval is how often the last use of an API key is recorded, so busy keys do not
+// cause a write on every request
+const apiKeyTouchInterval = time.Minute
+
 // AuthService implements the AuthService interface
 type AuthService struct {
-	repository port.Repository
-	jwtService *auth.JWTService
-	log        *logrus.Logger
+	repository  port.Repository
+	revocations port.TokenRevocationStore
+	attempts    port.LoginAttemptStore
+	jwtService  *auth.JWTService
+	mailer      port.Mailer
+	refreshTTL  time.Duration
+	verifyTTL   time.Duration
+	verifyURL   *url.URL
+	resendAfter time.Duration
+	dailyLimit  int
+	resetTTL    time.Duration
+	resetURL    *url.URL
+	policy      model.PasswordPolicy
+	loginPolicy model.LoginPolicy
+	// dummyHash is compared against when no account uses the address being logged in with, so
+	// unknown addresses take as long to reject as wrong passwords
+	dummyHash []byte
+	log       *logrus.Logger
 }
 
-// NewAuthService creates a new authentication service
-func NewAuthService(repository port.Repository, log *logrus.Logger) (port.AuthService, error) {
-	jwtService, err := auth.NewJWTService()
+// NewAuthService creates a new authentication service that issues access tokens with the JWT
+// service, checks them against the revocation store, counts failed logins in the attempt store and
+// sends verification emails with the mailer. REFRESH_TOKEN_TTL overrides the lifetime of refresh
+// tokens; EMAIL_VERIFICATION_URL, EMAIL_VERIFICATION_TTL, EMAIL_VERIFICATION_RESEND_INTERVAL and
+// EMAIL_VERIFICATION_DAILY_LIMIT configure verification emails, PASSWORD_RESET_URL and
+// PASSWORD_RESET_TTL reset emails, and LOGIN_LOCKOUT_THRESHOLD and LOGIN_LOCKOUT_DURATION lockouts.
+func NewAuthService(repository port.Repository, jwtService *auth.JWTService, revocations port.TokenRevocationStore, attempts port.LoginAttemptStore, mailer port.Mailer, log *logrus.Logger) (port.AuthService, error) {
+	refreshTTL, err := durationEnv("REFRESH_TOKEN_TTL", RefreshTokenDuration)
+	if err != nil {
+		return nil, err
+	}
+	verifyTTL, err := durationEnv("EMAIL_VERIFICATION_TTL", EmailVerificationDuration)
+	if err != nil {
+		return nil, err
+	}
+	resendAfter, err := durationEnv("EMAIL_VERIFICATION_RESEND_INTERVAL", verificationResendInterval)
+	if err != nil {
+		return nil, err
+	}
+
+	dailyLimit := verificationDailyLimit
+	if raw := os.Getenv("EMAIL_VERIFICATION_DAILY_LIMIT"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 {
+			return nil, fmt.Errorf("invalid EMAIL_VERIFICATION_DAILY_LIMIT %q", raw)
+		}
+		dailyLimit = parsed
+	}
+
+	verifyURL, err := urlEnv("EMAIL_VERIFICATION_URL", defaultVerificationURL)
+	if err != nil {
+		return nil, err
+	}
+	resetTTL, err := durationEnv("PASSWORD_RESET_TTL", PasswordResetDuration)
+	if err != nil {
+		return nil, err
+	}
+	resetURL, err := urlEnv("PASSWORD_RESET_URL", defaultPasswordResetURL)
+	if err != nil {
+		return nil, err
+	}
+
+	loginPolicy := model.DefaultLoginPolicy
+	if raw := os.Getenv("LOGIN_LOCKOUT_THRESHOLD"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 0 {
+			return nil, fmt.Errorf("invalid LOGIN_LOCKOUT_THRESHOLD %q", raw)
+		}
+		loginPolicy.LockoutThreshold = parsed
+	}
+	if loginPolicy.LockoutDuration, err = durationEnv("LOGIN_LOCKOUT_DURATION", loginPolicy.LockoutDuration); err != nil {
+		return nil, err
+	}
+	if loginPolicy.FailureTTL < loginPolicy.LockoutDuration {
+		loginPolicy.FailureTTL = loginPolicy.LockoutDuration
+	}
+
+	// The dummy password is random so the dummy hash can never match
+	dummyPassword, err := uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+	dummyHash, err := bcrypt.GenerateFromPassword([]byte(dummyPassword.String()), bcrypt.DefaultCost)
 	if err != nil {
 		return nil, err
 	}
 
 	return &AuthService{
-		repository: repository,
-		jwtService: jwtService,
-		log:        log,
+		repository:  repository,
+		revocations: revocations,
+		attempts:    attempts,
+		jwtService:  jwtService,
+		mailer:      mailer,
+		refreshTTL:  refreshTTL,
+		verifyTTL:   verifyTTL,
+		verifyURL:   verifyURL,
+		resendAfter: resendAfter,
+		dailyLimit:  dailyLimit,
+		resetTTL:    resetTTL,
+		resetURL:    resetURL,
+		policy:      model.DefaultPasswordPolicy,
+		loginPolicy: loginPolicy,
+		dummyHash:   dummyHash,
+		log:         log,
 	}, nil
 }
 
-// Register registers a new user
-func (s *AuthService) Register(ctx context.Context, username, email, password string) (*model.UserResponse, string, error) {
+// durationEnv reads a positive duration from an environment variable, falling back to a default
+// when it is unset
+func durationEnv(name string, fallback time.Duration) (time.Duration, error) {
+	raw := os.Getenv(name)
+	if raw == "" {
+		return fallback, nil
+	}
+	parsed, err := time.ParseDuration(raw)
+	if err != nil || parsed <= 0 {
+		return 0, fmt.Errorf("invalid %s %q", name, raw)
+	}
+	return parsed, nil
+}
+
+// urlEnv reads an absolute URL from an environment variable, falling back to a default when it is
+// unset
+func urlEnv(name, fallback string) (*url.URL, error) {
+	raw := os.Getenv(name)
+	if raw == "" {
+		raw = fallback
+	}
+	parsed, err := url.Parse(raw)
+	if err != nil || !parsed.IsAbs() {
+		return nil, fmt.Errorf("invalid %s %q", name, raw)
+	}
+	return parsed, nil
+}
+
+// Register registers a new user, sends them a link to verify their email address and starts a
+// session for them. The account is usable at once, but cannot post reviews until verified.
+func (s *AuthService) Register(ctx context.Context, username, email, password string) (*model.UserResponse, *model.TokenPair, error) {
 	// Check if user already exists with the same email or username
 	existingUser, err := s.repository.GetUserByEmail(ctx, email)
 	if err == nil && existingUser != nil {
-		return nil, "", ErrUserAlreadyExists
+		return nil, nil, ErrUserAlreadyExists
 	}
 
 	existingUser, err = s.repository.GetUserByUsername(ctx, username)
 	if err == nil && existingUser != nil {
-		return nil, "", ErrUserAlreadyExists
+		return nil, nil, ErrUserAlreadyExists
+	}
+
+	if err := s.policy.Check(password, username, email); err != nil {
+		return nil, nil, err
 	}
 
 	// Create new user
 	user, err := model.NewUser(username, email, password)
 	if err != nil {
-		return nil, "", err
+		return nil, nil, err
 	}
 
 	// Save user to database
 	err = s.repository.CreateUser(ctx, user)
 	if err != nil {
-		return nil, "", err
+		return nil, nil, err
+	}
+
+	// The user can ask for another email, so a failure to send this one does not fail registration
+	if err := s.sendVerification(ctx, user, time.Now()); err != nil {
+		s.log.WithError(err).WithField("user_id", user.ID).Error("Failed to send verification email")
 	}
 
-	// Generate JWT token
-	token, err := s.jwtService.GenerateToken(user)
+	tokens, err := s.startSession(ctx, user)
 	if err != nil {
-		return nil, "", err
+		return nil, nil, err
 	}
 
 	response := user.ToResponse()
-	return &response, token, nil
+	return &response, tokens, nil
 }
 
-// Login authenticates a user and returns a token
-func (s *AuthService) Login(ctx context.Context, email, password string) (*model.UserResponse, string, error) {
+// Login authenticates a user and starts a session, returning an access token and a refresh token.
+// Failed attempts are counted per account and per client address; callers that keep failing must
+// wait exponentially longer between attempts, and an account that keeps failing is locked until
+// the lockout ends or an admin unlocks it. Attempts are throttled and passwords compared the same
+// way whether or not an account uses the address, so logins reveal nothing about which do.
+func (s *AuthService) Login(ctx context.Context, email, password, clientIP string) (*model.UserResponse, *model.TokenPair, error) {
+	now := time.Now()
+	accountKey, clientKey := accountAttemptKey(email), clientAttemptKey(clientIP)
+	if err := s.checkLoginAttempts(ctx, accountKey, clientKey, now); err != nil {
+		return nil, nil, err
+	}
+
 	// Get user by email
 	user, err := s.repository.GetUserByEmail(ctx, email)
-	if err != nil {
-		return nil, "", ErrInvalidCredentials
+	if err != nil || user == nil {
+		bcrypt.CompareHashAndPassword(s.dummyHash, []byte(password))
+		return nil, nil, s.loginFailed(ctx, nil, accountKey, clientKey, clientIP, now)
+	}
+
+	// Locked accounts are refused whatever the password, so guesses during a lockout reveal
+	// nothing either
+	if user.IsLocked(now) {
+		bcrypt.CompareHashAndPassword(s.dummyHash, []byte(password))
+		return nil, nil, &model.LoginThrottledError{RetryAfter: user.LockedUntil.Sub(now)}
 	}
 
 	// Check password
 	if !user.CheckPassword(password) {
-		return nil, "", ErrInvalidCredentials
+		return nil, nil, s.loginFailed(ctx, user, accountKey, clientKey, clientIP, now)
+	}
+	if err := s.attempts.Reset(ctx, accountKey); err != nil {
+		s.log.WithError(err).Warn("Failed to reset failed login count")
 	}
 
-	// Generate JWT token
-	token, err := s.jwtService.GenerateToken(user)
+	// Load roles to embed in the token
+	if err := s.loadRoles(ctx, user); err != nil {
+		return nil, nil, err
+	}
+
+	tokens, err := s.startSession(ctx, user)
 	if err != nil {
-		return nil, "", err
+		return nil, nil, err
 	}
 
 	response := user.ToResponse()
-	return &response, token, nil
+	return &response, tokens, nil
 }
 
-// ValidateToken validates a token and returns the user ID if valid
-func (s *AuthService) ValidateToken(token string) (uuid.UUID, error) {
-	userID, err := s.jwtService.ExtractUserID(token)
+// Refresh exchanges a refresh token for a new access token and a new refresh token. Each refresh
+// token can be exchanged once; presenting one again revokes every token of its session.
+func (s *AuthService) Refresh(ctx context.Context, refreshToken string) (*model.TokenPair, error) {
+	now := time.Now()
+	stored, err := s.repository.GetRefreshTokenByHash(ctx, model.HashRefreshToken(refreshToken))
 	if err != nil {
-		return uuid.Nil, ErrInvalidToken
+		if !errors.Is(err, model.ErrInvalidRefreshToken) {
+			s.log.WithError(err).Error("Failed to get refresh token")
+		}
+		return nil, err
 	}
-	return userID, nil
-}
\ No newline at end of file
+
+	if stored.RotatedAt != nil {
+		return nil, s.revokeFamily(ctx, stored)
+	}
+	if !stored.IsActive(now) {
+		return nil, model.ErrInvalidRefreshToken
+	}
+
+	// Roles are reloaded so grants and revocations take effect on refresh
+	user, err := s.repository.GetUserByID(ctx, stored.UserID)
+	if err != nil {
+		return nil, model.ErrInvalidRefreshToken
+	}
+	if err := s.loadRoles(ctx, user); err != nil {
+		return nil, err
+	}
+
+	next, token, err := model.NewRefreshToken(user.ID, stored.FamilyID, s.refreshTTL, now)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repository.RotateRefreshToken(ctx, stored.ID, next, now); err != nil {
+		// Another request exchanged the token first
+		if errors.Is(err, model.ErrRefreshTokenReused) {
+			return nil, s.revokeFamily(ctx, stored)
+		}
+		s.log.WithError(err).Error("Failed to rotate refresh token")
+		return nil, err
+	}
+
+	return s.tokenPair(ctx, user, next, token)
+}
+
+// Logout revokes the caller's access token until it expires and, if a refresh token of theirs is
+// given, every refresh token of its session. Unknown refresh tokens are ignored, so logging out
+// twice succeeds.
+func (s *AuthService) Logout(ctx context.Context, principal *model.Principal, refreshToken string) error {
+	// API keys are revoked by admins, not by logging out
+	if principal.TokenID == "" {
+		return ErrInvalidToken
+	}
+	if err := s.revocations.RevokeToken(ctx, principal.TokenID, principal.ExpiresAt); err != nil {
+		s.log.WithError(err).Error("Failed to revoke access token")
+		return err
+	}
+
+	if refreshToken == "" {
+		return nil
+	}
+	stored, err := s.repository.GetRefreshTokenByHash(ctx, model.HashRefreshToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, model.ErrInvalidRefreshToken) {
+			return nil
+		}
+		s.log.WithError(err).Error("Failed to get refresh token")
+		return err
+	}
+	if stored.UserID != principal.UserID {
+		return nil
+	}
+	if err := s.repository.RevokeRefreshTokenFamily(ctx, stored.FamilyID, time.Now()); err != nil {
+		s.log.WithError(err).Error("Failed to revoke refresh token family")
+		return err
+	}
+	return nil
+}
+
+// RevokeSessions revokes every token issued to a user by bumping their token generation, which
+// invalidates access tokens of earlier generations, and revoking their refresh tokens
+func (s *AuthService) RevokeSessions(ctx context.Context, adminID, userID uuid.UUID) error {
+	if _, err := s.repository.GetUserByID(ctx, userID); err != nil {
+		return ErrUserNotFound
+	}
+
+	log := s.log.WithFields(logrus.Fields{"admin_id": adminID, "user_id": userID})
+	if err := s.revokeAllSessions(ctx, userID, log); err != nil {
+		return err
+	}
+	log.Info("Revoked all sessions of user")
+	return nil
+}
+
+// ExpireAccessTokens invalidates the access tokens issued to a user by bumping their token
+// generation. Refresh tokens stay valid, and reload the user's roles when exchanged.
+func (s *AuthService) ExpireAccessTokens(ctx context.Context, userID uuid.UUID) error {
+	return s.bumpGeneration(ctx, userID, s.log.WithField("user_id", userID))
+}
+
+// revokeAllSessions bumps a user's token generation and revokes their refresh tokens
+func (s *AuthService) revokeAllSessions(ctx context.Context, userID uuid.UUID, log *logrus.Entry) error {
+	if err := s.bumpGeneration(ctx, userID, log); err != nil {
+		return err
+	}
+
+	// Refresh tokens issued after the generation was bumped carry the new generation, so revoking
+	// them second leaves no window for a refresh to keep a session alive
+	if err := s.repository.RevokeUserRefreshTokens(ctx, userID, time.Now()); err != nil {
+		log.WithError(err).Error("Failed to revoke refresh tokens")
+		return err
+	}
+	return nil
+}
+
+// UnlockAccount ends a user's lockout and forgets their failed logins
+func (s *AuthService) UnlockAccount(ctx context.Context, adminID, userID uuid.UUID, clientIP string) error {
+	user, err := s.repository.GetUserByID(ctx, userID)
+	if err != nil || user == nil {
+		return ErrUserNotFound
+	}
+
+	log := s.log.WithFields(logrus.Fields{"admin_id": adminID, "user_id": userID})
+	if err := s.repository.UnlockUser(ctx, userID); err != nil {
+		log.WithError(err).Error("Failed to unlock user")
+		return err
+	}
+	if err := s.attempts.Reset(ctx, accountAttemptKey(user.Email)); err != nil {
+		log.WithError(err).Warn("Failed to reset failed login count")
+	}
+	s.audit(ctx, model.NewAuditEvent(userID, &adminID, model.AuditActionAccountUnlocked, clientIP, time.Now()))
+
+	log.Info("Unlocked account")
+	return nil
+}
+
+// RequestPasswordReset emails a password reset link to the user with the given address, if there
+// is one. It returns nothing and does the same work whether or not the address is known, sending
+// the email after returning, so callers cannot tell which addresses have accounts. Links are
+// throttled per user; throttled requests are dropped silently.
+func (s *AuthService) RequestPasswordReset(ctx context.Context, email, clientIP string) {
+	user, err := s.repository.GetUserByEmail(ctx, email)
+	if err != nil || user == nil {
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), passwordResetSendTimeout)
+		defer cancel()
+
+		log := s.log.WithField("user_id", user.ID)
+		if err := s.sendPasswordReset(ctx, user, clientIP, time.Now()); err != nil {
+			log.WithError(err).Error("Failed to send password reset email")
+		}
+	}()
+}
+
+// ResetPassword sets a new password for the user a reset token was sent to. The password must
+// satisfy the password policy; resetting it uses up every outstanding reset token of the user,
+// revokes all their sessions and is recorded in the audit log.
+func (s *AuthService) ResetPassword(ctx context.Context, token, password, clientIP string) error {
+	claims, err := s.jwtService.ValidatePurposeToken(string(model.TokenPurposePasswordReset), token)
+	if err != nil {
+		return model.ErrInvalidResetToken
+	}
+
+	stored, err := s.repository.GetUserTokenByHash(ctx, model.TokenPurposePasswordReset, model.HashUserToken(token))
+	if err != nil {
+		if errors.Is(err, model.ErrUserTokenNotFound) {
+			return model.ErrInvalidResetToken
+		}
+		s.log.WithError(err).Error("Failed to get password reset token")
+		return err
+	}
+
+	now := time.Now()
+	if !stored.IsActive(now) || stored.ID.String() != claims.ID || stored.UserID.String() != claims.Subject {
+		return model.ErrInvalidResetToken
+	}
+
+	user, err := s.repository.GetUserByID(ctx, stored.UserID)
+	if err != nil || user == nil || user.Email != claims.Email {
+		return model.ErrInvalidResetToken
+	}
+
+	if err := s.policy.Check(password, user.Username, user.Email); err != nil {
+		return err
+	}
+	passwordHash, err := model.HashPassword(password)
+	if err != nil {
+		return err
+	}
+
+	log := s.log.WithField("user_id", user.ID)
+	if err := s.repository.ResetPassword(ctx, stored.ID, user.ID, passwordHash, now); err != nil {
+		// Another request used the token first
+		if errors.Is(err, model.ErrUserTokenNotFound) {
+			return model.ErrInvalidResetToken
+		}
+		log.WithError(err).Error("Failed to reset password")
+		return err
+	}
+
+	// Whoever knew the old password may hold sessions started with it
+	if err := s.revokeAllSessions(ctx, user.ID, log); err != nil {
+		return err
+	}
+	if err := s.attempts.Reset(ctx, accountAttemptKey(user.Email)); err != nil {
+		log.WithError(err).Warn("Failed to reset failed login count")
+	}
+	s.audit(ctx, model.NewAuditEvent(user.ID, nil, model.AuditActionPasswordReset, clientIP, now))
+
+	log.Info("Reset password and revoked all sessions of user")
+	return nil
+}
+
+// ValidateToken validates a token, checks it has been neither revoked on logout nor issued before
+// the user's sessions were revoked, and returns the user ID and roles it carries
+func (s *AuthService) ValidateToken(ctx context.Context, token string) (*model.Principal, error) {
+	principal, err := s.jwtService.ExtractPrincipal(token)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	revoked, err := s.revocations.IsTokenRevoked(ctx, principal.TokenID)
+	if err != nil {
+		s.log.WithError(err).Error("Failed to check token revocation")
+		return nil, err
+	}
+	if revoked {
+		return nil, ErrTokenRevoked
+	}
+
+	generation, err := s.generation(ctx, principal.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if principal.Generation < generation {
+		return nil, ErrTokenRevoked
+	}
+	return principal, nil
+}
+
+// ValidateAPIKey checks an API key is active and returns the caller it authenticates, holding the
+// roles the key's user holds now
+func (s *AuthService) ValidateAPIKey(ctx context.Context, key string) (*model.Principal, error) {
+	stored, err := s.repository.GetAPIKeyByHash(ctx, model.HashAPIKey(key))
+	if err != nil {
+		if errors.Is(err, model.ErrInvalidAPIKey) {
+			return nil, err
+		}
+		s.log.WithError(err).Error("Failed to get API key")
+		return nil, err
+	}
+
+	now := time.Now()
+	if !stored.IsActive(now) {
+		return nil, model.ErrInvalidAPIKey
+	}
+
+	grants, err := s.repository.GetUserRoles(ctx, stored.UserID)
+	if err != nil {
+		s.log.WithError(err).WithField("user_id", stored.UserID).Error("Failed to get user roles")
+		return nil, err
+	}
+	roles := make([]model.Role, len(grants))
+	for i, grant := range grants {
+		roles[i] = grant.Role
+	}
+
+	if stored.LastUsedAt == nil || now.Sub(*stored.LastUsedAt) >= apiKeyTouchInterval {
+		if err := s.repository.TouchAPIKey(ctx, stored.ID, now); err != nil {
+			s.log.WithError(err).WithField("key_id", stored.ID).Warn("Failed to record API key use")
+		}
+	}
+	return stored.Principal(roles), nil
+}
+
+// VerifyEmail marks the email address of the user a verification token was sent to as verified.
+// Each token can be used once, and only while the user's address is still the one it was sent to.
+func (s *AuthService) VerifyEmail(ctx context.Context, token string) (*model.UserResponse, error) {
+	claims, err := s.jwtService.ValidatePurposeToken(string(model.TokenPurposeEmailVerification), token)
+	if err != nil {
+		return nil, model.ErrInvalidVerificationToken
+	}
+
+	stored, err := s.repository.GetUserTokenByHash(ctx, model.TokenPurposeEmailVerification, model.HashUserToken(token))
+	if err != nil {
+		if errors.Is(err, model.ErrUserTokenNotFound) {
+			return nil, model.ErrInvalidVerificationToken
+		}
+		s.log.WithError(err).Error("Failed to get verification token")
+		return nil, err
+	}
+
+	now := time.Now()
+	if !stored.IsActive(now) || stored.ID.String() != claims.ID || stored.UserID.String() != claims.Subject {
+		return nil, model.ErrInvalidVerificationToken
+	}
+
+	user, err := s.repository.GetUserByID(ctx, stored.UserID)
+	if err != nil || user == nil || user.Email != claims.Email {
+		return nil, model.ErrInvalidVerificationToken
+	}
+
+	if err := s.repository.VerifyEmail(ctx, stored.ID, user.ID, now); err != nil {
+		// Another request used the token first
+		if errors.Is(err, model.ErrUserTokenNotFound) {
+			return nil, model.ErrInvalidVerificationToken
+		}
+		s.log.WithError(err).WithField("user_id", user.ID).Error("Failed to verify email")
+		return nil, err
+	}
+
+	if user.EmailVerifiedAt == nil {
+		user.EmailVerifiedAt = &now
+	}
+	if err := s.loadRoles(ctx, user); err != nil {
+		return nil, err
+	}
+	response := user.ToResponse()
+	return &response, nil
+}
+
+// ResendVerification sends a user another verification email, at most once per resend interval
+// and up to the daily limit
+func (s *AuthService) ResendVerification(ctx context.Context, userID uuid.UUID) error {
+	user, err := s.repository.GetUserByID(ctx, userID)
+	if err != nil || user == nil {
+		return ErrUserNotFound
+	}
+	if user.IsEmailVerified() {
+		return model.ErrEmailAlreadyVerified
+	}
+
+	now := time.Now()
+	recent, err := s.repository.CountUserTokensSince(ctx, userID, model.TokenPurposeEmailVerification, now.Add(-s.resendAfter))
+	if err != nil {
+		s.log.WithError(err).WithField("user_id", userID).Error("Failed to count verification tokens")
+		return err
+	}
+	today, err := s.repository.CountUserTokensSince(ctx, userID, model.TokenPurposeEmailVerification, now.Add(-24*time.Hour))
+	if err != nil {
+		s.log.WithError(err).WithField("user_id", userID).Error("Failed to count verification tokens")
+		return err
+	}
+	if recent > 0 || today >= s.dailyLimit {
+		return model.ErrVerificationThrottled
+	}
+
+	if err := s.sendVerification(ctx, user, now); err != nil {
+		s.log.WithError(err).WithField("user_id", userID).Error("Failed to send verification email")
+		return err
+	}
+	return nil
+}
+
+// PublicKeys returns the keys other services can verify access tokens with
+func (s *AuthService) PublicKeys() *model.JSONWebKeySet {
+	return s.jwtService.JWKS()
+}
+
+// bumpGeneration increments a user's token generation, invalidating access tokens of earlier
+// generations, and caches the new generation
+func (s *AuthService) bumpGeneration(ctx context.Context, userID uuid.UUID, log *logrus.Entry) error {
+	generation, err := s.repository.IncrementTokenGeneration(ctx, userID)
+	if err != nil {
+		log.WithError(err).Error("Failed to increment token generation")
+		return err
+	}
+	if err := s.revocations.SetGeneration(ctx, userID, generation, generationCacheTTL); err != nil {
+		log.WithError(err).Warn("Failed to cache token generation")
+	}
+	return nil
+}
+
+// generation returns the current token generation of a user, caching it in the revocation store
+func (s *AuthService) generation(ctx context.Context, userID uuid.UUID) (int, error) {
+	if generation, ok, err := s.revocations.Generation(ctx, userID); err == nil && ok {
+		return generation, nil
+	} else if err != nil {
+		s.log.WithError(err).Warn("Failed to get cached token generation")
+	}
+
+	generation, err := s.repository.GetTokenGeneration(ctx, userID)
+	if err != nil {
+		s.log.WithError(err).WithField("user_id", userID).Error("Failed to get token generation")
+		return 0, err
+	}
+	if err := s.revocations.SetGeneration(ctx, userID, generation, generationCacheTTL); err != nil {
+		s.log.WithError(err).Warn("Failed to cache token generation")
+	}
+	return generation, nil
+}
+
+// sendVerification records a new verification token for a user and emails them a link carrying it.
+// Earlier tokens stay valid until they expire, so a delayed email still works.
+func (s *AuthService) sendVerification(ctx context.Context, user *model.User, now time.Time) error {
+	stored := model.NewUserToken(user.ID, model.TokenPurposeEmailVerification, s.verifyTTL, now)
+	token, err := s.jwtService.GeneratePurposeToken(string(stored.Purpose), stored.ID, user.ID, user.Email, stored.ExpiresAt)
+	if err != nil {
+		return err
+	}
+	stored.SetToken(token)
+	if err := s.repository.CreateUserToken(ctx, stored); err != nil {
+		return err
+	}
+
+	return s.mailer.Send(ctx, &model.EmailMessage{
+		To:      user.Email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nPlease confirm this is your email address by opening the link below:\n\n%s\n\nThe link expires in %s. If you did not sign up, you can ignore this email.\n",
+			user.Username, tokenLink(s.verifyURL, token), s.verifyTTL,
+		),
+	})
+}
+
+// checkLoginAttempts returns a model.LoginThrottledError if the account or the client address must
+// wait longer after their last failed login
+func (s *AuthService) checkLoginAttempts(ctx context.Context, accountKey, clientKey string, now time.Time) error {
+	var wait time.Duration
+	for _, check := range []struct {
+		key   string
+		delay func(int) time.Duration
+	}{
+		{accountKey, s.loginPolicy.AccountDelay},
+		{clientKey, s.loginPolicy.Client.Delay},
+	} {
+		failures, last, err := s.attempts.Failures(ctx, check.key)
+		if err != nil {
+			// Logins keep working if the store fails
+			s.log.WithError(err).Warn("Failed to get failed login count")
+			continue
+		}
+		if remaining := last.Add(check.delay(failures)).Sub(now); remaining > wait {
+			wait = remaining
+		}
+	}
+
+	if wait > 0 {
+		return &model.LoginThrottledError{RetryAfter: wait}
+	}
+	return nil
+}
+
+// loginFailed records a failed login against the account and the client address, locking the
+// user's account once it reaches the lockout threshold, and returns ErrInvalidCredentials. The user
+// is nil when no account uses the address.
+func (s *AuthService) loginFailed(ctx context.Context, user *model.User, accountKey, clientKey, clientIP string, now time.Time) error {
+	if _, err := s.attempts.RecordFailure(ctx, clientKey, now, s.loginPolicy.FailureTTL); err != nil {
+		s.log.WithError(err).Warn("Failed to record failed login")
+	}
+	failures, err := s.attempts.RecordFailure(ctx, accountKey, now, s.loginPolicy.FailureTTL)
+	if err != nil {
+		s.log.WithError(err).Warn("Failed to record failed login")
+		return ErrInvalidCredentials
+	}
+	if user == nil || !s.loginPolicy.ShouldLock(failures) {
+		return ErrInvalidCredentials
+	}
+
+	log := s.log.WithFields(logrus.Fields{"user_id": user.ID, "failures": failures})
+	if err := s.repository.LockUser(ctx, user.ID, now.Add(s.loginPolicy.LockoutDuration)); err != nil {
+		log.WithError(err).Error("Failed to lock user")
+		return ErrInvalidCredentials
+	}
+	s.audit(ctx, model.NewAuditEvent(user.ID, nil, model.AuditActionAccountLocked, clientIP, now))
+	log.Warn("Locked account after too many failed logins")
+	return ErrInvalidCredentials
+}
+
+// accountAttemptKey is the key failed logins with an email address are counted under, whether or
+// not an account uses it
+func accountAttemptKey(email string) string {
+	return "account:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+// clientAttemptKey is the key failed logins from a client address are counted under
+func clientAttemptKey(clientIP string) string {
+	return "client:" + clientIP
+}
+
+// sendPasswordReset records a new reset token for a user and emails them a link carrying it, unless
+// they were sent one too recently
+func (s *AuthService) sendPasswordReset(ctx context.Context, user *model.User, clientIP string, now time.Time) error {
+	recent, err := s.repository.CountUserTokensSince(ctx, user.ID, model.TokenPurposePasswordReset, now.Add(-passwordResetInterval))
+	if err != nil {
+		return err
+	}
+	today, err := s.repository.CountUserTokensSince(ctx, user.ID, model.TokenPurposePasswordReset, now.Add(-24*time.Hour))
+	if err != nil {
+		return err
+	}
+	if recent > 0 || today >= passwordResetDailyLimit {
+		s.log.WithField("user_id", user.ID).Warn("Password reset requested too often; not sending another")
+		return nil
+	}
+
+	stored := model.NewUserToken(user.ID, model.TokenPurposePasswordReset, s.resetTTL, now)
+	token, err := s.jwtService.GeneratePurposeToken(string(stored.Purpose), stored.ID, user.ID, user.Email, stored.ExpiresAt)
+	if err != nil {
+		return err
+	}
+	stored.SetToken(token)
+	if err := s.repository.CreateUserToken(ctx, stored); err != nil {
+		return err
+	}
+	s.audit(ctx, model.NewAuditEvent(user.ID, nil, model.AuditActionPasswordResetRequested, clientIP, now))
+
+	return s.mailer.Send(ctx, &model.EmailMessage{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nSomeone asked to reset the password of your account. To choose a new password, open the link below:\n\n%s\n\nThe link expires in %s and can be used once. If you did not ask for this, you can ignore this email; your password has not changed.\n",
+			user.Username, tokenLink(s.resetURL, token), s.resetTTL,
+		),
+	})
+}
+
+// audit records an audit event, logging rather than failing when it cannot be stored, since the
+// change it records has already been made
+func (s *AuthService) audit(ctx context.Context, event *model.AuditEvent) {
+	if err := s.repository.CreateAuditEvent(ctx, event); err != nil {
+		s.log.WithError(err).WithFields(logrus.Fields{
+			"user_id": event.UserID,
+			"action":  event.Action,
+		}).Error("Failed to record audit event")
+	}
+}
+
+// tokenLink returns the page URL with the token added as its token query parameter
+func tokenLink(page *url.URL, token string) string {
+	link := *page
+	query := link.Query()
+	query.Set("token", token)
+	link.RawQuery = query.Encode()
+	return link.String()
+}
+
+// loadRoles loads the roles a user holds so they are embedded in their access token
+func (s *AuthService) loadRoles(ctx context.Context, user *model.User) error {
+	grants, err := s.repository.GetUserRoles(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	user.Roles = user.Roles[:0]
+	for _, grant := range grants {
+		user.Roles = append(user.Roles, grant.Role)
+	}
+	return nil
+}
+
+// startSession issues the first refresh token of a new token family along with an access token
+func (s *AuthService) startSession(ctx context.Context, user *model.User) (*model.TokenPair, error) {
+	refresh, token, err := model.NewRefreshToken(user.ID, uuid.New(), s.refreshTTL, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repository.CreateRefreshToken(ctx, refresh); err != nil {
+		s.log.WithError(err).Error("Failed to create refresh token")
+		return nil, err
+	}
+	return s.tokenPair(ctx, user, refresh, token)
+}
+
+// tokenPair generates an access token for the user and pairs it with a refresh token
+func (s *AuthService) tokenPair(ctx context.Context, user *model.User, refresh *model.RefreshToken, token string) (*model.TokenPair, error) {
+	generation, err := s.generation(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	accessToken, expiresAt, err := s.jwtService.GenerateToken(user, generation)
+	if err != nil {
+		return nil, err
+	}
+	return &model.TokenPair{
+		AccessToken:           accessToken,
+		AccessTokenExpiresAt:  expiresAt,
+		RefreshToken:          token,
+		RefreshTokenExpiresAt: refresh.ExpiresAt,
+	}, nil
+}
+
+// revokeFamily revokes every refresh token descending from the same login after one of them was
+// reused, and returns model.ErrRefreshTokenReused
+func (s *AuthService) revokeFamily(ctx context.Context, reused *model.RefreshToken) error {
+	log := s.log.WithFields(logrus.Fields{"user_id": reused.UserID, "family_id": reused.FamilyID})
+	if err := s.repository.RevokeRefreshTokenFamily(ctx, reused.FamilyID, time.Now()); err != nil {
+		log.WithError(err).Error("Failed to revoke refresh token family")
+		return err
+	}
+	log.Warn("Refresh token reused; revoked its token family")
+	return model.ErrRefreshTokenReused
+}
@@ -1,18 +1,37 @@
 package main
 
 import (
+        "context"
         "fmt"
         "os"
+        "strconv"
+        "strings"
+        "time"
 
         "github.com/gin-gonic/gin"
+        "github.com/sirupsen/logrus"
         swaggerFiles "github.com/swaggo/files"
         ginSwagger "github.com/swaggo/gin-swagger"
 
         _ "rating-system/docs" // Import generated docs
+        "rating-system/internal/domain/model"
+        "rating-system/internal/domain/port"
         domainService "rating-system/internal/domain/service"
+        "rating-system/internal/infrastructure/attestation"
+        "rating-system/internal/infrastructure/auth"
+        "rating-system/internal/infrastructure/brigading"
+        "rating-system/internal/infrastructure/duplicates"
+        "rating-system/internal/infrastructure/contentfilter"
         "rating-system/internal/infrastructure/db"
+        "rating-system/internal/infrastructure/events"
         "rating-system/internal/infrastructure/handler"
+        "rating-system/internal/infrastructure/highlights"
+        "rating-system/internal/infrastructure/loginattempts"
+        "rating-system/internal/infrastructure/mail"
+        "rating-system/internal/infrastructure/ratelimit"
         "rating-system/internal/infrastructure/repository"
+        "rating-system/internal/infrastructure/revocation"
+        "rating-system/internal/infrastructure/sentiment"
         "rating-system/internal/service"
         "rating-system/pkg/logger"
 )
@@ -52,24 +71,144 @@ func main() {
         // Initialize repository
         repo := repository.NewPostgresRepository(dbConn, log)
 
+        // Limit the length of review and comment text
+        model.SetContentLimits(contentLimitsFromEnv())
+
+        // Enable attestation verification when signing keys are configured
+        var svcOpts []domainService.Option
+        verifier, err := attestation.NewVerifierFromEnv()
+        if err != nil {
+                log.WithError(err).Warn("Attestation verification disabled")
+        } else {
+                svcOpts = append(svcOpts, domainService.WithAttestationVerifier(verifier))
+        }
+        svcOpts = append(svcOpts, domainService.WithReviewPolicy(reviewPolicyFromEnv()))
+        svcOpts = append(svcOpts, domainService.WithVerifiedEmailRequired(os.Getenv("REQUIRE_EMAIL_VERIFICATION") != "false"))
+        if maxDepth, err := strconv.Atoi(os.Getenv("COMMENT_MAX_DEPTH")); err == nil {
+                svcOpts = append(svcOpts, domainService.WithCommentMaxDepth(maxDepth))
+        }
+        if maxMentions, err := strconv.Atoi(os.Getenv("MAX_MENTIONS_PER_POST")); err == nil {
+                svcOpts = append(svcOpts, domainService.WithMaxMentions(maxMentions))
+        }
+        if emojis := reactionEmojisFromEnv(); len(emojis) > 0 {
+                svcOpts = append(svcOpts, domainService.WithReactionEmojis(emojis))
+        }
+
+        // Screen review and comment text for spam and abuse
+        svcOpts = append(svcOpts, domainService.WithContentFilter(contentFilterFromEnv(log)))
+
+        // Flag, and optionally quarantine, ratings that look like part of a brigading campaign
+        if os.Getenv("BRIGADING_DETECTION") != "none" {
+                svcOpts = append(svcOpts, domainService.WithBrigadingDetector(brigading.NewDetector(repo, brigadingConfigFromEnv())))
+        }
+
+        // Flag, or reject, reviews that copy the text of other accounts' reviews
+        if os.Getenv("DUPLICATE_DETECTION") != "none" {
+                svcOpts = append(svcOpts, domainService.WithDuplicateDetector(duplicates.NewDetector(repo, duplicatesConfigFromEnv())))
+        }
+
+        // Score review sentiment offline unless disabled
+        if os.Getenv("SENTIMENT_ANALYZER") != "none" {
+                svcOpts = append(svcOpts, domainService.WithSentimentAnalyzer(sentiment.NewLexiconAnalyzer(nil)))
+        }
+
+        // Deliver domain events to background subscribers
+        bus := events.NewBus(1024, 2, log)
+        defer bus.Close()
+        svcOpts = append(svcOpts, domainService.WithEventPublisher(bus))
+
+        // Extract review highlights, updating cached services as reviews are published
+        highlightsSvc := highlights.NewService(repo, highlightsConfigFromEnv(), log)
+        bus.Subscribe(model.EventTypeReviewPublished, highlightsSvc.HandleReviewPublished)
+        svcOpts = append(svcOpts, domainService.WithHighlightsProvider(highlightsSvc))
+
+        // Record notifications for comment, mention and moderation activity
+        notificationSvc := domainService.NewNotificationService(repo, log)
+        bus.Subscribe(model.EventTypeCommentCreated, notificationSvc.HandleEvent)
+        bus.Subscribe(model.EventTypeReviewModerated, notificationSvc.HandleEvent)
+        bus.Subscribe(model.EventTypeContentModerated, notificationSvc.HandleEvent)
+        bus.Subscribe(model.EventTypeUsersMentioned, notificationSvc.HandleEvent)
+
+        // Accept content reports and record moderator decisions
+        moderationSvc := domainService.NewModerationService(repo, bus, log)
+
+        // Shadow-ban abusive accounts
+        accountSvc := domainService.NewAccountService(repo, log)
+
+        // Let users block each other
+        blockSvc := domainService.NewBlockService(repo, log)
+
+        // Issue API keys to integrations
+        apiKeySvc := domainService.NewAPIKeyService(repo, log)
+
+        // Review ratings flagged by the brigading detector
+        ratingFlagSvc := domainService.NewRatingFlagService(repo, log)
+
+        // List clusters of near-duplicate reviews
+        duplicateSvc := domainService.NewDuplicateService(repo, log)
+
         // Initialize service
-        svc := domainService.NewRatingService(repo, log)
+        svc := domainService.NewRatingService(repo, log, svcOpts...)
 
-        // Initialize authentication service
-        authSvc, err := service.NewAuthService(repo, log)
+        // Sign access tokens, reloading the signing keys so they can be rotated without a restart
+        jwtSvc, err := auth.NewJWTService()
+        if err != nil {
+                log.WithError(err).Fatal("Failed to initialize JWT service")
+        }
+        keysInterval := time.Minute
+        if configured, err := time.ParseDuration(os.Getenv("JWT_KEYS_RELOAD_INTERVAL")); err == nil && configured > 0 {
+                keysInterval = configured
+        }
+        go jwtSvc.Watch(context.Background(), keysInterval, log)
+
+        // Send verification and password reset emails over SMTP, or into an outbox directory when no server is configured
+        mailer, err := mailerFromEnv(log)
+        if err != nil {
+                log.WithError(err).Fatal("Failed to initialize mailer")
+        }
+
+        // Initialize authentication service, remembering revoked access tokens and failed logins in
+        // process memory
+        authSvc, err := service.NewAuthService(repo, jwtSvc, revocation.NewMemoryStore(), loginattempts.NewMemoryStore(), mailer, log)
         if err != nil {
                 log.WithError(err).Fatal("Failed to initialize auth service")
         }
 
+        // Grant and revoke user roles, expiring the access tokens of users losing a privileged role
+        roleSvc := domainService.NewRoleService(repo, authSvc, log)
+
+        // Publish pending reviews once their cool-down has elapsed
+        go runReviewPublisher(svc, log, time.Minute)
+
         // Initialize HTTP handler with Gin
         router := gin.Default()
         router.Use(gin.Recovery())
         router.Use(corsMiddleware())
+        // Gin trusts X-Forwarded-For from every peer unless told otherwise, which would let clients
+        // pick the address their rate limits and login throttling are keyed by
+        if err := router.SetTrustedProxies(trustedProxiesFromEnv()); err != nil {
+                log.WithError(err).Fatal("Invalid TRUSTED_PROXIES")
+        }
+
+        // Rate limit the auth routes and writes; the limiters share one in-process store
+        rateLimitStore := ratelimit.NewMemoryStore()
+        limits := rateLimits{
+                auth:  handler.RateLimit(rateLimiterFromEnv(rateLimitStore, "auth", "RATE_LIMIT_AUTH", "10/1m", log), log),
+                write: handler.RateLimitWrites(rateLimiterFromEnv(rateLimitStore, "write", "RATE_LIMIT_WRITE", "60/1m", log), log),
+        }
         
         // Initialize API handlers
         h := handler.NewHandler(svc, log)
         authH := handler.NewAuthHandler(authSvc, log)
-        setupRoutes(router, h, authH)
+        notificationH := handler.NewNotificationHandler(notificationSvc, log)
+        moderationH := handler.NewModerationHandler(moderationSvc, log)
+        roleH := handler.NewRoleHandler(roleSvc, log)
+        ratingFlagH := handler.NewRatingFlagHandler(ratingFlagSvc, log)
+        accountH := handler.NewAccountHandler(accountSvc, log)
+        duplicateH := handler.NewDuplicateHandler(duplicateSvc, log)
+        blockH := handler.NewBlockHandler(blockSvc, log)
+        apiKeyH := handler.NewAPIKeyHandler(apiKeySvc, log)
+        setupRoutes(router, limits, h, authH, notificationH, moderationH, roleH, ratingFlagH, accountH, duplicateH, blockH, apiKeyH)
 
         // Run the server
         port := os.Getenv("PORT")
@@ -83,21 +222,38 @@ func main() {
         }
 }
 
-func setupRoutes(router *gin.Engine, h *handler.Handler, authH *handler.AuthHandler) {
+// rateLimits holds the rate-limiting middleware of each route group
+type rateLimits struct {
+        auth  gin.HandlerFunc
+        write gin.HandlerFunc
+}
+
+func setupRoutes(router *gin.Engine, limits rateLimits, h *handler.Handler, authH *handler.AuthHandler, notificationH *handler.NotificationHandler, moderationH *handler.ModerationHandler, roleH *handler.RoleHandler, ratingFlagH *handler.RatingFlagHandler, accountH *handler.AccountHandler, duplicateH *handler.DuplicateHandler, blockH *handler.BlockHandler, apiKeyH *handler.APIKeyHandler) {
         // Swagger documentation endpoint
         router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+
+        // Public keys for services verifying access tokens themselves
+        router.GET("/.well-known/jwks.json", authH.JWKS)
         
         api := router.Group("/api/v1")
         {
                 // Auth routes - no authentication required
                 auth := api.Group("/auth")
+                auth.Use(limits.auth)
                 {
                         auth.POST("/register", authH.Register)
                         auth.POST("/login", authH.Login)
+                        auth.POST("/refresh", authH.Refresh)
+                        auth.POST("/verify-email", authH.VerifyEmail)
+                        auth.POST("/verify-email/resend", authH.AuthMiddleware(), authH.ResendVerification)
+                        auth.POST("/password/forgot", authH.ForgotPassword)
+                        auth.POST("/password/reset", authH.ResetPassword)
+                        auth.POST("/logout", authH.AuthMiddleware(), authH.Logout)
                 }
 
                 // Public routes - no authentication required
                 public := api.Group("")
+                public.Use(authH.OptionalAuthMiddleware())
                 {
                         // Service ratings can be viewed without authentication
                         public.GET("/ratings/service/:serviceID", h.GetRatingsByService)
@@ -105,30 +261,98 @@ func setupRoutes(router *gin.Engine, h *handler.Handler, authH *handler.AuthHand
                         
                         // Reviews can be viewed without authentication
                         public.GET("/reviews/service/:serviceID", h.GetReviewsByService)
+                        public.GET("/reviews/service/:serviceID/sentiment", h.GetSentimentSummary)
+                        public.GET("/services/:serviceID/highlights", h.GetServiceHighlights)
                         public.GET("/reviews/:reviewID", h.GetReviewByID)
                         
                         // Comments can be viewed without authentication
                         public.GET("/comments/review/:reviewID", h.GetCommentsByReview)
+                        public.GET("/comments/review/:reviewID/tree", h.GetCommentTree)
+
+                        // Allowed reaction emojis
+                        public.GET("/reactions", h.GetReactionEmojis)
                 }
 
-                // Protected routes - require authentication
+                // Protected routes - require authentication, and the permission from the matrix in
+                // model/role.go for each route, so API key scopes apply to all of them
                 secured := api.Group("")
-                secured.Use(authH.AuthMiddleware())
+                secured.Use(authH.AuthMiddleware(), limits.write)
                 {
                         ratings := secured.Group("/ratings")
                         {
-                                ratings.POST("", h.CreateRating)
-                                ratings.GET("/service/:serviceID/me", h.GetUserRating)
+                                ratings.POST("", handler.RequirePermission(model.PermissionWriteRatings), h.CreateRating)
+                                ratings.GET("/service/:serviceID/me", handler.RequirePermission(model.PermissionReadRatings), h.GetUserRating)
                         }
                         
                         reviews := secured.Group("/reviews")
                         {
-                                reviews.POST("", h.CreateReview)
+                                reviews.POST("", handler.RequirePermission(model.PermissionWriteReviews), h.CreateReview)
+                                reviews.POST("/:reviewID/submit", handler.RequirePermission(model.PermissionWriteReviews), h.SubmitReview)
+                                reviews.POST("/:reviewID/reactions", handler.RequirePermission(model.PermissionReact), h.ToggleReviewReaction)
+                        }
+
+                        users := secured.Group("/users")
+                        {
+                                users.GET("/me/reviews", handler.RequirePermission(model.PermissionReadReviews), h.GetMyReviews)
+                                users.GET("/me/blocks", handler.RequirePermission(model.PermissionBlockUsers), blockH.GetBlockedUsers)
+                                users.POST("/:userID/block", handler.RequirePermission(model.PermissionBlockUsers), blockH.BlockUser)
+                                users.DELETE("/:userID/block", handler.RequirePermission(model.PermissionBlockUsers), blockH.UnblockUser)
                         }
                         
                         comments := secured.Group("/comments")
                         {
-                                comments.POST("", h.CreateComment)
+                                comments.POST("", handler.RequirePermission(model.PermissionWriteComments), h.CreateComment)
+                                comments.DELETE("/:commentID", handler.RequirePermission(model.PermissionWriteComments), h.DeleteComment)
+                                comments.POST("/:commentID/reactions", handler.RequirePermission(model.PermissionReact), h.ToggleCommentReaction)
+                        }
+
+                        notifications := secured.Group("/notifications")
+                        notifications.Use(handler.RequirePermission(model.PermissionManageNotifications))
+                        {
+                                notifications.GET("", notificationH.GetNotifications)
+                                notifications.POST("/read-all", notificationH.MarkAllNotificationsRead)
+                                notifications.POST("/:notificationID/read", notificationH.MarkNotificationRead)
+                                notifications.GET("/preferences", notificationH.GetNotificationPreferences)
+                                notifications.PUT("/preferences", notificationH.UpdateNotificationPreferences)
+                        }
+
+                        secured.POST("/reports", handler.RequirePermission(model.PermissionReportContent), moderationH.ReportContent)
+
+                        moderation := secured.Group("/moderation")
+                        moderation.Use(handler.RequirePermission(model.PermissionViewModeration))
+                        {
+                                moderation.GET("/reviews", h.GetPendingReviews)
+                                moderation.POST("/reviews/:reviewID/approve", handler.RequirePermission(model.PermissionModerate), h.ApproveReview)
+                                moderation.POST("/reviews/:reviewID/reject", handler.RequirePermission(model.PermissionModerate), h.RejectReview)
+                                moderation.GET("/reports", moderationH.GetModerationQueue)
+                                moderation.GET("/content/:targetType/:targetID/decisions", moderationH.GetModerationDecisions)
+                                moderation.POST("/content/:targetType/:targetID/decisions", handler.RequirePermission(model.PermissionModerate), moderationH.DecideContent)
+                        }
+
+                        admin := secured.Group("/admin")
+                        {
+                                manageRoles := handler.RequirePermission(model.PermissionManageRoles)
+                                admin.GET("/users/:userID/roles", manageRoles, roleH.GetUserRoles)
+                                admin.POST("/users/:userID/roles", manageRoles, roleH.GrantRole)
+                                admin.DELETE("/users/:userID/roles/:role", manageRoles, roleH.RevokeRole)
+
+                                manageUsers := handler.RequirePermission(model.PermissionManageUsers)
+                                admin.GET("/shadow-bans", manageUsers, accountH.GetShadowBans)
+                                admin.POST("/users/:userID/shadow-ban", manageUsers, accountH.ShadowBanUser)
+                                admin.DELETE("/users/:userID/shadow-ban", manageUsers, accountH.LiftShadowBan)
+                                admin.POST("/users/:userID/revoke-sessions", manageUsers, authH.RevokeSessions)
+                                admin.POST("/users/:userID/unlock", manageUsers, authH.UnlockAccount)
+
+                                manageAPIKeys := handler.RequirePermission(model.PermissionManageAPIKeys)
+                                admin.GET("/api-keys", manageAPIKeys, apiKeyH.GetAPIKeys)
+                                admin.POST("/api-keys", manageAPIKeys, apiKeyH.CreateAPIKey)
+                                admin.DELETE("/api-keys/:keyID", manageAPIKeys, apiKeyH.RevokeAPIKey)
+
+                                admin.GET("/rating-flags", handler.RequirePermission(model.PermissionViewModeration), ratingFlagH.GetRatingFlags)
+                                admin.GET("/rating-flags/:flagID", handler.RequirePermission(model.PermissionViewModeration), ratingFlagH.GetRatingFlag)
+                                admin.POST("/rating-flags/:flagID/resolve", handler.RequirePermission(model.PermissionModerate), ratingFlagH.ResolveRatingFlag)
+
+                                admin.GET("/duplicate-clusters", handler.RequirePermission(model.PermissionViewModeration), duplicateH.GetDuplicateClusters)
                         }
                 }
         }
@@ -138,7 +362,7 @@ func corsMiddleware() gin.HandlerFunc {
         return func(c *gin.Context) {
                 c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
                 c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
-                c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+                c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-API-Key, accept, origin, Cache-Control, X-Requested-With")
                 c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE")
 
                 if c.Request.Method == "OPTIONS" {
@@ -149,3 +373,208 @@ func corsMiddleware() gin.HandlerFunc {
                 c.Next()
         }
 }
+
+// reviewPolicyFromEnv reads the review publication policy from REVIEW_REQUIRE_MODERATION and REVIEW_COOLDOWN
+func reviewPolicyFromEnv() model.ReviewPolicy {
+        var policy model.ReviewPolicy
+        if requireModeration, err := strconv.ParseBool(os.Getenv("REVIEW_REQUIRE_MODERATION")); err == nil {
+                policy.RequireModeration = requireModeration
+        }
+        if coolDown, err := time.ParseDuration(os.Getenv("REVIEW_COOLDOWN")); err == nil {
+                policy.CoolDown = coolDown
+        }
+        return policy
+}
+
+// contentLimitsFromEnv reads the text length limits from REVIEW_TITLE_MAX_LENGTH, REVIEW_CONTENT_MAX_LENGTH
+// and COMMENT_MAX_LENGTH
+func contentLimitsFromEnv() model.ContentLimits {
+        limits := model.DefaultContentLimits()
+        if length, err := strconv.Atoi(os.Getenv("REVIEW_TITLE_MAX_LENGTH")); err == nil {
+                limits.ReviewTitle = length
+        }
+        if length, err := strconv.Atoi(os.Getenv("REVIEW_CONTENT_MAX_LENGTH")); err == nil {
+                limits.ReviewContent = length
+        }
+        if length, err := strconv.Atoi(os.Getenv("COMMENT_MAX_LENGTH")); err == nil {
+                limits.CommentContent = length
+        }
+        return limits
+}
+
+// reactionEmojisFromEnv reads the comma separated REACTION_EMOJIS list
+func reactionEmojisFromEnv() []string {
+        var emojis []string
+        for _, emoji := range strings.Split(os.Getenv("REACTION_EMOJIS"), ",") {
+                if emoji = strings.TrimSpace(emoji); emoji != "" {
+                        emojis = append(emojis, emoji)
+                }
+        }
+        return emojis
+}
+
+// trustedProxiesFromEnv reads the comma separated TRUSTED_PROXIES list. An empty list trusts no
+// proxy, so the client address is always the peer address.
+func trustedProxiesFromEnv() []string {
+        var proxies []string
+        for _, proxy := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+                if proxy = strings.TrimSpace(proxy); proxy != "" {
+                        proxies = append(proxies, proxy)
+                }
+        }
+        return proxies
+}
+
+// contentFilterFromEnv builds the content filter from the rules file named by CONTENT_FILTER_RULES,
+// reloading it every CONTENT_FILTER_RELOAD_INTERVAL, or from the default rules if no file is set
+func contentFilterFromEnv(log *logrus.Logger) port.ContentFilter {
+        path := os.Getenv("CONTENT_FILTER_RULES")
+        if path == "" {
+                return contentfilter.NewChainFromRules(contentfilter.DefaultRules())
+        }
+
+        reloader, err := contentfilter.NewReloader(path, log)
+        if err != nil {
+                log.WithError(err).Fatal("Failed to load content filter rules")
+        }
+
+        interval := 30 * time.Second
+        if configured, err := time.ParseDuration(os.Getenv("CONTENT_FILTER_RELOAD_INTERVAL")); err == nil && configured > 0 {
+                interval = configured
+        }
+        go reloader.Watch(context.Background(), interval)
+        return reloader
+}
+
+// mailerFromEnv creates the mailer emails are sent with: an SMTP mailer when SMTP_HOST is set, and
+// otherwise an outbox writing them to MAIL_OUTBOX_DIR for development
+func mailerFromEnv(log *logrus.Logger) (port.Mailer, error) {
+        from := os.Getenv("MAIL_FROM")
+        if from == "" {
+                from = "Rating System <no-reply@localhost>"
+        }
+
+        host := os.Getenv("SMTP_HOST")
+        if host == "" {
+                dir := os.Getenv("MAIL_OUTBOX_DIR")
+                if dir == "" {
+                        dir = "mail-outbox"
+                }
+                log.WithField("dir", dir).Warn("SMTP_HOST not set; writing emails to the outbox directory")
+                return mail.NewOutboxMailer(dir, from)
+        }
+
+        smtpPort := 587
+        if raw := os.Getenv("SMTP_PORT"); raw != "" {
+                parsed, err := strconv.Atoi(raw)
+                if err != nil {
+                        return nil, fmt.Errorf("invalid SMTP_PORT %q", raw)
+                }
+                smtpPort = parsed
+        }
+        return mail.NewSMTPMailer(mail.SMTPConfig{
+                Host:     host,
+                Port:     smtpPort,
+                Username: os.Getenv("SMTP_USERNAME"),
+                Password: os.Getenv("SMTP_PASSWORD"),
+                From:     from,
+        })
+}
+
+// rateLimiterFromEnv creates the limiter of a route group from a requests/window limit such as
+// "60/1m" in the environment variable, falling back to the default. "off" disables the limit.
+func rateLimiterFromEnv(store ratelimit.Store, group, env, fallback string, log *logrus.Logger) port.RateLimiter {
+        value := os.Getenv(env)
+        if value == "" {
+                value = fallback
+        }
+        if value == "off" {
+                return nil
+        }
+
+        limit, err := ratelimit.ParseLimit(value)
+        if err != nil {
+                log.WithError(err).Fatalf("Invalid %s", env)
+        }
+        return ratelimit.NewLimiter(store, group, limit)
+}
+
+// highlightsConfigFromEnv reads the highlights cache settings from HIGHLIGHTS_CACHE_TTL and HIGHLIGHTS_LIMIT
+func highlightsConfigFromEnv() highlights.Config {
+        cfg := highlights.DefaultConfig()
+        if ttl, err := time.ParseDuration(os.Getenv("HIGHLIGHTS_CACHE_TTL")); err == nil {
+                cfg.CacheTTL = ttl
+        }
+        if limit, err := strconv.Atoi(os.Getenv("HIGHLIGHTS_LIMIT")); err == nil {
+                cfg.Limit = limit
+        }
+        return cfg
+}
+
+// brigadingConfigFromEnv reads the brigading detector settings from the BRIGADING_* variables
+func brigadingConfigFromEnv() brigading.Config {
+        cfg := brigading.DefaultConfig()
+        if age, err := time.ParseDuration(os.Getenv("BRIGADING_MIN_ACCOUNT_AGE")); err == nil {
+                cfg.MinAccountAge = age
+        }
+        if window, err := time.ParseDuration(os.Getenv("BRIGADING_BURST_WINDOW")); err == nil {
+                cfg.BurstWindow = window
+        }
+        if threshold, err := strconv.Atoi(os.Getenv("BRIGADING_BURST_THRESHOLD")); err == nil {
+                cfg.BurstThreshold = threshold
+        }
+        if window, err := time.ParseDuration(os.Getenv("BRIGADING_CLUSTER_WINDOW")); err == nil {
+                cfg.ClusterWindow = window
+        }
+        if threshold, err := strconv.Atoi(os.Getenv("BRIGADING_CLUSTER_THRESHOLD")); err == nil {
+                cfg.ClusterThreshold = threshold
+        }
+        if deviation, err := strconv.ParseFloat(os.Getenv("BRIGADING_MAX_DEVIATION"), 64); err == nil {
+                cfg.MaxDeviation = deviation
+        }
+        if threshold, err := strconv.ParseFloat(os.Getenv("BRIGADING_FLAG_THRESHOLD"), 64); err == nil {
+                cfg.FlagThreshold = threshold
+        }
+        if threshold, err := strconv.ParseFloat(os.Getenv("BRIGADING_QUARANTINE_THRESHOLD"), 64); err == nil {
+                cfg.QuarantineThreshold = threshold
+        }
+        return cfg
+}
+
+// duplicatesConfigFromEnv reads the duplicate detector settings from the DUPLICATE_* variables
+func duplicatesConfigFromEnv() duplicates.Config {
+        cfg := duplicates.DefaultConfig()
+        if threshold, err := strconv.ParseFloat(os.Getenv("DUPLICATE_THRESHOLD"), 64); err == nil {
+                cfg.Threshold = threshold
+        }
+        switch os.Getenv("DUPLICATE_ACTION") {
+        case "flag":
+                cfg.Action = model.FilterFlag
+        case "reject":
+                cfg.Action = model.FilterReject
+        }
+        if scope := os.Getenv("DUPLICATE_SCOPE"); scope != "" {
+                cfg.Scope = model.DuplicateScope(scope)
+        }
+        if length, err := strconv.Atoi(os.Getenv("DUPLICATE_MIN_LENGTH")); err == nil {
+                cfg.MinLength = length
+        }
+        return cfg
+}
+
+// runReviewPublisher periodically publishes pending reviews whose cool-down has elapsed
+func runReviewPublisher(svc port.Service, log *logrus.Logger, interval time.Duration) {
+        ticker := time.NewTicker(interval)
+        defer ticker.Stop()
+
+        for range ticker.C {
+                published, err := svc.PublishDueReviews(context.Background())
+                if err != nil {
+                        log.WithError(err).Error("Failed to publish due reviews")
+                        continue
+                }
+                if published > 0 {
+                        log.WithField("count", published).Info("Published reviews after cool-down")
+                }
+        }
+}
@@ -0,0 +1,232 @@
+// Package markdown renders the restricted Markdown subset accepted in reviews and comments
+// to safe HTML.
+//
+// Supported syntax: paragraphs, line breaks, **bold**, *italic*, `code`, fenced code blocks,
+// "- " and "1. " lists, "> " quotes and [links](https://example.com). Everything else,
+// including raw HTML, is escaped and shown as text, so the output only ever contains the tags
+// in AllowedTags. Links are limited to http, https and mailto URLs and carry rel="nofollow".
+package markdown
+
+import (
+	"html"
+	"net/url"
+	"strings"
+)
+
+// AllowedTags lists every tag Render can produce
+var AllowedTags = []string{"p", "br", "strong", "em", "code", "pre", "a", "ul", "ol", "li", "blockquote"}
+
+// allowedSchemes lists the URL schemes links may use
+var allowedSchemes = map[string]bool{"http": true, "https": true, "mailto": true}
+
+// Render converts Markdown source to sanitised HTML
+func Render(source string) string {
+	lines := strings.Split(strings.ReplaceAll(source, "\r\n", "\n"), "\n")
+
+	var b strings.Builder
+	renderBlocks(&b, lines)
+	return b.String()
+}
+
+// renderBlocks renders consecutive lines as paragraphs, lists, quotes and code blocks
+func renderBlocks(b *strings.Builder, lines []string) {
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "":
+			i++
+
+		case strings.HasPrefix(trimmed, "```"):
+			end := i + 1
+			for end < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[end]), "```") {
+				end++
+			}
+			b.WriteString("<pre><code>")
+			b.WriteString(html.EscapeString(strings.Join(lines[i+1:end], "\n")))
+			b.WriteString("</code></pre>")
+			i = end + 1
+
+		case isQuote(trimmed):
+			var quoted []string
+			for ; i < len(lines) && isQuote(strings.TrimSpace(lines[i])); i++ {
+				quoted = append(quoted, strings.TrimPrefix(strings.TrimPrefix(strings.TrimSpace(lines[i]), ">"), " "))
+			}
+			b.WriteString("<blockquote>")
+			renderBlocks(b, quoted)
+			b.WriteString("</blockquote>")
+
+		case bulletItem(trimmed) != "":
+			i = renderList(b, lines, i, "ul", bulletItem)
+
+		case orderedItem(trimmed) != "":
+			i = renderList(b, lines, i, "ol", orderedItem)
+
+		default:
+			var paragraph []string
+			for ; i < len(lines); i++ {
+				next := strings.TrimSpace(lines[i])
+				if next == "" || strings.HasPrefix(next, "```") || isQuote(next) || bulletItem(next) != "" || orderedItem(next) != "" {
+					break
+				}
+				paragraph = append(paragraph, next)
+			}
+			b.WriteString("<p>")
+			for j, text := range paragraph {
+				if j > 0 {
+					b.WriteString("<br>")
+				}
+				renderInline(b, text, true)
+			}
+			b.WriteString("</p>")
+		}
+	}
+}
+
+// renderList renders the list starting at lines[i] and returns the index of the line after it
+func renderList(b *strings.Builder, lines []string, i int, tag string, item func(string) string) int {
+	b.WriteString("<" + tag + ">")
+	for ; i < len(lines); i++ {
+		text := item(strings.TrimSpace(lines[i]))
+		if text == "" {
+			break
+		}
+		b.WriteString("<li>")
+		renderInline(b, text, true)
+		b.WriteString("</li>")
+	}
+	b.WriteString("</" + tag + ">")
+	return i
+}
+
+func isQuote(line string) bool {
+	return strings.HasPrefix(line, ">")
+}
+
+// bulletItem returns the text of a "- " or "* " list item, or "" if the line is not one
+func bulletItem(line string) string {
+	if len(line) > 2 && (line[0] == '-' || line[0] == '*') && line[1] == ' ' {
+		return strings.TrimSpace(line[2:])
+	}
+	return ""
+}
+
+// orderedItem returns the text of a "1. " list item, or "" if the line is not one
+func orderedItem(line string) string {
+	digits := 0
+	for digits < len(line) && digits < 9 && line[digits] >= '0' && line[digits] <= '9' {
+		digits++
+	}
+	if digits > 0 && len(line) > digits+2 && line[digits] == '.' && line[digits+1] == ' ' {
+		return strings.TrimSpace(line[digits+2:])
+	}
+	return ""
+}
+
+// renderInline renders emphasis, code spans and links within a line. Links are not rendered
+// inside link text.
+func renderInline(b *strings.Builder, text string, links bool) {
+	for i := 0; i < len(text); {
+		rest := text[i:]
+
+		switch {
+		case rest[0] == '\\' && len(rest) > 1 && strings.ContainsRune("\\`*_[]()>#-!", rune(rest[1])):
+			b.WriteString(html.EscapeString(rest[1:2]))
+			i += 2
+			continue
+
+		case rest[0] == '`':
+			if end := strings.IndexByte(rest[1:], '`'); end > 0 {
+				b.WriteString("<code>" + html.EscapeString(rest[1:1+end]) + "</code>")
+				i += end + 2
+				continue
+			}
+
+		case strings.HasPrefix(rest, "**") || strings.HasPrefix(rest, "__"):
+			if inner, n := delimited(rest, rest[:2], i == 0 || !isWordByte(text[i-1])); n > 0 {
+				b.WriteString("<strong>")
+				renderInline(b, inner, links)
+				b.WriteString("</strong>")
+				i += n
+				continue
+			}
+
+		case rest[0] == '*' || rest[0] == '_':
+			if inner, n := delimited(rest, rest[:1], i == 0 || !isWordByte(text[i-1])); n > 0 {
+				b.WriteString("<em>")
+				renderInline(b, inner, links)
+				b.WriteString("</em>")
+				i += n
+				continue
+			}
+
+		case rest[0] == '[' && links:
+			if label, href, n := link(rest); n > 0 {
+				if safe, ok := sanitizeURL(href); ok {
+					b.WriteString(`<a href="` + html.EscapeString(safe) + `" rel="nofollow">`)
+					renderInline(b, label, false)
+					b.WriteString("</a>")
+				} else {
+					renderInline(b, label, false)
+				}
+				i += n
+				continue
+			}
+		}
+
+		b.WriteString(html.EscapeString(rest[:1]))
+		i++
+	}
+}
+
+// delimited returns the text between an opening delimiter at the start of s and the next
+// closing one, and the length consumed. Underscores only open emphasis at a word boundary,
+// so snake_case is left alone.
+func delimited(s, delim string, boundary bool) (string, int) {
+	if delim[0] == '_' && !boundary {
+		return "", 0
+	}
+	end := strings.Index(s[len(delim):], delim)
+	if end <= 0 {
+		return "", 0
+	}
+	inner := s[len(delim) : len(delim)+end]
+	if strings.TrimSpace(inner) != inner {
+		return "", 0
+	}
+	return inner, len(delim)*2 + end
+}
+
+// link parses "[label](href)" at the start of s and returns its parts and length
+func link(s string) (label, href string, n int) {
+	closeLabel := strings.Index(s, "](")
+	if closeLabel < 1 {
+		return "", "", 0
+	}
+	closeHref := strings.IndexByte(s[closeLabel+2:], ')')
+	if closeHref < 0 {
+		return "", "", 0
+	}
+	return s[1:closeLabel], strings.TrimSpace(s[closeLabel+2 : closeLabel+2+closeHref]), closeLabel + 3 + closeHref
+}
+
+// sanitizeURL accepts absolute http, https and mailto URLs
+func sanitizeURL(raw string) (string, bool) {
+	if raw == "" || strings.ContainsAny(raw, " \t\n\"'<>`") {
+		return "", false
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", false
+	}
+	scheme := strings.ToLower(u.Scheme)
+	if !allowedSchemes[scheme] || (scheme != "mailto" && u.Host == "") {
+		return "", false
+	}
+	return u.String(), true
+}
+
+func isWordByte(c byte) bool {
+	return c == '_' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= 0x80
+}
@@ -0,0 +1,60 @@
+package markdown
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderInline(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		want   string
+	}{
+		{"plain", "Great service", "<p>Great service</p>"},
+		{"emphasis", "**really** *very* _good_", "<p><strong>really</strong> <em>very</em> <em>good</em></p>"},
+		{"snake case", "use snake_case_names", "<p>use snake_case_names</p>"},
+		{"code", "run `rm -rf <dir>`", "<p>run <code>rm -rf &lt;dir&gt;</code></p>"},
+		{"escaped", `\*not emphasis\*`, "<p>*not emphasis*</p>"},
+		{"link", "[our site](https://example.com/a?b=1&c=2)", `<p><a href="https://example.com/a?b=1&amp;c=2" rel="nofollow">our site</a></p>`},
+		{"mailto", "[mail](mailto:team@example.com)", `<p><a href="mailto:team@example.com" rel="nofollow">mail</a></p>`},
+		{"line breaks", "first\nsecond", "<p>first<br>second</p>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Render(tt.source))
+		})
+	}
+}
+
+func TestRenderBlocks(t *testing.T) {
+	source := "Intro\n\n- one\n- **two**\n\n1. first\n2. second\n\n> quoted\n> text\n\n```\n<b>code</b>\n```"
+
+	assert.Equal(t,
+		"<p>Intro</p><ul><li>one</li><li><strong>two</strong></li></ul><ol><li>first</li><li>second</li></ol>"+
+			"<blockquote><p>quoted<br>text</p></blockquote><pre><code>&lt;b&gt;code&lt;/b&gt;</code></pre>",
+		Render(source))
+}
+
+func TestRenderSanitises(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		want   string
+	}{
+		{"raw html", `<script>alert(1)</script><img src=x onerror="alert(1)">`, "<p>&lt;script&gt;alert(1)&lt;/script&gt;&lt;img src=x onerror=&#34;alert(1)&#34;&gt;</p>"},
+		{"javascript link", "[click](javascript:alert(1))", "<p>click)</p>"},
+		{"data link", "[click](data:text/html;base64,PHNjcmlwdD4=)", "<p>click</p>"},
+		{"relative link", "[click](/admin)", "<p>click</p>"},
+		{"attribute breakout", `[click](https://example.com/"onmouseover="alert(1))`, "<p>click)</p>"},
+		{"nested link", "[[inner](https://a.example)](https://b.example)", `<p><a href="https://a.example" rel="nofollow">[inner</a>](https://b.example)</p>`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Render(tt.source))
+		})
+	}
+}
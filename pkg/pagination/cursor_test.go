@@ -0,0 +1,26 @@
+package pagination
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	cursor := Cursor{CreatedAt: time.Date(2024, 3, 1, 12, 30, 0, 123456000, time.UTC), ID: uuid.New()}
+
+	decoded, err := DecodeCursor(cursor.Encode())
+	require.NoError(t, err)
+	assert.True(t, cursor.CreatedAt.Equal(decoded.CreatedAt))
+	assert.Equal(t, cursor.ID, decoded.ID)
+}
+
+func TestDecodeCursorInvalid(t *testing.T) {
+	for _, encoded := range []string{"", "not base64!", "bm9waXBl", Cursor{}.Encode()[:4]} {
+		_, err := DecodeCursor(encoded)
+		assert.ErrorIs(t, err, ErrInvalidCursor, encoded)
+	}
+}
@@ -6,6 +6,9 @@ CREATE TABLE IF NOT EXISTS users (
     username VARCHAR(255) NOT NULL,
     email VARCHAR(255) NOT NULL,
     password_hash VARCHAR(255) NOT NULL,
+    token_generation INT NOT NULL DEFAULT 0,
+    email_verified_at TIMESTAMP NULL,
+    locked_until TIMESTAMP NULL,
     created_at TIMESTAMP NOT NULL,
     updated_at TIMESTAMP NOT NULL,
     CONSTRAINT unique_username UNIQUE (username),
@@ -16,12 +19,123 @@ CREATE TABLE IF NOT EXISTS users (
 CREATE INDEX IF NOT EXISTS idx_users_username ON users(username);
 CREATE INDEX IF NOT EXISTS idx_users_email ON users(email);
 
+-- Create user roles table; every user implicitly holds the user role, so only elevated roles are
+-- stored. granted_by is NULL for the bootstrapped first admin.
+CREATE TABLE IF NOT EXISTS user_roles (
+    user_id CHAR(36) NOT NULL,
+    role VARCHAR(32) NOT NULL,
+    granted_by CHAR(36) NULL,
+    created_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (user_id, role),
+    CONSTRAINT chk_user_role CHECK (role IN ('service_owner', 'moderator', 'admin')),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (granted_by) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_roles_role ON user_roles(role);
+
+-- Create shadow-bans table; content a shadow-banned user writes is marked shadowed and only
+-- shown to them
+CREATE TABLE IF NOT EXISTS shadow_bans (
+    user_id CHAR(36) PRIMARY KEY,
+    banned_by CHAR(36) NULL,
+    reason VARCHAR(500) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (banned_by) REFERENCES users(id) ON DELETE SET NULL
+);
+
+-- Create refresh tokens table; only hashes of the tokens are stored, and tokens descending from
+-- the same login share a family_id so a reused token revokes the whole family
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    id CHAR(36) PRIMARY KEY,
+    family_id CHAR(36) NOT NULL,
+    user_id CHAR(36) NOT NULL,
+    token_hash CHAR(64) NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    rotated_at TIMESTAMP NULL,
+    revoked_at TIMESTAMP NULL,
+    created_at TIMESTAMP NOT NULL,
+    CONSTRAINT unique_refresh_token_hash UNIQUE (token_hash),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_family_id ON refresh_tokens(family_id);
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_user_id ON refresh_tokens(user_id);
+
+-- Create API keys table; only hashes of the keys are stored, and scopes are a comma separated list
+-- of permissions
+CREATE TABLE IF NOT EXISTS api_keys (
+    id CHAR(36) PRIMARY KEY,
+    user_id CHAR(36) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    hint VARCHAR(20) NOT NULL,
+    key_hash CHAR(64) NOT NULL,
+    scopes VARCHAR(500) NOT NULL,
+    service_id CHAR(36) NULL,
+    created_by CHAR(36) NOT NULL,
+    expires_at TIMESTAMP NULL,
+    last_used_at TIMESTAMP NULL,
+    revoked_at TIMESTAMP NULL,
+    created_at TIMESTAMP NOT NULL,
+    CONSTRAINT unique_api_key_hash UNIQUE (key_hash),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (created_by) REFERENCES users(id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_api_keys_user_id ON api_keys(user_id);
+
+-- Create user tokens table for single-use tokens sent by email (e.g. email verification and
+-- password reset); only hashes of the tokens are stored
+CREATE TABLE IF NOT EXISTS user_tokens (
+    id CHAR(36) PRIMARY KEY,
+    user_id CHAR(36) NOT NULL,
+    purpose VARCHAR(50) NOT NULL,
+    token_hash CHAR(64) NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP NULL,
+    created_at TIMESTAMP NOT NULL,
+    CONSTRAINT unique_user_token_hash UNIQUE (token_hash),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_tokens_user_purpose ON user_tokens(user_id, purpose, created_at);
+
+-- Create audit events table; security relevant account changes such as password resets are kept
+-- as an audit trail
+CREATE TABLE IF NOT EXISTS audit_events (
+    id CHAR(36) PRIMARY KEY,
+    user_id CHAR(36) NOT NULL,
+    actor_id CHAR(36) NULL,
+    action VARCHAR(50) NOT NULL,
+    ip_address VARCHAR(45) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_events_user_id ON audit_events(user_id, created_at);
+
+-- Create user blocks table (users whose reviews and comments the blocker does not want to see)
+CREATE TABLE IF NOT EXISTS user_blocks (
+    blocker_id CHAR(36) NOT NULL,
+    blocked_id CHAR(36) NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (blocker_id, blocked_id),
+    CONSTRAINT chk_user_blocks_self CHECK (blocker_id <> blocked_id),
+    FOREIGN KEY (blocker_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (blocked_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
 -- Create ratings table
 CREATE TABLE IF NOT EXISTS ratings (
     id CHAR(36) PRIMARY KEY,
     user_id CHAR(36) NOT NULL,
     service_id CHAR(36) NOT NULL,
     score INT NOT NULL,
+    verified BOOLEAN NOT NULL DEFAULT FALSE,
+    verified_at TIMESTAMP NULL,
+    quarantined BOOLEAN NOT NULL DEFAULT FALSE,
+    shadowed BOOLEAN NOT NULL DEFAULT FALSE,
     created_at TIMESTAMP NOT NULL,
     updated_at TIMESTAMP NOT NULL,
     CONSTRAINT chk_score CHECK (score >= 1 AND score <= 5),
@@ -32,6 +146,62 @@ CREATE TABLE IF NOT EXISTS ratings (
 -- Create index for service_id for efficient queries
 CREATE INDEX IF NOT EXISTS idx_ratings_service_id ON ratings(service_id);
 CREATE INDEX IF NOT EXISTS idx_ratings_user_id ON ratings(user_id);
+CREATE INDEX IF NOT EXISTS idx_ratings_service_verified ON ratings(service_id, verified);
+CREATE INDEX IF NOT EXISTS idx_ratings_service_created ON ratings(service_id, created_at);
+
+-- Create rating clients table; the address and device each rating was submitted from, used to
+-- spot several accounts rating a service from one place
+CREATE TABLE IF NOT EXISTS rating_clients (
+    rating_id CHAR(36) PRIMARY KEY,
+    ip VARCHAR(64) NOT NULL,
+    device_id VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    FOREIGN KEY (rating_id) REFERENCES ratings(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_rating_clients_ip ON rating_clients(ip, created_at);
+CREATE INDEX IF NOT EXISTS idx_rating_clients_device ON rating_clients(device_id, created_at);
+
+-- Create rating flags table; signals holds the raised signals as JSON
+CREATE TABLE IF NOT EXISTS rating_flags (
+    id CHAR(36) PRIMARY KEY,
+    rating_id CHAR(36) NOT NULL,
+    user_id CHAR(36) NOT NULL,
+    service_id CHAR(36) NOT NULL,
+    rating_score INT NOT NULL,
+    suspicion_score DOUBLE PRECISION NOT NULL,
+    signals TEXT NOT NULL,
+    ip VARCHAR(64) NOT NULL,
+    device_id VARCHAR(255) NOT NULL,
+    quarantined BOOLEAN NOT NULL,
+    status VARCHAR(16) NOT NULL,
+    resolved_by CHAR(36) NULL,
+    resolved_at TIMESTAMP NULL,
+    created_at TIMESTAMP NOT NULL,
+    CONSTRAINT chk_rating_flag_status CHECK (status IN ('open', 'cleared', 'confirmed')),
+    FOREIGN KEY (rating_id) REFERENCES ratings(id) ON DELETE CASCADE,
+    FOREIGN KEY (resolved_by) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_rating_flags_status ON rating_flags(status, created_at);
+CREATE INDEX IF NOT EXISTS idx_rating_flags_service ON rating_flags(service_id, created_at);
+
+-- Create attestations table (redeemed proofs of interaction, single use per token)
+CREATE TABLE IF NOT EXISTS attestations (
+    id CHAR(36) PRIMARY KEY,
+    token_id VARCHAR(255) NOT NULL,
+    rating_id CHAR(36) NOT NULL,
+    user_id CHAR(36) NOT NULL,
+    service_id CHAR(36) NOT NULL,
+    order_id VARCHAR(255) NOT NULL,
+    algorithm VARCHAR(16) NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    verified_at TIMESTAMP NOT NULL,
+    CONSTRAINT unique_attestation_token UNIQUE (token_id),
+    FOREIGN KEY (rating_id) REFERENCES ratings(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_attestations_rating_id ON attestations(rating_id);
 
 -- Create reviews table
 CREATE TABLE IF NOT EXISTS reviews (
@@ -41,9 +211,17 @@ CREATE TABLE IF NOT EXISTS reviews (
     rating_id CHAR(36) NOT NULL,
     title VARCHAR(255) NOT NULL,
     content TEXT NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'published',
+    moderation_status VARCHAR(16) NOT NULL DEFAULT 'visible',
+    sentiment_score DOUBLE PRECISION NULL,
+    publish_at TIMESTAMP NULL,
+    published_at TIMESTAMP NULL,
+    shadowed BOOLEAN NOT NULL DEFAULT FALSE,
     created_at TIMESTAMP NOT NULL,
     updated_at TIMESTAMP NOT NULL,
     CONSTRAINT unique_rating UNIQUE (rating_id),
+    CONSTRAINT chk_review_status CHECK (status IN ('draft', 'pending', 'published', 'rejected')),
+    CONSTRAINT chk_review_moderation_status CHECK (moderation_status IN ('visible', 'pending_review', 'hidden', 'removed')),
     FOREIGN KEY (rating_id) REFERENCES ratings(id) ON DELETE CASCADE,
     FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
 );
@@ -52,19 +230,146 @@ CREATE TABLE IF NOT EXISTS reviews (
 CREATE INDEX IF NOT EXISTS idx_reviews_service_id ON reviews(service_id);
 CREATE INDEX IF NOT EXISTS idx_reviews_user_id ON reviews(user_id);
 CREATE INDEX IF NOT EXISTS idx_reviews_rating_id ON reviews(rating_id);
+CREATE INDEX IF NOT EXISTS idx_reviews_service_status ON reviews(service_id, status);
+CREATE INDEX IF NOT EXISTS idx_reviews_status_publish_at ON reviews(status, publish_at);
+
+-- Create review signatures table (MinHash signatures for near-duplicate detection; cluster_id is
+-- the first review of the duplicate cluster and is kept if that review is deleted)
+CREATE TABLE IF NOT EXISTS review_signatures (
+    review_id CHAR(36) PRIMARY KEY,
+    user_id CHAR(36) NOT NULL,
+    service_id CHAR(36) NOT NULL,
+    minhash TEXT NOT NULL,
+    cluster_id CHAR(36) NULL,
+    similarity DOUBLE PRECISION NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    FOREIGN KEY (review_id) REFERENCES reviews(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_review_signatures_cluster ON review_signatures(cluster_id, created_at);
+
+-- Create review signature bands table (LSH band hashes used to find candidate duplicates)
+CREATE TABLE IF NOT EXISTS review_signature_bands (
+    review_id CHAR(36) NOT NULL,
+    band INT NOT NULL,
+    band_hash BIGINT NOT NULL,
+    PRIMARY KEY (review_id, band),
+    FOREIGN KEY (review_id) REFERENCES review_signatures(review_id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_review_signature_bands_hash ON review_signature_bands(band_hash);
 
 -- Create comments table
 CREATE TABLE IF NOT EXISTS comments (
     id CHAR(36) PRIMARY KEY,
     user_id CHAR(36) NOT NULL,
     review_id CHAR(36) NOT NULL,
+    parent_id CHAR(36) NULL,
+    thread_id CHAR(36) NOT NULL,
+    depth INT NOT NULL DEFAULT 0,
     content TEXT NOT NULL,
+    deleted BOOLEAN NOT NULL DEFAULT FALSE,
+    deleted_at TIMESTAMP NULL,
+    moderation_status VARCHAR(16) NOT NULL DEFAULT 'visible',
+    shadowed BOOLEAN NOT NULL DEFAULT FALSE,
     created_at TIMESTAMP NOT NULL,
     updated_at TIMESTAMP NOT NULL,
+    CONSTRAINT chk_comment_moderation_status CHECK (moderation_status IN ('visible', 'pending_review', 'hidden', 'removed')),
     FOREIGN KEY (review_id) REFERENCES reviews(id) ON DELETE CASCADE,
+    FOREIGN KEY (parent_id) REFERENCES comments(id) ON DELETE CASCADE,
     FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
 );
 
 -- Create indexes for comments table
 CREATE INDEX IF NOT EXISTS idx_comments_review_id ON comments(review_id);
 CREATE INDEX IF NOT EXISTS idx_comments_user_id ON comments(user_id);
+CREATE INDEX IF NOT EXISTS idx_comments_review_parent ON comments(review_id, parent_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_comments_thread_id ON comments(thread_id);
+CREATE INDEX IF NOT EXISTS idx_comments_parent_id ON comments(parent_id);
+CREATE INDEX IF NOT EXISTS idx_comments_review_created ON comments(review_id, deleted, created_at);
+
+-- Create notifications table
+CREATE TABLE IF NOT EXISTS notifications (
+    id CHAR(36) PRIMARY KEY,
+    user_id CHAR(36) NOT NULL,
+    type VARCHAR(32) NOT NULL,
+    actor_id CHAR(36) NULL,
+    review_id CHAR(36) NULL,
+    comment_id CHAR(36) NULL,
+    detail VARCHAR(255) NOT NULL DEFAULT '',
+    read_at TIMESTAMP NULL,
+    created_at TIMESTAMP NOT NULL,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_notifications_user_created ON notifications(user_id, created_at, id);
+CREATE INDEX IF NOT EXISTS idx_notifications_user_unread ON notifications(user_id, read_at);
+
+-- Create notification preferences table; types without a row are enabled
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    user_id CHAR(36) NOT NULL,
+    type VARCHAR(32) NOT NULL,
+    enabled BOOLEAN NOT NULL,
+    PRIMARY KEY (user_id, type),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- Create mentions table; target_id is a review or comment ID depending on target_type
+CREATE TABLE IF NOT EXISTS mentions (
+    id CHAR(36) PRIMARY KEY,
+    target_type VARCHAR(16) NOT NULL,
+    target_id CHAR(36) NOT NULL,
+    user_id CHAR(36) NOT NULL,
+    start_offset INTEGER NOT NULL,
+    end_offset INTEGER NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_mentions_target ON mentions(target_type, target_id, start_offset);
+CREATE INDEX IF NOT EXISTS idx_mentions_user_id ON mentions(user_id);
+
+-- Create reactions table; target_id is a review or comment ID depending on target_type
+CREATE TABLE IF NOT EXISTS reactions (
+    id CHAR(36) PRIMARY KEY,
+    target_type VARCHAR(16) NOT NULL,
+    target_id CHAR(36) NOT NULL,
+    user_id CHAR(36) NOT NULL,
+    emoji VARCHAR(32) NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    CONSTRAINT unique_user_reaction UNIQUE (target_type, target_id, user_id, emoji),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- Create reports table; target_id is a review or comment ID depending on target_type and
+-- reporter_id is NULL for reports raised by the content filter
+CREATE TABLE IF NOT EXISTS reports (
+    id CHAR(36) PRIMARY KEY,
+    reporter_id CHAR(36) NULL,
+    target_type VARCHAR(16) NOT NULL,
+    target_id CHAR(36) NOT NULL,
+    reason VARCHAR(32) NOT NULL,
+    details TEXT NOT NULL,
+    resolved_at TIMESTAMP NULL,
+    created_at TIMESTAMP NOT NULL,
+    CONSTRAINT unique_user_report UNIQUE (reporter_id, target_type, target_id),
+    FOREIGN KEY (reporter_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_reports_open_target ON reports(resolved_at, target_type, target_id);
+
+-- Create moderation decisions table; every decision is kept as an audit trail
+CREATE TABLE IF NOT EXISTS moderation_decisions (
+    id CHAR(36) PRIMARY KEY,
+    moderator_id CHAR(36) NOT NULL,
+    target_type VARCHAR(16) NOT NULL,
+    target_id CHAR(36) NOT NULL,
+    action VARCHAR(16) NOT NULL,
+    reason TEXT NOT NULL,
+    previous_status VARCHAR(16) NOT NULL,
+    status VARCHAR(16) NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    FOREIGN KEY (moderator_id) REFERENCES users(id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_moderation_decisions_target ON moderation_decisions(target_type, target_id, created_at);